@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// command is a sub command of tables-to-go.
+type command struct {
+	name string
+	// args are the arguments of the command as shown in its usage, minArgs
+	// and maxArgs their allowed number
+	args    string
+	minArgs int
+	maxArgs int
+	summary string
+	// offline commands neither need the flags nor the connection
+	offline bool
+}
+
+// commands are the sub commands of tables-to-go in the order of the usage,
+// generate is the default one.
+var commands = []command{
+	{name: "generate", summary: "generate the structs of the tables (default)"},
+	{name: "check", summary: "generate in memory and fail with exit code 3 if the files differ"},
+	{name: "list-tables", summary: "print the tables the settings select"},
+	{name: "describe", args: "<table>", minArgs: 1, maxArgs: 1, summary: "print the introspected columns of the table"},
+	{name: "init", args: "[file]", maxArgs: 1, summary: "write a config file with the settings and the discovered tables (default tables-to-go.yaml)"},
+	{name: "version", summary: "print the version and build information", offline: true},
+	{name: "completion", args: "<bash|zsh|fish|powershell>", minArgs: 1, maxArgs: 1, summary: "print the shell completion script", offline: true},
+	{name: "help", args: "[command]", maxArgs: 1, summary: "print the usage of tables-to-go or of the command", offline: true},
+}
+
+// commandNames returns the sorted names of the commands.
+func commandNames() []string {
+	names := make([]string, len(commands))
+	for i, cmd := range commands {
+		names[i] = cmd.name
+	}
+	slices.Sort(names)
+	return names
+}
+
+// lookupCommand returns the command of the given name.
+func lookupCommand(name string) (command, error) {
+	i := slices.IndexFunc(commands, func(cmd command) bool { return cmd.name == name })
+	if i < 0 {
+		return command{}, fmt.Errorf("unknown command %q, see 'tables-to-go help'", name)
+	}
+	return commands[i], nil
+}
+
+// parseCommand returns the command given as first of the positional
+// arguments, generate if none is given, and its arguments.
+func parseCommand(positional []string) (command, []string, error) {
+	if len(positional) == 0 {
+		return commands[0], nil, nil
+	}
+
+	cmd, err := lookupCommand(positional[0])
+	if err != nil {
+		return cmd, nil, err
+	}
+	args := positional[1:]
+	if len(args) < cmd.minArgs || len(args) > cmd.maxArgs {
+		return cmd, nil, fmt.Errorf("usage: %s", cmd.usage())
+	}
+	return cmd, args, nil
+}
+
+// usage returns the synopsis of the command.
+func (cmd command) usage() string {
+	usage := "tables-to-go " + cmd.name
+	if !cmd.offline {
+		usage += " [flags]"
+	}
+	if cmd.args != "" {
+		usage += " " + cmd.args
+	}
+	return usage
+}
+
+// parseInterspersed parses the flags of the flag set in args like Parse, but
+// goes on with the flags after positional arguments, so the command can be
+// given before, between or after the flags. All arguments after the
+// terminator "--" are positional. It returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// writeUsage writes the usage of tables-to-go with the commands and the flags
+// of the flag set, or the usage of the given command.
+func writeUsage(w io.Writer, fs *flag.FlagSet, cmd *command) {
+	if cmd != nil {
+		fmt.Fprintf(w, "Usage:\n  %s\n\n%s.\n", cmd.usage(), capitalize(cmd.summary))
+		if cmd.offline {
+			return
+		}
+	} else {
+		fmt.Fprint(w, "Usage:\n  tables-to-go [command] [flags] [arguments]\n\nCommands:\n")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, cmd := range commands {
+			fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.summary)
+		}
+		_ = tw.Flush()
+		fmt.Fprint(w, "\nThe flags can be given before or after the command. Run 'tables-to-go help <command>'\nfor the usage of a command.\n")
+	}
+
+	fmt.Fprint(w, "\nFlags:\n")
+	output := fs.Output()
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+	fs.SetOutput(output)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterspersed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc       string
+		args       []string
+		positional []string
+		dbType     string
+		verbose    bool
+		err        string
+	}{
+		{
+			desc:   "flags only",
+			args:   []string{"-t", "mysql"},
+			dbType: "mysql",
+		},
+		{
+			desc:       "command before the flags",
+			args:       []string{"list-tables", "-t", "mysql", "-v"},
+			positional: []string{"list-tables"},
+			dbType:     "mysql",
+			verbose:    true,
+		},
+		{
+			desc:       "command between the flags",
+			args:       []string{"-t", "mysql", "describe", "-v", "users"},
+			positional: []string{"describe", "users"},
+			dbType:     "mysql",
+			verbose:    true,
+		},
+		{
+			desc:       "flags after the positional arguments",
+			args:       []string{"describe", "users", "-t", "mysql"},
+			positional: []string{"describe", "users"},
+			dbType:     "mysql",
+		},
+		{
+			desc:       "terminator",
+			args:       []string{"-v", "--", "describe", "-t", "mysql"},
+			positional: []string{"describe", "-t", "mysql"},
+			dbType:     "pg",
+			verbose:    true,
+		},
+		{
+			desc:       "terminator after the command",
+			args:       []string{"describe", "-t", "mysql", "--", "-users"},
+			positional: []string{"describe", "-users"},
+			dbType:     "mysql",
+		},
+		{
+			desc:       "terminator as argument of a flag",
+			args:       []string{"-t", "--", "describe"},
+			positional: []string{"describe"},
+			dbType:     "--",
+		},
+		{
+			desc: "unknown flag after the command",
+			args: []string{"generate", "-x"},
+			err:  "flag provided but not defined: -x",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			fs := flag.NewFlagSet("tables-to-go", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			dbType := fs.String("t", "pg", "type of database")
+			verbose := fs.Bool("v", false, "verbose output")
+
+			positional, err := parseInterspersed(fs, test.args)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, test.positional, positional)
+			assert.Equal(t, test.dbType, *dbType)
+			assert.Equal(t, test.verbose, *verbose)
+		})
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc       string
+		positional []string
+		command    string
+		args       []string
+		err        string
+	}{
+		{
+			desc:    "generate by default",
+			command: "generate",
+		},
+		{
+			desc:       "generate",
+			positional: []string{"generate"},
+			command:    "generate",
+			args:       []string{},
+		},
+		{
+			desc:       "check",
+			positional: []string{"check"},
+			command:    "check",
+			args:       []string{},
+		},
+		{
+			desc:       "list-tables",
+			positional: []string{"list-tables"},
+			command:    "list-tables",
+			args:       []string{},
+		},
+		{
+			desc:       "describe",
+			positional: []string{"describe", "users"},
+			command:    "describe",
+			args:       []string{"users"},
+		},
+		{
+			desc:       "init",
+			positional: []string{"init"},
+			command:    "init",
+			args:       []string{},
+		},
+		{
+			desc:       "init with file",
+			positional: []string{"init", "config.toml"},
+			command:    "init",
+			args:       []string{"config.toml"},
+		},
+		{
+			desc:       "version",
+			positional: []string{"version"},
+			command:    "version",
+			args:       []string{},
+		},
+		{
+			desc:       "help of command",
+			positional: []string{"help", "describe"},
+			command:    "help",
+			args:       []string{"describe"},
+		},
+		{
+			desc:       "unknown command",
+			positional: []string{"generat"},
+			err:        `unknown command "generat", see 'tables-to-go help'`,
+		},
+		{
+			desc:       "missing argument",
+			positional: []string{"describe"},
+			err:        "usage: tables-to-go describe [flags] <table>",
+		},
+		{
+			desc:       "too many arguments",
+			positional: []string{"version", "now"},
+			err:        "usage: tables-to-go version",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cmd, args, err := parseCommand(test.positional)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, test.command, cmd.name)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+var (
+	// completionShells are the shells supported by the completion command
+	completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+	// completionValues maps the flags to their known values
+	completionValues = map[string][]string{
+		"t": {
+			string(settings.DBTypePostgresql), string(settings.DBTypeMySQL),
+			string(settings.DBTypeSQLite), string(settings.DBTypeOracle),
+			string(settings.DBTypeCockroachDB), string(settings.DBTypeMariaDB),
+		},
+		"null": {
+			string(settings.NullTypeSQL), string(settings.NullTypeNative),
+			string(settings.NullTypePrimitive), string(settings.NullTypePointer),
+			string(settings.NullTypeJSON), string(settings.NullTypeGuregu),
+		},
+		"log-level": {
+			string(settings.LogLevelTrace), string(settings.LogLevelDebug), string(settings.LogLevelInfo),
+			string(settings.LogLevelWarn), string(settings.LogLevelError),
+		},
+		"list-format":      {string(settings.ListFormatPlain), string(settings.ListFormatJSON), string(settings.ListFormatTable)},
+		"log-format":       {string(settings.LogFormatText), string(settings.LogFormatJSON)},
+		"format":           {string(settings.OutputFormatCamelCase), string(settings.OutputFormatOriginal)},
+		"fn-format":        {string(settings.FileNameFormatCamelCase), string(settings.FileNameFormatSnakeCase)},
+		"field-order":      {string(settings.FieldOrderOrdinal), string(settings.FieldOrderAlphabetical), string(settings.FieldOrderPKFirst)},
+		"go-version":       {string(settings.GoVersion121), string(settings.GoVersion122), string(settings.GoVersion124)},
+		"tags-db-case":     {string(settings.DbTagCaseOriginal), string(settings.DbTagCaseLower), string(settings.DbTagCaseQuoted)},
+		"tags-name-format": {string(settings.TagNameFormatCamelCase), string(settings.TagNameFormatSnakeCase), string(settings.TagNameFormatOriginal)},
+		"tags-json-omitempty": {
+			string(settings.OmitModeNone), string(settings.OmitModeAll), string(settings.OmitModeNullable),
+		},
+		"tags-json-omitzero": {
+			string(settings.OmitModeNone), string(settings.OmitModeAll), string(settings.OmitModeNullable),
+		},
+		"tags-order": {
+			"db", "json", "yaml", "toml", "csv", "mapstructure", "gorm", "bun", "xorm", "pg", "reform",
+			"validate", "swaggertype", "example", "enums", "protobuf", "faker", "graphql", "parquet",
+			"structable",
+		},
+	}
+
+	// completionFiles are the flags taking a file and completionDirs the
+	// ones taking a directory
+	completionFiles = []string{"config", "emit-ir", "from-dbml", "from-ir", "from-sql", "password-file", "plugin", "socket", "tags-protobuf-fields"}
+	completionDirs  = []string{"of"}
+)
+
+// completionFlag is a flag as needed for the completion scripts.
+type completionFlag struct {
+	name        string
+	description string
+	isBool      bool
+	values      []string
+	isFile      bool
+	isDir       bool
+}
+
+// writeCompletion writes the completion script for the given shell covering
+// the commands and the flags of the flag set with their known values.
+func writeCompletion(w io.Writer, shell string, fs *flag.FlagSet) error {
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		description, _, _ := strings.Cut(f.Usage, "\n")
+		description, _, _ = strings.Cut(description, ". ")
+		isBool := false
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok {
+			isBool = b.IsBoolFlag()
+		}
+		flags = append(flags, completionFlag{
+			name:        f.Name,
+			description: strings.TrimSuffix(description, "."),
+			isBool:      isBool,
+			values:      completionValues[settings.FlagName(f.Name)],
+			isFile:      contains(completionFiles, settings.FlagName(f.Name)),
+			isDir:       contains(completionDirs, settings.FlagName(f.Name)),
+		})
+	})
+	sort.Slice(flags, func(i, j int) bool { return flags[i].name < flags[j].name })
+
+	switch shell {
+	case "bash":
+		writeBashCompletion(w, flags)
+	case "zsh":
+		writeZshCompletion(w, flags)
+	case "fish":
+		writeFishCompletion(w, flags)
+	case "powershell":
+		writePowershellCompletion(w, flags)
+	default:
+		return fmt.Errorf("unsupported shell %q, supported: %v", shell, completionShells)
+	}
+	return nil
+}
+
+func writeBashCompletion(w io.Writer, flags []completionFlag) {
+	names := make([]string, len(flags))
+	for i, f := range flags {
+		names[i] = "-" + f.name
+	}
+
+	fmt.Fprintln(w, "# bash completion for tables-to-go")
+	fmt.Fprintln(w, "_tables_to_go() {")
+	fmt.Fprintln(w, `	local cur="${COMP_WORDS[COMP_CWORD]}"`)
+	fmt.Fprintln(w, `	local prev="${COMP_WORDS[COMP_CWORD-1]}"`)
+	fmt.Fprintln(w, `	case "$prev" in`)
+	for _, f := range flags {
+		switch {
+		case len(f.values) > 0:
+			fmt.Fprintf(w, "\t-%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", f.name, strings.Join(f.values, " "))
+		case f.isFile:
+			fmt.Fprintf(w, "\t-%s) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n", f.name)
+		case f.isDir:
+			fmt.Fprintf(w, "\t-%s) COMPREPLY=($(compgen -d -- \"$cur\")); return ;;\n", f.name)
+		}
+	}
+	fmt.Fprintf(w, "\tcompletion) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", strings.Join(completionShells, " "))
+	fmt.Fprintln(w, "\tesac")
+	fmt.Fprintln(w, `	if [[ "$cur" == -* ]]; then`)
+	fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(names, " "))
+	fmt.Fprintln(w, "\telse")
+	fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(commandNames(), " "))
+	fmt.Fprintln(w, "\tfi")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "complete -o default -F _tables_to_go tables-to-go")
+}
+
+func writeZshCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprintln(w, "#compdef tables-to-go")
+	fmt.Fprintln(w, "# zsh completion for tables-to-go")
+	fmt.Fprintln(w, "_arguments \\")
+	for _, f := range flags {
+		spec := "-" + f.name + "[" + zshEscape(f.description) + "]"
+		if !f.isBool {
+			action := " "
+			switch {
+			case len(f.values) > 0:
+				action = "(" + strings.Join(f.values, " ") + ")"
+			case f.isFile:
+				action = "_files"
+			case f.isDir:
+				action = "_files -/"
+			}
+			spec += ":" + f.name + ":" + action
+		}
+		fmt.Fprintf(w, "\t'%s' \\\n", strings.ReplaceAll(spec, "'", `'\''`))
+	}
+	fmt.Fprintf(w, "\t'1:command:(%s)' \\\n", strings.Join(commandNames(), " "))
+	fmt.Fprintf(w, "\t'2:shell:(%s)'\n", strings.Join(completionShells, " "))
+}
+
+// zshEscape escapes the characters with a special meaning in the
+// descriptions of _arguments.
+func zshEscape(s string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`, ":", `\:`).Replace(s)
+}
+
+func writeFishCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprintln(w, "# fish completion for tables-to-go")
+	fmt.Fprintln(w, "complete -c tables-to-go -f")
+	fmt.Fprintf(w, "complete -c tables-to-go -n __fish_use_subcommand -a '%s'\n", strings.Join(commandNames(), " "))
+	fmt.Fprintf(w, "complete -c tables-to-go -n '__fish_seen_subcommand_from completion' -a '%s'\n", strings.Join(completionShells, " "))
+	for _, f := range flags {
+		line := "complete -c tables-to-go -o " + fishQuote(f.name) + " -d " + fishQuote(f.description)
+		switch {
+		case len(f.values) > 0:
+			line += " -x -a " + fishQuote(strings.Join(f.values, " "))
+		case f.isFile:
+			line += " -r -F"
+		case f.isDir:
+			line += " -x -a '(__fish_complete_directories)'"
+		case !f.isBool:
+			line += " -x"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// fishQuote quotes the string for fish.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+func writePowershellCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprintln(w, "# powershell completion for tables-to-go")
+	fmt.Fprintln(w, "Register-ArgumentCompleter -Native -CommandName tables-to-go -ScriptBlock {")
+	fmt.Fprintln(w, "\tparam($wordToComplete, $commandAst, $cursorPosition)")
+	fmt.Fprintln(w, "\t$values = @{")
+	for _, f := range flags {
+		if len(f.values) > 0 {
+			fmt.Fprintf(w, "\t\t'-%s' = @(%s)\n", f.name, powershellList(f.values))
+		}
+	}
+	fmt.Fprintf(w, "\t\t'completion' = @(%s)\n", powershellList(completionShells))
+	fmt.Fprintln(w, "\t}")
+
+	names := make([]string, len(flags))
+	for i, f := range flags {
+		names[i] = "-" + f.name
+	}
+	fmt.Fprintf(w, "\t$flags = @(%s)\n", powershellList(names))
+	fmt.Fprintf(w, "\t$commands = @(%s)\n", powershellList(commandNames()))
+	fmt.Fprintln(w, "\t$words = @($commandAst.CommandElements | Where-Object { $_.Extent.EndOffset -lt $cursorPosition } | ForEach-Object { $_.ToString() })")
+	fmt.Fprintln(w, "\t$prev = if ($words.Count -gt 1) { $words[-1] } else { '' }")
+	fmt.Fprintln(w, "\tif ($values.ContainsKey($prev)) { $candidates = $values[$prev] }")
+	fmt.Fprintln(w, "\telseif ($wordToComplete -like '-*') { $candidates = $flags }")
+	fmt.Fprintln(w, "\telse { $candidates = $commands }")
+	fmt.Fprintln(w, "\t$candidates | Where-Object { $_ -like \"$wordToComplete*\" } | ForEach-Object {")
+	fmt.Fprintln(w, "\t\t[System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)")
+	fmt.Fprintln(w, "\t}")
+	fmt.Fprintln(w, "}")
+}
+
+// powershellList returns the strings as comma separated powershell literals.
+func powershellList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the completion scripts")
+
+func TestWriteCompletion(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("tables-to-go", flag.ContinueOnError)
+	fs.String("t", "pg", "type of database to use")
+	fs.Bool("v", false, "verbose output")
+	fs.String("config", "", "config file to load. Example: -config tables-to-go.yaml")
+	fs.String("of", ".", "output file path")
+	fs.String("pn", "dto", "package name [default: dto]: it's the name of the package")
+
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var out bytes.Buffer
+			if !assert.NoError(t, writeCompletion(&out, shell, fs)) {
+				return
+			}
+
+			golden := filepath.Join("testdata", "completion."+shell)
+			if *update {
+				assert.NoError(t, os.WriteFile(golden, out.Bytes(), 0666))
+				return
+			}
+			expected, err := os.ReadFile(golden)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, string(expected), out.String())
+		})
+	}
+}
+
+func TestWriteCompletion_AllFlagsAndCommands(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("tables-to-go", flag.ContinueOnError)
+	newCmdArgs(fs)
+
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var out bytes.Buffer
+			if !assert.NoError(t, writeCompletion(&out, shell, fs)) {
+				return
+			}
+
+			words := map[string]struct{}{}
+			for _, word := range strings.FieldsFunc(out.String(), func(r rune) bool {
+				return unicode.IsSpace(r) || strings.ContainsRune(`'"()[],`, r)
+			}) {
+				words[word] = struct{}{}
+			}
+
+			fs.VisitAll(func(f *flag.Flag) {
+				if shell == "fish" {
+					assert.Contains(t, out.String(), "-o "+fishQuote(f.Name)+" ", "flag -%s", f.Name)
+					return
+				}
+				assert.Contains(t, words, "-"+f.Name, "flag -%s", f.Name)
+			})
+			for _, name := range append(commandNames(), completionShells...) {
+				assert.Contains(t, words, name, "command %s", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/internal/cli"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// initConfig probes the database and writes a starter config file with the
+// settings and the discovered tables, in TOML if the file has the extension
+// .toml. An existing file is never overwritten.
+func initConfig(ctx context.Context, s *settings.Settings, db database.Database, file string) error {
+	if file == "" {
+		file = settings.DefaultConfigFiles[0]
+	}
+	if _, err := os.Stat(file); err == nil {
+		return fmt.Errorf("config file %q already exists", file)
+	}
+
+	tables, err := cli.Tables(ctx, s, db)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = table.Name
+	}
+
+	var buf bytes.Buffer
+	isTOML := strings.EqualFold(filepath.Ext(file), ".toml")
+	if err = s.WriteConfigTemplate(&buf, isTOML, names); err != nil {
+		return fmt.Errorf("could not create config file: %w", err)
+	}
+	if err = os.WriteFile(file, buf.Bytes(), 0666); err != nil {
+		return fmt.Errorf("could not write config file: %w", err)
+	}
+
+	slog.Info("wrote config file", "file", file, "tables", len(names))
+	return nil
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/cache"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// cachedDatabase is the database of the schema cached by a former run, it
+// does not get cached again.
+type cachedDatabase struct {
+	*ir.Database
+}
+
+// OpenCachedDatabase returns the database of the schema cached in CacheDir of
+// the settings by a former run, if it is younger than CacheTTL, or else the
+// one of OpenDatabase whose introspected schema Run caches. The schemas read
+// from files are not cached.
+func OpenCachedDatabase(settings *settings.Settings) (database.Database, error) {
+	name, ok := cacheFile(settings)
+	if !ok || settings.NoCache {
+		return OpenDatabase(settings)
+	}
+
+	info, err := os.Stat(name)
+	if err != nil {
+		slog.Debug("schema not cached, introspecting", "file", name)
+		return OpenDatabase(settings)
+	}
+	age := time.Since(info.ModTime())
+	if settings.CacheTTL > 0 && age > settings.CacheTTL {
+		slog.Debug("cached schema expired, introspecting", "file", name, "age", age.Round(time.Second))
+		return OpenDatabase(settings)
+	}
+
+	schema, err := ir.ReadFile(name)
+	if err != nil {
+		slog.Warn("could not read the cached schema, introspecting", "file", name, "error", err)
+		return OpenDatabase(settings)
+	}
+	db, err := ir.NewDatabase(settings, schema)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("generating from the cached schema", "file", name, "age", age.Round(time.Second))
+	return cachedDatabase{db}, nil
+}
+
+// writeCache caches the introspected tables in CacheDir of the settings, if
+// any. Tables failed to introspect leave the cache as it is, a later run
+// would miss them.
+func writeCache(settings *settings.Settings, db database.Database, tables []*database.Table, complete bool) error {
+	if !caches(settings, db) || !complete {
+		return nil
+	}
+	name, _ := cacheFile(settings)
+
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return fmt.Errorf("could not create the cache directory: %w", err)
+	}
+	// written aside first to never leave a partially written schema behind
+	tmp := name + ".tmp"
+	if err := ir.FromTables(settings, tables).WriteFile(tmp); err != nil {
+		return fmt.Errorf("could not cache the schema: %w", err)
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		return fmt.Errorf("could not cache the schema: %w", err)
+	}
+	slog.Debug("cached the schema", "file", name)
+	return nil
+}
+
+// caches returns true if the schema introspected from the database gets
+// cached by the settings, the cached schema does not get cached again.
+func caches(settings *settings.Settings, db database.Database) bool {
+	_, cached := db.(cachedDatabase)
+	_, ok := cacheFile(settings)
+	return ok && !cached
+}
+
+// cacheFile returns the file caching the schema of the database of the
+// settings, false if they do not cache it. The file differs by the tables
+// selected, by the version of tables-to-go and by whether the foreign keys get
+// introspected.
+func cacheFile(settings *settings.Settings) (string, bool) {
+	if settings.CacheDir == "" || settings.FromIR != "" || len(settings.FromSQL) > 0 || settings.FromDBML != "" {
+		return "", false
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d\n%s\n%s\n%s\n%s\n%s\n%t\n", ir.Version, settings.GeneratorVersion,
+		settings.Tables.String(), settings.TablesInclude.String(), settings.TablesExclude.String(), settings.IgnoreMarker,
+		settings.HasRelations())
+	version := hex.EncodeToString(hash.Sum(nil))
+
+	return cache.NewDir(settings.CacheDir).SchemaFile(cache.KeyOf(settings, version)), true
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database/fake"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestOpenCachedDatabase(t *testing.T) {
+	outputPath, cacheDir := t.TempDir(), t.TempDir()
+	newSettings := func() *settings.Settings {
+		s := settings.New()
+		s.OutputFilePath = outputPath
+		s.CacheDir = cacheDir
+		s.GeneratorVersion = "v2.0.0"
+		return s
+	}
+	s := newSettings()
+	users := &database.Table{
+		Name:    "users",
+		Columns: []database.Column{{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"}},
+	}
+	ctx := context.Background()
+
+	// nothing cached yet
+	db, err := OpenCachedDatabase(s)
+	assert.NoError(t, err)
+	_, cached := db.(cachedDatabase)
+	assert.False(t, cached)
+
+	// generating caches the introspected schema
+	assert.NoError(t, Run(ctx, s, fake.New(s, users), output.NewFileWriter(s.OutputFilePath)))
+	name, ok := cacheFile(s)
+	assert.True(t, ok)
+	assert.FileExists(t, name)
+
+	db, err = OpenCachedDatabase(s)
+	assert.NoError(t, err)
+	_, cached = db.(cachedDatabase)
+	assert.True(t, cached)
+	tables, err := db.GetTables(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, db.GetColumnsOfTable(ctx, tables[0]))
+	assert.Equal(t, users.Columns, tables[0].Columns)
+
+	// generating from the cache does not refresh it
+	expired := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(name, expired, expired))
+	assert.NoError(t, Run(ctx, s, db, output.NewFileWriter(s.OutputFilePath)))
+	info, err := os.Stat(name)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, expired, info.ModTime(), time.Second)
+
+	tests := []struct {
+		desc     string
+		settings func(*settings.Settings)
+		cached   bool
+	}{
+		{
+			desc:     "expired",
+			settings: func(*settings.Settings) {},
+			cached:   false,
+		},
+		{
+			desc:     "never expiring",
+			settings: func(s *settings.Settings) { s.CacheTTL = 0 },
+			cached:   true,
+		},
+		{
+			desc:     "not read without cache",
+			settings: func(s *settings.Settings) { s.CacheTTL = 0; s.NoCache = true },
+			cached:   false,
+		},
+		{
+			desc:     "other tables selected",
+			settings: func(s *settings.Settings) { s.CacheTTL = 0; s.Tables = []string{"users"} },
+			cached:   false,
+		},
+		{
+			desc:     "other version",
+			settings: func(s *settings.Settings) { s.CacheTTL = 0; s.GeneratorVersion = "v2.1.0" },
+			cached:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := newSettings()
+			test.settings(s)
+			db, err := OpenCachedDatabase(s)
+			assert.NoError(t, err)
+			_, cached := db.(cachedDatabase)
+			assert.Equal(t, test.cached, cached)
+		})
+	}
+}
+
+func TestWriteCache_incomplete(t *testing.T) {
+	s := settings.New()
+	s.CacheDir = t.TempDir()
+
+	assert.NoError(t, writeCache(s, database.New(s), []*database.Table{{Name: "users"}}, false))
+	name, _ := cacheFile(s)
+	assert.NoFileExists(t, name)
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Check generates the files in memory like Run, compares them with the files
+// in the output path and writes the ones out of date to w: files which would
+// be created or updated and generated files not generated anymore, followed
+// by their unified diffs. It returns true if any file is out of date.
+func Check(ctx context.Context, settings *settings.Settings, db database.Database, w io.Writer) (bool, error) {
+	out := output.NewDryRunWriter(settings.OutputFilePath)
+	if err := Run(ctx, settings, db, out); err != nil {
+		return false, err
+	}
+
+	var outdated, names []string
+	for name, change := range out.Changes() {
+		switch change {
+		case output.ChangeCreate:
+			outdated = append(outdated, name+output.FileWriterExtension+": missing")
+		case output.ChangeUpdate:
+			outdated = append(outdated, name+output.FileWriterExtension+": differs from the schema")
+		default:
+			continue
+		}
+		names = append(names, name)
+	}
+	stale, err := staleFiles(settings.OutputFilePath, out.Changes())
+	if err != nil {
+		return false, fmt.Errorf("could not find stale files: %w", err)
+	}
+	for _, name := range stale {
+		outdated = append(outdated, name+output.FileWriterExtension+": not generated anymore")
+	}
+	names = append(names, stale...)
+
+	if len(outdated) == 0 {
+		return false, nil
+	}
+
+	sort.Strings(outdated)
+	fmt.Fprintf(w, "%d generated files in %s are out of date, regenerate them:\n", len(outdated), settings.OutputFilePath)
+	for _, file := range outdated {
+		fmt.Fprintf(w, "  %s\n", file)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		generated, _ := out.Content(name)
+		if err = writeDiff(w, filepath.Join(settings.OutputFilePath, name+output.FileWriterExtension), generated); err != nil {
+			return false, fmt.Errorf("could not diff %s: %w", name+output.FileWriterExtension, err)
+		}
+	}
+	return true, nil
+}
+
+// writeDiff writes the unified diff of the file to the generated content to
+// w. A missing file diffs from /dev/null, empty generated content, i.e. of a
+// file not generated anymore, to /dev/null.
+func writeDiff(w io.Writer, file, generated string) error {
+	existing, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	diff := difflib.UnifiedDiff{
+		A:        diffLines(string(existing)),
+		B:        diffLines(generated),
+		FromFile: file,
+		ToFile:   file,
+		Context:  3,
+	}
+	if os.IsNotExist(err) {
+		diff.FromFile = os.DevNull
+	}
+	if generated == "" {
+		diff.ToFile = os.DevNull
+	}
+
+	fmt.Fprintln(w)
+	return difflib.WriteUnifiedDiff(w, diff)
+}
+
+// diffLines splits the content into its lines keeping their line breaks,
+// unlike difflib.SplitLines without an empty line following the last one.
+func diffLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestCheck(t *testing.T) {
+	s := settings.New()
+	s.OutputFilePath = t.TempDir()
+	s.GeneratorVersion = "v2.0.0"
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.On("GetTables").Return([]*database.Table{table}, nil)
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+	mdb.On("GetColumnsOfTable", table).Return(nil)
+
+	var buf bytes.Buffer
+	drift, err := Check(context.Background(), s, mdb, &buf)
+	assert.NoError(t, err)
+	assert.True(t, drift)
+	file := filepath.Join(s.OutputFilePath, "Users.go")
+	assert.Equal(t, "1 generated files in "+s.OutputFilePath+" are out of date, regenerate them:\n  Users.go: missing\n\n"+
+		"--- "+os.DevNull+"\n+++ "+file+"\n@@ -0,0 +1,11 @@\n+// Generated by tables-to-go v2.0.0\n+\n+package dto\n+\n"+
+		"+type Users struct {\n+\tID int `db:\"id\"`\n+}\n+\n+func (u Users) TableName() string {\n+\treturn \"users\"\n+}\n", buf.String())
+
+	// nothing got written by the check
+	entries, err := os.ReadDir(s.OutputFilePath)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.NoError(t, Run(context.Background(), s, mdb, output.NewFileWriter(s.OutputFilePath)))
+
+	buf.Reset()
+	drift, err = Check(context.Background(), s, mdb, &buf)
+	assert.NoError(t, err)
+	assert.False(t, drift)
+	assert.Empty(t, buf.String())
+
+	content := "// Generated by tables-to-go v1.0.0\n\npackage dto\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(s.OutputFilePath, "Orders.go"), []byte(content), 0666))
+	assert.NoError(t, os.WriteFile(filepath.Join(s.OutputFilePath, "Users.go"), []byte(content), 0666))
+
+	buf.Reset()
+	drift, err = Check(context.Background(), s, mdb, &buf)
+	assert.NoError(t, err)
+	assert.True(t, drift)
+	assert.Contains(t, buf.String(), "  Orders.go: not generated anymore\n  Users.go: differs from the schema\n")
+	assert.Contains(t, buf.String(), "--- "+filepath.Join(s.OutputFilePath, "Orders.go")+"\n+++ "+os.DevNull+"\n@@ -1,3 +0,0 @@\n-// Generated by tables-to-go v1.0.0\n")
+	assert.Contains(t, buf.String(), "@@ -1,3 +1,11 @@\n-// Generated by tables-to-go v1.0.0\n+// Generated by tables-to-go v2.0.0\n \n package dto\n")
+}
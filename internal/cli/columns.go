@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"strconv"
+	"strings"
+)
+
+// writeColumns writes the constants of the column names of the struct and,
+// unless the methods of the Model interface are written anyway, the methods
+// Columns, Values and ScanTargets in the order of the fields.
+func writeColumns(content *strings.Builder, structName string, fields []structField, withModelMethods bool) {
+	content.WriteString("\nconst (\n")
+	for _, field := range fields {
+		content.WriteString(columnConstName(structName, field))
+		content.WriteString(" = ")
+		content.WriteString(strconv.Quote(field.column.Name))
+		content.WriteString("\n")
+	}
+	content.WriteString(")\n")
+
+	if withModelMethods {
+		return
+	}
+
+	receiver := strings.ToLower(string(structName[0]))
+	var columns, values, scanTargets []string
+	for _, field := range fields {
+		columns = append(columns, columnConstName(structName, field))
+		values = append(values, receiver+"."+field.name)
+		scanTargets = append(scanTargets, "&"+receiver+"."+field.name)
+	}
+
+	writeSliceMethod(content, structName, "Columns", "[]string", columns, false)
+	writeSliceMethod(content, structName, "Values", "[]any", values, false)
+	writeSliceMethod(content, structName, "ScanTargets", "[]any", scanTargets, true)
+}
+
+// columnConstName returns the name of the constant of the column name of the
+// field, e.g. UsersColumnEmail.
+func columnConstName(structName string, field structField) string {
+	return structName + "Column" + field.name
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+func TestWriteColumns(t *testing.T) {
+	t.Parallel()
+
+	fields := []structField{
+		{
+			name:   "ID",
+			column: database.Column{Name: "id"},
+		},
+		{
+			name:   "EMail",
+			column: database.Column{Name: "e-mail"},
+		},
+	}
+
+	tests := []struct {
+		desc             string
+		withModelMethods bool
+		expected         string
+	}{
+		{
+			desc: "constants and methods",
+			expected: `
+const (
+FooColumnID = "id"
+FooColumnEMail = "e-mail"
+)
+
+func (f Foo) Columns() []string {
+	return []string{FooColumnID, FooColumnEMail}
+}
+
+func (f Foo) Values() []any {
+	return []any{f.ID, f.EMail}
+}
+
+func (f *Foo) ScanTargets() []any {
+	return []any{&f.ID, &f.EMail}
+}
+`,
+		},
+		{
+			desc:             "constants only along with the methods of the Model interface",
+			withModelMethods: true,
+			expected: `
+const (
+FooColumnID = "id"
+FooColumnEMail = "e-mail"
+)
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var content strings.Builder
+			writeColumns(&content, "Foo", fields, test.withModelMethods)
+			assert.Equal(t, test.expected, content.String())
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package cli
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// integerTypes are the Go types the id of LastInsertId converts to.
+var integerTypes = []string{"int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64"}
+
+// crudImports returns the import paths the CRUD repository of the settings
+// needs besides context.
+func crudImports(s *settings.Settings) []string {
+	if s.CRUD == settings.CRUDSQLX {
+		return []string{"github.com/jmoiron/sqlx"}
+	}
+	return nil
+}
+
+// addCRUDHelpers registers the DBTX interface the CRUD repositories of
+// database/sql query by as helper type.
+func addCRUDHelpers(s *settings.Settings, helpers *helperTypes) {
+	if s.CRUD == settings.CRUDSQL {
+		helpers.add("DBTX", dbtxDecl, "context", "database/sql")
+	}
+}
+
+// crudDialect writes the SQL of the CRUD repositories in the dialect of the
+// database type, the queries are built once at generation time.
+type crudDialect struct {
+	dbType settings.DBType
+	// schema qualifies the tables, empty for the default one
+	schema string
+}
+
+// newCRUDDialect returns the dialect of the settings, qualifying the tables
+// by their schema, see qualifyingSchema.
+func newCRUDDialect(s *settings.Settings) crudDialect {
+	return crudDialect{dbType: s.DbType.Dialect(), schema: qualifyingSchema(s)}
+}
+
+// qualifyingSchema returns the schema the generated SQL qualifies the tables
+// by, empty if it is the default one the connection resolves them in: public
+// of Postgres and Oracle, the database connected to of MySQL unless several
+// are generated, SQLite has no schemas.
+func qualifyingSchema(s *settings.Settings) string {
+	switch s.DbType.Dialect() {
+	case settings.DBTypeSQLite:
+	case settings.DBTypeMySQL:
+		if len(s.Schemas) > 0 {
+			return s.DbName
+		}
+	case settings.DBTypeOracle:
+		if s.Schema != "" && s.Schema != "public" {
+			return strings.ToUpper(s.Schema)
+		}
+	default:
+		if s.Schema != "" && s.Schema != "public" {
+			return s.Schema
+		}
+	}
+	return ""
+}
+
+func (d crudDialect) quote(name string) string {
+	if d.dbType == settings.DBTypeMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// table returns the quoted name of the table, qualified by the schema.
+func (d crudDialect) table(name string) string {
+	if d.schema == "" {
+		return d.quote(name)
+	}
+	return d.quote(d.schema) + "." + d.quote(name)
+}
+
+func (d crudDialect) quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.quote(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (d crudDialect) bindVar(n int) string {
+	switch d.dbType {
+	case settings.DBTypePostgresql:
+		return "$" + strconv.Itoa(n)
+	case settings.DBTypeOracle:
+		return ":" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
+// assign returns the columns joined by sep as "column = bindVar", the bind
+// variables numbered from offset on.
+func (d crudDialect) assign(columns []string, offset int, sep string) string {
+	assigned := make([]string, len(columns))
+	for i, column := range columns {
+		assigned[i] = d.quote(column) + " = " + d.bindVar(offset+i)
+	}
+	return strings.Join(assigned, sep)
+}
+
+// returning reports if the database sets the auto increment columns of an
+// INSERT by its RETURNING clause.
+func (d crudDialect) returning() bool {
+	return d.dbType == settings.DBTypePostgresql || d.dbType == settings.DBTypeSQLite
+}
+
+// writeCRUD writes the repository of the table with its CRUD operations for
+// the given struct. Tables without primary key get Insert and List only, the
+// views, which are read-only, no Insert, Update and Delete.
+func writeCRUD(content *strings.Builder, s *settings.Settings, db database.Database, t *database.Table, structName string, fields []structField) {
+	d := newCRUDDialect(s)
+	table := t.Name
+	repository := structName + "Repository"
+
+	var columns, insertColumns, pkColumns, setColumns []string
+	var targets, insertValues, pkParams, pkArgs, setValues []string
+	var autoIncrement []structField
+	for _, field := range fields {
+		value := "m." + field.name
+		columns = append(columns, field.column.Name)
+		targets = append(targets, "&"+value)
+
+		if isCRUDAutoIncrement(s, db, field.column, fields) {
+			autoIncrement = append(autoIncrement, field)
+		} else {
+			insertColumns = append(insertColumns, field.column.Name)
+			insertValues = append(insertValues, value)
+		}
+		if db.IsPrimaryKey(field.column) {
+			param := crudParamName(field.name)
+			pkColumns = append(pkColumns, field.column.Name)
+			pkParams = append(pkParams, param+" "+field.goType)
+			pkArgs = append(pkArgs, param)
+		} else {
+			setColumns = append(setColumns, field.column.Name)
+			setValues = append(setValues, value)
+		}
+	}
+
+	dbType := "DBTX"
+	if s.CRUD == settings.CRUDSQLX {
+		dbType = "sqlx.ExtContext"
+	}
+	if t.IsView() {
+		fmt.Fprintf(content, "\n// %s provides the read operations of view %s.\n", repository, table)
+	} else {
+		fmt.Fprintf(content, "\n// %s provides the CRUD operations of table %s.\n", repository, table)
+	}
+	fmt.Fprintf(content, "type %s struct {\n\tdb %s\n}\n", repository, dbType)
+	fmt.Fprintf(content, "\n// New%[1]s returns the %[1]s querying db.\n", repository)
+	fmt.Fprintf(content, "func New%[1]s(db %[2]s) *%[1]s {\n\treturn &%[1]s{db: db}\n}\n", repository, dbType)
+
+	selectAll := "SELECT " + d.quoteAll(columns) + " FROM " + d.table(table)
+	wherePK := " WHERE " + d.assign(pkColumns, 1, " AND ")
+
+	if !t.IsView() {
+		writeCRUDInsert(content, s, d, table, repository, structName, insertColumns, insertValues, autoIncrement)
+	}
+
+	if len(pkColumns) > 0 {
+		// GetByPK
+		fmt.Fprintf(content, "\n// GetByPK returns the row of the primary key.\n")
+		fmt.Fprintf(content, "func (r *%s) GetByPK(ctx context.Context, %s) (*%s, error) {\n", repository, strings.Join(pkParams, ", "), structName)
+		fmt.Fprintf(content, "\tvar m %s\n", structName)
+		if s.CRUD == settings.CRUDSQLX {
+			fmt.Fprintf(content, "\tif err := sqlx.GetContext(ctx, r.db, &m, %s%s); err != nil {\n", crudQuery(selectAll+wherePK), crudArgs(pkArgs))
+		} else {
+			fmt.Fprintf(content, "\tif err := r.db.QueryRowContext(ctx, %s%s).Scan(%s); err != nil {\n", crudQuery(selectAll+wherePK), crudArgs(pkArgs), strings.Join(targets, ", "))
+		}
+		content.WriteString("\t\treturn nil, err\n\t}\n\treturn &m, nil\n}\n")
+
+		// Update, the primary key columns are the only ones if there is
+		// nothing to set
+		if len(setColumns) > 0 && !t.IsView() {
+			update := "UPDATE " + d.table(table) + " SET " + d.assign(setColumns, 1, ", ") +
+				" WHERE " + d.assign(pkColumns, len(setColumns)+1, " AND ")
+			var pkValues []string
+			for _, field := range fields {
+				if db.IsPrimaryKey(field.column) {
+					pkValues = append(pkValues, "m."+field.name)
+				}
+			}
+			fmt.Fprintf(content, "\n// Update updates the row of the primary key of m.\n")
+			fmt.Fprintf(content, "func (r *%s) Update(ctx context.Context, m *%s) error {\n", repository, structName)
+			fmt.Fprintf(content, "\t_, err := r.db.ExecContext(ctx, %s%s)\n\treturn err\n}\n", crudQuery(update), crudArgs(append(setValues, pkValues...)))
+		}
+
+		// Delete
+		if !t.IsView() {
+			fmt.Fprintf(content, "\n// Delete deletes the row of the primary key.\n")
+			fmt.Fprintf(content, "func (r *%s) Delete(ctx context.Context, %s) error {\n", repository, strings.Join(pkParams, ", "))
+			fmt.Fprintf(content, "\t_, err := r.db.ExecContext(ctx, %s%s)\n\treturn err\n}\n", crudQuery("DELETE FROM "+d.table(table)+wherePK), crudArgs(pkArgs))
+		}
+	}
+
+	// List
+	if t.IsView() {
+		fmt.Fprintf(content, "\n// List returns all rows of view %s.\n", table)
+	} else {
+		fmt.Fprintf(content, "\n// List returns all rows of table %s.\n", table)
+	}
+	fmt.Fprintf(content, "func (r *%s) List(ctx context.Context) ([]%s, error) {\n", repository, structName)
+	fmt.Fprintf(content, "\tvar list []%s\n", structName)
+	if s.CRUD == settings.CRUDSQLX {
+		fmt.Fprintf(content, "\terr := sqlx.SelectContext(ctx, r.db, &list, %s)\n\treturn list, err\n}\n", crudQuery(selectAll))
+		return
+	}
+	fmt.Fprintf(content, "\trows, err := r.db.QueryContext(ctx, %s)\n", crudQuery(selectAll))
+	content.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n\tdefer rows.Close()\n\n")
+	content.WriteString("\tfor rows.Next() {\n")
+	fmt.Fprintf(content, "\t\tvar m %s\n", structName)
+	fmt.Fprintf(content, "\t\tif err := rows.Scan(%s); err != nil {\n\t\t\treturn nil, err\n\t\t}\n", strings.Join(targets, ", "))
+	content.WriteString("\t\tlist = append(list, m)\n\t}\n\treturn list, rows.Err()\n}\n")
+}
+
+// writeCRUDInsert writes the Insert of the repository, setting the auto
+// increment columns in m where the database returns them.
+func writeCRUDInsert(content *strings.Builder, s *settings.Settings, d crudDialect, table, repository, structName string, insertColumns, insertValues []string, autoIncrement []structField) {
+	insert := "INSERT INTO " + d.table(table) + " (" + d.quoteAll(insertColumns) + ") VALUES (" + crudBindVars(d, len(insertColumns)) + ")"
+	if len(insertColumns) == 0 {
+		insert = "INSERT INTO " + d.table(table) + " DEFAULT VALUES"
+		if d.dbType == settings.DBTypeMySQL {
+			insert = "INSERT INTO " + d.table(table) + " () VALUES ()"
+		}
+	}
+	args := crudArgs(insertValues)
+	content.WriteString("\n// Insert inserts the row of m, the auto increment columns are left to the\n// database")
+	switch {
+	case len(autoIncrement) > 0 && d.returning():
+		var returned, scanned []string
+		for _, field := range autoIncrement {
+			returned = append(returned, field.column.Name)
+			scanned = append(scanned, "&m."+field.name)
+		}
+		insert += " RETURNING " + d.quoteAll(returned)
+		rowMethod := "QueryRowContext"
+		if s.CRUD == settings.CRUDSQLX {
+			rowMethod = "QueryRowxContext"
+		}
+		content.WriteString(" and set in m.\n")
+		fmt.Fprintf(content, "func (r *%s) Insert(ctx context.Context, m *%s) error {\n", repository, structName)
+		fmt.Fprintf(content, "\treturn r.db.%s(ctx, %s%s).Scan(%s)\n}\n", rowMethod, crudQuery(insert), args, strings.Join(scanned, ", "))
+	case len(autoIncrement) == 1 && d.dbType == settings.DBTypeMySQL && slices.Contains(integerTypes, autoIncrement[0].goType):
+		content.WriteString(" and set in m.\n")
+		fmt.Fprintf(content, "func (r *%s) Insert(ctx context.Context, m *%s) error {\n", repository, structName)
+		fmt.Fprintf(content, "\tresult, err := r.db.ExecContext(ctx, %s%s)\n", crudQuery(insert), args)
+		content.WriteString("\tif err != nil {\n\t\treturn err\n\t}\n")
+		content.WriteString("\tid, err := result.LastInsertId()\n\tif err != nil {\n\t\treturn err\n\t}\n")
+		fmt.Fprintf(content, "\tm.%s = %s(id)\n\treturn nil\n}\n", autoIncrement[0].name, autoIncrement[0].goType)
+	default:
+		content.WriteString(".\n")
+		fmt.Fprintf(content, "func (r *%s) Insert(ctx context.Context, m *%s) error {\n", repository, structName)
+		fmt.Fprintf(content, "\t_, err := r.db.ExecContext(ctx, %s%s)\n\treturn err\n}\n", crudQuery(insert), args)
+	}
+}
+
+// isCRUDAutoIncrement returns if the database sets the column on insert.
+// Of SQLite, that is the rowid alias only, the single primary key column of
+// type INTEGER, not any primary key column.
+func isCRUDAutoIncrement(s *settings.Settings, db database.Database, column database.Column, fields []structField) bool {
+	if s.DbType != settings.DBTypeSQLite {
+		return db.IsAutoIncrement(column)
+	}
+	primaryKeys := 0
+	for _, field := range fields {
+		if db.IsPrimaryKey(field.column) {
+			primaryKeys++
+		}
+	}
+	return primaryKeys == 1 && db.IsPrimaryKey(column) && strings.EqualFold(column.DataType, "integer")
+}
+
+// crudParamName returns the name of the parameter of the primary key field,
+// the field with its leading upper case letters lowered, e.g. userID of
+// UserID or httpID of HTTPID, not colliding with Go keywords or the names
+// used in the CRUD operations.
+func crudParamName(field string) string {
+	runes := []rune(field)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	// the last one of several upper case letters begins the next word,
+	// unless they are all of the name
+	if upper > 1 && upper < len(runes) && unicode.IsLower(runes[upper]) {
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	name := string(runes)
+	if token.IsKeyword(name) || slices.Contains([]string{"ctx", "r", "m", "err"}, name) {
+		name += "_"
+	}
+	return name
+}
+
+// crudQuery returns the query as Go string literal, a raw one unless the
+// query quotes by backquotes.
+func crudQuery(query string) string {
+	if strconv.CanBackquote(query) {
+		return "`" + query + "`"
+	}
+	return strconv.Quote(query)
+}
+
+// crudBindVars returns the n bind variables of an INSERT.
+func crudBindVars(d crudDialect, n int) string {
+	vars := make([]string, n)
+	for i := range vars {
+		vars[i] = d.bindVar(i + 1)
+	}
+	return strings.Join(vars, ", ")
+}
+
+// crudArgs returns the arguments of a query following its SQL.
+func crudArgs(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(args, ", ")
+}
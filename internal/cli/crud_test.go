@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestWriteCRUD(t *testing.T) {
+	t.Parallel()
+
+	primaryKey := sql.NullString{String: "PRIMARY KEY", Valid: true}
+
+	tests := []struct {
+		desc      string
+		dbType    settings.DBType
+		crud      settings.CRUD
+		schema    string
+		schemas   map[string]settings.SchemaOutput
+		tableType string
+		fields    []structField
+		expected  []string
+		missing   []string
+	}{
+		{
+			desc:   "postgres with database/sql returns the serial id",
+			dbType: settings.DBTypePostgresql,
+			crud:   settings.CRUDSQL,
+			fields: []structField{
+				{name: "ID", goType: "int", column: database.Column{Name: "id", ConstraintType: primaryKey,
+					DefaultValue: sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true}}},
+				{name: "Name", goType: "string", column: database.Column{Name: "name"}},
+			},
+			expected: []string{
+				"type UsersRepository struct {\n\tdb DBTX\n}\n",
+				"func NewUsersRepository(db DBTX) *UsersRepository {\n\treturn &UsersRepository{db: db}\n}\n",
+				"\treturn r.db.QueryRowContext(ctx, `INSERT INTO \"users\" (\"name\") VALUES ($1) RETURNING \"id\"`, m.Name).Scan(&m.ID)\n",
+				"func (r *UsersRepository) GetByPK(ctx context.Context, id int) (*Users, error) {\n\tvar m Users\n" +
+					"\tif err := r.db.QueryRowContext(ctx, `SELECT \"id\", \"name\" FROM \"users\" WHERE \"id\" = $1`, id).Scan(&m.ID, &m.Name); err != nil {\n",
+				"\t_, err := r.db.ExecContext(ctx, `UPDATE \"users\" SET \"name\" = $1 WHERE \"id\" = $2`, m.Name, m.ID)\n",
+				"func (r *UsersRepository) Delete(ctx context.Context, id int) error {\n\t_, err := r.db.ExecContext(ctx, `DELETE FROM \"users\" WHERE \"id\" = $1`, id)\n",
+				"\trows, err := r.db.QueryContext(ctx, `SELECT \"id\", \"name\" FROM \"users\"`)\n",
+				"\t\tif err := rows.Scan(&m.ID, &m.Name); err != nil {\n",
+			},
+		},
+		{
+			desc:   "mysql with sqlx sets the last insert id",
+			dbType: settings.DBTypeMySQL,
+			crud:   settings.CRUDSQLX,
+			fields: []structField{
+				{name: "ID", goType: "int64", column: database.Column{Name: "id", ColumnKey: "PRI", Extra: "auto_increment"}},
+				{name: "Type", goType: "string", column: database.Column{Name: "type"}},
+			},
+			expected: []string{
+				"type UsersRepository struct {\n\tdb sqlx.ExtContext\n}\n",
+				"\tresult, err := r.db.ExecContext(ctx, \"INSERT INTO `users` (`type`) VALUES (?)\", m.Type)\n",
+				"\tm.ID = int64(id)\n",
+				"\tif err := sqlx.GetContext(ctx, r.db, &m, \"SELECT `id`, `type` FROM `users` WHERE `id` = ?\", id); err != nil {\n",
+				"\terr := sqlx.SelectContext(ctx, r.db, &list, \"SELECT `id`, `type` FROM `users`\")\n",
+			},
+		},
+		{
+			desc:   "composite primary key without other columns",
+			dbType: settings.DBTypeSQLite,
+			crud:   settings.CRUDSQL,
+			fields: []structField{
+				{name: "UserID", goType: "int", column: database.Column{Name: "user_id", ColumnKey: "PK"}},
+				{name: "Type", goType: "string", column: database.Column{Name: "type", ColumnKey: "PK"}},
+			},
+			expected: []string{
+				"\t_, err := r.db.ExecContext(ctx, `INSERT INTO \"users\" (\"user_id\", \"type\") VALUES (?, ?)`, m.UserID, m.Type)\n",
+				"func (r *UsersRepository) GetByPK(ctx context.Context, userID int, type_ string) (*Users, error) {\n",
+				"\t_, err := r.db.ExecContext(ctx, `DELETE FROM \"users\" WHERE \"user_id\" = ? AND \"type\" = ?`, userID, type_)\n",
+			},
+			missing: []string{"Update("},
+		},
+		{
+			desc:   "sqlite returns the rowid alias",
+			dbType: settings.DBTypeSQLite,
+			crud:   settings.CRUDSQL,
+			fields: []structField{
+				{name: "ID", goType: "int", column: database.Column{Name: "id", DataType: "INTEGER", ColumnKey: "PK"}},
+				{name: "Name", goType: "string", column: database.Column{Name: "name", DataType: "TEXT"}},
+			},
+			expected: []string{
+				"\treturn r.db.QueryRowContext(ctx, `INSERT INTO \"users\" (\"name\") VALUES (?) RETURNING \"id\"`, m.Name).Scan(&m.ID)\n",
+			},
+		},
+		{
+			desc:   "table without primary key",
+			dbType: settings.DBTypePostgresql,
+			crud:   settings.CRUDSQL,
+			fields: []structField{
+				{name: "Name", goType: "string", column: database.Column{Name: "name"}},
+			},
+			expected: []string{"Insert(", "List("},
+			missing:  []string{"GetByPK(", "Update(", "Delete("},
+		}, {
+			desc:      "view is read-only",
+			dbType:    settings.DBTypeMySQL,
+			crud:      settings.CRUDSQL,
+			tableType: database.TableTypeView,
+			fields: []structField{
+				{name: "ID", goType: "int", column: database.Column{Name: "id", ColumnKey: "PRI"}},
+				{name: "Name", goType: "string", column: database.Column{Name: "name"}},
+			},
+			expected: []string{"// UsersRepository provides the read operations of view users.\n", "GetByPK(", "List("},
+			missing:  []string{"Insert(", "Update(", "Delete("},
+		},
+		{
+			desc:   "postgres qualifies the tables of other schemas than public",
+			dbType: settings.DBTypePostgresql,
+			crud:   settings.CRUDSQL,
+			schema: "billing",
+			fields: []structField{
+				{name: "ID", goType: "int", column: database.Column{Name: "id", ConstraintType: primaryKey}},
+				{name: "Name", goType: "string", column: database.Column{Name: "name"}},
+			},
+			expected: []string{
+				"`INSERT INTO \"billing\".\"users\" (\"id\", \"name\") VALUES ($1, $2)`",
+				"`SELECT \"id\", \"name\" FROM \"billing\".\"users\" WHERE \"id\" = $1`",
+				"`UPDATE \"billing\".\"users\" SET \"name\" = $1 WHERE \"id\" = $2`",
+				"`DELETE FROM \"billing\".\"users\" WHERE \"id\" = $1`",
+				"`SELECT \"id\", \"name\" FROM \"billing\".\"users\"`",
+			},
+		},
+		{
+			desc:   "oracle qualifies the tables by the upper case schema",
+			dbType: settings.DBTypeOracle,
+			crud:   settings.CRUDSQL,
+			schema: "hr",
+			fields: []structField{
+				{name: "Name", goType: "string", column: database.Column{Name: "name"}},
+			},
+			expected: []string{"`INSERT INTO \"HR\".\"users\" (\"name\") VALUES (:1)`"},
+		},
+		{
+			desc:   "mysql qualifies the tables by the database of multi-schema runs",
+			dbType: settings.DBTypeMySQL,
+			crud:   settings.CRUDSQL,
+			schema: "billing",
+			schemas: map[string]settings.SchemaOutput{
+				"billing": {},
+				"auth":    {},
+			},
+			fields: []structField{
+				{name: "Name", goType: "string", column: database.Column{Name: "name"}},
+			},
+			expected: []string{"\"INSERT INTO `billing`.`users` (`name`) VALUES (?)\"", "\"SELECT `name` FROM `billing`.`users`\""},
+		},
+		{
+			desc:   "mysql queries the database connected to",
+			dbType: settings.DBTypeMySQL,
+			crud:   settings.CRUDSQL,
+			schema: "billing",
+			fields: []structField{
+				{name: "Name", goType: "string", column: database.Column{Name: "name"}},
+			},
+			expected: []string{"\"INSERT INTO `users` (`name`) VALUES (?)\""},
+		},
+		{
+			desc:   "sqlite has no schemas",
+			dbType: settings.DBTypeSQLite,
+			crud:   settings.CRUDSQL,
+			schema: "billing",
+			fields: []structField{
+				{name: "Name", goType: "string", column: database.Column{Name: "name"}},
+			},
+			expected: []string{"`INSERT INTO \"users\" (\"name\") VALUES (?)`"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			s.CRUD = test.crud
+			if test.schema != "" {
+				s.Schema = test.schema
+				s.DbName = test.schema
+			}
+			s.Schemas = test.schemas
+			db := database.New(s)
+
+			var content strings.Builder
+			writeCRUD(&content, s, db, &database.Table{Name: "users", Type: test.tableType}, "Users", test.fields)
+
+			for _, expected := range test.expected {
+				assert.Contains(t, content.String(), expected)
+			}
+			for _, missing := range test.missing {
+				assert.NotContains(t, content.String(), missing)
+			}
+		})
+	}
+}
+
+func TestCrudParamName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{field: "ID", expected: "id"},
+		{field: "UserID", expected: "userID"},
+		{field: "HTTPStatus", expected: "httpStatus"},
+		{field: "Type", expected: "type_"},
+		{field: "Err", expected: "err_"},
+		{field: "X1click", expected: "x1click"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			assert.Equal(t, test.expected, crudParamName(test.field))
+		})
+	}
+}
+
+func TestRun_WithCRUD(t *testing.T) {
+	tests := []struct {
+		desc    string
+		crud    settings.CRUD
+		imports string
+		helpers bool
+	}{
+		{
+			desc:    "database/sql",
+			crud:    settings.CRUDSQL,
+			imports: "import (\n\t\"context\"\n)\n",
+			helpers: true,
+		},
+		{
+			desc:    "sqlx",
+			crud:    settings.CRUDSQLX,
+			imports: "import (\n\t\"context\"\n\t\n\"github.com/jmoiron/sqlx\"\n)\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.CRUD = test.crud
+			db := database.New(s)
+
+			table := &database.Table{
+				Name: "users",
+				Columns: []database.Column{
+					{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO", ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true}},
+				},
+			}
+
+			mdb := newMockDB(db)
+			mdb.On("GetTables").Return([]*database.Table{table}, nil)
+			mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+			mdb.On("GetColumnsOfTable", table).Return(nil)
+
+			w := newMockWriter()
+			w.On("Write", "Users", mock.Anything).Return(nil)
+			if test.helpers {
+				w.On("Write", helperTypesFileName, mock.Anything).Return(nil)
+			}
+
+			assert.NoError(t, Run(context.Background(), s, mdb, w))
+			w.AssertExpectations(t)
+
+			assert.Contains(t, w.Calls[0].Arguments.String(1), test.imports)
+			assert.Contains(t, w.Calls[0].Arguments.String(1), "func (r *UsersRepository) GetByPK(ctx context.Context, id int) (*Users, error) {")
+			if test.helpers {
+				assert.Contains(t, w.Calls[1].Arguments.String(1), "type DBTX interface {")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/dbml"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ddl"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/plugin"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// OpenDatabase returns the database to generate from: the schema file of
+// FromIR, the tables of the SQL files of FromSQL or of the DBML file of
+// FromDBML or else the database of the settings to connect to, by the dialect
+// plugin of Plugin, if any, connecting through the SSH tunnel of SSHHost, if
+// any.
+func OpenDatabase(settings *settings.Settings) (database.Database, error) {
+	switch {
+	case settings.FromIR != "":
+		return ir.Open(settings)
+	case len(settings.FromSQL) > 0:
+		return ddl.Open(settings)
+	case settings.FromDBML != "":
+		return dbml.Open(settings)
+	}
+
+	var db database.Database
+	if settings.Plugin != "" {
+		var err error
+		if db, err = plugin.Open(settings); err != nil {
+			return nil, err
+		}
+	} else {
+		db = database.New(settings)
+	}
+	if settings.SSHHost != "" {
+		return database.NewSSHTunnel(db, settings), nil
+	}
+	return db, nil
+}
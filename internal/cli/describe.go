@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// describedColumn is a column as described by Describe, merged from the rows
+// of all constraints the column is part of.
+type describedColumn struct {
+	column database.Column
+	keys   []string
+}
+
+// Describe writes the introspected columns of the table to w with their
+// database type, nullability, keys and the struct field and Go type they map
+// to by the settings, without generating anything.
+func Describe(ctx context.Context, settings *settings.Settings, db database.Database, table string, w io.Writer) error {
+
+	helpers = newHelperTypes()
+
+	tables, err := db.GetTables(ctx, table)
+	if err != nil {
+		return fmt.Errorf("could not get table %q: %w", table, err)
+	}
+	if len(tables) == 0 {
+		return fmt.Errorf("table %q not found", table)
+	}
+	t := tables[0]
+
+	if err = db.PrepareGetColumnsOfTableStmt(ctx); err != nil {
+		return fmt.Errorf("could not prepare the get-column-statement: %w", err)
+	}
+	columns := withEnums(ctx, settings, db, tables[:1], func(int) error {
+		return db.GetColumnsOfTable(ctx, t)
+	})
+	if err = columns(0); err != nil {
+		return fmt.Errorf("could not get columns of table %q: %w", t.Name, err)
+	}
+
+	tableName, err := structNameOf(settings, t.Name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Table %s, generated as struct %s in %s\n", t.Name, tableName, naming.FileName(settings, t.Name, tableName)+output.FileWriterExtension)
+	if !settings.IsTableIncluded(t.Name) {
+		fmt.Fprintln(w, "Note: the table is left out by the table filters")
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tCOLUMN\tTYPE\tNULL\tKEYS\tDEFAULT\tFIELD\tGO TYPE")
+	for _, c := range describeColumns(db, t.Columns) {
+		column := c.column
+
+		null := "NO"
+		if db.IsNullable(column) {
+			null = "YES"
+		}
+
+		field, goType := "-", "-"
+		if isColumnExcluded(settings, t.Name, column) {
+			field = "(excluded)"
+		} else {
+			if field, err = fieldNameOf(settings, t.Name, column.Name); err != nil {
+				return err
+			}
+			goType, _ = mapDbColumnTypeToGoType(settings, db, t.Name, column)
+			if !isMappedType(settings, db, t.Name, column) {
+				goType += " (unmapped)"
+			}
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			column.OrdinalPosition, column.Name, columnType(column), null,
+			orDash(strings.Join(c.keys, ", ")), orDash(column.DefaultValue.String), field, goType)
+	}
+	return tw.Flush()
+}
+
+// describeColumns merges the columns occurring once per constraint, see ISSUE-4
+// in createTableStructString, and collects their keys.
+func describeColumns(db database.Database, columns []database.Column) []describedColumn {
+	var described []describedColumn
+
+	for _, column := range database.MergeColumns(columns) {
+		var keys []string
+		if db.IsPrimaryKey(column) {
+			keys = append(keys, "PK")
+		}
+		if db.IsAutoIncrement(column) {
+			keys = append(keys, "auto increment")
+		}
+		for _, constraint := range column.Constraints {
+			switch constraint.Type {
+			case "FOREIGN KEY":
+				keys = append(keys, "FK "+constraint.Name)
+			case "UNIQUE":
+				keys = appendKey(keys, "unique")
+			}
+		}
+		switch column.ColumnKey {
+		case "UNI":
+			keys = appendKey(keys, "unique")
+		case "MUL":
+			keys = append(keys, "index")
+		}
+
+		described = append(described, describedColumn{column: column, keys: keys})
+	}
+	return described
+}
+
+// appendKey appends the key unless the keys contain it.
+func appendKey(keys []string, key string) []string {
+	if slices.Contains(keys, key) {
+		return keys
+	}
+	return append(keys, key)
+}
+
+// orDash returns s or "-" if empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
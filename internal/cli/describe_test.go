@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	assert.NoError(t, s.ColumnsExclude.Set("legacy"))
+	assert.NoError(t, s.TablesExclude.Set("^users$"))
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				DefaultValue:    sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "org_id",
+				DataType:        "integer",
+				IsNullable:      "YES",
+				ConstraintName:  sql.NullString{String: "users_org_fk", Valid: true},
+				ConstraintType:  sql.NullString{String: "FOREIGN KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "org_id",
+				DataType:        "integer",
+				IsNullable:      "YES",
+				ConstraintName:  sql.NullString{String: "users_org_key", Valid: true},
+				ConstraintType:  sql.NullString{String: "UNIQUE", Valid: true},
+			},
+			{
+				OrdinalPosition:        3,
+				Name:                   "name",
+				DataType:               "character varying",
+				IsNullable:             "NO",
+				CharacterMaximumLength: sql.NullInt64{Int64: 100, Valid: true},
+			},
+			{
+				OrdinalPosition: 4,
+				Name:            "location",
+				DataType:        "geometry",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 5,
+				Name:            "legacy",
+				DataType:        "text",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.On("GetTables", []string{"users"}).Return([]*database.Table{table}, nil)
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+	mdb.On("GetColumnsOfTable", table).Return(nil)
+
+	var buf bytes.Buffer
+	assert.NoError(t, Describe(context.Background(), s, mdb, "users", &buf))
+
+	expected := `Table users, generated as struct Users in Users.go
+Note: the table is left out by the table filters
+
+#  COLUMN    TYPE                    NULL  KEYS                     DEFAULT                            FIELD       GO TYPE
+1  id        integer                 NO    PK, auto increment       nextval('users_id_seq'::regclass)  ID          int
+2  org_id    integer                 YES   FK users_org_fk, unique  -                                  OrgID       sql.NullInt64
+3  name      character varying(100)  NO    -                        -                                  Name        string
+4  location  geometry                YES   -                        -                                  Location    sql.NullString (unmapped)
+5  legacy    text                    YES   -                        -                                  (excluded)  -
+`
+	assert.Equal(t, expected, buf.String())
+	mdb.AssertExpectations(t)
+}
+
+func TestDescribe_NotFound(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	mdb := newMockDB(database.New(s))
+	mdb.On("GetTables", []string{"missing"}).Return([]*database.Table{}, nil)
+	mdb.On("GetTables", []string{"broken"}).Return(nil, errors.New("connection lost"))
+
+	var buf bytes.Buffer
+	assert.EqualError(t, Describe(context.Background(), s, mdb, "missing", &buf), `table "missing" not found`)
+	assert.Error(t, Describe(context.Background(), s, mdb, "broken", &buf))
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// changesRecorder is implemented by writers recording the changes instead of
+// writing them, like the output.DryRunWriter.
+type changesRecorder interface {
+	Changes() map[string]output.Change
+}
+
+// dryRunReport collects the summary of a dry run. A nil dryRunReport collects
+// nothing.
+type dryRunReport struct {
+	discovered int
+
+	// filtered are the names of the tables left out by the filters or the
+	// interactive selection
+	filtered []string
+
+	// columns are the number of columns per generated table
+	columns []tableColumns
+
+	// unmapped are the columns by their database type not mapped to a
+	// specific Go type
+	unmapped map[string][]string
+}
+
+type tableColumns struct {
+	table string
+	count int
+}
+
+// newDryRunReport creates the report of a dry run, or nil if the settings do
+// not ask for a dry run.
+func newDryRunReport(settings *settings.Settings) *dryRunReport {
+	if !settings.DryRun {
+		return nil
+	}
+	return &dryRunReport{
+		unmapped: map[string][]string{},
+	}
+}
+
+// tables records the discovered tables and the ones left for generation.
+func (r *dryRunReport) tables(discovered, generated []*database.Table) {
+	if r == nil {
+		return
+	}
+
+	r.discovered = len(discovered)
+	for _, table := range discovered {
+		if !slices.Contains(generated, table) {
+			r.filtered = append(r.filtered, table.Name)
+		}
+	}
+}
+
+// table records the columns of the table which would be generated.
+func (r *dryRunReport) table(settings *settings.Settings, db database.Database, table *database.Table) {
+	if r == nil {
+		return
+	}
+
+	// columns can occur multiple times, see ISSUE-4 in createTableStructString
+	columns := map[string]struct{}{}
+	for _, column := range table.Columns {
+		if _, ok := columns[column.Name]; ok || isColumnExcluded(settings, table.Name, column) {
+			continue
+		}
+		columns[column.Name] = struct{}{}
+
+		if !isMappedType(settings, db, table.Name, column) {
+			r.unmapped[column.DataType] = append(r.unmapped[column.DataType], table.Name+"."+column.Name)
+		}
+	}
+	r.columns = append(r.columns, tableColumns{table: table.Name, count: len(columns)})
+}
+
+// write writes the summary with the changes of the files recorded by out, if
+// it records them, and the stale files in the output path of the settings.
+func (r *dryRunReport) write(w io.Writer, settings *settings.Settings, out output.Writer) error {
+	if r == nil {
+		return nil
+	}
+
+	var changes map[string]output.Change
+	if recorder, ok := out.(changesRecorder); ok {
+		changes = recorder.Changes()
+	}
+	stale, err := staleFiles(settings.OutputFilePath, changes)
+	if err != nil {
+		return fmt.Errorf("could not find stale files: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "Dry run summary, nothing got written")
+	fmt.Fprintf(tw, "  tables discovered:\t%d\n", r.discovered)
+	fmt.Fprintf(tw, "  tables filtered:\t%d", len(r.filtered))
+	if len(r.filtered) > 0 {
+		fmt.Fprintf(tw, " (%s)", strings.Join(r.filtered, ", "))
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintf(tw, "  columns per table:\n")
+	for _, columns := range r.columns {
+		fmt.Fprintf(tw, "    %s\t%d\n", columns.table, columns.count)
+	}
+
+	fmt.Fprintf(tw, "  files:\n")
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(tw, "    %s\t%s\n", changes[name], name+output.FileWriterExtension)
+	}
+	for _, name := range stale {
+		fmt.Fprintf(tw, "    stale\t%s (generated before, not anymore)\n", name+output.FileWriterExtension)
+	}
+
+	if len(r.unmapped) > 0 {
+		fmt.Fprintf(tw, "  unmapped types (generated as string):\n")
+		types := make([]string, 0, len(r.unmapped))
+		for dataType := range r.unmapped {
+			types = append(types, dataType)
+		}
+		sort.Strings(types)
+		for _, dataType := range types {
+			fmt.Fprintf(tw, "    %s\t%s\n", dataType, strings.Join(r.unmapped[dataType], ", "))
+		}
+	}
+
+	return tw.Flush()
+}
+
+// staleFiles returns the names of the files in dir generated by tables-to-go
+// which are not part of the changes, i.e. not generated anymore.
+func staleFiles(dir string, changes map[string]output.Change) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), output.FileWriterExtension)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if _, ok = changes[name]; ok {
+			continue
+		}
+		generated, err := isGeneratedFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if generated {
+			stale = append(stale, name)
+		}
+	}
+	return stale, nil
+}
+
+// isGeneratedFile returns true if the file starts with the header written by
+// fileHeader.
+func isGeneratedFile(name string) (bool, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	return strings.HasPrefix(line, generatedByPrefix), nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestDryRunReport(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	assert.Nil(t, newDryRunReport(s))
+
+	s.DryRun = true
+	s.OutputFilePath = t.TempDir()
+	assert.NoError(t, s.ColumnsExclude.Set("legacy"))
+	db := database.New(s)
+
+	places := &database.Table{
+		Name: "places",
+		Columns: []database.Column{
+			{Name: "id", DataType: "integer", IsNullable: "NO"},
+			{Name: "id", DataType: "integer", IsNullable: "NO"},
+			{Name: "location", DataType: "geometry", IsNullable: "YES"},
+			{Name: "legacy", DataType: "xml", IsNullable: "YES"},
+		},
+	}
+	users := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{Name: "id", DataType: "integer", IsNullable: "NO"},
+			{Name: "name", DataType: "text", IsNullable: "NO"},
+			{Name: "area", DataType: "geometry", IsNullable: "NO"},
+		},
+	}
+	audit := &database.Table{Name: "audit_log"}
+
+	report := newDryRunReport(s)
+	report.tables([]*database.Table{audit, places, users}, []*database.Table{places, users})
+	report.table(s, db, places)
+	report.table(s, db, users)
+
+	content := "// Generated by tables-to-go v2.0.0\n\npackage dto\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(s.OutputFilePath, "Users.go"), []byte(content), 0666))
+	assert.NoError(t, os.WriteFile(filepath.Join(s.OutputFilePath, "AuditLog.go"), []byte(content), 0666))
+	assert.NoError(t, os.WriteFile(filepath.Join(s.OutputFilePath, "custom.go"), []byte("package dto\n"), 0666))
+
+	out := output.NewDryRunWriter(s.OutputFilePath)
+	assert.NoError(t, out.Write("Places", "package dto\n"))
+	assert.NoError(t, out.Write("Users", "package dto\ntype Users struct{}\n"))
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.write(&buf, s, out))
+
+	expected := `Dry run summary, nothing got written
+  tables discovered:  3
+  tables filtered:    1 (audit_log)
+  columns per table:
+    places  2
+    users   3
+  files:
+    create  Places.go
+    update  Users.go
+    stale   AuditLog.go (generated before, not anymore)
+  unmapped types (generated as string):
+    geometry  places.location, users.area
+`
+	assert.Equal(t, expected, buf.String())
+
+	// nothing got written
+	entries, err := os.ReadDir(s.OutputFilePath)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	// a nil report collects and writes nothing
+	var disabled *dryRunReport
+	disabled.tables([]*database.Table{audit}, nil)
+	disabled.table(s, db, users)
+	assert.NoError(t, disabled.write(&buf, s, out))
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// enumMethodsDecl are the methods of an enum type scanning and storing it as
+// string. The verb is the name of the enum type.
+const enumMethodsDecl = `
+// Scan implements the sql.Scanner interface.
+func (e *%[1]s) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		*e = %[1]s(v)
+	case []byte:
+		*e = %[1]s(v)
+	default:
+		return fmt.Errorf("cannot scan %%T into %[1]s", src)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (e %[1]s) Value() (driver.Value, error) {
+	return string(e), nil
+}`
+
+// withEnums returns the function waiting for the columns of the i-th table,
+// which gets the enum types of the table as well if the settings generate
+// enum types and the database declares them apart from the columns. The enum
+// columns of the other databases have their values already.
+func withEnums(ctx context.Context, s *settings.Settings, db database.Database, tables []*database.Table, columns func(i int) error) func(i int) error {
+	if !s.EnumTypes {
+		return columns
+	}
+	getter, ok := database.As[database.EnumsGetter](db)
+	if !ok {
+		return columns
+	}
+	return func(i int) error {
+		if err := columns(i); err != nil {
+			return err
+		}
+		if err := getter.GetEnumsOfTable(ctx, tables[i]); err != nil {
+			return fmt.Errorf("could not get enum types: %w", err)
+		}
+		return nil
+	}
+}
+
+// enumTypeOf registers the enum type of the enum column of the table and
+// returns its name: the one of the enum type of the column, e.g. OrderStatus
+// of order_status, or else the one of the table and the column, e.g.
+// OrdersStatus. The name gets renamed by the RenameStrategy of the settings
+// if it collides with a struct, a reserved name or another enum type.
+func enumTypeOf(s *settings.Settings, table string, column database.Column) (string, error) {
+	typeName, of := column.EnumType, "enum type "+column.EnumType
+	if typeName == "" {
+		typeName, of = table+"_"+column.Name, "enum column "+column.Name+" of table "+table
+	}
+	name, err := formatColumnName(s, typeName, table)
+	if err != nil {
+		return "", err
+	}
+
+	constants := make([]string, len(column.EnumValues))
+	for i, value := range column.EnumValues {
+		if constants[i], err = formatColumnName(s, value, table); err != nil {
+			return "", err
+		}
+	}
+
+	reserved := func(name string) bool {
+		return isReservedTypeName(s, name) || isStructName(s, name) ||
+			helpers.declaresOther(name, enumDecl(name, of, column.EnumValues, constants))
+	}
+	if renamed := renamedBy(s, name, reserved); helpers.has(renamed) {
+		// the same enum type of another column
+		return renamed, nil
+	}
+	name = rename(s, name, of, reserved)
+
+	helpers.add(name, enumDecl(name, of, column.EnumValues, constants), "database/sql/driver", "fmt")
+	return name, nil
+}
+
+// enumDecl returns the declaration of the enum type of the given name with a
+// constant of every value, named by the name and the constant of the value.
+func enumDecl(name, of string, values, constants []string) string {
+	var decl strings.Builder
+	fmt.Fprintf(&decl, "// %s is the %s.\n", name, of)
+	fmt.Fprintf(&decl, "type %s string\n\n", name)
+	decl.WriteString("const (\n")
+	taken := map[string]struct{}{}
+	for i, value := range values {
+		constant := name + constants[i]
+		if _, ok := taken[constant]; ok {
+			constant = uniqueFieldName(taken, constant)
+		}
+		taken[constant] = struct{}{}
+		fmt.Fprintf(&decl, "\t%s %s = %s\n", constant, name, strconv.Quote(value))
+	}
+	decl.WriteString(")\n")
+	fmt.Fprintf(&decl, enumMethodsDecl, name)
+	return decl.String()
+}
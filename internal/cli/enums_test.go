@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestRun_EnumTypes(t *testing.T) {
+	tests := []struct {
+		desc      string
+		goVersion settings.GoVersion
+		expected  string
+	}{
+		{
+			desc:      "nullable as pointer",
+			goVersion: settings.GoVersion121,
+			expected:  "package dto\n\ntype Orders struct {\nID int `db:\"id\"`\nStatus OrderStatus `db:\"status\"`\nPrevious *OrderStatus `db:\"previous\"`\nChannel OrdersChannel `db:\"channel\"`\n}\n\nfunc (o Orders) TableName() string {\n\treturn \"orders\"\n}\n",
+		},
+		{
+			desc:      "nullable as sql.Null[T]",
+			goVersion: settings.GoVersion122,
+			expected:  "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype Orders struct {\nID int `db:\"id\"`\nStatus OrderStatus `db:\"status\"`\nPrevious sql.Null[OrderStatus] `db:\"previous\"`\nChannel OrdersChannel `db:\"channel\"`\n}\n\nfunc (o Orders) TableName() string {\n\treturn \"orders\"\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.EnumTypes = true
+			s.GoVersion = test.goVersion
+			db := database.New(s)
+
+			status := []string{"pending", "in progress", "in-progress"}
+			table := &database.Table{
+				Name: "orders",
+				Columns: []database.Column{
+					{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+					{OrdinalPosition: 2, Name: "status", DataType: "USER-DEFINED", IsNullable: "NO", EnumType: "order_status", EnumValues: status},
+					{OrdinalPosition: 3, Name: "previous", DataType: "USER-DEFINED", IsNullable: "YES", EnumType: "order_status", EnumValues: status},
+					{OrdinalPosition: 4, Name: "channel", DataType: "enum", IsNullable: "NO", EnumValues: []string{"web", "1-click"}},
+				},
+			}
+
+			mdb := newMockDB(db)
+			mdb.On("GetTables").Return([]*database.Table{table}, nil)
+			mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+			mdb.On("GetColumnsOfTable", table).Return(nil)
+
+			w := newMockWriter()
+			w.On("Write", "Orders", test.expected).Return(nil)
+			w.On("Write", "types_gen", mock.Anything).Return(nil)
+
+			assert.NoError(t, Run(context.Background(), s, mdb, w))
+			w.AssertExpectations(t)
+
+			content := w.Calls[1].Arguments.String(1)
+			assert.Contains(t, content, "import (\n\t\"database/sql/driver\"\n\t\"fmt\"\n)\n")
+			assert.Contains(t, content, "// OrderStatus is the enum type order_status.\ntype OrderStatus string\n\nconst (\n"+
+				"\tOrderStatusPending OrderStatus = \"pending\"\n"+
+				"\tOrderStatusInProgress OrderStatus = \"in progress\"\n"+
+				"\tOrderStatusInProgress2 OrderStatus = \"in-progress\"\n)\n")
+			assert.Contains(t, content, "func (e *OrderStatus) Scan(src any) error {")
+			assert.Contains(t, content, "func (e OrderStatus) Value() (driver.Value, error) {")
+			assert.Contains(t, content, "// OrdersChannel is the enum column channel of table orders.\ntype OrdersChannel string\n\nconst (\n"+
+				"\tOrdersChannelWeb OrdersChannel = \"web\"\n"+
+				"\tOrdersChannelX1_click OrdersChannel = \"1-click\"\n)\n")
+		})
+	}
+}
+
+func TestRun_EnumTypes_NameClash(t *testing.T) {
+	var warnings []string
+	SetHooks(Hooks{OnWarning: func(message string) { warnings = append(warnings, message) }})
+	defer SetHooks(Hooks{})
+
+	s := settings.New()
+	s.EnumTypes = true
+	s.GoVersion = settings.GoVersion121
+	db := database.New(s)
+
+	status := []string{"pending", "shipped"}
+	orderStatus := &database.Table{
+		Name: "order_status",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+		},
+	}
+	orders := &database.Table{
+		Name: "orders",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "status", DataType: "USER-DEFINED", IsNullable: "NO", EnumType: "order_status", EnumValues: status},
+			{OrdinalPosition: 2, Name: "previous", DataType: "USER-DEFINED", IsNullable: "YES", EnumType: "order_status", EnumValues: status},
+			{OrdinalPosition: 3, Name: "kind", DataType: "enum", IsNullable: "NO", EnumValues: []string{"web"}},
+			{OrdinalPosition: 4, Name: "channel", DataType: "USER-DEFINED", IsNullable: "NO", EnumType: "orders_kind", EnumValues: []string{"mail"}},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.On("GetTables").Return([]*database.Table{orderStatus, orders}, nil)
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+	mdb.On("GetColumnsOfTable", orderStatus).Return(nil)
+	mdb.On("GetColumnsOfTable", orders).Return(nil)
+
+	w := newMockWriter()
+	w.On("Write", "OrderStatus", "package dto\n\ntype OrderStatus struct {\nID int `db:\"id\"`\n}\n\nfunc (o OrderStatus) TableName() string {\n\treturn \"order_status\"\n}\n").Return(nil)
+	w.On("Write", "Orders", "package dto\n\ntype Orders struct {\nStatus OrderStatus_ `db:\"status\"`\nPrevious *OrderStatus_ `db:\"previous\"`\nKind OrdersKind `db:\"kind\"`\nChannel OrdersKind_ `db:\"channel\"`\n}\n\nfunc (o Orders) TableName() string {\n\treturn \"orders\"\n}\n").Return(nil)
+	w.On("Write", "types_gen", mock.Anything).Return(nil)
+
+	assert.NoError(t, Run(context.Background(), s, mdb, w))
+	w.AssertExpectations(t)
+
+	content := w.Calls[2].Arguments.String(1)
+	assert.Contains(t, content, "// OrderStatus_ is the enum type order_status.\ntype OrderStatus_ string\n\nconst (\n"+
+		"\tOrderStatus_Pending OrderStatus_ = \"pending\"\n"+
+		"\tOrderStatus_Shipped OrderStatus_ = \"shipped\"\n)\n")
+	assert.Contains(t, content, "// OrdersKind is the enum column kind of table orders.\ntype OrdersKind string\n")
+	assert.Contains(t, content, "// OrdersKind_ is the enum type orders_kind.\ntype OrdersKind_ string\n\nconst (\n"+
+		"\tOrdersKind_Mail OrdersKind_ = \"mail\"\n)\n")
+	assert.Equal(t, []string{
+		"enum type order_status named OrderStatus collides with Go or the generated code, renamed it to OrderStatus_",
+		"enum type orders_kind named OrdersKind collides with Go or the generated code, renamed it to OrdersKind_",
+	}, warnings)
+}
+
+func TestRun_EnumTypes_Disabled(t *testing.T) {
+	s := settings.New()
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "orders",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "status", DataType: "enum", IsNullable: "NO", EnumValues: []string{"pending"}},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.On("GetTables").Return([]*database.Table{table}, nil)
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+	mdb.On("GetColumnsOfTable", table).Return(nil)
+
+	w := newMockWriter()
+	w.On("Write", "Orders", "package dto\n\ntype Orders struct {\nStatus string `db:\"status\"`\n}\n\nfunc (o Orders) TableName() string {\n\treturn \"orders\"\n}\n").Return(nil)
+
+	assert.NoError(t, Run(context.Background(), s, mdb, w))
+	w.AssertExpectations(t)
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// TableError is the failure of a table, or of a column of it.
+type TableError struct {
+	Table string
+	// Column is the failing column of the table, empty if the table failed
+	// as a whole
+	Column string
+	Err    error
+}
+
+// Error is the implementation of the error interface.
+func (e *TableError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("table %q: %v", e.Table, e.Err)
+	}
+	return fmt.Sprintf("column %q of table %q: %v", e.Column, e.Table, e.Err)
+}
+
+// Unwrap returns the error of the table.
+func (e *TableError) Unwrap() error {
+	return e.Err
+}
+
+// TableErrors are the failures of the tables of a run continuing on errors,
+// returned at its end.
+type TableErrors []*TableError
+
+// Error is the implementation of the error interface.
+func (e TableErrors) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d tables failed:", len(e))
+	for _, err := range e {
+		sb.WriteString("\n  ")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap returns the errors of the tables.
+func (e TableErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// add adds the failure of the table, keeping the context of the TableError
+// of the failure, if any.
+func (e *TableErrors) add(table string, err error) {
+	var tableErr *TableError
+	if errors.As(err, &tableErr) {
+		*e = append(*e, tableErr)
+		return
+	}
+	*e = append(*e, &TableError{Table: table, Err: err})
+}
+
+// collect adds the failures of err, if it is TableErrors, and returns nil,
+// otherwise it returns err.
+func (e *TableErrors) collect(err error) error {
+	var failures TableErrors
+	if !errors.As(err, &failures) {
+		return err
+	}
+	*e = append(*e, failures...)
+	return nil
+}
+
+// err returns the failures as error, nil if there are none.
+func (e TableErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// tableStatus is the outcome of every table of a run continuing on errors,
+// printed at its end. A nil tableStatus records nothing.
+type tableStatus struct {
+	tables []string
+	status map[string]string
+}
+
+// newTableStatus creates the status of the tables of a run, or nil if the
+// settings do not continue on errors.
+func newTableStatus(settings *settings.Settings, tables []*database.Table) *tableStatus {
+	if !settings.ContinueOnError {
+		return nil
+	}
+	s := &tableStatus{
+		tables: make([]string, len(tables)),
+		status: map[string]string{},
+	}
+	for i, table := range tables {
+		s.tables[i] = table.Name
+	}
+	return s
+}
+
+// set records the status of the table.
+func (s *tableStatus) set(table, status string) {
+	if s == nil {
+		return
+	}
+	s.status[table] = status
+}
+
+// write prints the status of every table to w, the failures override the
+// recorded status, tables without status got skipped, e.g. by a hook.
+func (s *tableStatus) write(w io.Writer, failures TableErrors) error {
+	if s == nil || w == nil {
+		return nil
+	}
+
+	status := map[string]string{}
+	for table, st := range s.status {
+		status[table] = st
+	}
+	for _, failure := range failures {
+		if failure.Column == "" {
+			status[failure.Table] = fmt.Sprintf("failed: %v", failure.Err)
+		} else {
+			status[failure.Table] = fmt.Sprintf("failed at column %q: %v", failure.Column, failure.Err)
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Status of %d tables, %d failed:\n", len(s.tables), len(failures))
+	for _, table := range s.tables {
+		st, ok := status[table]
+		if !ok {
+			st = "skipped"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", table, st)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestTableErrors(t *testing.T) {
+	t.Parallel()
+
+	errDenied := errors.New("permission denied")
+
+	var failures TableErrors
+	assert.NoError(t, failures.err())
+
+	failures.add("users", errDenied)
+	failures.add("orders", fmt.Errorf("could not write: %w", &TableError{Table: "orders", Column: "total", Err: errors.New("invalid name")}))
+	assert.Equal(t, TableErrors{
+		{Table: "users", Err: errDenied},
+		{Table: "orders", Column: "total", Err: errors.New("invalid name")},
+	}, failures)
+
+	err := failures.err()
+	assert.ErrorIs(t, err, errDenied)
+	assert.EqualError(t, err, "2 tables failed:\n"+
+		`  table "users": permission denied`+"\n"+
+		`  column "total" of table "orders": invalid name`)
+
+	var collected TableErrors
+	assert.NoError(t, collected.collect(fmt.Errorf("schema public: %w", err)))
+	assert.Equal(t, failures, collected)
+	assert.Equal(t, errDenied, collected.collect(errDenied))
+}
+
+func TestTableStatus_write(t *testing.T) {
+	t.Parallel()
+
+	tables := []*database.Table{{Name: "users"}, {Name: "audit_log"}, {Name: "items"}, {Name: "secrets"}, {Name: "orders"}}
+	failures := TableErrors{
+		{Table: "audit_log", Err: errors.New("could not get its columns: permission denied")},
+		{Table: "items", Column: "location", Err: errors.New("unknown type")},
+	}
+
+	s := settings.New()
+	assert.Nil(t, newTableStatus(s, tables))
+	assert.NoError(t, newTableStatus(s, tables).write(&bytes.Buffer{}, failures))
+
+	s.ContinueOnError = true
+	status := newTableStatus(s, tables)
+	status.set("users", "generated")
+	status.set("secrets", "skipped, no columns")
+
+	var buf bytes.Buffer
+	assert.NoError(t, status.write(&buf, failures))
+	assert.Equal(t, "Status of 5 tables, 2 failed:\n"+
+		"  users      generated\n"+
+		"  audit_log  failed: could not get its columns: permission denied\n"+
+		`  items      failed at column "location": unknown type`+"\n"+
+		"  secrets    skipped, no columns\n"+
+		"  orders     skipped\n", buf.String())
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func init() {
+	output.RegisterFormatter(settings.FormatterGo, goFormatter{})
+}
+
+// goFormatter is the built-in formatter generating the Go structs. Generate
+// writes them through the writer directly, Format serves the users of the
+// formatter outside of the generation.
+type goFormatter struct{}
+
+// Format is the implementation of the OutputFormatter interface. The database
+// type of the settings becomes the one of the schema.
+func (goFormatter) Format(settings *settings.Settings, schema *ir.Schema) ([]output.FormattedFile, error) {
+	db, err := ir.NewDatabase(settings, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	tables, err := db.GetTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		if err = db.GetColumnsOfTable(ctx, table); err != nil {
+			return nil, err
+		}
+	}
+
+	target := output.NewMemoryTarget()
+	if _, err = generateStructs(settings, db, tables, output.NewTargetWriter(target), nil, nil); err != nil {
+		return nil, err
+	}
+
+	files := target.Files()
+	formatted := make([]output.FormattedFile, 0, len(files))
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		formatted = append(formatted, output.FormattedFile{Name: name, Content: files[name]})
+	}
+	return formatted, nil
+}
+
+// formatFiles writes the files the formatter creates of the tables to out,
+// which has to write them as they are, and returns the names of the tables.
+func formatFiles(settings *settings.Settings, formatter output.OutputFormatter, tables []*database.Table, out output.Writer) ([]string, error) {
+	raw, ok := out.(output.RawWriter)
+	if !ok {
+		return nil, fmt.Errorf("formatter %q needs a writer writing the files as they are", settings.Formatter)
+	}
+
+	tables = slices.DeleteFunc(slices.Clone(tables), func(table *database.Table) bool {
+		return !hooks.tableStart(table)
+	})
+
+	files, err := formatter.Format(settings, ir.FromTables(settings, tables))
+	if err != nil {
+		return nil, fmt.Errorf("formatter %q could not format the tables: %w", settings.Formatter, err)
+	}
+	for _, file := range files {
+		if err = raw.WriteRaw(file.Name, file.Content); err != nil {
+			return nil, fmt.Errorf("could not write %s: %w", file.Name, err)
+		}
+		hooks.fileWritten(out, file.Name)
+	}
+
+	generated := make([]string, 0, len(tables))
+	for _, table := range tables {
+		generated = append(generated, table.Name)
+	}
+	return generated, nil
+}
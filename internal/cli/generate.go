@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/tagger"
+)
+
+// Introspect returns the tables Run would generate with their columns, the
+// first step of Run. In force mode tables whose columns can not be read are
+// left out, continuing on errors they are returned as TableErrors along with
+// the other tables. The tables get written to the schema representation of
+// the settings, if any. With Snapshot of the settings all queries run in one
+// read-only transaction, if the database supports it.
+func Introspect(ctx context.Context, settings *settings.Settings, db database.Database) ([]*database.Table, error) {
+	endSnapshot, err := beginSnapshot(ctx, settings, db)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := introspect(ctx, settings, db)
+	if endErr := endSnapshot(); endErr != nil && err == nil {
+		return nil, endErr
+	}
+	return tables, err
+}
+
+// introspect is Introspect without the snapshot.
+func introspect(ctx context.Context, settings *settings.Settings, db database.Database) ([]*database.Table, error) {
+	tables, err := Tables(ctx, settings, db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.PrepareGetColumnsOfTableStmt(ctx); err != nil {
+		return nil, fmt.Errorf("could not prepare the get-column-statement: %w", err)
+	}
+
+	columns, stop := fetchColumns(ctx, db, tables, settings.Jobs, settings.BatchColumns)
+	defer stop()
+	if columns, err = withForeignKeys(ctx, settings, db, tables, columns); err != nil {
+		return nil, err
+	}
+	columns = withEnums(ctx, settings, db, tables, columns)
+
+	var failures TableErrors
+	introspected := make([]*database.Table, 0, len(tables))
+	for i, table := range tables {
+		if err = columns(i); err != nil {
+			if !(settings.Force || settings.ContinueOnError) || ctx.Err() != nil {
+				return nil, fmt.Errorf("could not get columns of table %q: %w", table.Name, err)
+			}
+			slog.Error("could not get columns of table", "table", table.Name, "error", err)
+			hooks.warning(fmt.Sprintf("skipped table %q, could not get its columns: %v", table.Name, err))
+			if settings.ContinueOnError {
+				failures.add(table.Name, fmt.Errorf("could not get its columns: %w", err))
+			}
+			continue
+		}
+		if isEmptyTable(table, nil) {
+			continue
+		}
+		introspected = append(introspected, table)
+	}
+
+	if err = writeIR(settings, introspected); err != nil {
+		return nil, err
+	}
+
+	return introspected, failures.err()
+}
+
+// Generate writes the files of the formatter of the settings of the
+// introspected tables to out, by default the structs and the helper types,
+// the second step of Run, and returns the names of the generated tables. In
+// force mode tables failing to generate are left out, continuing on errors
+// they are returned as TableErrors along with the names of the other tables.
+func Generate(settings *settings.Settings, db database.Database, tables []*database.Table, out output.Writer) ([]string, error) {
+	formatter, err := output.LookupFormatter(settings.Formatter)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := formatter.(goFormatter); !ok {
+		return formatFiles(settings, formatter, tables, out)
+	}
+
+	manifest := newManifest(settings)
+	inc, err := newIncremental(settings)
+	if err != nil {
+		return nil, err
+	}
+	var failures TableErrors
+	generated, err := generateStructs(settings, db, tables, manifest.writer(out), manifest, inc)
+	if err = failures.collect(err); err != nil {
+		return nil, err
+	}
+
+	if err := manifest.write(settings); err != nil {
+		return nil, err
+	}
+
+	// embedded nothing gets printed
+	if err := inc.write(nil); err != nil {
+		return nil, err
+	}
+
+	return generated, failures.err()
+}
+
+// generateStructs writes the structs of the tables and the helper types to
+// out and returns the names of the generated tables, continuing on errors
+// along with the TableErrors of the failing ones.
+func generateStructs(settings *settings.Settings, db database.Database, tables []*database.Table, out output.Writer, manifest *manifest, inc *incremental) ([]string, error) {
+	if err := tagger.VerifyNames(settings.Tags); err != nil {
+		return nil, err
+	}
+
+	taggers = tagger.NewTaggers(settings)
+	helpers = newHelperTypes()
+	if err := setStructTemplate(settings); err != nil {
+		return nil, err
+	}
+	setRelatedTables(tables)
+
+	if settings.Strict {
+		var unmapped []string
+		for _, table := range tables {
+			unmapped = append(unmapped, unmappedColumns(settings, db, table)...)
+		}
+		if len(unmapped) > 0 {
+			return nil, fmt.Errorf("strict mode: %d columns have types without mapping, exclude them or leave out -strict:\n  %s",
+				len(unmapped), strings.Join(unmapped, "\n  "))
+		}
+	}
+
+	var failures TableErrors
+	generated := make([]string, 0, len(tables))
+	for _, table := range tables {
+		if isEmptyTable(table, manifest) {
+			continue
+		}
+		for _, column := range unmappedColumns(settings, db, table) {
+			manifest.warn("no mapping for the type of %s, generated as string", column)
+		}
+		written, err := writeTableOrSkip(settings, db, out, table, manifest, inc, &failures)
+		if err != nil {
+			return nil, err
+		}
+		if written {
+			generated = append(generated, table.Name)
+		}
+	}
+
+	if err := writePackageFiles(settings, out); err != nil {
+		return nil, err
+	}
+
+	return generated, failures.err()
+}
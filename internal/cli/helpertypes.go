@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	// helperTypesFileName is the name of the file (without extension) shared
+	// helper types of a package get written to by default.
+	helperTypesFileName = "types_gen"
+
+	// nullTypesFileName is the name of the file (without extension) the
+	// generated Null* wrapper types get written to.
+	nullTypesFileName = "nulltypes_gen"
+)
+
+// helperTypes collects the declarations of helper types (null wrappers, enums,
+// range types, ...) the generated structs depend on. Every declaration gets
+// written only once per package into a shared file, regardless of how many
+// tables make use of it, which avoids redeclaration errors.
+type helperTypes struct {
+	files map[string]*helperFile
+	names map[string]struct{}
+}
+
+// helperFile holds the imports and declarations of a single shared file.
+type helperFile struct {
+	imports map[string]struct{}
+	decls   map[string]string
+}
+
+func newHelperTypes() *helperTypes {
+	return &helperTypes{
+		files: map[string]*helperFile{},
+		names: map[string]struct{}{},
+	}
+}
+
+// add registers the declaration of the helper type with the given name along
+// with the imports it needs in the default shared file.
+func (h *helperTypes) add(name, decl string, imports ...string) {
+	h.addTo(helperTypesFileName, name, decl, imports...)
+}
+
+// addTo registers the declaration of the helper type with the given name along
+// with the imports it needs in the given shared file. Types already registered
+// are skipped, no matter which file they were registered for, types which
+// may clash get renamed beforehand, see declaresOther.
+func (h *helperTypes) addTo(fileName, name, decl string, imports ...string) {
+	if _, ok := h.names[name]; ok {
+		return
+	}
+	h.names[name] = struct{}{}
+
+	file, ok := h.files[fileName]
+	if !ok {
+		file = &helperFile{
+			imports: map[string]struct{}{},
+			decls:   map[string]string{},
+		}
+		h.files[fileName] = file
+	}
+
+	file.decls[name] = decl
+	for _, imp := range imports {
+		file.imports[imp] = struct{}{}
+	}
+}
+
+// has returns true if the helper type with the given name is registered.
+func (h *helperTypes) has(name string) bool {
+	_, ok := h.names[name]
+	return ok
+}
+
+// declaresOther returns true if the helper type with the given name is
+// registered with another declaration than the given one.
+func (h *helperTypes) declaresOther(name, decl string) bool {
+	if !h.has(name) {
+		return false
+	}
+	for _, file := range h.files {
+		if registered, ok := file.decls[name]; ok {
+			return registered != decl
+		}
+	}
+	return false
+}
+
+// isEmpty returns true if no helper type was registered.
+func (h *helperTypes) isEmpty() bool {
+	return len(h.names) == 0
+}
+
+// fileNames returns the sorted names of all shared files to write.
+func (h *helperTypes) fileNames() []string {
+	names := make([]string, 0, len(h.files))
+	for name := range h.files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// content renders the given shared file of the given package. Imports and
+// declarations are sorted to produce a stable output across runs.
+func (h *helperTypes) content(fileName, packageName string) string {
+	var content strings.Builder
+
+	content.WriteString("package ")
+	content.WriteString(packageName)
+	content.WriteString("\n\n")
+
+	file, ok := h.files[fileName]
+	if !ok {
+		return strings.TrimSuffix(content.String(), "\n")
+	}
+
+	if len(file.imports) > 0 {
+		imports := make([]string, 0, len(file.imports))
+		for imp := range file.imports {
+			imports = append(imports, imp)
+		}
+		slices.Sort(imports)
+
+		content.WriteString("import (\n")
+		for _, imp := range imports {
+			content.WriteString("\t\"")
+			content.WriteString(imp)
+			content.WriteString("\"\n")
+		}
+		content.WriteString(")\n\n")
+	}
+
+	names := make([]string, 0, len(file.decls))
+	for name := range file.decls {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		content.WriteString(strings.TrimSpace(file.decls[name]))
+		content.WriteString("\n\n")
+	}
+
+	return strings.TrimSuffix(content.String(), "\n")
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelperTypes_Content(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		add      func(h *helperTypes)
+		expected string
+	}{
+		{
+			desc:     "no helper types renders only the package clause",
+			add:      func(*helperTypes) {},
+			expected: "package dto\n",
+		},
+		{
+			desc: "helper types without imports are rendered sorted by name",
+			add: func(h *helperTypes) {
+				h.add("Mood", "type Mood string")
+				h.add("Color", "type Color string")
+			},
+			expected: "package dto\n\ntype Color string\n\ntype Mood string\n",
+		},
+		{
+			desc: "duplicated helper types are declared only once",
+			add: func(h *helperTypes) {
+				h.add("NullTime", "type NullTime struct {\n\tsql.NullTime\n}", "database/sql")
+				h.add("Color", "type Color string")
+				h.add("NullTime", "type NullTime struct {\n\tsql.NullTime\n}", "database/sql")
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype Color string\n\ntype NullTime struct {\n\tsql.NullTime\n}\n",
+		},
+		{
+			desc: "imports of all helper types are merged and sorted",
+			add: func(h *helperTypes) {
+				h.add("NullTime", "type NullTime struct {\n\tTime time.Time\n}", "time")
+				h.add("NullString", "type NullString struct {\n\tsql.NullString\n}", "database/sql")
+			},
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype NullString struct {\n\tsql.NullString\n}\n\ntype NullTime struct {\n\tTime time.Time\n}\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			h := newHelperTypes()
+			test.add(h)
+			assert.Equal(t, test.expected, h.content(helperTypesFileName, "dto"))
+		})
+	}
+}
+
+func TestHelperTypes_AddTo(t *testing.T) {
+	t.Parallel()
+
+	h := newHelperTypes()
+	h.add("Color", "type Color string")
+	h.addTo(nullTypesFileName, "NullString", "type NullString struct {\n\tsql.NullString\n}", "database/sql")
+	h.add("NullString", "type NullString string")
+
+	assert.Equal(t, []string{nullTypesFileName, helperTypesFileName}, h.fileNames())
+	assert.Equal(t, "package dto\n\ntype Color string\n", h.content(helperTypesFileName, "dto"))
+	assert.Equal(t, "package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype NullString struct {\n\tsql.NullString\n}\n", h.content(nullTypesFileName, "dto"))
+}
+
+func TestHelperTypes_DeclaresOther(t *testing.T) {
+	t.Parallel()
+
+	h := newHelperTypes()
+	h.add("OrderStatus", "type OrderStatus string")
+
+	assert.True(t, h.has("OrderStatus"))
+	assert.False(t, h.declaresOther("OrderStatus", "type OrderStatus string"))
+	assert.True(t, h.declaresOther("OrderStatus", "type OrderStatus int"))
+	assert.False(t, h.declaresOther("OrdersStatus", "type OrdersStatus int"))
+}
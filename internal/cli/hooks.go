@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+)
+
+// Hooks get called during the generation, e.g. to collect metrics, drive a
+// progress UI or veto tables. Hooks left nil are not called.
+type Hooks struct {
+	// OnTableStart is called before the table gets generated, returning
+	// false skips it.
+	OnTableStart func(table *database.Table) bool
+	// OnColumnMapped is called for every field of the struct of the table
+	// with its column and the Go type the column got mapped to.
+	OnColumnMapped func(table *database.Table, column database.Column, goType string)
+	// OnFileWritten is called with the name of every written file with its
+	// extension. Writers only recording the changes write no files.
+	OnFileWritten func(name string)
+	// OnWarning is called with every warning of the generation, e.g. about
+	// columns without mapping or skipped tables.
+	OnWarning func(message string)
+}
+
+// hooks are the hooks of the generation.
+var hooks Hooks
+
+// SetHooks replaces the hooks of the generation, the zero Hooks remove them.
+func SetHooks(h Hooks) {
+	hooks = h
+}
+
+// tableStart returns false if the table is vetoed by OnTableStart.
+func (h Hooks) tableStart(table *database.Table) bool {
+	return h.OnTableStart == nil || h.OnTableStart(table)
+}
+
+// columnMapped calls OnColumnMapped, if any.
+func (h Hooks) columnMapped(table *database.Table, column database.Column, goType string) {
+	if h.OnColumnMapped != nil {
+		h.OnColumnMapped(table, column, goType)
+	}
+}
+
+// fileWritten calls OnFileWritten, if any, unless out only records the
+// changes.
+func (h Hooks) fileWritten(out output.Writer, name string) {
+	if _, ok := out.(changesRecorder); ok || h.OnFileWritten == nil {
+		return
+	}
+	h.OnFileWritten(name)
+}
+
+// warning calls OnWarning, if any, and collects the warning for the summary
+// of the run.
+func (h Hooks) warning(message string) {
+	warnings.add(message)
+	if h.OnWarning != nil {
+		h.OnWarning(message)
+	}
+}
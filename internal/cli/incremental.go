@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// stateFileName is the name of the state of the incremental generation
+// written into the output path.
+const stateFileName = "tables-to-go-state.json"
+
+// incremental skips writing the structs of the tables unchanged since the
+// previous run: their fingerprint, the hash of their column metadata, and
+// the options of the run are the same and their file still exists. A nil
+// incremental writes all tables.
+type incremental struct {
+	path     string
+	previous incrementalState
+	next     incrementalState
+
+	updated []string
+	skipped []string
+}
+
+// incrementalState is the state of the incremental generation persisted
+// between the runs.
+type incrementalState struct {
+	Generator string `json:"generator"`
+	// Settings is the hash of the options of the run, other options
+	// regenerate all tables
+	Settings string                      `json:"settings"`
+	Tables   map[string]tableFingerprint `json:"tables"`
+}
+
+// tableFingerprint is the fingerprint of a generated table and the name of
+// its file without extension.
+type tableFingerprint struct {
+	Fingerprint string `json:"fingerprint"`
+	File        string `json:"file"`
+}
+
+// newIncremental creates the incremental generation with the state of the
+// previous run in the output path, or nil if the settings do not ask for
+// it. Without a previous state all tables get written.
+func newIncremental(settings *settings.Settings) (*incremental, error) {
+	if !settings.Incremental {
+		return nil, nil
+	}
+
+	inc := &incremental{
+		path: settings.OutputFilePath,
+		next: incrementalState{
+			Generator: settings.GeneratorVersion,
+			Settings:  optionsHash(settings.Options),
+			Tables:    map[string]tableFingerprint{},
+		},
+	}
+
+	content, err := os.ReadFile(filepath.Join(inc.path, stateFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return inc, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read the state of the incremental generation: %w", err)
+	}
+	if err = json.Unmarshal(content, &inc.previous); err != nil {
+		return nil, fmt.Errorf("could not parse the state of the incremental generation %q: %w", stateFileName, err)
+	}
+	return inc, nil
+}
+
+// optionsHash returns the hash of the options.
+func optionsHash(options map[string]string) string {
+	hash := sha256.New()
+	for _, name := range slices.Sorted(maps.Keys(options)) {
+		fmt.Fprintf(hash, "%s=%s\n", name, options[name])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// fingerprint returns the hash of the column metadata of the table.
+func fingerprint(table *database.Table) (string, error) {
+	content, err := json.Marshal(ir.TableOf(table))
+	if err != nil {
+		return "", fmt.Errorf("could not fingerprint table %q: %w", table.Name, err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// unchanged returns true if the table written to the file of the given name
+// is unchanged since the previous run, it is recorded as skipped then.
+func (inc *incremental) unchanged(table *database.Table, fileName string) (bool, error) {
+	if inc == nil {
+		return false, nil
+	}
+
+	sum, err := fingerprint(table)
+	if err != nil {
+		return false, err
+	}
+	current := tableFingerprint{Fingerprint: sum, File: fileName}
+	inc.next.Tables[table.Name] = current
+
+	if inc.previous.Generator != inc.next.Generator || inc.previous.Settings != inc.next.Settings ||
+		inc.previous.Tables[table.Name] != current {
+		return false, nil
+	}
+	if _, err = os.Stat(filepath.Join(inc.path, fileName+output.FileWriterExtension)); err != nil {
+		return false, nil
+	}
+
+	inc.skipped = append(inc.skipped, table.Name)
+	return true, nil
+}
+
+// written records the table got written.
+func (inc *incremental) written(table *database.Table) {
+	if inc == nil {
+		return
+	}
+	inc.updated = append(inc.updated, table.Name)
+}
+
+// write writes the state next to the generated files and the summary of the
+// updated and skipped tables to w, if any.
+func (inc *incremental) write(w io.Writer) error {
+	if inc == nil {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(inc.next, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not create the state of the incremental generation: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(inc.path, stateFileName), append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("could not write the state of the incremental generation: %w", err)
+	}
+
+	if w == nil {
+		return nil
+	}
+	fmt.Fprintf(w, "Incremental generation: %d tables updated, %d skipped as unchanged\n", len(inc.updated), len(inc.skipped))
+	if len(inc.updated) > 0 {
+		fmt.Fprintf(w, "  updated: %s\n", strings.Join(inc.updated, ", "))
+	}
+	return nil
+}
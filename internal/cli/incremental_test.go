@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// countingWriter records the names of the written files.
+type countingWriter struct {
+	output.Writer
+	written []string
+}
+
+func (w *countingWriter) Write(tableName string, content string) error {
+	w.written = append(w.written, tableName)
+	return w.Writer.Write(tableName, content)
+}
+
+func TestGenerate_Incremental(t *testing.T) {
+	dir := t.TempDir()
+
+	s := settings.New()
+	s.OutputFilePath = dir
+	s.Incremental = true
+	s.Options = map[string]string{"incremental": "true"}
+	db := database.New(s)
+
+	newTables := func(userColumns ...string) []*database.Table {
+		users := &database.Table{Name: "users"}
+		for i, name := range userColumns {
+			users.Columns = append(users.Columns, database.Column{OrdinalPosition: i + 1, Name: name, DataType: "integer", IsNullable: "NO"})
+		}
+		return []*database.Table{users, {
+			Name:    "orders",
+			Columns: []database.Column{{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"}},
+		}}
+	}
+
+	generate := func(tables []*database.Table) []string {
+		out := &countingWriter{Writer: output.NewFileWriter(dir)}
+		generated, err := Generate(s, db, tables, out)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"users", "orders"}, generated)
+		return out.written
+	}
+
+	// the first run writes all tables
+	assert.Equal(t, []string{"Users", "Orders"}, generate(newTables("id")))
+	assert.FileExists(t, filepath.Join(dir, stateFileName))
+
+	// unchanged tables are skipped
+	assert.Empty(t, generate(newTables("id")))
+
+	// changed tables are written
+	assert.Equal(t, []string{"Users"}, generate(newTables("id", "age")))
+
+	// deleted files are written again
+	assert.NoError(t, os.Remove(filepath.Join(dir, "Orders.go")))
+	assert.Equal(t, []string{"Orders"}, generate(newTables("id", "age")))
+
+	// other options write all tables
+	s.Options["no-initialism"] = "true"
+	assert.Equal(t, []string{"Users", "Orders"}, generate(newTables("id", "age")))
+}
+
+func TestIncremental_write(t *testing.T) {
+	s := settings.New()
+	s.OutputFilePath = t.TempDir()
+	s.Incremental = true
+	inc, err := newIncremental(s)
+	assert.NoError(t, err)
+
+	users := &database.Table{Name: "users"}
+	unchanged, err := inc.unchanged(users, "Users")
+	assert.NoError(t, err)
+	assert.False(t, unchanged)
+	inc.written(users)
+
+	var buf bytes.Buffer
+	assert.NoError(t, inc.write(&buf))
+	assert.Equal(t, "Incremental generation: 1 tables updated, 0 skipped as unchanged\n  updated: users\n", buf.String())
+
+	// a broken state produces error
+	assert.NoError(t, os.WriteFile(filepath.Join(s.OutputFilePath, stateFileName), []byte("{"), 0644))
+	_, err = newIncremental(s)
+	assert.ErrorContains(t, err, "could not parse the state of the incremental generation")
+}
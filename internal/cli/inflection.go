@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+var (
+	// irregularSingulars maps irregular plural nouns to their singular.
+	irregularSingulars = map[string]string{
+		"aliases":   "alias",
+		"analyses":  "analysis",
+		"children":  "child",
+		"criteria":  "criterion",
+		"feet":      "foot",
+		"geese":     "goose",
+		"indices":   "index",
+		"matrices":  "matrix",
+		"men":       "man",
+		"mice":      "mouse",
+		"movies":    "movie",
+		"people":    "person",
+		"phenomena": "phenomenon",
+		"quizzes":   "quiz",
+		"shoes":     "shoe",
+		"teeth":     "tooth",
+		"vertices":  "vertex",
+		"women":     "woman",
+	}
+
+	// uncountables are nouns without a distinct singular.
+	uncountables = map[string]struct{}{
+		"data":        {},
+		"equipment":   {},
+		"feedback":    {},
+		"fish":        {},
+		"information": {},
+		"metadata":    {},
+		"money":       {},
+		"news":        {},
+		"series":      {},
+		"sheep":       {},
+		"software":    {},
+		"species":     {},
+	}
+
+	// singularSuffixes are the suffixes of regular plural nouns replaced by
+	// their singular, the first matching one applies.
+	singularSuffixes = []struct {
+		plural   string
+		singular string
+	}{
+		{"ss", "ss"},
+		{"us", "us"},
+		{"is", "is"},
+		{"sses", "ss"},
+		{"uses", "us"},
+		{"shes", "sh"},
+		{"ches", "ch"},
+		{"xes", "x"},
+		{"ies", "y"},
+		{"s", ""},
+	}
+)
+
+// singularName returns the singular of the table name by the first matching
+// inflection rule of the settings, or by the built-in rules.
+func singularName(settings *settings.Settings, name string) string {
+	for _, rule := range settings.InflectionRules {
+		if rule.Pattern.MatchString(name) {
+			return rule.Pattern.ReplaceAllString(name, rule.Replacement)
+		}
+	}
+	return singularize(name, settings.SingularExceptions)
+}
+
+// singularize returns the singular of the last word of the snake_case or
+// CamelCase name, e.g. order_items gets order_item. The exceptions map plural
+// words to their singular and win over the built-in rules.
+func singularize(name string, exceptions map[string]string) string {
+	start := lastWordIndex(name)
+	prefix, word := name[:start], name[start:]
+	lower := strings.ToLower(word)
+
+	if singular, ok := exceptions[lower]; ok {
+		return prefix + matchCase(word, singular)
+	}
+	if singular, ok := irregularSingulars[lower]; ok {
+		return prefix + matchCase(word, singular)
+	}
+	if _, ok := uncountables[lower]; ok {
+		return name
+	}
+
+	for _, suffix := range singularSuffixes {
+		if !strings.HasSuffix(lower, suffix.plural) || len(lower) <= len(suffix.plural) {
+			continue
+		}
+		stem := word[:len(word)-len(suffix.plural)]
+		return prefix + stem + matchCase(word, suffix.singular)
+	}
+
+	return name
+}
+
+// lastWordIndex returns the index of the last word of the snake_case or
+// CamelCase name.
+func lastWordIndex(name string) int {
+	if i := strings.LastIndexAny(name, "_ "); i >= 0 {
+		return i + 1
+	}
+	for i := len(name) - 1; i > 0; i-- {
+		if unicode.IsUpper(rune(name[i])) && unicode.IsLower(rune(name[i-1])) {
+			return i
+		}
+	}
+	return 0
+}
+
+// matchCase returns the replacement in the case of the word it replaces:
+// upper-case, capitalized or as it is.
+func matchCase(word, replacement string) string {
+	if word == "" || replacement == "" {
+		return replacement
+	}
+	if strings.ToUpper(word) == word && strings.ToLower(word) != word {
+		return strings.ToUpper(replacement)
+	}
+	if unicode.IsUpper(rune(word[0])) {
+		return strings.ToUpper(replacement[:1]) + replacement[1:]
+	}
+	return replacement
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestSingularize(t *testing.T) {
+	tests := []struct {
+		desc       string
+		input      string
+		exceptions map[string]string
+		expected   string
+	}{
+		{
+			desc:     "regular plural",
+			input:    "users",
+			expected: "user",
+		},
+		{
+			desc:     "last word of snake_case name",
+			input:    "order_items",
+			expected: "order_item",
+		},
+		{
+			desc:     "last word of CamelCase name",
+			input:    "OrderItems",
+			expected: "OrderItem",
+		},
+		{
+			desc:     "plural ending with ies",
+			input:    "categories",
+			expected: "category",
+		},
+		{
+			desc:     "plural ending with sses",
+			input:    "user_addresses",
+			expected: "user_address",
+		},
+		{
+			desc:     "plural ending with xes",
+			input:    "boxes",
+			expected: "box",
+		},
+		{
+			desc:     "singular ending with s is kept",
+			input:    "order_status",
+			expected: "order_status",
+		},
+		{
+			desc:     "irregular plural in upper-case",
+			input:    "PEOPLE",
+			expected: "PERSON",
+		},
+		{
+			desc:     "irregular plural capitalized",
+			input:    "ParentChildren",
+			expected: "ParentChild",
+		},
+		{
+			desc:     "uncountable is kept",
+			input:    "sensor_data",
+			expected: "sensor_data",
+		},
+		{
+			desc:       "exception wins over the rules",
+			input:      "octopi",
+			exceptions: map[string]string{"octopi": "octopus"},
+			expected:   "octopus",
+		},
+		{
+			desc:     "singular is kept",
+			input:    "user",
+			expected: "user",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual := singularize(tt.input, tt.exceptions)
+			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
+		})
+	}
+}
+
+func TestSingularName(t *testing.T) {
+	tests := []struct {
+		desc     string
+		rules    []string
+		input    string
+		expected string
+	}{
+		{
+			desc:     "no rules use the built-in rules, which keep unknown words",
+			input:    "user_schemata",
+			expected: "user_schemata",
+		},
+		{
+			desc:     "matching rule replaces the built-in rules",
+			rules:    []string{"(?i)schemata$=schema"},
+			input:    "user_schemata",
+			expected: "user_schema",
+		},
+		{
+			desc:     "first matching rule wins",
+			rules:    []string{"^nope$=no", "ae$=a", "a$=um"},
+			input:    "formulae",
+			expected: "formula",
+		},
+		{
+			desc:     "rule with submatches",
+			rules:    []string{"^(.*)_lst$=${1}_list"},
+			input:    "item_lst",
+			expected: "item_list",
+		},
+		{
+			desc:     "not matching rules use the built-in rules",
+			rules:    []string{"(?i)schemata$=schema"},
+			input:    "users",
+			expected: "user",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := settings.New()
+			for _, rule := range tt.rules {
+				assert.NoError(t, s.InflectionRules.Set(rule))
+			}
+			actual := singularName(s, tt.input)
+			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+// errSelectionAborted is returned if the interactive table selection got
+// aborted by the user.
+var errSelectionAborted = errors.New("interactive table selection aborted")
+
+const interactiveHelp = "toggle by numbers or ranges (1,3-5), filter by /text (/ clears), " +
+	"select (a) or deselect (n) all shown, generate the selected tables with an empty line or quit (q)"
+
+// selectTables lists the tables with checkboxes on out and reads the commands
+// selecting the tables to generate from in, until an empty line confirms
+// the selection.
+func selectTables(in io.Reader, out io.Writer, tables []*database.Table) ([]*database.Table, error) {
+	selected := make([]bool, len(tables))
+	filter := ""
+
+	scanner := bufio.NewScanner(in)
+	for {
+		shown := filterTableIndices(tables, filter)
+		printTableSelection(out, tables, selected, shown, filter)
+
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, fmt.Errorf("could not read the table selection: %w", err)
+			}
+			return nil, errSelectionAborted
+		}
+		command := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case command == "":
+			var result []*database.Table
+			for i, table := range tables {
+				if selected[i] {
+					result = append(result, table)
+				}
+			}
+			if len(result) == 0 {
+				fmt.Fprintln(out, "no tables selected")
+				continue
+			}
+			return result, nil
+		case command == "q":
+			return nil, errSelectionAborted
+		case command == "a" || command == "n":
+			for _, i := range shown {
+				selected[i] = command == "a"
+			}
+		case strings.HasPrefix(command, "/"):
+			filter = strings.TrimSpace(command[1:])
+		default:
+			numbers, err := parseSelection(command, len(tables))
+			if err != nil {
+				fmt.Fprintf(out, "%v, %s\n", err, interactiveHelp)
+				continue
+			}
+			for _, n := range numbers {
+				selected[n-1] = !selected[n-1]
+			}
+		}
+	}
+}
+
+// printTableSelection prints the shown tables with their number and checkbox.
+func printTableSelection(out io.Writer, tables []*database.Table, selected []bool, shown []int, filter string) {
+	count := 0
+	for _, s := range selected {
+		if s {
+			count++
+		}
+	}
+
+	fmt.Fprintf(out, "\n> %d of %d tables selected", count, len(tables))
+	if filter != "" {
+		fmt.Fprintf(out, ", showing %d matching %q", len(shown), filter)
+	}
+	fmt.Fprintln(out)
+
+	for _, i := range shown {
+		checkbox := "[ ]"
+		if selected[i] {
+			checkbox = "[x]"
+		}
+		fmt.Fprintf(out, "  %s %3d %s\n", checkbox, i+1, tables[i].Name)
+	}
+	fmt.Fprintf(out, "%s\n> ", interactiveHelp)
+}
+
+// filterTableIndices returns the indices of the tables fuzzy matching the
+// filter.
+func filterTableIndices(tables []*database.Table, filter string) []int {
+	indices := make([]int, 0, len(tables))
+	for i, table := range tables {
+		if fuzzyMatch(table.Name, filter) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// fuzzyMatch returns true if all characters of the pattern occur in s in the
+// same order, case-insensitive.
+func fuzzyMatch(s, pattern string) bool {
+	s, pattern = strings.ToLower(s), strings.ToLower(pattern)
+	for _, c := range pattern {
+		i := strings.IndexRune(s, c)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(c)):]
+	}
+	return true
+}
+
+// parseSelection parses the comma separated numbers and ranges like 1,3-5 of
+// tables between 1 and count.
+func parseSelection(selection string, count int) ([]int, error) {
+	var numbers []int
+	for _, part := range strings.Split(selection, ",") {
+		part = strings.TrimSpace(part)
+		from, to, isRange := strings.Cut(part, "-")
+
+		first, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(strings.TrimSpace(to)); err != nil {
+				return nil, fmt.Errorf("invalid selection %q", part)
+			}
+		}
+		if first < 1 || last > count || first > last {
+			return nil, fmt.Errorf("selection %q out of range 1-%d", part, count)
+		}
+
+		for n := first; n <= last; n++ {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers, nil
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+func TestSelectTables(t *testing.T) {
+	tables := []*database.Table{
+		{Name: "customers"},
+		{Name: "orders"},
+		{Name: "order_items"},
+		{Name: "orders_archive"},
+	}
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected []string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "toggle numbers and ranges",
+			input:    "1,2-4\n4\n\n",
+			expected: []string{"customers", "orders", "order_items"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "select all matching the fuzzy filter",
+			input:    "/oitm\na\n/\n\n",
+			expected: []string{"order_items"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "deselect all shown",
+			input:    "a\n/arch\nn\n\n",
+			expected: []string{"customers", "orders", "order_items"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "invalid and empty selections are ignored",
+			input:    "5\nfoo\n\n2\n\n",
+			expected: []string{"orders"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "quit aborts",
+			input:    "1\nq\n",
+			expected: nil,
+			isError:  assert.Error,
+		},
+		{
+			desc:     "end of input aborts",
+			input:    "1\n",
+			expected: nil,
+			isError:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			selected, err := selectTables(strings.NewReader(tt.input), io.Discard, tables)
+			tt.isError(t, err)
+
+			var actual []string
+			for _, table := range selected {
+				actual = append(actual, table.Name)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		pattern  string
+		expected bool
+	}{
+		{
+			desc:     "empty pattern matches",
+			input:    "orders",
+			pattern:  "",
+			expected: true,
+		},
+		{
+			desc:     "characters in order match",
+			input:    "order_items",
+			pattern:  "oitm",
+			expected: true,
+		},
+		{
+			desc:     "match is case-insensitive",
+			input:    "OrderItems",
+			pattern:  "orit",
+			expected: true,
+		},
+		{
+			desc:     "characters out of order do not match",
+			input:    "orders",
+			pattern:  "sr",
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fuzzyMatch(tt.input, tt.pattern))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+// fetchAhead is the number of tables per job whose columns get fetched ahead
+// of the generation.
+const fetchAhead = 2
+
+// fetchColumns starts getting the columns of the tables by the given number
+// of jobs in parallel and returns the function waiting for the columns of
+// the i-th table and returning its error. Stop has to be called once done
+// to let the jobs skip the remaining tables, as does the cancellation of the
+// context. With a single job the columns get fetched on the call of columns,
+// one table after the other, with multiple jobs at most fetchAhead tables per
+// job ahead of the last call, so the memory stays bounded on large schemas.
+// In batch mode the columns of all tables get
+// fetched at once by the databases supporting it, falling back to fetching
+// them per table if that fails.
+func fetchColumns(ctx context.Context, db database.Database, tables []*database.Table, jobs int, batch bool) (columns func(i int) error, stop func()) {
+	if batcher, ok := database.As[database.ColumnsBatchGetter](db); batch && ok && len(tables) > 0 {
+		err := batcher.GetColumnsOfTables(ctx, tables)
+		if err == nil || ctx.Err() != nil {
+			return func(int) error { return err }, func() {}
+		}
+		slog.Warn("could not get the columns of all tables in one query, getting them per table", "error", err)
+	}
+
+	jobs = limitJobs(db, jobs)
+	if jobs <= 1 {
+		return func(i int) error {
+			return db.GetColumnsOfTable(ctx, tables[i])
+		}, func() {}
+	}
+
+	done := make([]chan error, len(tables))
+	for i := range done {
+		done[i] = make(chan error, 1)
+	}
+
+	indices := make(chan int)
+	stopped := make(chan struct{})
+	ahead := make(chan struct{}, jobs*fetchAhead)
+
+	go func() {
+		defer close(indices)
+		for i := range tables {
+			select {
+			case ahead <- struct{}{}:
+			case <-stopped:
+				return
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case indices <- i:
+			case <-stopped:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for range min(jobs, len(tables)) {
+		go func() {
+			for i := range indices {
+				done[i] <- db.GetColumnsOfTable(ctx, tables[i])
+			}
+		}()
+	}
+
+	var once sync.Once
+	return func(i int) error {
+			select {
+			case err := <-done[i]:
+				<-ahead
+				return err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, func() {
+			once.Do(func() { close(stopped) })
+		}
+}
+
+// limitJobs returns the number of jobs limited by the database, if it limits
+// them.
+func limitJobs(db database.Database, jobs int) int {
+	limiter, ok := database.As[database.JobsLimiter](db)
+	if !ok {
+		return jobs
+	}
+	if limit := limiter.MaxJobs(); limit > 0 && jobs > limit {
+		slog.Info("number of jobs limited by the database", "jobs", limit, "requested", jobs)
+		return limit
+	}
+	return jobs
+}
@@ -0,0 +1,329 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database/fake"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// columnsDB fails getting the columns of the tables in failing and records
+// the maximum number of parallel calls.
+type columnsDB struct {
+	database.Database
+
+	failing map[string]bool
+
+	mu       sync.Mutex
+	fetched  int
+	running  int
+	parallel int
+}
+
+func (db *columnsDB) GetColumnsOfTable(_ context.Context, table *database.Table) error {
+	db.mu.Lock()
+	db.fetched++
+	db.running++
+	db.parallel = max(db.parallel, db.running)
+	db.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	db.mu.Lock()
+	db.running--
+	db.mu.Unlock()
+
+	if db.failing[table.Name] {
+		return errors.New("failed")
+	}
+	table.Columns = []database.Column{{Name: table.Name + "_id"}}
+	return nil
+}
+
+func TestFetchColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		jobs     int
+		parallel bool
+	}{
+		{
+			desc:     "single job fetches one table after the other",
+			jobs:     1,
+			parallel: false,
+		},
+		{
+			desc:     "multiple jobs fetch the tables in parallel",
+			jobs:     3,
+			parallel: true,
+		},
+		{
+			desc:     "more jobs than tables",
+			jobs:     20,
+			parallel: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tables := make([]*database.Table, 6)
+			for i := range tables {
+				tables[i] = &database.Table{Name: fmt.Sprintf("t%d", i)}
+			}
+			db := &columnsDB{failing: map[string]bool{"t2": true}}
+
+			columns, stop := fetchColumns(context.Background(), db, tables, test.jobs, false)
+			defer stop()
+
+			for i, table := range tables {
+				err := columns(i)
+				if table.Name == "t2" {
+					assert.EqualError(t, err, "failed")
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, table.Name+"_id", table.Columns[0].Name)
+			}
+
+			assert.LessOrEqual(t, db.parallel, min(test.jobs, len(tables)))
+			assert.Equal(t, test.parallel, db.parallel > 1)
+		})
+	}
+}
+
+func TestFetchColumns_Stop(t *testing.T) {
+	t.Parallel()
+
+	tables := make([]*database.Table, 10)
+	for i := range tables {
+		tables[i] = &database.Table{Name: fmt.Sprintf("t%d", i)}
+	}
+	db := &columnsDB{}
+
+	columns, stop := fetchColumns(context.Background(), db, tables, 2, false)
+	assert.NoError(t, columns(0))
+
+	// stopping early and twice neither blocks nor panics
+	stop()
+	stop()
+}
+
+func TestFetchColumns_Ahead(t *testing.T) {
+	t.Parallel()
+
+	tables := make([]*database.Table, 20)
+	for i := range tables {
+		tables[i] = &database.Table{Name: fmt.Sprintf("t%d", i)}
+	}
+	db := &columnsDB{}
+
+	columns, stop := fetchColumns(context.Background(), db, tables, 2, false)
+	defer stop()
+	assert.NoError(t, columns(0))
+
+	// the jobs wait for the generation instead of fetching all tables
+	time.Sleep(100 * time.Millisecond)
+	db.mu.Lock()
+	assert.LessOrEqual(t, db.fetched, 1+2*fetchAhead)
+	db.mu.Unlock()
+
+	for i := 1; i < len(tables); i++ {
+		assert.NoError(t, columns(i))
+	}
+	assert.Equal(t, len(tables), db.fetched)
+}
+
+// batchDB gets the columns of all tables at once, or fails doing so.
+type batchDB struct {
+	columnsDB
+
+	fail    bool
+	batches int
+}
+
+func (db *batchDB) GetColumnsOfTables(_ context.Context, tables []*database.Table) error {
+	db.batches++
+	if db.fail {
+		return errors.New("too many tables")
+	}
+	for _, table := range tables {
+		table.Columns = []database.Column{{Name: table.Name + "_batched"}}
+	}
+	return nil
+}
+
+func TestFetchColumns_Batch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		batch    bool
+		fail     bool
+		batches  int
+		expected string
+	}{
+		{
+			desc:     "batch gets the columns of all tables at once",
+			batch:    true,
+			batches:  1,
+			expected: "_batched",
+		},
+		{
+			desc:     "failing batch falls back to the tables one by one",
+			batch:    true,
+			fail:     true,
+			batches:  1,
+			expected: "_id",
+		},
+		{
+			desc:     "no batch without batch mode",
+			batch:    false,
+			batches:  0,
+			expected: "_id",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tables := []*database.Table{{Name: "t0"}, {Name: "t1"}}
+			db := &batchDB{fail: test.fail}
+
+			columns, stop := fetchColumns(context.Background(), db, tables, 2, test.batch)
+			defer stop()
+
+			for i, table := range tables {
+				assert.NoError(t, columns(i))
+				assert.Equal(t, table.Name+test.expected, table.Columns[0].Name)
+			}
+			assert.Equal(t, test.batches, db.batches)
+		})
+	}
+}
+
+// limitedDB limits the number of jobs.
+type limitedDB struct {
+	columnsDB
+
+	limit int
+}
+
+func (db *limitedDB) MaxJobs() int {
+	return db.limit
+}
+
+func TestFetchColumns_Limit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		jobs     int
+		limit    int
+		parallel int
+	}{
+		{
+			desc:     "jobs limited by the database",
+			jobs:     6,
+			limit:    2,
+			parallel: 2,
+		},
+		{
+			desc:     "jobs below the limit",
+			jobs:     2,
+			limit:    4,
+			parallel: 2,
+		},
+		{
+			desc:     "no limit",
+			jobs:     6,
+			limit:    0,
+			parallel: 6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tables := make([]*database.Table, 6)
+			for i := range tables {
+				tables[i] = &database.Table{Name: fmt.Sprintf("t%d", i)}
+			}
+			db := &limitedDB{limit: test.limit}
+
+			columns, stop := fetchColumns(context.Background(), db, tables, test.jobs, false)
+			defer stop()
+
+			for i := range tables {
+				assert.NoError(t, columns(i))
+			}
+			assert.LessOrEqual(t, db.parallel, test.parallel)
+		})
+	}
+}
+
+// shuffledDB gets the columns of the tables after a random delay, so the jobs
+// finish in a different order on every run.
+type shuffledDB struct {
+	*fake.Database
+}
+
+func (db shuffledDB) GetColumnsOfTable(ctx context.Context, table *database.Table) error {
+	time.Sleep(time.Duration(rand.IntN(5)) * time.Millisecond)
+	return db.Database.GetColumnsOfTable(ctx, table)
+}
+
+func TestRun_Deterministic(t *testing.T) {
+	var tables []*database.Table
+	for i := range 12 {
+		tables = append(tables, &database.Table{
+			Name: fmt.Sprintf("table_%02d", i),
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO", ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true}},
+				{OrdinalPosition: 2, Name: "amount", DataType: "numeric", IsNullable: "YES"},
+				{OrdinalPosition: 3, Name: "created_at", DataType: "timestamp", IsNullable: "YES"},
+				{OrdinalPosition: 4, Name: fmt.Sprintf("note_%d", i), DataType: "text", IsNullable: "YES"},
+				{OrdinalPosition: 5, Name: "location", DataType: "point", IsNullable: "NO"},
+			},
+		})
+	}
+
+	generate := func() string {
+		s := settings.New()
+		s.OutputFilePath = t.TempDir()
+		s.Jobs = 4
+		s.Null = settings.NullTypeJSON
+		s.Manifest = true
+		s.TagsJSON = true
+		s.Options = map[string]string{"jobs": "4", "null": "json", "manifest": "true", "tags-json": "true"}
+
+		db := shuffledDB{fake.New(s, tables...)}
+		assert.NoError(t, Run(context.Background(), s, db, output.NewFileWriter(s.OutputFilePath)))
+		return s.OutputFilePath
+	}
+
+	expected, actual := generate(), generate()
+
+	entries, err := os.ReadDir(expected)
+	assert.NoError(t, err)
+	// the structs, the helper types and the manifest
+	assert.Len(t, entries, len(tables)+2)
+	for _, entry := range entries {
+		expectedContent, err := os.ReadFile(filepath.Join(expected, entry.Name()))
+		assert.NoError(t, err)
+		actualContent, err := os.ReadFile(filepath.Join(actual, entry.Name()))
+		assert.NoError(t, err)
+		assert.Equal(t, string(expectedContent), string(actualContent), entry.Name())
+	}
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// listedTable is a table as listed by ListTables, the rows and the comment
+// are only set with the details.
+type listedTable struct {
+	Name    string  `json:"name"`
+	Rows    *int64  `json:"rows,omitempty"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+// ListTables writes the tables Run would generate to w in the ListFormat of
+// the settings, with their number of rows and comment if ListDetails is set.
+func ListTables(ctx context.Context, settings *settings.Settings, db database.Database, w io.Writer) error {
+	tables, err := Tables(ctx, settings, db)
+	if err != nil {
+		return err
+	}
+
+	listed := make([]listedTable, len(tables))
+	for i, table := range tables {
+		listed[i].Name = table.Name
+		if !settings.ListDetails {
+			continue
+		}
+
+		getter, ok := database.As[database.TableInfoGetter](db)
+		if !ok {
+			return fmt.Errorf("details of tables not supported for database type %q", settings.DbType)
+		}
+		info, err := getter.GetTableInfo(ctx, table)
+		if err != nil {
+			return err
+		}
+		if info.Rows.Valid {
+			listed[i].Rows = &info.Rows.Int64
+		}
+		comment := info.Comment.String
+		listed[i].Comment = &comment
+	}
+
+	switch settings.ListFormat {
+	case "json":
+		return writeTablesJSON(w, listed)
+	case "table":
+		return writeTablesTable(w, listed, settings.ListDetails)
+	default:
+		return writeTablesPlain(w, listed, settings.ListDetails)
+	}
+}
+
+// writeTablesPlain writes one table per line, with the details separated by
+// tabs.
+func writeTablesPlain(w io.Writer, tables []listedTable, details bool) error {
+	for _, table := range tables {
+		line := table.Name
+		if details {
+			line += "\t" + rowsString(table.Rows) + "\t" + *table.Comment
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTablesJSON(w io.Writer, tables []listedTable) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tables)
+}
+
+// writeTablesTable writes the tables as aligned columns with a header.
+func writeTablesTable(w io.Writer, tables []listedTable, details bool) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if details {
+		fmt.Fprintln(tw, "TABLE\tROWS\tCOMMENT")
+	} else {
+		fmt.Fprintln(tw, "TABLE")
+	}
+	for _, table := range tables {
+		if details {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", table.Name, rowsString(table.Rows), *table.Comment)
+		} else {
+			fmt.Fprintln(tw, table.Name)
+		}
+	}
+	return tw.Flush()
+}
+
+// rowsString returns the number of rows or "-" if unknown.
+func rowsString(rows *int64) string {
+	if rows == nil {
+		return "-"
+	}
+	return strconv.FormatInt(*rows, 10)
+}
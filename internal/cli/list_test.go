@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+type mockInfoDB struct {
+	*mockDB
+}
+
+func (db *mockInfoDB) GetTableInfo(_ context.Context, table *database.Table) (database.TableInfo, error) {
+	args := db.Called(table)
+	return args.Get(0).(database.TableInfo), args.Error(1)
+}
+
+func TestListTables(t *testing.T) {
+	t.Parallel()
+
+	orders := &database.Table{Name: "orders"}
+	users := &database.Table{Name: "users"}
+	archive := &database.Table{Name: "orders_archive"}
+
+	tests := []struct {
+		desc     string
+		format   settings.ListFormat
+		details  bool
+		expected string
+	}{
+		{
+			desc:     "plain lists the names",
+			format:   settings.ListFormatPlain,
+			expected: "orders\nusers\n",
+		},
+		{
+			desc:     "plain with details separates them by tabs",
+			format:   settings.ListFormatPlain,
+			details:  true,
+			expected: "orders\t42\tall orders\nusers\t-\t\n",
+		},
+		{
+			desc:     "json lists the names",
+			format:   settings.ListFormatJSON,
+			expected: "[\n  {\n    \"name\": \"orders\"\n  },\n  {\n    \"name\": \"users\"\n  }\n]\n",
+		},
+		{
+			desc:    "json with details",
+			format:  settings.ListFormatJSON,
+			details: true,
+			expected: "[\n  {\n    \"name\": \"orders\",\n    \"rows\": 42,\n    \"comment\": \"all orders\"\n  },\n" +
+				"  {\n    \"name\": \"users\",\n    \"comment\": \"\"\n  }\n]\n",
+		},
+		{
+			desc:     "table with details aligns the columns",
+			format:   settings.ListFormatTable,
+			details:  true,
+			expected: "TABLE   ROWS  COMMENT\norders  42    all orders\nusers   -     \n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			assert.NoError(t, s.TablesExclude.Set("_archive$"))
+			s.ListFormat = test.format
+			s.ListDetails = test.details
+
+			db := &mockInfoDB{mockDB: newMockDB(database.New(s))}
+			db.On("GetTables").Return([]*database.Table{orders, users, archive}, nil)
+			if test.details {
+				db.On("GetTableInfo", orders).Return(database.TableInfo{
+					Rows:    sql.NullInt64{Int64: 42, Valid: true},
+					Comment: sql.NullString{String: "all orders", Valid: true},
+				}, nil)
+				db.On("GetTableInfo", users).Return(database.TableInfo{}, nil)
+			}
+
+			var buf bytes.Buffer
+			assert.NoError(t, ListTables(context.Background(), s, db, &buf))
+			assert.Equal(t, test.expected, buf.String())
+			db.AssertExpectations(t)
+		})
+	}
+}
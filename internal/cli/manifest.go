@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// manifestFileName is the name of the manifest written into the output path.
+const manifestFileName = "tables-to-go-manifest.json"
+
+// manifest collects the tables, files and warnings of a run to let other
+// tools verify where the generated files come from. It holds no timestamp,
+// so the same run produces the same manifest. A nil manifest collects
+// nothing.
+type manifest struct {
+	Generator string            `json:"generator"`
+	Tables    []string          `json:"tables"`
+	Files     []manifestFile    `json:"files"`
+	Settings  map[string]string `json:"settings"`
+	Warnings  []string          `json:"warnings"`
+
+	written map[string]struct{}
+}
+
+// manifestFile is a file written by the run and the SHA-256 of its content.
+type manifestFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+}
+
+// newManifest creates the manifest of a run, or nil if the settings do not
+// ask for one.
+func newManifest(settings *settings.Settings) *manifest {
+	if !settings.Manifest {
+		return nil
+	}
+	return &manifest{
+		Generator: settings.GeneratorVersion,
+		Tables:    []string{},
+		Files:     []manifestFile{},
+		Settings:  settings.Options,
+		Warnings:  []string{},
+		written:   map[string]struct{}{},
+	}
+}
+
+// writer returns a writer recording the files written by out.
+func (m *manifest) writer(out output.Writer) output.Writer {
+	if m == nil {
+		return out
+	}
+	return manifestWriter{Writer: out, manifest: m}
+}
+
+// table records the table got generated.
+func (m *manifest) table(name string) {
+	if m == nil {
+		return
+	}
+	m.Tables = append(m.Tables, name)
+}
+
+// warn records the warning and passes it to the hooks.
+func (m *manifest) warn(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	hooks.warning(message)
+	if m == nil {
+		return
+	}
+	m.Warnings = append(m.Warnings, message)
+}
+
+// write hashes the written files in the output path of the settings and
+// writes the manifest next to them.
+func (m *manifest) write(settings *settings.Settings) error {
+	if m == nil {
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(m.written)) {
+		fileName := name + output.FileWriterExtension
+		content, err := os.ReadFile(filepath.Join(settings.OutputFilePath, fileName))
+		if err != nil {
+			return fmt.Errorf("could not hash %q for the manifest: %w", fileName, err)
+		}
+		sum := sha256.Sum256(content)
+		m.Files = append(m.Files, manifestFile{Name: fileName, SHA256: hex.EncodeToString(sum[:])})
+	}
+
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not create manifest: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(settings.OutputFilePath, manifestFileName), append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("could not write manifest: %w", err)
+	}
+	return nil
+}
+
+// manifestWriter records the files successfully written by the Writer.
+type manifestWriter struct {
+	output.Writer
+	manifest *manifest
+}
+
+// Write is the implementation of the Writer interface.
+func (w manifestWriter) Write(tableName string, content string) error {
+	if err := w.Writer.Write(tableName, content); err != nil {
+		return err
+	}
+	w.manifest.written[tableName] = struct{}{}
+	return nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestRun_Manifest(t *testing.T) {
+	dir := t.TempDir()
+
+	s := settings.New()
+	s.OutputFilePath = dir
+	s.Manifest = true
+	s.GeneratorVersion = "v2.0.0"
+	s.Options = map[string]string{"d": "shop", "manifest": "true"}
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "location",
+				DataType:        "point",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, output.NewFileWriter(dir))
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "Users.go"))
+	assert.NoError(t, err)
+	sum := sha256.Sum256(content)
+
+	content, err = os.ReadFile(filepath.Join(dir, manifestFileName))
+	assert.NoError(t, err)
+
+	var actual manifest
+	assert.NoError(t, json.Unmarshal(content, &actual))
+	assert.Equal(t, manifest{
+		Generator: "v2.0.0",
+		Tables:    []string{"users"},
+		Files:     []manifestFile{{Name: "Users.go", SHA256: hex.EncodeToString(sum[:])}},
+		Settings:  map[string]string{"d": "shop", "manifest": "true"},
+		Warnings:  []string{"no mapping for the type of users.location (point), generated as string"},
+	}, actual)
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// NamingStrategy names the structs generated of the tables, their fields and
+// their files.
+type NamingStrategy interface {
+	// StructName returns the name of the struct of the table.
+	StructName(settings *settings.Settings, table string) (string, error)
+	// FieldName returns the name of the field of the column of the table.
+	FieldName(settings *settings.Settings, table, column string) (string, error)
+	// FileName returns the name of the file without extension of the struct
+	// of the table.
+	FileName(settings *settings.Settings, table, structName string) string
+}
+
+// DefaultNaming is the naming by the settings: prefixes and suffixes,
+// stripping, singularizing, the struct names, the output and file name formats
+// and the initialisms.
+type DefaultNaming struct{}
+
+// StructName is the implementation of the NamingStrategy interface.
+func (DefaultNaming) StructName(settings *settings.Settings, table string) (string, error) {
+	return structTypeName(settings, table)
+}
+
+// FieldName is the implementation of the NamingStrategy interface.
+func (DefaultNaming) FieldName(settings *settings.Settings, table, column string) (string, error) {
+	return formatColumnName(settings, column, table)
+}
+
+// FileName is the implementation of the NamingStrategy interface.
+func (DefaultNaming) FileName(settings *settings.Settings, _, structName string) string {
+	return fileNameOf(settings, structName)
+}
+
+// naming is the naming strategy of the generation.
+var naming NamingStrategy = DefaultNaming{}
+
+// SetNamingStrategy replaces the naming of the structs, fields and files by
+// the strategy, nil restores the DefaultNaming.
+func SetNamingStrategy(strategy NamingStrategy) {
+	if strategy == nil {
+		strategy = DefaultNaming{}
+	}
+	naming = strategy
+}
+
+// generatedImports are the names of the packages the generated code imports.
+// The files of a package must not declare what one of them imports, so no
+// struct must be named like them.
+var generatedImports = []string{"bun", "context", "fmt", "json", "null", "sql", "strconv", "strings", "structable", "time"}
+
+// structNameOf returns the name of the struct of the table by the naming
+// strategy, renamed by the RenameStrategy of the settings if it collides with
+// a Go keyword, a predeclared identifier, an import or a helper type of the
+// generated code.
+func structNameOf(s *settings.Settings, table string) (string, error) {
+	name, err := naming.StructName(s, table)
+	if err != nil {
+		return "", err
+	}
+	return rename(s, name, "struct of table "+strconv.Quote(table), func(name string) bool {
+		return isReservedTypeName(s, name)
+	}), nil
+}
+
+// isReservedTypeName returns true if no type of the generated code can be
+// named by the name: a Go keyword, a predeclared identifier, an import or a
+// helper type of the generated code.
+func isReservedTypeName(s *settings.Settings, name string) bool {
+	return token.IsKeyword(name) || types.Universe.Lookup(name) != nil ||
+		slices.Contains(generatedImports, name) || isHelperTypeName(s, name)
+}
+
+// isStructName returns true if the name is the one of the struct of a table
+// of the run.
+func isStructName(s *settings.Settings, name string) bool {
+	for table := range relatedTables {
+		structName, err := naming.StructName(s, table)
+		if err == nil && renamedBy(s, structName, func(name string) bool { return isReservedTypeName(s, name) }) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldNameOf returns the name of the field of the column by the naming
+// strategy, renamed by the RenameStrategy of the settings if it is a Go
+// keyword.
+func fieldNameOf(s *settings.Settings, table, column string) (string, error) {
+	name, err := naming.FieldName(s, table, column)
+	if err != nil {
+		return "", err
+	}
+	return rename(s, name, "field of column "+strconv.Quote(column)+" of table "+strconv.Quote(table), token.IsKeyword), nil
+}
+
+// rename renames the name by the RenameStrategy of the settings as long as it
+// is reserved and reports the rename.
+func rename(s *settings.Settings, name, of string, reserved func(string) bool) string {
+	renamed := renamedBy(s, name, reserved)
+	if renamed != name {
+		slog.Warn("name collides with Go or the generated code, renamed it", "name", name, "renamed", renamed)
+		hooks.warning(fmt.Sprintf("%s named %s collides with Go or the generated code, renamed it to %s", of, name, renamed))
+	}
+	return renamed
+}
+
+// renamedBy returns the name renamed by the RenameStrategy of the settings as
+// long as it is reserved.
+func renamedBy(s *settings.Settings, name string, reserved func(string) bool) string {
+	for reserved(name) {
+		if s.RenameStrategy == settings.RenameStrategyPrefix {
+			name = "X" + name
+		} else {
+			name += "_"
+		}
+	}
+	return name
+}
+
+// isHelperTypeName returns true if the name is the one of a helper type the
+// generation by the settings may declare.
+func isHelperTypeName(s *settings.Settings, name string) bool {
+	if s.GenericRepository && slices.Contains([]string{"Model", "DBTX", "Repository", "NewRepository", "bindVar", "quoteIdent", "tableIdent"}, name) {
+		return true
+	}
+	if s.CRUD == settings.CRUDSQL && name == "DBTX" {
+		return true
+	}
+	if s.Null != settings.NullTypeJSON {
+		return false
+	}
+	if name == "Null" {
+		return true
+	}
+	for sqlType := range nullWrapperFields {
+		if name == strings.TrimPrefix(sqlType, "sql.") {
+			return true
+		}
+	}
+	return false
+}
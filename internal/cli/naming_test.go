@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// lowerNaming names the structs and fields like the tables and columns in
+// lower case, which may be Go keywords.
+type lowerNaming struct {
+	DefaultNaming
+}
+
+func (lowerNaming) StructName(_ *settings.Settings, table string) (string, error) {
+	return strings.ToLower(table), nil
+}
+
+func (lowerNaming) FieldName(_ *settings.Settings, _, column string) (string, error) {
+	return strings.ToLower(column), nil
+}
+
+func TestStructNameOf(t *testing.T) {
+	SetNamingStrategy(lowerNaming{})
+	defer SetNamingStrategy(nil)
+
+	tests := []struct {
+		desc     string
+		table    string
+		settings func(s *settings.Settings)
+		expected string
+	}{
+		{
+			desc:     "no collision",
+			table:    "users",
+			expected: "users",
+		},
+		{
+			desc:     "keyword",
+			table:    "type",
+			expected: "type_",
+		},
+		{
+			desc:  "keyword prefixed",
+			table: "func",
+			settings: func(s *settings.Settings) {
+				s.RenameStrategy = settings.RenameStrategyPrefix
+			},
+			expected: "Xfunc",
+		},
+		{
+			desc:     "predeclared identifier",
+			table:    "string",
+			expected: "string_",
+		},
+		{
+			desc:     "import",
+			table:    "time",
+			expected: "time_",
+		},
+		{
+			desc:     "helper type of other settings",
+			table:    "Model",
+			expected: "model",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			if test.settings != nil {
+				test.settings(s)
+			}
+			name, err := structNameOf(s, test.table)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, name)
+		})
+	}
+}
+
+func TestStructNameOf_HelperTypes(t *testing.T) {
+	var warnings []string
+	SetHooks(Hooks{OnWarning: func(message string) { warnings = append(warnings, message) }})
+	defer SetHooks(Hooks{})
+
+	s := settings.New()
+	s.GenericRepository = true
+	s.Null = settings.NullTypeJSON
+
+	for table, expected := range map[string]string{"repository": "Repository_", "new_repository": "NewRepository_", "null_string": "NullString_", "null": "Null_", "users": "Users"} {
+		name, err := structNameOf(s, table)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, name)
+	}
+	assert.Len(t, warnings, 4)
+	assert.Contains(t, warnings, `struct of table "repository" named Repository collides with Go or the generated code, renamed it to Repository_`)
+}
+
+func TestFieldNameOf(t *testing.T) {
+	SetNamingStrategy(lowerNaming{})
+	defer SetNamingStrategy(nil)
+
+	tests := []struct {
+		desc     string
+		column   string
+		strategy settings.RenameStrategy
+		expected string
+	}{
+		{
+			desc:     "no collision",
+			column:   "name",
+			expected: "name",
+		},
+		{
+			desc:     "keyword",
+			column:   "type",
+			expected: "type_",
+		},
+		{
+			desc:     "keyword prefixed",
+			column:   "range",
+			strategy: settings.RenameStrategyPrefix,
+			expected: "Xrange",
+		},
+		{
+			desc:     "predeclared identifiers are valid fields",
+			column:   "string",
+			expected: "string",
+		},
+		{
+			desc:     "imports are valid fields",
+			column:   "time",
+			expected: "time",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			if test.strategy != "" {
+				s.RenameStrategy = test.strategy
+			}
+			name, err := fieldNameOf(s, "users", test.column)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, name)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// nullWrapperDecl is the declaration of a Null* wrapper type embedding the
+// sql.Null* type of the same name. The wrapper marshals invalid values to JSON
+// null instead of the {"String": "", "Valid": false} representation of the
+// database/sql types. The verbs are the wrapper name and the value field.
+const nullWrapperDecl = `// %[1]s wraps sql.%[1]s and marshals to JSON null if not valid.
+type %[1]s struct {
+	sql.%[1]s
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n %[1]s) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.%[2]s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *%[1]s) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = %[1]s{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.%[2]s); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}`
+
+// genericNullWrapperDecl is the declaration of the generic Null wrapper type
+// embedding sql.Null[T] (Go 1.22+), used instead of one Null* wrapper per
+// type.
+const genericNullWrapperDecl = `// Null wraps sql.Null[T] and marshals to JSON null if not valid.
+type Null[T any] struct {
+	sql.Null[T]
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = Null[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.V); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}`
+
+// nullWrapperFields maps the supported sql.Null* types to the name of their
+// value field.
+var nullWrapperFields = map[string]string{
+	"sql.NullString":  "String",
+	"sql.NullInt64":   "Int64",
+	"sql.NullFloat64": "Float64",
+	"sql.NullBool":    "Bool",
+	"sql.NullTime":    "Time",
+}
+
+// gureguNullImport is the import path of the types of the null type guregu.
+const gureguNullImport = "github.com/guregu/null/v5"
+
+// gureguNullTypes maps the supported sql.Null* types to the ones of the null
+// type guregu, the other types use its generic null.Value[T].
+var gureguNullTypes = map[string]string{
+	"sql.NullString":  "null.String",
+	"sql.NullInt64":   "null.Int",
+	"sql.NullFloat64": "null.Float",
+	"sql.NullBool":    "null.Bool",
+	"sql.NullTime":    "null.Time",
+}
+
+// addNullWrapper registers the Null* wrapper type for the given sql.Null* type
+// and returns the name of the wrapper type to use for the struct field.
+func addNullWrapper(helpers *helperTypes, sqlType string) string {
+	field, ok := nullWrapperFields[sqlType]
+	if !ok {
+		return sqlType
+	}
+	name := strings.TrimPrefix(sqlType, "sql.")
+	helpers.addTo(nullTypesFileName, name, fmt.Sprintf(nullWrapperDecl, name, field), "database/sql", "encoding/json")
+	return name
+}
+
+// addGenericNullWrapper registers the generic Null wrapper type and returns the
+// type to use for the struct field of the given value type.
+func addGenericNullWrapper(helpers *helperTypes, valueType string) string {
+	helpers.addTo(nullTypesFileName, "Null", genericNullWrapperDecl, "database/sql", "encoding/json")
+	return "Null[" + valueType + "]"
+}
@@ -0,0 +1,35 @@
+package cli
+
+// unixMilliDecl is the declaration of UnixMilli, a point in time as the
+// milliseconds since the epoch, which parquet-go encodes as INT64 with the
+// TIMESTAMP_MILLIS of the parquet-tags, unlike time.Time. It scans and stores
+// the timestamps of the database.
+const unixMilliDecl = `// UnixMilli is a point in time as the milliseconds since the epoch.
+type UnixMilli int64
+
+// Time returns the point in time.
+func (m UnixMilli) Time() time.Time {
+	return time.UnixMilli(int64(m))
+}
+
+// Scan implements the sql.Scanner interface.
+func (m *UnixMilli) Scan(src any) error {
+	t, ok := src.(time.Time)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into UnixMilli", src)
+	}
+	*m = UnixMilli(t.UnixMilli())
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (m UnixMilli) Value() (driver.Value, error) {
+	return m.Time(), nil
+}`
+
+// unixMilliType registers the UnixMilli type of the temporal columns of the
+// parquet-tags and returns its name.
+func unixMilliType() string {
+	helpers.add("UnixMilli", unixMilliDecl, "database/sql/driver", "fmt", "time")
+	return "UnixMilli"
+}
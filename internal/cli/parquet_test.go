@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// scanEventsMain scans a row of a timestamp and a NULL by a driver returning
+// them like the ones of the databases into the generated Events struct and
+// stores the timestamp again.
+const scanEventsMain = `package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+type rowDriver struct{}
+
+func (rowDriver) Open(string) (driver.Conn, error) { return conn{}, nil }
+
+type conn struct{}
+
+func (conn) Prepare(string) (driver.Stmt, error) { return stmt{}, nil }
+func (conn) Close() error                        { return nil }
+func (conn) Begin() (driver.Tx, error)           { return nil, errors.New("no transactions") }
+
+type stmt struct{}
+
+func (stmt) Close() error  { return nil }
+func (stmt) NumInput() int { return -1 }
+func (stmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("no exec")
+}
+func (stmt) Query([]driver.Value) (driver.Rows, error) { return &rows{}, nil }
+
+type rows struct{ done bool }
+
+func (*rows) Columns() []string { return []string{"created_at", "deleted_at"} }
+func (*rows) Close() error      { return nil }
+func (r *rows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = time.Date(2024, 5, 1, 12, 30, 0, 500_000_000, time.UTC)
+	dest[1] = nil
+	return nil
+}
+
+func main() {
+	sql.Register("row", rowDriver{})
+	db, err := sql.Open("row", "")
+	if err != nil {
+		panic(err)
+	}
+	var e Events
+	if err = db.QueryRow("SELECT created_at, deleted_at FROM events").Scan(&e.CreatedAt, &e.DeletedAt); err != nil {
+		panic(err)
+	}
+	value, err := e.CreatedAt.Value()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(int64(e.CreatedAt), e.DeletedAt == nil, value.(time.Time).UTC().Format(time.RFC3339Nano))
+}
+`
+
+func TestRun_TagsParquetMillis_Scan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the generated code")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go not found")
+	}
+
+	s := settings.New()
+	s.PackageName = "main"
+	s.TagsParquet = true
+	s.TagsParquetMillis = true
+	assert.NoError(t, s.Null.Set(string(settings.NullTypeNative)))
+
+	dir := t.TempDir()
+	files := runParquetTable(t, s)
+	files["main"] = scanEventsMain
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name+".go"), []byte(content), 0o600))
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module events\n\ngo 1.21\n"), 0o600))
+
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "GOTOOLCHAIN=local")
+	output, err := cmd.CombinedOutput()
+	if !assert.NoError(t, err, string(output)) {
+		return
+	}
+
+	assert.Equal(t, "1714566600500 true 2024-05-01T12:30:00.5Z\n", string(output))
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// pgArrayTypes maps the element types of the array columns of Postgres, the
+// names of the array types without their underscore like int4 of _int4, to
+// their array type of github.com/lib/pq and their slice.
+var pgArrayTypes = map[string]struct{ pq, native string }{
+	"int2":    {pq: "pq.Int64Array", native: "[]int64"},
+	"int4":    {pq: "pq.Int64Array", native: "[]int64"},
+	"int8":    {pq: "pq.Int64Array", native: "[]int64"},
+	"float4":  {pq: "pq.Float64Array", native: "[]float64"},
+	"float8":  {pq: "pq.Float64Array", native: "[]float64"},
+	"numeric": {pq: "pq.Float64Array", native: "[]float64"},
+	"bool":    {pq: "pq.BoolArray", native: "[]bool"},
+	"bytea":   {pq: "pq.ByteaArray", native: "[][]byte"},
+	"text":    {pq: "pq.StringArray", native: "[]string"},
+	"varchar": {pq: "pq.StringArray", native: "[]string"},
+	"bpchar":  {pq: "pq.StringArray", native: "[]string"},
+	"citext":  {pq: "pq.StringArray", native: "[]string"},
+	"uuid":    {pq: "pq.StringArray", native: "[]string"},
+}
+
+// mapPgType maps the array and JSON columns of Postgres to Go types with the
+// imports they need. The types are the same for nullable columns, since NULL
+// scans into their nil slices. It returns false for the other columns.
+func mapPgType(s *settings.Settings, column database.Column) (goType string, imports []string, ok bool) {
+	if s.DbType.Dialect() != settings.DBTypePostgresql {
+		return "", nil, false
+	}
+
+	switch column.DataType {
+	case "json", "jsonb":
+		return "json.RawMessage", []string{"encoding/json"}, true
+	case "ARRAY":
+		types, ok := pgArrayTypes[strings.TrimPrefix(column.UdtName, "_")]
+		if !ok {
+			return "", nil, false
+		}
+		if s.ArrayType == settings.ArrayTypeNative {
+			return types.native, nil, true
+		}
+		return types.pq, []string{"github.com/lib/pq"}, true
+	}
+	return "", nil, false
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestMapPgType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc      string
+		dbType    settings.DBType
+		arrayType settings.ArrayType
+		column    database.Column
+		expected  string
+		imports   []string
+	}{
+		{
+			desc:     "jsonb",
+			column:   database.Column{DataType: "jsonb", IsNullable: "YES"},
+			expected: "json.RawMessage",
+			imports:  []string{"encoding/json"},
+		},
+		{
+			desc:     "json of mysql",
+			dbType:   settings.DBTypeMySQL,
+			column:   database.Column{DataType: "json"},
+			expected: "",
+		},
+		{
+			desc:     "integer array of pq",
+			column:   database.Column{DataType: "ARRAY", UdtName: "_int4"},
+			expected: "pq.Int64Array",
+			imports:  []string{"github.com/lib/pq"},
+		},
+		{
+			desc:     "text array of pq",
+			column:   database.Column{DataType: "ARRAY", UdtName: "_text"},
+			expected: "pq.StringArray",
+			imports:  []string{"github.com/lib/pq"},
+		},
+		{
+			desc:      "bytea array native",
+			arrayType: settings.ArrayTypeNative,
+			column:    database.Column{DataType: "ARRAY", UdtName: "_bytea"},
+			expected:  "[][]byte",
+		},
+		{
+			desc:      "float array native",
+			arrayType: settings.ArrayTypeNative,
+			column:    database.Column{DataType: "ARRAY", UdtName: "_float8"},
+			expected:  "[]float64",
+		},
+		{
+			desc:     "array of unknown element type",
+			column:   database.Column{DataType: "ARRAY", UdtName: "_tsrange"},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			if test.dbType != "" {
+				s.DbType = test.dbType
+			}
+			if test.arrayType != "" {
+				s.ArrayType = test.arrayType
+			}
+
+			goType, imports, ok := mapPgType(s, test.column)
+			assert.Equal(t, test.expected != "", ok)
+			assert.Equal(t, test.expected, goType)
+			assert.Equal(t, test.imports, imports)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+const (
+	// progressInterval is the minimum time between two progress lines
+	progressInterval = 5 * time.Second
+
+	// progressBarWidth is the number of characters of the progress bar
+	progressBarWidth = 30
+)
+
+// progress reports the processed tables out of the total. On terminals it
+// redraws a progress bar in place, otherwise it writes a progress line at
+// most every progressInterval. A nil progress reports nothing.
+type progress struct {
+	out   io.Writer
+	total int
+	done  int
+
+	// bar redraws the progress bar instead of writing lines
+	bar bool
+
+	now  func() time.Time
+	last time.Time
+}
+
+// newProgress creates the progress of the total tables written to out, or nil
+// if the progress reporting is disabled by the settings. The bar is only
+// drawn on terminals and without verbose output, which would break it.
+func newProgress(settings *settings.Settings, out io.Writer, total int) *progress {
+	if !settings.Progress {
+		return nil
+	}
+	return &progress{
+		out:   out,
+		total: total,
+		bar:   isTerminal(out) && !settings.Verbose,
+		now:   time.Now,
+	}
+}
+
+// start reports the table getting processed next.
+func (p *progress) start(table string) {
+	if p == nil {
+		return
+	}
+
+	if p.bar {
+		fmt.Fprintf(p.out, "\r%s %s\033[K", p.status(), table)
+	} else if now := p.now(); p.done == 0 || now.Sub(p.last) >= progressInterval {
+		fmt.Fprintf(p.out, "%s, processing %s\n", p.status(), table)
+		p.last = now
+	}
+	p.done++
+}
+
+// finish reports that all tables got processed.
+func (p *progress) finish() {
+	if p == nil {
+		return
+	}
+
+	p.done = p.total
+	if p.bar {
+		fmt.Fprintf(p.out, "\r%s\033[K\n", p.status())
+		return
+	}
+	fmt.Fprintln(p.out, p.status())
+}
+
+// status returns the processed tables out of the total, as bar if enabled.
+func (p *progress) status() string {
+	percent := 100
+	if p.total > 0 {
+		percent = 100 * p.done / p.total
+	}
+
+	status := fmt.Sprintf("%d/%d tables done (%d%%)", p.done, p.total, percent)
+	if !p.bar {
+		return status
+	}
+
+	filled := progressBarWidth * percent / 100
+	return "[" + strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled) + "] " + status
+}
+
+// isTerminal returns true if w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestNewProgress(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	assert.Nil(t, newProgress(s, &bytes.Buffer{}, 3))
+
+	s.Progress = true
+	p := newProgress(s, &bytes.Buffer{}, 3)
+	if assert.NotNil(t, p) {
+		assert.False(t, p.bar)
+		assert.Equal(t, 3, p.total)
+	}
+
+	// a nil progress reports nothing
+	var disabled *progress
+	disabled.start("users")
+	disabled.finish()
+}
+
+func TestProgress_Lines(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := &progress{
+		out:   &buf,
+		total: 4,
+		now:   func() time.Time { return now },
+	}
+
+	p.start("users")
+	now = now.Add(time.Second)
+	p.start("orders")
+	now = now.Add(progressInterval)
+	p.start("items")
+	p.start("tags")
+	p.finish()
+
+	expected := "0/4 tables done (0%), processing users\n" +
+		"2/4 tables done (50%), processing items\n" +
+		"4/4 tables done (100%)\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestProgress_Bar(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p := &progress{
+		out:   &buf,
+		total: 2,
+		bar:   true,
+		now:   time.Now,
+	}
+
+	p.start("users")
+	p.start("orders")
+	p.finish()
+
+	expected := "\r[                              ] 0/2 tables done (0%) users\033[K" +
+		"\r[===============               ] 1/2 tables done (50%) orders\033[K" +
+		"\r[==============================] 2/2 tables done (100%)\033[K\n"
+	assert.Equal(t, expected, buf.String())
+}
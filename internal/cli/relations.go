@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// relatedTables are the names of the tables of the run, the relation fields
+// reference the structs of these tables only.
+var relatedTables = map[string]struct{}{}
+
+// setRelatedTables sets the tables of the run the relation fields can
+// reference.
+func setRelatedTables(tables []*database.Table) {
+	relatedTables = make(map[string]struct{}, len(tables))
+	for _, table := range tables {
+		relatedTables[table.Name] = struct{}{}
+	}
+}
+
+// withForeignKeys returns the function waiting for the columns of the i-th
+// table, which gets the foreign keys of the table as well if the settings
+// generate relations. It fails right away if the database can not get them.
+func withForeignKeys(ctx context.Context, s *settings.Settings, db database.Database, tables []*database.Table, columns func(i int) error) (func(i int) error, error) {
+	if !s.HasRelations() {
+		return columns, nil
+	}
+	getter, ok := database.As[database.ForeignKeysGetter](db)
+	if !ok {
+		return nil, fmt.Errorf("relations not supported for database type %q", s.DbType)
+	}
+	return func(i int) error {
+		if err := columns(i); err != nil {
+			return err
+		}
+		if err := getter.GetForeignKeysOfTable(ctx, tables[i]); err != nil {
+			return fmt.Errorf("could not get foreign keys: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+// referenceComments returns the comments of the columns of the foreign keys of
+// the table naming the referenced table and columns, e.g.
+// "references authors(id)", by the names of the columns.
+func referenceComments(table *database.Table) map[string]string {
+	comments := map[string]string{}
+	for _, foreignKey := range table.ForeignKeys {
+		comment := "references " + foreignKey.ReferencedTable + "(" + strings.Join(foreignKey.ReferencedColumns, ", ") + ")"
+		for _, column := range foreignKey.Columns {
+			if _, ok := comments[column]; !ok {
+				comments[column] = comment
+			}
+		}
+	}
+	return comments
+}
+
+// relationField is a field of the struct of the table referenced by a foreign
+// key of a single column, e.g. Author *Author of the column author_id.
+type relationField struct {
+	name     string
+	typeName string
+}
+
+// relationFields returns the fields of the structs of the tables referenced by
+// the foreign keys of single columns, named by the column without its id
+// suffix or else by the referenced struct, and adds their names to the taken
+// ones. The foreign keys of excluded columns and the ones referencing tables
+// not of the run get none.
+func relationFields(s *settings.Settings, table *database.Table, excluded []string, taken map[string]struct{}) ([]relationField, error) {
+	var fields []relationField
+	for _, foreignKey := range table.ForeignKeys {
+		if len(foreignKey.Columns) != 1 {
+			continue
+		}
+		column := foreignKey.Columns[0]
+		if _, ok := relatedTables[foreignKey.ReferencedTable]; !ok || slices.Contains(excluded, column) {
+			continue
+		}
+
+		typeName, err := structNameOf(s, foreignKey.ReferencedTable)
+		if err != nil {
+			return nil, err
+		}
+
+		name := typeName
+		if stripped := trimIDSuffix(column); stripped != "" {
+			if name, err = fieldNameOf(s, table.Name, stripped); err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := taken[name]; ok {
+			name = uniqueFieldName(taken, name)
+		}
+		taken[name] = struct{}{}
+
+		fields = append(fields, relationField{name: name, typeName: typeName})
+	}
+	return fields, nil
+}
+
+// trimIDSuffix returns the name of the column without its id suffix, e.g.
+// author of author_id or authorId, empty if it has none.
+func trimIDSuffix(column string) string {
+	lower := strings.ToLower(column)
+	if !strings.HasSuffix(lower, "id") || len(column) <= 2 {
+		return ""
+	}
+	name := column[:len(column)-2]
+	switch {
+	case strings.HasSuffix(name, "_") || strings.HasSuffix(name, " ") || strings.HasSuffix(name, "-"):
+		return strings.TrimRight(name, "_ -")
+	case column[len(column)-2] == 'I' && unicode.IsLower(rune(name[len(name)-1])):
+		return name
+	}
+	return ""
+}
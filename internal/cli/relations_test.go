@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestRun_Relations(t *testing.T) {
+	id := database.Column{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"}
+	foreignKeys := []database.ForeignKey{
+		{Name: "books_author_id_fkey", Columns: []string{"author_id"}, ReferencedTable: "authors", ReferencedColumns: []string{"id"}},
+		{Name: "books_editor_fkey", Columns: []string{"editor"}, ReferencedTable: "authors", ReferencedColumns: []string{"id"}},
+		{Name: "books_publisher_id_fkey", Columns: []string{"publisher_id"}, ReferencedTable: "publishers", ReferencedColumns: []string{"id"}},
+	}
+
+	tests := []struct {
+		desc      string
+		relations settings.Relations
+		settings  func(s *settings.Settings)
+		expected  string
+	}{
+		{
+			desc:      "none",
+			relations: settings.RelationsNone,
+			expected:  "package dto\n\ntype Books struct {\nID int `db:\"id\"`\nAuthorID int `db:\"author_id\"`\nEditor int `db:\"editor\"`\nPublisherID int `db:\"publisher_id\"`\n}\n\nfunc (b Books) TableName() string {\n\treturn \"books\"\n}\n",
+		},
+		{
+			desc:      "comments",
+			relations: settings.RelationsComments,
+			expected:  "package dto\n\ntype Books struct {\nID int `db:\"id\"`\n// references authors(id)\nAuthorID int `db:\"author_id\"`\n// references authors(id)\nEditor int `db:\"editor\"`\n// references publishers(id)\nPublisherID int `db:\"publisher_id\"`\n}\n\nfunc (b Books) TableName() string {\n\treturn \"books\"\n}\n",
+		},
+		{
+			desc:      "fields of the tables of the run",
+			relations: settings.RelationsFields,
+			expected:  "package dto\n\ntype Books struct {\nID int `db:\"id\"`\n// references authors(id)\nAuthorID int `db:\"author_id\"`\n// references authors(id)\nEditor int `db:\"editor\"`\n// references publishers(id)\nPublisherID int `db:\"publisher_id\"`\n\nAuthor *Authors `db:\"-\"`\nAuthors *Authors `db:\"-\"`\n}\n\nfunc (b Books) TableName() string {\n\treturn \"books\"\n}\n",
+		},
+		{
+			desc:      "fields excluded from the serialization",
+			relations: settings.RelationsFields,
+			settings: func(s *settings.Settings) {
+				s.TagsJSON = true
+				s.TagsYAML = true
+			},
+			expected: "package dto\n\ntype Books struct {\nID int `db:\"id\" json:\"id\" yaml:\"id\"`\n// references authors(id)\nAuthorID int `db:\"author_id\" json:\"author_id\" yaml:\"author_id\"`\n// references authors(id)\nEditor int `db:\"editor\" json:\"editor\" yaml:\"editor\"`\n// references publishers(id)\nPublisherID int `db:\"publisher_id\" json:\"publisher_id\" yaml:\"publisher_id\"`\n\nAuthor *Authors `db:\"-\" json:\"-\" yaml:\"-\"`\nAuthors *Authors `db:\"-\" json:\"-\" yaml:\"-\"`\n}\n\nfunc (b Books) TableName() string {\n\treturn \"books\"\n}\n",
+		},
+		{
+			desc:      "fields without tags",
+			relations: settings.RelationsFields,
+			settings: func(s *settings.Settings) {
+				s.TagsNoDb = true
+			},
+			expected: "package dto\n\ntype Books struct {\nID int \n// references authors(id)\nAuthorID int \n// references authors(id)\nEditor int \n// references publishers(id)\nPublisherID int \n\nAuthor *Authors\nAuthors *Authors\n}\n\nfunc (b Books) TableName() string {\n\treturn \"books\"\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Relations = test.relations
+			if test.settings != nil {
+				test.settings(s)
+			}
+			db := database.New(s)
+
+			authors := &database.Table{Name: "authors", Columns: []database.Column{id}}
+			books := &database.Table{Name: "books", Columns: []database.Column{
+				id,
+				{OrdinalPosition: 2, Name: "author_id", DataType: "integer", IsNullable: "NO"},
+				{OrdinalPosition: 3, Name: "editor", DataType: "integer", IsNullable: "NO"},
+				{OrdinalPosition: 4, Name: "publisher_id", DataType: "integer", IsNullable: "NO"},
+			}}
+
+			mdb := newMockDB(db)
+			mdb.On("GetTables").Return([]*database.Table{authors, books}, nil)
+			mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+			mdb.On("GetColumnsOfTable", mock.Anything).Return(nil)
+			if test.relations != settings.RelationsNone {
+				mdb.On("GetForeignKeysOfTable", authors).Return(nil)
+				mdb.On("GetForeignKeysOfTable", books).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(0).(*database.Table).ForeignKeys = foreignKeys
+				})
+			}
+
+			w := newMockWriter()
+			w.On("Write", "Authors", mock.Anything).Return(nil)
+			w.On("Write", "Books", test.expected).Return(nil)
+
+			assert.NoError(t, Run(context.Background(), s, mdb, w))
+			mdb.AssertExpectations(t)
+			w.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_Relations_NotSupported(t *testing.T) {
+	s := settings.New()
+	s.Relations = settings.RelationsComments
+
+	mdb := newMockDB(database.New(s))
+	mdb.On("GetTables").Return([]*database.Table{{Name: "books"}}, nil)
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+
+	// hides the foreign keys of the database
+	db := struct{ database.Database }{mdb}
+	err := Run(context.Background(), s, db, newMockWriter())
+	assert.EqualError(t, err, `relations not supported for database type "pg"`)
+}
+
+func TestTrimIDSuffix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		column   string
+		expected string
+	}{
+		{column: "author_id", expected: "author"},
+		{column: "AUTHOR_ID", expected: "AUTHOR"},
+		{column: "authorId", expected: "author"},
+		{column: "AuthorID", expected: "Author"},
+		{column: "author id", expected: "author"},
+		{column: "id", expected: ""},
+		{column: "paid", expected: ""},
+		{column: "editor", expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.column, func(t *testing.T) {
+			assert.Equal(t, test.expected, trimIDSuffix(test.column))
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// structField is a generated struct field and the column it represents.
+type structField struct {
+	name   string
+	goType string
+	column database.Column
+}
+
+const modelDecl = `// Model is implemented by the generated structs and provides the table
+// metadata needed by the generic Repository.
+type Model interface {
+	TableName() string
+	SchemaName() string
+	Columns() []string
+	InsertColumns() []string
+	PrimaryKeyColumns() []string
+	PrimaryKeyValues() []any
+	Values() []any
+	InsertValues() []any
+	ScanTargets() []any
+}`
+
+const dbtxDecl = `// DBTX is satisfied by *sql.DB, *sql.Tx and *sql.Conn.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}`
+
+const repositoryDecl = `// Repository provides basic CRUD operations for any generated Model.
+type Repository[T Model] struct {
+	db       DBTX
+	newModel func() T
+}
+
+// NewRepository creates a new Repository. The function newModel must return a
+// new, empty instance of T which rows get scanned into.
+func NewRepository[T Model](db DBTX, newModel func() T) *Repository[T] {
+	return &Repository[T]{
+		db:       db,
+		newModel: newModel,
+	}
+}
+
+// Insert inserts the model. Auto increment columns are left to the database.
+func (r *Repository[T]) Insert(ctx context.Context, m T) error {
+	columns := m.InsertColumns()
+	query := "INSERT INTO " + tableIdent(m) + " (" + quoteIdents(columns) + ") VALUES (" + bindVars(1, len(columns)) + ")"
+	_, err := r.db.ExecContext(ctx, query, m.InsertValues()...)
+	return err
+}
+
+// Get returns the model identified by the given primary key values.
+func (r *Repository[T]) Get(ctx context.Context, pk ...any) (T, error) {
+	m := r.newModel()
+	where, err := wherePrimaryKey(m, 1)
+	if err != nil {
+		return m, err
+	}
+	query := "SELECT " + quoteIdents(m.Columns()) + " FROM " + tableIdent(m) + where
+	err = r.db.QueryRowContext(ctx, query, pk...).Scan(m.ScanTargets()...)
+	return m, err
+}
+
+// List returns all models of the table.
+func (r *Repository[T]) List(ctx context.Context) ([]T, error) {
+	m := r.newModel()
+	rows, err := r.db.QueryContext(ctx, "SELECT "+quoteIdents(m.Columns())+" FROM "+tableIdent(m))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []T
+	for rows.Next() {
+		m := r.newModel()
+		if err := rows.Scan(m.ScanTargets()...); err != nil {
+			return nil, err
+		}
+		models = append(models, m)
+	}
+	return models, rows.Err()
+}
+
+// Update updates all columns of the model identified by its primary key.
+func (r *Repository[T]) Update(ctx context.Context, m T) error {
+	columns := m.Columns()
+	set := make([]string, len(columns))
+	for i, column := range columns {
+		set[i] = quoteIdent(column) + " = " + bindVar(i+1)
+	}
+	where, err := wherePrimaryKey(m, len(columns)+1)
+	if err != nil {
+		return err
+	}
+	query := "UPDATE " + tableIdent(m) + " SET " + strings.Join(set, ", ") + where
+	_, err = r.db.ExecContext(ctx, query, append(m.Values(), m.PrimaryKeyValues()...)...)
+	return err
+}
+
+// Delete deletes the model identified by its primary key.
+func (r *Repository[T]) Delete(ctx context.Context, m T) error {
+	where, err := wherePrimaryKey(m, 1)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, "DELETE FROM "+tableIdent(m)+where, m.PrimaryKeyValues()...)
+	return err
+}
+
+func wherePrimaryKey(m Model, offset int) (string, error) {
+	pk := m.PrimaryKeyColumns()
+	if len(pk) == 0 {
+		return "", fmt.Errorf("table %q has no primary key", m.TableName())
+	}
+	conditions := make([]string, len(pk))
+	for i, column := range pk {
+		conditions[i] = quoteIdent(column) + " = " + bindVar(offset+i)
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), nil
+}
+
+// tableIdent returns the quoted name of the table of the model, qualified by
+// its schema unless it is the default one.
+func tableIdent(m Model) string {
+	if schema := m.SchemaName(); schema != "" {
+		return quoteIdent(schema) + "." + quoteIdent(m.TableName())
+	}
+	return quoteIdent(m.TableName())
+}
+
+func quoteIdents(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteIdent(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func bindVars(offset, n int) string {
+	vars := make([]string, n)
+	for i := range vars {
+		vars[i] = bindVar(offset + i)
+	}
+	return strings.Join(vars, ", ")
+}`
+
+// bindVarDecls maps the database types to the implementation of the bind
+// variable function used by the generic Repository.
+var bindVarDecls = map[settings.DBType]string{
+	settings.DBTypePostgresql: `func bindVar(n int) string {
+	return "$" + strconv.Itoa(n)
+}`,
+	settings.DBTypeOracle: `func bindVar(n int) string {
+	return ":" + strconv.Itoa(n)
+}`,
+	settings.DBTypeMySQL: `func bindVar(int) string {
+	return "?"
+}`,
+	settings.DBTypeSQLite: `func bindVar(int) string {
+	return "?"
+}`,
+}
+
+// quoteIdentDecls maps the database types to the implementation of the
+// function quoting the names of tables and columns in the SQL of the generic
+// Repository, so names of mixed case or reserved words stay as they are.
+var quoteIdentDecls = map[settings.DBType]string{
+	settings.DBTypePostgresql: quoteIdentDoubleQuotes,
+	settings.DBTypeOracle:     quoteIdentDoubleQuotes,
+	settings.DBTypeSQLite:     quoteIdentDoubleQuotes,
+	settings.DBTypeMySQL:      "func quoteIdent(name string) string {\n\treturn \"`\" + strings.ReplaceAll(name, \"`\", \"``\") + \"`\"\n}",
+}
+
+const quoteIdentDoubleQuotes = `func quoteIdent(name string) string {
+	return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""
+}`
+
+// addRepositoryHelpers registers the generic Repository and its dependencies
+// as helper types.
+func addRepositoryHelpers(s *settings.Settings, helpers *helperTypes) {
+	helpers.add("Model", modelDecl)
+	helpers.add("DBTX", dbtxDecl, "context", "database/sql")
+	helpers.add("Repository", repositoryDecl, "context", "fmt", "strings")
+
+	bindVarDecl, ok := bindVarDecls[s.DbType.Dialect()]
+	if !ok {
+		bindVarDecl = bindVarDecls[settings.DBTypeMySQL]
+	}
+	var imports []string
+	if strings.Contains(bindVarDecl, "strconv.") {
+		imports = append(imports, "strconv")
+	}
+	helpers.add("bindVar", bindVarDecl, imports...)
+
+	quoteDecl, ok := quoteIdentDecls[s.DbType.Dialect()]
+	if !ok {
+		quoteDecl = quoteIdentDoubleQuotes
+	}
+	helpers.add("quoteIdent", quoteDecl, "strings")
+}
+
+// writeModelMethods writes the methods implementing the Model interface of
+// the generic Repository for the given struct, the table of the struct is in
+// the given schema, empty for the default one.
+func writeModelMethods(content *strings.Builder, db database.Database, structName, schema string, fields []structField) {
+	receiver := strings.ToLower(string(structName[0]))
+
+	content.WriteString("\nfunc (")
+	content.WriteString(receiver)
+	content.WriteString(" ")
+	content.WriteString(structName)
+	content.WriteString(") SchemaName() string {\n\treturn ")
+	content.WriteString(strconv.Quote(schema))
+	content.WriteString("\n}\n")
+
+	var columns, insertColumns, pkColumns, values, insertValues, pkValues, scanTargets []string
+	for _, field := range fields {
+		quoted := strconv.Quote(field.column.Name)
+		value := receiver + "." + field.name
+
+		columns = append(columns, quoted)
+		values = append(values, value)
+		scanTargets = append(scanTargets, "&"+value)
+
+		if !db.IsAutoIncrement(field.column) {
+			insertColumns = append(insertColumns, quoted)
+			insertValues = append(insertValues, value)
+		}
+		if db.IsPrimaryKey(field.column) {
+			pkColumns = append(pkColumns, quoted)
+			pkValues = append(pkValues, value)
+		}
+	}
+
+	writeSliceMethod(content, structName, "Columns", "[]string", columns, false)
+	writeSliceMethod(content, structName, "InsertColumns", "[]string", insertColumns, false)
+	writeSliceMethod(content, structName, "PrimaryKeyColumns", "[]string", pkColumns, false)
+	writeSliceMethod(content, structName, "PrimaryKeyValues", "[]any", pkValues, false)
+	writeSliceMethod(content, structName, "Values", "[]any", values, false)
+	writeSliceMethod(content, structName, "InsertValues", "[]any", insertValues, false)
+	writeSliceMethod(content, structName, "ScanTargets", "[]any", scanTargets, true)
+}
+
+// writeSliceMethod writes the method of the struct returning the slice of the
+// result type of the elements, on the pointer receiver if pointer is set.
+func writeSliceMethod(content *strings.Builder, structName, name, resultType string, elems []string, pointer bool) {
+	content.WriteString("\nfunc (")
+	content.WriteString(strings.ToLower(string(structName[0])))
+	content.WriteString(" ")
+	if pointer {
+		content.WriteString("*")
+	}
+	content.WriteString(structName)
+	content.WriteString(") ")
+	content.WriteString(name)
+	content.WriteString("() ")
+	content.WriteString(resultType)
+	content.WriteString(" {\n\treturn ")
+	content.WriteString(resultType)
+	content.WriteString("{")
+	content.WriteString(strings.Join(elems, ", "))
+	content.WriteString("}\n}\n")
+}
@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestWriteModelMethods(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	s.DbType = settings.DBTypePostgresql
+	db := database.New(s)
+
+	fields := []structField{
+		{
+			name: "ID",
+			column: database.Column{
+				Name:           "id",
+				DefaultValue:   sql.NullString{String: "nextval('foo_id_seq'::regclass)", Valid: true},
+				ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+		},
+		{
+			name:   "Name",
+			column: database.Column{Name: "name"},
+		},
+	}
+
+	tests := []struct {
+		desc   string
+		schema string
+	}{
+		{
+			desc: "default schema",
+		},
+		{
+			desc:   "non-default schema",
+			schema: "other",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var content strings.Builder
+			writeModelMethods(&content, db, "Foo", test.schema, fields)
+
+			expected := `
+func (f Foo) SchemaName() string {
+	return "` + test.schema + `"
+}
+
+func (f Foo) Columns() []string {
+	return []string{"id", "name"}
+}
+
+func (f Foo) InsertColumns() []string {
+	return []string{"name"}
+}
+
+func (f Foo) PrimaryKeyColumns() []string {
+	return []string{"id"}
+}
+
+func (f Foo) PrimaryKeyValues() []any {
+	return []any{f.ID}
+}
+
+func (f Foo) Values() []any {
+	return []any{f.ID, f.Name}
+}
+
+func (f Foo) InsertValues() []any {
+	return []any{f.Name}
+}
+
+func (f *Foo) ScanTargets() []any {
+	return []any{&f.ID, &f.Name}
+}
+`
+			assert.Equal(t, expected, content.String())
+		})
+	}
+}
+
+func TestAddRepositoryHelpers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dbType        settings.DBType
+		expected      string
+		expectedQuote string
+	}{
+		{
+			dbType:        settings.DBTypePostgresql,
+			expected:      `return "$" + strconv.Itoa(n)`,
+			expectedQuote: `return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""`,
+		},
+		{
+			dbType:        settings.DBTypeMySQL,
+			expected:      `return "?"`,
+			expectedQuote: "return \"`\" + strings.ReplaceAll(name, \"`\", \"``\") + \"`\"",
+		},
+		{
+			dbType:        settings.DBTypeSQLite,
+			expected:      `return "?"`,
+			expectedQuote: `return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""`,
+		},
+		{
+			dbType:        settings.DBTypeOracle,
+			expected:      `return ":" + strconv.Itoa(n)`,
+			expectedQuote: `return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.dbType.String(), func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+
+			helpers := newHelperTypes()
+			addRepositoryHelpers(s, helpers)
+
+			content := helpers.content(helperTypesFileName, "dto")
+			assert.Contains(t, content, "type Model interface")
+			assert.Contains(t, content, "type Repository[T Model] struct")
+			assert.Contains(t, content, test.expected)
+			assert.Contains(t, content, test.expectedQuote)
+			assert.Contains(t, content, `"SELECT " + quoteIdents(m.Columns()) + " FROM " + tableIdent(m)`)
+			assert.Contains(t, content, `return quoteIdent(schema) + "." + quoteIdent(m.TableName())`)
+		})
+	}
+}
+
+func TestQualifyingSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		schema   string
+		schemas  map[string]settings.SchemaOutput
+		expected string
+	}{
+		{
+			desc:   "postgres default schema",
+			dbType: settings.DBTypePostgresql,
+			schema: "public",
+		},
+		{
+			desc:     "postgres non-default schema",
+			dbType:   settings.DBTypePostgresql,
+			schema:   "other",
+			expected: "other",
+		},
+		{
+			desc:     "cockroach non-default schema",
+			dbType:   settings.DBTypeCockroachDB,
+			schema:   "other",
+			expected: "other",
+		},
+		{
+			desc:     "oracle non-default schema",
+			dbType:   settings.DBTypeOracle,
+			schema:   "hr",
+			expected: "HR",
+		},
+		{
+			desc:   "mysql database connected to",
+			dbType: settings.DBTypeMySQL,
+			schema: "other",
+		},
+		{
+			desc:     "mysql multi-schema run",
+			dbType:   settings.DBTypeMySQL,
+			schema:   "other",
+			schemas:  map[string]settings.SchemaOutput{"other": {}, "auth": {}},
+			expected: "other",
+		},
+		{
+			desc:   "sqlite has no schemas",
+			dbType: settings.DBTypeSQLite,
+			schema: "other",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			s.Schema = test.schema
+			s.DbName = test.schema
+			s.Schemas = test.schemas
+
+			assert.Equal(t, test.expected, qualifyingSchema(s))
+		})
+	}
+}
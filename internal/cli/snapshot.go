@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// beginSnapshot begins the snapshot of the introspection if the settings ask
+// for it and the database supports it, end ends it. Databases not supporting
+// it get introspected without.
+func beginSnapshot(ctx context.Context, settings *settings.Settings, db database.Database) (end func() error, err error) {
+	snapshotter, ok := database.As[database.Snapshotter](db)
+	if !settings.Snapshot || !ok {
+		if settings.Snapshot {
+			slog.Warn("database does not support snapshots, introspecting without", "type", settings.DbType)
+		}
+		return func() error { return nil }, nil
+	}
+	if err = snapshotter.BeginSnapshot(ctx); err != nil {
+		return nil, err
+	}
+	return snapshotter.EndSnapshot, nil
+}
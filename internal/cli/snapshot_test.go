@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// snapshotDB records the snapshots, or fails beginning them.
+type snapshotDB struct {
+	database.Database
+
+	fail  bool
+	begun int
+	ended int
+}
+
+func (db *snapshotDB) BeginSnapshot(context.Context) error {
+	if db.fail {
+		return errors.New("could not begin the snapshot: permission denied")
+	}
+	db.begun++
+	return nil
+}
+
+func (db *snapshotDB) EndSnapshot() error {
+	db.ended++
+	return nil
+}
+
+func TestBeginSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		snapshot bool
+		fail     bool
+		begun    int
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "snapshot begun and ended",
+			snapshot: true,
+			begun:    1,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "no snapshot without the setting",
+			snapshot: false,
+			begun:    0,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "failing snapshot produces error",
+			snapshot: true,
+			fail:     true,
+			begun:    0,
+			isError:  assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Snapshot = test.snapshot
+			db := &snapshotDB{fail: test.fail}
+
+			end, err := beginSnapshot(context.Background(), s, db)
+			test.isError(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.begun, db.begun)
+			assert.NoError(t, end())
+			assert.Equal(t, test.begun, db.ended)
+		})
+	}
+}
+
+func TestBeginSnapshot_notSupported(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	s.Snapshot = true
+	end, err := beginSnapshot(context.Background(), s, &columnsDB{})
+	assert.NoError(t, err)
+	assert.NoError(t, end())
+}
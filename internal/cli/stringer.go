@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// redacted replaces the values of sensitive fields in the generated String
+// methods.
+const redacted = "[REDACTED]"
+
+// hasSensitiveFields returns true if any of the fields represents a sensitive
+// column.
+func hasSensitiveFields(s *settings.Settings, fields []structField) bool {
+	for _, field := range fields {
+		if s.IsSensitiveColumn(field.column.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeStringMethod writes a String method printing the struct like %+v would
+// but with the values of the sensitive fields redacted, so they do not leak
+// into logs.
+func writeStringMethod(content *strings.Builder, s *settings.Settings, structName string, fields []structField) {
+	receiver := strings.ToLower(string(structName[0]))
+
+	var format strings.Builder
+	var args []string
+	for i, field := range fields {
+		if i > 0 {
+			format.WriteString(" ")
+		}
+		format.WriteString(field.name)
+		format.WriteString(":")
+		if s.IsSensitiveColumn(field.column.Name) {
+			format.WriteString(redacted)
+			continue
+		}
+		format.WriteString("%v")
+		args = append(args, receiver+"."+field.name)
+	}
+
+	content.WriteString("\n// String implements fmt.Stringer with the sensitive fields redacted.\n")
+	content.WriteString("func (")
+	content.WriteString(receiver)
+	content.WriteString(" ")
+	content.WriteString(structName)
+	content.WriteString(") String() string {\n\treturn fmt.Sprintf(\"")
+	content.WriteString(structName)
+	content.WriteString("{")
+	content.WriteString(format.String())
+	content.WriteString("}\"")
+	for _, arg := range args {
+		content.WriteString(", ")
+		content.WriteString(arg)
+	}
+	content.WriteString(")\n}\n")
+}
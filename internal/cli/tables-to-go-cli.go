@@ -1,22 +1,32 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/iancoleman/strcase"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/tagger"
 )
 
 var (
-	taggers tagger.Tagger
+	taggers *tagger.Taggers
+	helpers *helperTypes
 	caser   = cases.Title(language.English, cases.NoLower)
 
 	// some strings for idiomatic go in column names
@@ -24,120 +34,568 @@ var (
 	initialisms = []string{"ID", "JSON", "XML", "HTTP", "URL"}
 )
 
-// Run runs the transformations by creating the concrete Database by the provided settings
-func Run(settings *settings.Settings, db database.Database, out output.Writer) (err error) {
+// generatedByPrefix starts the header of the generated files.
+const generatedByPrefix = "// Generated by tables-to-go"
+
+// Run runs the transformations by creating the concrete Database by the provided settings.
+// It stops before the next table once the context is done.
+func Run(ctx context.Context, settings *settings.Settings, db database.Database, out output.Writer) (err error) {
+
+	if err := tagger.VerifyNames(settings.Tags); err != nil {
+		return err
+	}
+
+	formatter, err := output.LookupFormatter(settings.Formatter)
+	if err != nil {
+		return err
+	}
+	warnings.reset()
+
+	// the structs get written table by table, the files of other formatters
+	// once all tables are introspected
+	_, structs := formatter.(goFormatter)
 
 	taggers = tagger.NewTaggers(settings)
+	helpers = newHelperTypes()
+	if err := setStructTemplate(settings); err != nil {
+		return err
+	}
+
+	slog.Info("running", "type", settings.DbType)
 
-	fmt.Printf("running for %q...\r\n", settings.DbType)
+	endSnapshot, err := beginSnapshot(ctx, settings, db)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if endErr := endSnapshot(); endErr != nil && err == nil {
+			err = endErr
+		}
+	}()
 
-	tables, err := db.GetTables(settings.Tables...)
+	discovered, err := db.GetTables(ctx, settings.Tables...)
 	if err != nil {
 		return fmt.Errorf("could not get tables: %w", err)
 	}
+	tables := filterTables(settings, discovered)
+
+	if settings.Interactive {
+		if tables, err = selectTables(os.Stdin, os.Stdout, tables); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("tables found", "count", len(tables))
+	setRelatedTables(tables)
+
+	report := newDryRunReport(settings)
+	report.tables(discovered, tables)
+
+	manifest := newManifest(settings)
+	out = manifest.writer(out)
+	status := newTableStatus(settings, tables)
 
-	if settings.Verbose {
-		fmt.Printf("> number of tables: %v\r\n", len(tables))
+	inc, err := newIncremental(settings)
+	if err != nil {
+		return err
 	}
 
-	if err = db.PrepareGetColumnsOfTableStmt(); err != nil {
+	if err = db.PrepareGetColumnsOfTableStmt(ctx); err != nil {
 		return fmt.Errorf("could not prepare the get-column-statement: %w", err)
 	}
 
-	for _, table := range tables {
+	progress := newProgress(settings, os.Stderr, len(tables))
+
+	// the columns get introspected in parallel, the structs get generated in
+	// the order of the tables since the taggers keep the state of the table
+	columns, stop := fetchColumns(ctx, db, tables, settings.Jobs, settings.BatchColumns)
+	defer stop()
+	if columns, err = withForeignKeys(ctx, settings, db, tables, columns); err != nil {
+		return err
+	}
+	columns = withEnums(ctx, settings, db, tables, columns)
+
+	// in strict mode nothing gets written until all tables are known to have
+	// mapped types only
+	var (
+		pending  []*database.Table
+		unmapped []string
+	)
 
-		if settings.Verbose {
-			fmt.Printf("> processing table %q\r\n", table.Name)
+	// the introspected tables for the files written once all tables are
+	// known, otherwise every table gets released once it is written to keep
+	// the memory bounded on large schemas
+	var introspected []*database.Table
+	keep := !structs || settings.EmitIR != "" || caches(settings, db)
+
+	// the failing tables when continuing on errors
+	var failures TableErrors
+
+	for i, table := range tables {
+
+		// forcing does not skip the cancellation
+		if err = ctx.Err(); err != nil {
+			return err
 		}
 
-		if err = db.GetColumnsOfTable(table); err != nil {
-			if !settings.Force {
+		slog.Debug("processing table", "table", table.Name)
+		progress.start(table.Name)
+
+		if err = columns(i); err != nil {
+			if !(settings.Force || settings.ContinueOnError) || ctx.Err() != nil {
 				return fmt.Errorf("could not get columns of table %q: %w", table.Name, err)
 			}
-			fmt.Printf("could not get columns of table %q: %v\n", table.Name, err)
+			slog.Error("could not get columns of table", "table", table.Name, "error", err)
+			manifest.warn("skipped table %q, could not get its columns: %v", table.Name, err)
+			if settings.ContinueOnError {
+				failures.add(table.Name, fmt.Errorf("could not get its columns: %w", err))
+			}
 			continue
 		}
 
-		if settings.Verbose {
-			fmt.Printf("\t> number of columns: %v\r\n", len(table.Columns))
+		slog.Debug("columns found", "table", table.Name, "count", len(table.Columns))
+		if isEmptyTable(table, manifest) {
+			status.set(table.Name, "skipped, no columns")
+			continue
+		}
+		report.table(settings, db, table)
+		if keep {
+			introspected = append(introspected, table)
 		}
 
-		tableName, content, err := createTableStructString(settings, db, table)
+		if !structs {
+			continue
+		}
 
-		if err != nil {
-			if !settings.Force {
-				return fmt.Errorf("could not create string for table %q: %w", table.Name, err)
-			}
-			fmt.Printf("could not create string for table %q: %v\n", table.Name, err)
+		if settings.Strict {
+			unmapped = append(unmapped, unmappedColumns(settings, db, table)...)
+			pending = append(pending, table)
 			continue
 		}
 
-		fileName := camelCaseString(tableName)
-		if settings.IsFileNameFormatSnakeCase() {
-			fileName = strcase.ToSnake(fileName)
+		for _, column := range unmappedColumns(settings, db, table) {
+			manifest.warn("no mapping for the type of %s, generated as string", column)
 		}
 
-		err = out.Write(fileName, content)
-		if err != nil {
-			if !settings.Force {
-				return fmt.Errorf("could not write struct for table %q: %w", table.Name, err)
-			}
-			fmt.Printf("could not write struct for table %q: %v\n", table.Name, err)
+		if err = writeTableWithStatus(settings, db, out, table, manifest, inc, &failures, status); err != nil {
+			return err
 		}
+		if !keep {
+			tables[i] = nil
+		}
+	}
+
+	progress.finish()
+
+	if err = writeIR(settings, introspected); err != nil {
+		return err
+	}
+	if err = writeCache(settings, db, introspected, len(introspected) == len(tables)); err != nil {
+		return err
+	}
+
+	if !structs {
+		if _, err = formatFiles(settings, formatter, introspected, out); err != nil {
+			return err
+		}
+		for _, table := range introspected {
+			status.set(table.Name, "generated")
+		}
+		if err = status.write(summaryWriter(settings), failures); err != nil {
+			return err
+		}
+		if err = warnings.write(summaryWriter(settings)); err != nil {
+			return err
+		}
+		slog.Info("done")
+		return failures.err()
+	}
+
+	if len(unmapped) > 0 {
+		return fmt.Errorf("strict mode: %d columns have types without mapping, exclude them or leave out -strict:\n  %s",
+			len(unmapped), strings.Join(unmapped, "\n  "))
+	}
+	for _, table := range pending {
+		if err = writeTableWithStatus(settings, db, out, table, manifest, inc, &failures, status); err != nil {
+			return err
+		}
+	}
+
+	if err = writePackageFiles(settings, out); err != nil {
+		return err
+	}
+
+	if err = report.write(os.Stdout, settings, out); err != nil {
+		return err
+	}
+
+	if err = manifest.write(settings); err != nil {
+		return err
+	}
+
+	// quiet runs print no summary
+	var summary io.Writer = os.Stdout
+	if settings.Quiet {
+		summary = nil
+	}
+	if err = inc.write(summary); err != nil {
+		return err
+	}
+	if err = status.write(summaryWriter(settings), failures); err != nil {
+		return err
+	}
+	if err = warnings.write(summaryWriter(settings)); err != nil {
+		return err
+	}
+
+	slog.Info("done")
+
+	return failures.err()
+}
+
+// summaryWriter returns where the warnings of the run get summarized, nil for
+// quiet runs. The summary goes to stderr to keep it out of the structs
+// written to stdout.
+func summaryWriter(settings *settings.Settings) io.Writer {
+	if settings.Quiet {
+		return nil
 	}
+	return os.Stderr
+}
 
-	fmt.Println("done!")
+// isEmptyTable returns true if the table has no columns, e.g. since the user
+// lacks the privileges to read them, and warns about skipping it instead of
+// generating an empty struct.
+func isEmptyTable(table *database.Table, manifest *manifest) bool {
+	if len(table.Columns) > 0 {
+		return false
+	}
+	slog.Warn("skipped table without columns", "table", table.Name)
+	manifest.warn("skipped table %q, it has no columns, e.g. missing the privileges to read them", table.Name)
+	return true
+}
+
+// writeIR writes the representation of the introspected tables to the file
+// given by the settings, if any.
+func writeIR(settings *settings.Settings, tables []*database.Table) error {
+	if settings.EmitIR == "" {
+		return nil
+	}
+	return ir.FromTables(settings, tables).WriteFile(settings.EmitIR)
+}
+
+// writeTableOrSkip writes the struct of the table, records it in the manifest
+// and returns whether it got written, in force mode errors skip the table,
+// continuing on errors adds them to the failures. Tables unchanged since the
+// previous incremental generation are generated but not written.
+func writeTableOrSkip(settings *settings.Settings, db database.Database, out output.Writer, table *database.Table, manifest *manifest, inc *incremental, failures *TableErrors) (bool, error) {
+	if !hooks.tableStart(table) {
+		slog.Debug("skipped table vetoed by hook", "table", table.Name)
+		return false, nil
+	}
+	_, err := writeTable(settings, db, out, table, inc)
+	if err != nil && (settings.Force || settings.ContinueOnError) {
+		slog.Error("skipped table", "table", table.Name, "error", err)
+		manifest.warn("skipped table %q: %v", table.Name, err)
+		if settings.ContinueOnError {
+			failures.add(table.Name, err)
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	manifest.table(table.Name)
+	return true, nil
+}
+
+// writeTableWithStatus is writeTableOrSkip recording the status of the
+// written table.
+func writeTableWithStatus(settings *settings.Settings, db database.Database, out output.Writer, table *database.Table, manifest *manifest, inc *incremental, failures *TableErrors, status *tableStatus) error {
+	written, err := writeTableOrSkip(settings, db, out, table, manifest, inc, failures)
+	if written {
+		status.set(table.Name, "generated")
+	}
+	return err
+}
+
+// unmappedColumns returns the columns of the table, which are not excluded,
+// whose types have no mapping as "table.column (type)".
+func unmappedColumns(settings *settings.Settings, db database.Database, table *database.Table) []string {
+	var unmapped []string
+	for _, column := range table.Columns {
+		// columns can occur multiple times, see ISSUE-4 in createTableStructString
+		name := table.Name + "." + column.Name + " (" + columnType(column) + ")"
+		if isMappedType(settings, db, table.Name, column) || isColumnExcluded(settings, table.Name, column) || slices.Contains(unmapped, name) {
+			continue
+		}
+		unmapped = append(unmapped, name)
+	}
+	return unmapped
+}
+
+// writeTable writes the struct of the table with its columns and returns the
+// name of the written file. The struct of a table unchanged since the
+// previous incremental generation is not written.
+func writeTable(settings *settings.Settings, db database.Database, out output.Writer, table *database.Table, inc *incremental) (string, error) {
+	tableName, content, err := createTableStructString(settings, db, table)
+	if errors.As(err, new(*TableError)) {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not create string for table %q: %w", table.Name, err)
+	}
+
+	fileName := naming.FileName(settings, table.Name, tableName)
+
+	unchanged, err := inc.unchanged(table, fileName)
+	if err != nil {
+		return "", err
+	}
+	if unchanged {
+		slog.Debug("skipped unchanged table", "table", table.Name)
+		return fileName, nil
+	}
+
+	if err = out.Write(fileName, content); err != nil {
+		return "", fmt.Errorf("could not write struct for table %q: %w", table.Name, err)
+	}
+	hooks.fileWritten(out, fileName+output.FileWriterExtension)
+	inc.written(table)
+
+	return fileName, nil
+}
+
+// fileNameOf returns the name of the file of the struct without extension.
+func fileNameOf(settings *settings.Settings, tableName string) string {
+	fileName := camelCaseString(tableName)
+	if settings.IsFileNameFormatSnakeCase() {
+		fileName = strcase.ToSnake(fileName)
+	}
+	return fileName
+}
+
+// writePackageFiles writes the files shared by all tables of the package and
+// persists the state of the taggers.
+func writePackageFiles(settings *settings.Settings, out output.Writer) error {
+	// helper types are shared by all tables of the package, hence they are
+	// written once at the end instead of into every single table file.
+	for _, fileName := range helpers.fileNames() {
+		if err := out.Write(fileName, fileHeader(settings)+helpers.content(fileName, settings.PackageName)); err != nil {
+			return fmt.Errorf("could not write helper types to %q: %w", fileName, err)
+		}
+		hooks.fileWritten(out, fileName+output.FileWriterExtension)
+	}
+
+	// writers only recording the changes must not touch the state of the
+	// taggers either
+	if _, ok := out.(changesRecorder); ok {
+		return nil
+	}
+
+	if err := taggers.Persist(); err != nil {
+		return fmt.Errorf("could not persist state of the taggers: %w", err)
+	}
 
 	return nil
 }
 
-type columnInfo struct {
-	isNullable bool
-	isTemporal bool
+// trace logs the most verbose output like the processed columns.
+func trace(msg string, args ...any) {
+	settings.Trace(msg, args...)
 }
 
-func (c columnInfo) isNullableOrTemporal() bool {
-	return c.isNullable || c.isTemporal
+// fileHeader returns the comment put in front of the package clause of the
+// generated files with the version of tables-to-go and the given notes, or
+// an empty string if there is nothing to note.
+func fileHeader(settings *settings.Settings, notes ...string) string {
+	if settings.GeneratorVersion != "" {
+		notes = append([]string{strings.TrimPrefix(generatedByPrefix, "// ") + " " + settings.GeneratorVersion}, notes...)
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return "// " + strings.Join(notes, "\n// ") + "\n\n"
 }
 
-func createTableStructString(settings *settings.Settings, db database.Database, table *database.Table) (string, string, error) {
+// Tables returns the tables of the database included by the table names and
+// patterns of the settings, i.e. the ones Run would generate.
+func Tables(ctx context.Context, settings *settings.Settings, db database.Database) ([]*database.Table, error) {
+	tables, err := db.GetTables(ctx, settings.Tables...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get tables: %w", err)
+	}
+	return filterTables(settings, tables), nil
+}
 
-	var structFields strings.Builder
-	tableName := caser.String(settings.Prefix + table.Name + settings.Suffix)
+// filterTables returns the tables included by the include and exclude
+// patterns of the settings.
+func filterTables(settings *settings.Settings, tables []*database.Table) []*database.Table {
+	filtered := make([]*database.Table, 0, len(tables))
+	for _, table := range tables {
+		if !settings.IsTableIncluded(table.Name) {
+			continue
+		}
+		if settings.IsIgnoredByComment(table.Comment.String) {
+			trace("ignored table by comment", "table", table.Name)
+			continue
+		}
+		filtered = append(filtered, table)
+	}
+	return filtered
+}
+
+// isColumnExcluded returns true if the column of the table is excluded by
+// the settings or ignored by its comment.
+func isColumnExcluded(settings *settings.Settings, table string, column database.Column) bool {
+	return settings.IsColumnExcluded(table, column.Name) || settings.IsIgnoredByComment(column.Comment.String)
+}
+
+// structName returns the name of the table to derive the struct and file
+// name from: the overridden struct name or the table name without the
+// prefixes and suffixes to strip, singularized if enabled.
+func structName(settings *settings.Settings, table string) string {
+	if name, ok := settings.StructNames[table]; ok {
+		return name
+	}
+
+	name := table
+	for _, prefix := range settings.StripPrefixes {
+		if prefix != "" && strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			name = strings.TrimPrefix(name, prefix)
+			break
+		}
+	}
+	for _, suffix := range settings.StripSuffixes {
+		if suffix != "" && strings.HasSuffix(name, suffix) && len(name) > len(suffix) {
+			name = strings.TrimSuffix(name, suffix)
+			break
+		}
+	}
+	if settings.Singularize {
+		name = singularName(settings, name)
+	}
+	return name
+}
+
+// structTypeName returns the name of the struct of the table.
+func structTypeName(settings *settings.Settings, table string) (string, error) {
+	tableName := caser.String(settings.Prefix + structName(settings, table) + settings.Suffix)
 	// Replace any whitespace with underscores
 	tableName = strings.Map(replaceSpace, tableName)
 	if settings.IsOutputFormatCamelCase() {
 		tableName = camelCaseString(tableName)
 	}
 
+	if tableName == "" {
+		return "", fmt.Errorf("struct name of table %q is empty", table)
+	}
+
 	// Check that the table name doesn't contain any invalid characters for Go variables
 	if !validVariableName(tableName) {
-		return "", "", fmt.Errorf("table name %q contains invalid characters", table.Name)
+		return "", fmt.Errorf("table name %q contains invalid characters", table)
+	}
+	return tableName, nil
+}
+
+type columnInfo struct {
+	isNullable  bool
+	isTemporal  bool
+	isSensitive bool
+	// imports are the import paths of the types of registered type mappings
+	imports []string
+}
+
+func (c columnInfo) isNullableOrTemporal() bool {
+	return c.isNullable || c.isTemporal
+}
+
+// orderColumns returns the columns in the order of the struct fields given
+// by the settings. The columns come in their ordinal position from the
+// database, sorting is stable to keep it among equal columns.
+func orderColumns(settings *settings.Settings, db database.Database, columns []database.Column) []database.Column {
+	switch settings.FieldOrder {
+	case "alphabetical":
+		columns = slices.Clone(columns)
+		slices.SortStableFunc(columns, func(a, b database.Column) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	case "pk-first":
+		// a column occurs once per constraint, see ISSUE-4, it is a primary
+		// key column if any of its occurrences is
+		primaryKeys := map[string]bool{}
+		for _, column := range columns {
+			if db.IsPrimaryKey(column) {
+				primaryKeys[column.Name] = true
+			}
+		}
+		columns = slices.Clone(columns)
+		slices.SortStableFunc(columns, func(a, b database.Column) int {
+			switch {
+			case primaryKeys[a.Name] == primaryKeys[b.Name]:
+				return 0
+			case primaryKeys[a.Name]:
+				return -1
+			default:
+				return 1
+			}
+		})
+	}
+	return columns
+}
+
+func createTableStructString(settings *settings.Settings, db database.Database, table *database.Table) (string, string, error) {
+
+	var structFields strings.Builder
+	tableName, err := structNameOf(settings, table.Name)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := taggers.BeginTable(table.Name); err != nil {
+		return "", "", err
 	}
 
 	columnInfo := columnInfo{}
-	columns := map[string]struct{}{}
+	columns := reservedFieldNames(settings, table)
+	var comments map[string]string
+	if settings.HasRelations() {
+		comments = referenceComments(table)
+	}
+	var fields []structField
+	var templateFields []StructTemplateField
+	var excluded []string
+
+	// ISSUE-4: if columns are part of multiple constraints then the sql
+	// returns multiple rows per column name. They get merged, so the tags of
+	// a column are the ones of all of its constraints.
+	for _, column := range orderColumns(settings, db, database.MergeColumns(table.Columns)) {
+		if isColumnExcluded(settings, table.Name, column) {
+			excluded = append(excluded, column.Name)
+			trace("excluded column", "table", table.Name, "column", column.Name)
+			continue
+		}
 
-	for _, column := range table.Columns {
-		columnName, err := formatColumnName(settings, column.Name, table.Name)
+		columnName, err := fieldNameOf(settings, table.Name, column.Name)
 		if err != nil {
-			return "", "", err
+			return "", "", &TableError{Table: table.Name, Column: column.Name, Err: err}
 		}
 
-		// ISSUE-4: if columns are part of multiple constraints
-		// then the sql returns multiple rows per column name.
-		// Therefore, we check if we already added a column with
-		// that name to the struct, if so, skip.
+		// columns whose names map to the same field name, e.g. "user id"
+		// and "user_id", get numbered in the order of the columns
 		if _, ok := columns[columnName]; ok {
-			continue
+			unique := uniqueFieldName(columns, columnName)
+			slog.Warn("field name already taken, numbering it", "table", table.Name, "column", column.Name, "field", columnName, "renamed", unique)
+			hooks.warning(fmt.Sprintf("field %s of column %q of table %q already taken, named it %s", columnName, column.Name, table.Name, unique))
+			columnName = unique
 		}
 		columns[columnName] = struct{}{}
 
-		if settings.VVerbose {
-			fmt.Printf("\t\t> %v\r\n", column.Name)
-		}
+		trace("column", "table", table.Name, "column", column.Name)
 
-		columnType, col := mapDbColumnTypeToGoType(settings, db, column)
+		columnType, col := mapDbColumnTypeToGoType(settings, db, table.Name, column)
+		fields = append(fields, structField{name: columnName, goType: columnType, column: column})
+		hooks.columnMapped(table, column, columnType)
 
 		// save that we saw types of columns at least once
 		if !columnInfo.isTemporal {
@@ -146,22 +604,100 @@ func createTableStructString(settings *settings.Settings, db database.Database,
 		if !columnInfo.isNullable {
 			columnInfo.isNullable = col.isNullable
 		}
+		for _, imp := range col.imports {
+			if !slices.Contains(columnInfo.imports, imp) {
+				columnInfo.imports = append(columnInfo.imports, imp)
+			}
+		}
 
+		tag := taggers.GenerateTag(db, column)
+		templateFields = append(templateFields, StructTemplateField{
+			Name:         columnName,
+			Type:         columnType,
+			Tag:          tag,
+			Comment:      comments[column.Name],
+			Column:       column,
+			IsPrimaryKey: db.IsPrimaryKey(column),
+			IsNullable:   db.IsNullable(column),
+		})
+
+		if settings.WithComments {
+			writeDocComment(&structFields, column.Comment.String)
+		}
+		if comment, ok := comments[column.Name]; ok {
+			structFields.WriteString("// ")
+			structFields.WriteString(comment)
+			structFields.WriteString("\n")
+		}
 		structFields.WriteString(columnName)
 		structFields.WriteString(" ")
 		structFields.WriteString(columnType)
 		structFields.WriteString(" ")
-		structFields.WriteString(taggers.GenerateTag(db, column))
+		structFields.WriteString(tag)
+		if settings.TagsSwagger {
+			// swag takes the line comment of a field as its description
+			structFields.WriteString(" // ")
+			structFields.WriteString(columnDescription(db, column))
+		}
 		structFields.WriteString("\n")
 	}
 
+	var templateRelations []StructTemplateField
+	if settings.HasRelationFields() {
+		relations, err := relationFields(settings, table, excluded, columns)
+		if err != nil {
+			return "", "", &TableError{Table: table.Name, Err: err}
+		}
+		// the relation fields are no columns and get excluded from the
+		// taggers, serializing them could recurse through back-references
+		skipTag := taggers.GenerateSkipTag()
+		for _, relation := range relations {
+			templateRelations = append(templateRelations, StructTemplateField{Name: relation.name, Type: "*" + relation.typeName, Tag: skipTag})
+		}
+		if len(relations) > 0 {
+			structFields.WriteString("\n")
+		}
+		for _, relation := range relations {
+			structFields.WriteString(relation.name)
+			structFields.WriteString(" *")
+			structFields.WriteString(relation.typeName)
+			if skipTag != "" {
+				structFields.WriteString(" ")
+				structFields.WriteString(skipTag)
+			}
+			structFields.WriteString("\n")
+		}
+	}
+
 	if settings.IsMastermindStructableRecorder {
 		structFields.WriteString("\t\nstructable.Recorder\n")
 	}
 
+	columnInfo.isSensitive = hasSensitiveFields(settings, fields)
+
 	var fileContent strings.Builder
 
 	// write header infos
+	var notes []string
+	if len(excluded) > 0 {
+		notes = append(notes, "Excluded columns of table "+table.Name+": "+strings.Join(excluded, ", "))
+	}
+	if structTemplate != nil {
+		content, err := renderStructTemplate(fileHeader(settings, notes...), StructTemplateData{
+			Package:    settings.PackageName,
+			Imports:    fieldImports(settings, columnInfo),
+			Table:      table,
+			StructName: tableName,
+			Fields:     templateFields,
+			Relations:  templateRelations,
+		})
+		if err != nil {
+			return "", "", &TableError{Table: table.Name, Err: err}
+		}
+		return tableName, content, nil
+	}
+
+	fileContent.WriteString(fileHeader(settings, notes...))
 	fileContent.WriteString("package ")
 	fileContent.WriteString(settings.PackageName)
 	fileContent.WriteString("\n\n")
@@ -169,10 +705,31 @@ func createTableStructString(settings *settings.Settings, db database.Database,
 	// write imports
 	generateImports(&fileContent, settings, columnInfo)
 
+	if settings.WithComments {
+		writeDocComment(&fileContent, table.Comment.String)
+	}
+
+	// reform generates its code for structs marked with a magic comment
+	if settings.TagsReform {
+		fileContent.WriteString("//reform:")
+		fileContent.WriteString(table.Name)
+		fileContent.WriteString("\n")
+	}
+
 	// write struct with fields
 	fileContent.WriteString("type ")
 	fileContent.WriteString(tableName)
 	fileContent.WriteString(" struct {\n")
+	if settings.TagsBun {
+		fileContent.WriteString("bun.BaseModel `bun:\"table:")
+		fileContent.WriteString(table.Name)
+		fileContent.WriteString("\"`\n\n")
+	}
+	if settings.TagsGoPg {
+		fileContent.WriteString("tableName struct{} `pg:\"")
+		fileContent.WriteString(table.Name)
+		fileContent.WriteString("\"`\n\n")
+	}
 	fileContent.WriteString(structFields.String())
 	fileContent.WriteString("}")
 
@@ -182,38 +739,163 @@ func createTableStructString(settings *settings.Settings, db database.Database,
 	fileContent.WriteString(" ")
 	fileContent.WriteString(tableName)
 	fileContent.WriteString(") TableName() string {\n")
-	fileContent.WriteString("\treturn \"")
-	fileContent.WriteString(table.Name)
-	fileContent.WriteString("\"\n")
+	fileContent.WriteString("\treturn ")
+	fileContent.WriteString(strconv.Quote(table.Name))
+	fileContent.WriteString("\n")
 	fileContent.WriteString("}\n")
 
+	if columnInfo.isSensitive {
+		writeStringMethod(&fileContent, settings, tableName, fields)
+	}
+
+	if settings.WithColumns {
+		writeColumns(&fileContent, tableName, fields, settings.GenericRepository)
+	}
+
+	if settings.GenericRepository {
+		writeModelMethods(&fileContent, db, tableName, qualifyingSchema(settings), fields)
+		addRepositoryHelpers(settings, helpers)
+	}
+
+	if settings.HasCRUD() {
+		writeCRUD(&fileContent, settings, db, table, tableName, fields)
+		addCRUDHelpers(settings, helpers)
+	}
+
 	return tableName, fileContent.String(), nil
 }
 
+// reservedFieldNames returns the names of the methods and embedded fields the
+// struct of the table gets by the settings, no column must be named like them.
+func reservedFieldNames(settings *settings.Settings, table *database.Table) map[string]struct{} {
+	reserved := map[string]struct{}{"TableName": {}}
+	if settings.IsMastermindStructableRecorder {
+		reserved["Recorder"] = struct{}{}
+	}
+	if settings.TagsBun {
+		reserved["BaseModel"] = struct{}{}
+	}
+	if slices.ContainsFunc(table.Columns, func(column database.Column) bool { return settings.IsSensitiveColumn(column.Name) }) {
+		reserved["String"] = struct{}{}
+	}
+	if settings.GenericRepository {
+		for _, method := range []string{"Columns", "InsertColumns", "PrimaryKeyColumns", "PrimaryKeyValues", "Values", "InsertValues", "ScanTargets"} {
+			reserved[method] = struct{}{}
+		}
+	}
+	if settings.WithColumns {
+		for _, method := range []string{"Columns", "Values", "ScanTargets"} {
+			reserved[method] = struct{}{}
+		}
+	}
+	return reserved
+}
+
+// uniqueFieldName returns the name numbered by the first number from 2 on not
+// taken yet.
+func uniqueFieldName(taken map[string]struct{}, name string) string {
+	for i := 2; ; i++ {
+		unique := name + strconv.Itoa(i)
+		if _, ok := taken[unique]; !ok {
+			return unique
+		}
+	}
+}
+
 func generateImports(content *strings.Builder, settings *settings.Settings, columnInfo columnInfo) {
 
-	if !columnInfo.isNullableOrTemporal() && !settings.IsMastermindStructableRecorder {
+	if !columnInfo.isNullableOrTemporal() && !columnInfo.isSensitive && !settings.IsMastermindStructableRecorder && !settings.TagsBun &&
+		len(columnInfo.imports) == 0 && !settings.HasCRUD() {
 		return
 	}
 
 	content.WriteString("import (\n")
 
+	var written []string
+	if settings.HasCRUD() {
+		written = append(written, "context")
+	}
+
 	if columnInfo.isNullable && settings.IsNullTypeSQL() {
-		content.WriteString("\t\"database/sql\"\n")
+		written = append(written, "database/sql")
+	}
+
+	if columnInfo.isSensitive {
+		written = append(written, "fmt")
 	}
 
 	if columnInfo.isTemporal {
-		content.WriteString("\t\"time\"\n")
+		written = append(written, "time")
+	}
+
+	for _, imp := range written {
+		content.WriteString("\t\"" + imp + "\"\n")
+	}
+
+	// the imports of registered type mappings follow as own group
+	var extra []string
+	for _, imp := range columnInfo.imports {
+		if !slices.Contains(written, imp) {
+			extra = append(extra, imp)
+		}
+	}
+	if len(extra) > 0 {
+		slices.Sort(extra)
+		content.WriteString("\t\n")
+		for _, imp := range extra {
+			content.WriteString("\"" + imp + "\"\n")
+		}
 	}
 
 	if settings.IsMastermindStructableRecorder {
 		content.WriteString("\t\n\"github.com/Masterminds/structable\"\n")
 	}
 
+	if settings.TagsBun {
+		content.WriteString("\t\n\"github.com/uptrace/bun\"\n")
+	}
+
+	if columnInfo.isNullable && settings.IsNullTypeGuregu() {
+		content.WriteString("\t\n\"" + gureguNullImport + "\"\n")
+	}
+
+	for _, imp := range crudImports(settings) {
+		content.WriteString("\t\n\"" + imp + "\"\n")
+	}
+
 	content.WriteString(")\n\n")
 }
 
-func mapDbColumnTypeToGoType(s *settings.Settings, db database.Database, column database.Column) (goType string, columnInfo columnInfo) {
+// mapDbColumnTypeToGoType maps the type of the column of the table to a Go
+// type by the rules of the type map, the enum types, the registered type
+// mappings, see database.RegisterTypeMapping, or else by the built-in mapping.
+func mapDbColumnTypeToGoType(s *settings.Settings, db database.Database, table string, column database.Column) (goType string, columnInfo columnInfo) {
+	if rule, ok := s.TypeMapRuleOf(table, column.Name, column.DataType, columnType(column)); ok {
+		columnInfo.imports = rule.Imports
+		if db.IsNullable(column) {
+			return rule.Nullable, columnInfo
+		}
+		return rule.GoType, columnInfo
+	}
+	if s.EnumTypes && len(column.EnumValues) > 0 {
+		if name, err := enumTypeOf(s, table, column); err == nil {
+			if !db.IsNullable(column) {
+				return name, columnInfo
+			}
+			goType = getNullType(s, "*"+name, "*"+name)
+			columnInfo.isNullable = strings.HasPrefix(goType, "sql.") || strings.HasPrefix(goType, "null.")
+			return goType, columnInfo
+		}
+	}
+	if goType, imports, ok := database.MapType(db, column, s); ok {
+		columnInfo.imports = imports
+		return goType, columnInfo
+	}
+	if goType, imports, ok := mapPgType(s, column); ok {
+		columnInfo.imports = imports
+		return goType, columnInfo
+	}
+
 	if db.IsInteger(column) {
 		goType = "int"
 		if db.IsNullable(column) {
@@ -226,13 +908,20 @@ func mapDbColumnTypeToGoType(s *settings.Settings, db database.Database, column
 			goType = getNullType(s, "*float64", "sql.NullFloat64")
 			columnInfo.isNullable = true
 		}
+	} else if db.IsTemporal(column) && s.TagsParquetMillis && tagger.IsEnabled(s, "parquet") {
+		goType = unixMilliType()
+		if db.IsNullable(column) {
+			goType = "*" + goType
+		}
 	} else if db.IsTemporal(column) {
 		if !db.IsNullable(column) {
 			goType = "time.Time"
 			columnInfo.isTemporal = true
 		} else {
 			goType = getNullType(s, "*time.Time", "sql.NullTime")
-			columnInfo.isTemporal = s.Null == settings.NullTypeNative
+			// only the primitive pointer and the generic types need the time
+			// package, the sql.NullTime and its wrapper are declared elsewhere.
+			columnInfo.isTemporal = strings.Contains(goType, "time.Time")
 			columnInfo.isNullable = true
 		}
 	} else {
@@ -257,6 +946,70 @@ func mapDbColumnTypeToGoType(s *settings.Settings, db database.Database, column
 	return goType, columnInfo
 }
 
+// isMappedType returns true if the type of the column is mapped to a specific
+// Go type by mapDbColumnTypeToGoType instead of defaulting to string.
+// Enums are mapped to string by design.
+func isMappedType(settings *settings.Settings, db database.Database, table string, column database.Column) bool {
+	if _, ok := settings.TypeMapRuleOf(table, column.Name, column.DataType, columnType(column)); ok {
+		return true
+	}
+	if _, _, ok := database.MapType(db, column, settings); ok {
+		return true
+	}
+	if _, _, ok := mapPgType(settings, column); ok {
+		return true
+	}
+	return db.IsInteger(column) || db.IsFloat(column) || db.IsTemporal(column) ||
+		db.IsString(column) || db.IsText(column) || column.DataType == "boolean" ||
+		len(column.EnumValues) > 0
+}
+
+// columnDescription describes the column by its name and database type in the
+// way it would be declared in SQL, e.g. "name character varying(255) NOT NULL".
+func columnDescription(db database.Database, column database.Column) string {
+	var description strings.Builder
+
+	description.WriteString(column.Name)
+	description.WriteString(" ")
+	description.WriteString(columnType(column))
+	if !db.IsNullable(column) {
+		description.WriteString(" NOT NULL")
+	}
+
+	return description.String()
+}
+
+// writeDocComment writes the comment of a table or column in the database as
+// doc comment, line by line. Empty comments write nothing.
+func writeDocComment(content *strings.Builder, comment string) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if line == "" {
+			content.WriteString("//\n")
+			continue
+		}
+		content.WriteString("// ")
+		content.WriteString(line)
+		content.WriteString("\n")
+	}
+}
+
+// columnType returns the database type of the column as declared in SQL, e.g.
+// "character varying(255)".
+func columnType(column database.Column) string {
+	if column.ColumnType != "" {
+		return column.ColumnType
+	}
+	if column.CharacterMaximumLength.Valid && column.CharacterMaximumLength.Int64 > 0 {
+		return column.DataType + "(" + strconv.FormatInt(column.CharacterMaximumLength.Int64, 10) + ")"
+	}
+	return column.DataType
+}
+
 func camelCaseString(s string) string {
 	if s == "" {
 		return s
@@ -275,14 +1028,43 @@ func camelCaseString(s string) string {
 	return cc
 }
 
+// getNullType returns the type of a nullable column by the null type of the
+// settings. Since Go 1.22 the generic sql.Null[T] and a generic wrapper of it
+// are used instead of the sql.Null* types, T being the type the primitive
+// pointer points to.
 func getNullType(settings *settings.Settings, primitive string, sql string) string {
+	valueType := strings.TrimPrefix(primitive, "*")
 	if settings.IsNullTypeSQL() {
+		if settings.IsNullTypeGeneric() {
+			return "sql.Null[" + valueType + "]"
+		}
 		return sql
 	}
+	if settings.IsNullTypeJSON() {
+		if settings.IsNullTypeGeneric() {
+			return addGenericNullWrapper(helpers, valueType)
+		}
+		return addNullWrapper(helpers, sql)
+	}
+	if settings.IsNullTypeGuregu() {
+		if name, ok := gureguNullTypes[sql]; ok {
+			return name
+		}
+		return "null.Value[" + valueType + "]"
+	}
 	return primitive
 }
 
-func toInitialisms(s string) string {
+// initialismsOf returns the initialisms to use by the given settings, the
+// default ones extended or replaced by the configured ones.
+func initialismsOf(settings *settings.Settings) []string {
+	if settings.InitialismsOnly {
+		return settings.Initialisms
+	}
+	return append(slices.Clone(initialisms), settings.Initialisms...)
+}
+
+func toInitialisms(s string, initialisms []string) string {
 	for _, substr := range initialisms {
 		idx := indexCaseInsensitive(s, substr)
 		if idx == -1 {
@@ -319,29 +1101,26 @@ func replaceSpace(r rune) rune {
 	return r
 }
 
-// FormatColumnName checks for invalid characters and transforms a column name
-// according to the provided settings.
+// FormatColumnName transforms a column name according to the provided
+// settings into an exported Go identifier. Spaces become underscores, other
+// characters invalid in Go identifiers separate the words like underscores.
 func formatColumnName(settings *settings.Settings, column, table string) (string, error) {
 
 	// Replace any whitespace with underscores
-	columnName := strings.Map(replaceSpace, column)
-	columnName = caser.String(columnName)
+	sanitized := sanitizeName(strings.Map(replaceSpace, column))
+	columnName := caser.String(sanitized)
 
 	if settings.IsOutputFormatCamelCase() {
 		columnName = camelCaseString(columnName)
 	}
 	if settings.ShouldInitialism() {
-		columnName = toInitialisms(columnName)
-	}
-
-	// Check that the column name doesn't contain any invalid characters for Go variables
-	if !validVariableName(columnName) {
-		return "", fmt.Errorf("column name %q in table %q contains invalid characters", column, table)
+		columnName = toInitialisms(columnName, initialismsOf(settings))
 	}
 
 	// First character of an identifier in Go must be letter or _
-	// We want it to be an uppercase letter to be a public field
-	if !unicode.IsLetter(rune(columnName[0])) {
+	// We want it to be an uppercase letter to be a public field, letters
+	// without case like 火 are no upper case letters
+	if first, _ := utf8.DecodeRuneInString(columnName); !unicode.IsUpper(first) {
 		prefix := "X_"
 		if settings.IsOutputFormatCamelCase() {
 			prefix = "X"
@@ -351,13 +1130,32 @@ func formatColumnName(settings *settings.Settings, column, table string) (string
 			// avoid the Title'izing of the first non-digit character as done
 			// by cases.Caser. Eg: `1fish2fish` gets transformed to `X1Fish2fish`
 			// but we want `X1fish2fish`.
-			columnName = toInitialisms(column)
-		}
-		if settings.Verbose {
-			fmt.Printf("\t\t>column %q in table %q doesn't start with a letter; prepending with %q\n", column, table, prefix)
+			columnName = toInitialisms(sanitized, initialismsOf(settings))
 		}
+		slog.Debug("column doesn't start with an upper case letter, prepending prefix", "table", table, "column", column, "prefix", prefix)
 		columnName = prefix + columnName
 	}
 
 	return columnName, nil
 }
+
+// sanitizeName drops the characters invalid in Go identifiers from the name,
+// a run of them between valid ones becomes a single underscore, e.g.
+// "price-in-€" becomes "price_in".
+func sanitizeName(name string) string {
+	var sb strings.Builder
+	sb.Grow(len(name))
+	invalid := false
+	for _, r := range name {
+		if !validVariableName(string(r)) {
+			invalid = true
+			continue
+		}
+		if invalid && sb.Len() > 0 {
+			sb.WriteByte('_')
+		}
+		invalid = false
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -1,6 +1,9 @@
 package cli
 
 import (
+	"context"
+	"database/sql"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,7 +22,7 @@ func newMockDB(db database.Database) *mockDB {
 	return &mockDB{Database: db}
 }
 
-func (db *mockDB) Connect() error {
+func (db *mockDB) Connect(context.Context) error {
 	args := db.Called()
 	return args.Error(0)
 }
@@ -29,7 +32,7 @@ func (db *mockDB) Close() error {
 	return args.Error(0)
 }
 
-func (db *mockDB) GetTables(tables ...string) ([]*database.Table, error) {
+func (db *mockDB) GetTables(_ context.Context, tables ...string) ([]*database.Table, error) {
 	var args mock.Arguments
 	if len(tables) == 0 {
 		args = db.Called()
@@ -43,12 +46,17 @@ func (db *mockDB) GetTables(tables ...string) ([]*database.Table, error) {
 	return args.Get(0).([]*database.Table), nil
 }
 
-func (db *mockDB) PrepareGetColumnsOfTableStmt() error {
+func (db *mockDB) PrepareGetColumnsOfTableStmt(context.Context) error {
 	args := db.Called()
 	return args.Error(0)
 }
 
-func (db *mockDB) GetColumnsOfTable(table *database.Table) error {
+func (db *mockDB) GetColumnsOfTable(_ context.Context, table *database.Table) error {
+	args := db.Called(table)
+	return args.Error(0)
+}
+
+func (db *mockDB) GetForeignKeysOfTable(_ context.Context, table *database.Table) error {
 	args := db.Called(table)
 	return args.Error(0)
 }
@@ -96,6 +104,149 @@ func TestCamelCaseString(t *testing.T) {
 	}
 }
 
+func TestStructName(t *testing.T) {
+	tests := []struct {
+		desc          string
+		structNames   settings.MapFlag
+		stripPrefixes settings.StringsFlag
+		stripSuffixes settings.StringsFlag
+		singularize   bool
+		input         string
+		expected      string
+	}{
+		{
+			desc:     "no settings returns the table name",
+			input:    "tbl_users_t",
+			expected: "tbl_users_t",
+		},
+		{
+			desc:          "prefix and suffix get stripped",
+			stripPrefixes: settings.StringsFlag{"tbl_"},
+			stripSuffixes: settings.StringsFlag{"_t"},
+			input:         "tbl_users_t",
+			expected:      "users",
+		},
+		{
+			desc:          "only the first matching prefix gets stripped",
+			stripPrefixes: settings.StringsFlag{"vw_", "tbl_", "tbl_usr_"},
+			input:         "tbl_usr_acct",
+			expected:      "usr_acct",
+		},
+		{
+			desc:          "prefix equal to the table name is kept",
+			stripPrefixes: settings.StringsFlag{"tbl_"},
+			input:         "tbl_",
+			expected:      "tbl_",
+		},
+		{
+			desc:          "stripped table name gets singularized",
+			stripPrefixes: settings.StringsFlag{"tbl_"},
+			singularize:   true,
+			input:         "tbl_order_items",
+			expected:      "order_item",
+		},
+		{
+			desc:          "struct name wins over stripping",
+			structNames:   settings.MapFlag{"tbl_usr_acct": "UserAccount"},
+			stripPrefixes: settings.StringsFlag{"tbl_"},
+			input:         "tbl_usr_acct",
+			expected:      "UserAccount",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := settings.New()
+			s.StructNames = tt.structNames
+			s.StripPrefixes = tt.stripPrefixes
+			s.StripSuffixes = tt.stripSuffixes
+			s.Singularize = tt.singularize
+			actual := structName(s, tt.input)
+			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
+		})
+	}
+}
+
+func TestStructTypeName(t *testing.T) {
+	tests := []struct {
+		desc        string
+		structNames settings.MapFlag
+		input       string
+		expected    string
+		isError     assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "table name",
+			input:    "user_accounts",
+			expected: "UserAccounts",
+			isError:  assert.NoError,
+		},
+		{
+			desc:        "struct name",
+			structNames: settings.MapFlag{"tbl_usr": "user"},
+			input:       "tbl_usr",
+			expected:    "User",
+			isError:     assert.NoError,
+		},
+		{
+			desc:        "empty struct name produces error",
+			structNames: settings.MapFlag{"tbl_usr": ""},
+			input:       "tbl_usr",
+			isError:     assert.Error,
+		},
+		{
+			desc:    "table name of underscores produces error",
+			input:   "__",
+			isError: assert.Error,
+		},
+		{
+			desc:    "table name with invalid characters produces error",
+			input:   "user-accounts",
+			isError: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := settings.New()
+			s.StructNames = tt.structNames
+			actual, err := structTypeName(s, tt.input)
+			tt.isError(t, err)
+			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
+		})
+	}
+}
+
+func TestFileHeader(t *testing.T) {
+	tests := []struct {
+		desc     string
+		version  string
+		notes    []string
+		expected string
+	}{
+		{
+			desc:     "no version and no notes returns empty header",
+			expected: "",
+		},
+		{
+			desc:     "version only",
+			version:  "v2.1.0-abcdef12",
+			expected: "// Generated by tables-to-go v2.1.0-abcdef12\n\n",
+		},
+		{
+			desc:     "version and notes",
+			version:  "v2.1.0-abcdef12",
+			notes:    []string{"Excluded columns of table users: avatar"},
+			expected: "// Generated by tables-to-go v2.1.0-abcdef12\n// Excluded columns of table users: avatar\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := settings.New()
+			s.GeneratorVersion = tt.version
+			assert.Equal(t, tt.expected, fileHeader(s, tt.notes...))
+		})
+	}
+}
+
 func TestToInitialisms(t *testing.T) {
 	t.Parallel()
 
@@ -147,7 +298,7 @@ func TestToInitialisms(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			actual := toInitialisms(tt.input)
+			actual := toInitialisms(tt.input, initialisms)
 			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
 		})
 	}
@@ -197,11 +348,11 @@ func TestRun_StringTextColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\ntype TestTable struct {\nColumnName string `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName string `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -237,11 +388,11 @@ func TestRun_StringTextColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullString `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullString `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -278,11 +429,11 @@ func TestRun_StringTextColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -323,11 +474,11 @@ func TestRun_StringTextColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -369,11 +520,11 @@ func TestRun_StringTextColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -433,18 +584,18 @@ func TestRun_StringTextColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable1",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}\n\nfunc (t TestTable1) TableName() string {\n\treturn \"test_table_1\"\n}\n",
 							).
 							Return(nil)
 						w.
 							On(
 								"Write",
 								"TestTable2",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 string `db:\"column_name_1\"`\nColumnName2 sql.NullString `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 string `db:\"column_name_1\"`\nColumnName2 sql.NullString `db:\"column_name_2\"`\n}\n\nfunc (t TestTable2) TableName() string {\n\treturn \"test_table_2\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 				})
@@ -497,11 +648,11 @@ func TestRun_IntegerColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\ntype TestTable struct {\nColumnName int `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName int `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -537,11 +688,11 @@ func TestRun_IntegerColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullInt64 `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullInt64 `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -578,11 +729,11 @@ func TestRun_IntegerColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *int `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *int `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -623,11 +774,11 @@ func TestRun_IntegerColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullInt64 `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullInt64 `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -669,11 +820,11 @@ func TestRun_IntegerColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *int `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *int `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -733,18 +884,18 @@ func TestRun_IntegerColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable1",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullInt64 `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullInt64 `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}\n\nfunc (t TestTable1) TableName() string {\n\treturn \"test_table_1\"\n}\n",
 							).
 							Return(nil)
 						w.
 							On(
 								"Write",
 								"TestTable2",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 int `db:\"column_name_1\"`\nColumnName2 sql.NullInt64 `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 int `db:\"column_name_1\"`\nColumnName2 sql.NullInt64 `db:\"column_name_2\"`\n}\n\nfunc (t TestTable2) TableName() string {\n\treturn \"test_table_2\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 				})
@@ -797,11 +948,11 @@ func TestRun_FloatColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\ntype TestTable struct {\nColumnName float64 `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName float64 `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -837,11 +988,11 @@ func TestRun_FloatColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullFloat64 `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullFloat64 `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -878,11 +1029,11 @@ func TestRun_FloatColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *float64 `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *float64 `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -923,11 +1074,11 @@ func TestRun_FloatColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullFloat64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullFloat64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -969,11 +1120,11 @@ func TestRun_FloatColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *float64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *float64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1033,18 +1184,18 @@ func TestRun_FloatColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable1",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullFloat64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullFloat64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}\n\nfunc (t TestTable1) TableName() string {\n\treturn \"test_table_1\"\n}\n",
 							).
 							Return(nil)
 						w.
 							On(
 								"Write",
 								"TestTable2",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 float64 `db:\"column_name_1\"`\nColumnName2 sql.NullFloat64 `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 float64 `db:\"column_name_1\"`\nColumnName2 sql.NullFloat64 `db:\"column_name_2\"`\n}\n\nfunc (t TestTable2) TableName() string {\n\treturn \"test_table_2\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 				})
@@ -1097,11 +1248,11 @@ func TestRun_TemporalColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName time.Time `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName time.Time `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1137,11 +1288,11 @@ func TestRun_TemporalColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullTime `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullTime `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1178,11 +1329,11 @@ func TestRun_TemporalColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName *time.Time `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName *time.Time `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1223,11 +1374,11 @@ func TestRun_TemporalColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullTime `db:\"column_name_1\"`\nColumnName2 time.Time `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullTime `db:\"column_name_1\"`\nColumnName2 time.Time `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1269,11 +1420,11 @@ func TestRun_TemporalColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName1 *time.Time `db:\"column_name_1\"`\nColumnName2 time.Time `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName1 *time.Time `db:\"column_name_1\"`\nColumnName2 time.Time `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1333,18 +1484,18 @@ func TestRun_TemporalColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable1",
-								"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullTime `db:\"column_name_1\"`\nColumnName2 time.Time `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullTime `db:\"column_name_1\"`\nColumnName2 time.Time `db:\"column_name_2\"`\n}\n\nfunc (t TestTable1) TableName() string {\n\treturn \"test_table_1\"\n}\n",
 							).
 							Return(nil)
 						w.
 							On(
 								"Write",
 								"TestTable2",
-								"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable2 struct {\nColumnName1 time.Time `db:\"column_name_1\"`\nColumnName2 sql.NullTime `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable2 struct {\nColumnName1 time.Time `db:\"column_name_1\"`\nColumnName2 sql.NullTime `db:\"column_name_2\"`\n}\n\nfunc (t TestTable2) TableName() string {\n\treturn \"test_table_2\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 				})
@@ -1397,11 +1548,11 @@ func TestRun_BooleanColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\ntype TestTable struct {\nColumnName bool `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName bool `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1437,11 +1588,11 @@ func TestRun_BooleanColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullBool `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullBool `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1478,11 +1629,11 @@ func TestRun_BooleanColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *bool `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *bool `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1523,11 +1674,11 @@ func TestRun_BooleanColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullBool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullBool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1569,11 +1720,11 @@ func TestRun_BooleanColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *bool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *bool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1633,18 +1784,18 @@ func TestRun_BooleanColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable1",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullBool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullBool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}\n\nfunc (t TestTable1) TableName() string {\n\treturn \"test_table_1\"\n}\n",
 							).
 							Return(nil)
 						w.
 							On(
 								"Write",
 								"TestTable2",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 bool `db:\"column_name_1\"`\nColumnName2 sql.NullBool `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 bool `db:\"column_name_1\"`\nColumnName2 sql.NullBool `db:\"column_name_2\"`\n}\n\nfunc (t TestTable2) TableName() string {\n\treturn \"test_table_2\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 				})
@@ -1701,11 +1852,11 @@ func TestRun_UnknownColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\ntype TestTable struct {\nColumnName string `db:\"column_name\"`\n}",
+								"package dto\n\ntype TestTable struct {\nColumnName string `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1742,11 +1893,11 @@ func TestRun_UnknownColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullString `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullString `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1784,11 +1935,11 @@ func TestRun_UnknownColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1830,11 +1981,11 @@ func TestRun_UnknownColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1877,11 +2028,11 @@ func TestRun_UnknownColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable",
-								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 
@@ -1942,18 +2093,18 @@ func TestRun_UnknownColumns(t *testing.T) {
 							On(
 								"Write",
 								"TestTable1",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}\n\nfunc (t TestTable1) TableName() string {\n\treturn \"test_table_1\"\n}\n",
 							).
 							Return(nil)
 						w.
 							On(
 								"Write",
 								"TestTable2",
-								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 string `db:\"column_name_1\"`\nColumnName2 sql.NullString `db:\"column_name_2\"`\n}",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 string `db:\"column_name_1\"`\nColumnName2 sql.NullString `db:\"column_name_2\"`\n}\n\nfunc (t TestTable2) TableName() string {\n\treturn \"test_table_2\"\n}\n",
 							).
 							Return(nil)
 
-						err := Run(s, mdb, w)
+						err := Run(context.Background(), s, mdb, w)
 						assert.NoError(t, err)
 					})
 				})
@@ -1962,6 +2113,1130 @@ func TestRun_UnknownColumns(t *testing.T) {
 	}
 }
 
+func TestRun_NullTypeJSON(t *testing.T) {
+	s := settings.New()
+	s.Null = settings.NullTypeJSON
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "column_name_1",
+				DataType:        "text",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "column_name_2",
+				DataType:        "timestamp",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"TestTable",
+			"package dto\n\nimport (\n)\n\ntype TestTable struct {\nColumnName1 NullString `db:\"column_name_1\"`\nColumnName2 NullTime `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
+		).
+		Return(nil)
+	w.
+		On(
+			"Write",
+			"nulltypes_gen",
+			mock.MatchedBy(func(content string) bool {
+				return strings.Contains(content, "type NullString struct {\n\tsql.NullString\n}") &&
+					strings.Contains(content, "type NullTime struct {\n\tsql.NullTime\n}")
+			}),
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_NullTypeGuregu(t *testing.T) {
+	s := settings.New()
+	s.Null = settings.NullTypeGuregu
+	s.EnumTypes = true
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "column_name_1",
+				DataType:        "text",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "column_name_2",
+				DataType:        "timestamp",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "status",
+				DataType:        "USER-DEFINED",
+				IsNullable:      "YES",
+				EnumValues:      []string{"open", "paid"},
+				EnumType:        "status",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"TestTable",
+			"package dto\n\nimport (\n\t\n\"github.com/guregu/null/v5\"\n)\n\ntype TestTable struct {\nColumnName1 null.String `db:\"column_name_1\"`\nColumnName2 null.Time `db:\"column_name_2\"`\nStatus null.Value[Status] `db:\"status\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
+		).
+		Return(nil)
+	w.
+		On("Write", "types_gen", mock.Anything).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_GoVersion(t *testing.T) {
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "column_name_1",
+				DataType:        "integer",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "column_name_2",
+				DataType:        "timestamp",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	t.Run("sql.Null[T] since Go 1.22", func(t *testing.T) {
+		s := settings.New()
+		s.GoVersion = settings.GoVersion122
+		db := database.New(s)
+
+		mdb := newMockDB(db)
+		mdb.
+			On("GetTables").
+			Return([]*database.Table{table}, nil)
+		mdb.
+			On("PrepareGetColumnsOfTableStmt").
+			Return(nil)
+		mdb.
+			On("GetColumnsOfTable", table).
+			Return(nil)
+
+		w := newMockWriter()
+		w.
+			On(
+				"Write",
+				"TestTable",
+				"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName1 sql.Null[int] `db:\"column_name_1\"`\nColumnName2 sql.Null[time.Time] `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
+			).
+			Return(nil)
+
+		err := Run(context.Background(), s, mdb, w)
+		assert.NoError(t, err)
+		w.AssertExpectations(t)
+	})
+
+	t.Run("generic JSON wrapper since Go 1.22", func(t *testing.T) {
+		s := settings.New()
+		s.GoVersion = settings.GoVersion124
+		s.Null = settings.NullTypeJSON
+		db := database.New(s)
+
+		mdb := newMockDB(db)
+		mdb.
+			On("GetTables").
+			Return([]*database.Table{table}, nil)
+		mdb.
+			On("PrepareGetColumnsOfTableStmt").
+			Return(nil)
+		mdb.
+			On("GetColumnsOfTable", table).
+			Return(nil)
+
+		w := newMockWriter()
+		w.
+			On(
+				"Write",
+				"TestTable",
+				"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName1 Null[int] `db:\"column_name_1\"`\nColumnName2 Null[time.Time] `db:\"column_name_2\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
+			).
+			Return(nil)
+		w.
+			On(
+				"Write",
+				"nulltypes_gen",
+				mock.MatchedBy(func(content string) bool {
+					return strings.Contains(content, "type Null[T any] struct {\n\tsql.Null[T]\n}") &&
+						!strings.Contains(content, "type NullTime struct")
+				}),
+			).
+			Return(nil)
+
+		err := Run(context.Background(), s, mdb, w)
+		assert.NoError(t, err)
+		w.AssertExpectations(t)
+	})
+}
+
+func TestRun_TagsBun(t *testing.T) {
+	s := settings.New()
+	s.TagsNoDb = true
+	s.TagsBun = true
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "column_name",
+				DataType:        "text",
+				IsNullable:      "YES",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"TestTable",
+			"package dto\n\nimport (\n\t\"database/sql\"\n\t\n\"github.com/uptrace/bun\"\n)\n\ntype TestTable struct {\nbun.BaseModel `bun:\"table:test_table\"`\n\nColumnName sql.NullString `bun:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_TagsGoPg(t *testing.T) {
+	s := settings.New()
+	s.TagsNoDb = true
+	s.TagsGoPg = true
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "column_name",
+				DataType:        "text",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"TestTable",
+			"package dto\n\ntype TestTable struct {\ntableName struct{} `pg:\"test_table\"`\n\nColumnName string `pg:\"column_name,notnull,use_zero\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_TagsParquet(t *testing.T) {
+	tests := []struct {
+		desc      string
+		settings  func(s *settings.Settings)
+		createdAt string
+		deletedAt string
+	}{
+		{
+			desc: "temporal columns stay time.Time",
+			settings: func(s *settings.Settings) {
+				s.TagsParquet = true
+			},
+			createdAt: "CreatedAt time.Time `parquet:",
+			deletedAt: "DeletedAt *time.Time `parquet:",
+		},
+		{
+			desc: "temporal columns become UnixMilli by the flag",
+			settings: func(s *settings.Settings) {
+				s.TagsParquet = true
+				s.TagsParquetMillis = true
+			},
+			createdAt: "CreatedAt UnixMilli `parquet:",
+			deletedAt: "DeletedAt *UnixMilli `parquet:",
+		},
+		{
+			desc: "temporal columns become UnixMilli by the flag and -tags",
+			settings: func(s *settings.Settings) {
+				s.Tags = settings.StringsFlag{"parquet"}
+				s.TagsParquetMillis = true
+			},
+			createdAt: "CreatedAt UnixMilli `parquet:",
+			deletedAt: "DeletedAt *UnixMilli `parquet:",
+		},
+		{
+			desc: "flag without parquet-tags keeps time.Time",
+			settings: func(s *settings.Settings) {
+				s.TagsParquetMillis = true
+			},
+			createdAt: "CreatedAt time.Time \n",
+			deletedAt: "DeletedAt *time.Time \n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.TagsNoDb = true
+			assert.NoError(t, s.Null.Set(string(settings.NullTypeNative)))
+			test.settings(s)
+
+			files := runParquetTable(t, s)
+
+			assert.Contains(t, files["Events"], test.createdAt)
+			assert.Contains(t, files["Events"], test.deletedAt)
+			if strings.Contains(test.createdAt, "UnixMilli") {
+				assert.Contains(t, files[helperTypesFileName], "type UnixMilli int64")
+			} else {
+				assert.NotContains(t, files, helperTypesFileName)
+			}
+		})
+	}
+}
+
+// runParquetTable runs the generation of an events table with a not nullable
+// and a nullable timestamp column and returns the written files by name.
+func runParquetTable(t *testing.T, s *settings.Settings) map[string]string {
+	t.Helper()
+
+	db := database.New(s)
+	table := &database.Table{
+		Name: "events",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+			{OrdinalPosition: 2, Name: "created_at", DataType: "timestamp without time zone", IsNullable: "NO"},
+			{OrdinalPosition: 3, Name: "deleted_at", DataType: "timestamp with time zone", IsNullable: "YES"},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.On("GetTables").Return([]*database.Table{table}, nil)
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+	mdb.On("GetColumnsOfTable", table).Return(nil)
+
+	files := map[string]string{}
+	w := newMockWriter()
+	w.On("Write", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		files[args.String(0)] = args.String(1)
+	})
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	return files
+}
+
+func TestRun_TagsReform(t *testing.T) {
+	s := settings.New()
+	s.TagsNoDb = true
+	s.TagsReform = true
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "column_name",
+				DataType:        "text",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"TestTable",
+			"package dto\n\n//reform:test_table\ntype TestTable struct {\nColumnName string `reform:\"column_name\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_TagsSwagger(t *testing.T) {
+	s := settings.New()
+	s.TagsNoDb = true
+	s.TagsSwagger = true
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "column_name",
+				DataType:        "character varying",
+				IsNullable:      "YES",
+				CharacterMaximumLength: sql.NullInt64{
+					Int64: 64,
+					Valid: true,
+				},
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"TestTable",
+			"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullString `swaggertype:\"string\"` // column_name character varying(64)\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_SensitiveColumns(t *testing.T) {
+	s := settings.New()
+	s.TagsJSON = true
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "name",
+				DataType:        "text",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "password_hash",
+				DataType:        "text",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"TestTable",
+			"package dto\n\nimport (\n\t\"fmt\"\n)\n\ntype TestTable struct {\nName string `db:\"name\" json:\"name\"`\nPasswordHash string `db:\"password_hash\" json:\"-\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n\n// String implements fmt.Stringer with the sensitive fields redacted.\nfunc (t TestTable) String() string {\n\treturn fmt.Sprintf(\"TestTable{Name:%v PasswordHash:[REDACTED]}\", t.Name)\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_TableFilters(t *testing.T) {
+	s := settings.New()
+	assert.NoError(t, s.TablesInclude.Set("^order"))
+	assert.NoError(t, s.TablesExclude.Set("_archive$"))
+	db := database.New(s)
+
+	orders := &database.Table{
+		Name: "orders",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+		},
+	}
+	ordersArchive := &database.Table{Name: "orders_archive", Columns: orders.Columns}
+	customers := &database.Table{Name: "customers", Columns: orders.Columns}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{orders, ordersArchive, customers}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", orders).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Orders",
+			"package dto\n\ntype Orders struct {\nID int `db:\"id\"`\n}\n\nfunc (o Orders) TableName() string {\n\treturn \"orders\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	mdb.AssertExpectations(t)
+	w.AssertExpectations(t)
+}
+
+func TestRun_IgnoredByComment(t *testing.T) {
+	s := settings.New()
+	db := database.New(s)
+
+	ignore := sql.NullString{String: "tables-to-go:ignore", Valid: true}
+	orders := &database.Table{
+		Name: "orders",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "internal_note",
+				DataType:        "text",
+				IsNullable:      "YES",
+				Comment:         sql.NullString{String: "for support only, tables-to-go:ignore", Valid: true},
+			},
+		},
+	}
+	migrations := &database.Table{Name: "schema_migrations", Comment: ignore, Columns: orders.Columns}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{orders, migrations}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", orders).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Orders",
+			"// Excluded columns of table orders: internal_note\n\npackage dto\n\ntype Orders struct {\nID int `db:\"id\"`\n}\n\nfunc (o Orders) TableName() string {\n\treturn \"orders\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	mdb.AssertExpectations(t)
+	w.AssertExpectations(t)
+}
+
+func TestRun_ExcludedColumns(t *testing.T) {
+	s := settings.New()
+	assert.NoError(t, s.ColumnsExclude.Set("legacy_flag"))
+	assert.NoError(t, s.ColumnsExclude.Set("test_.*=.*_blob"))
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "test_table",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "image_blob",
+				DataType:        "bytea",
+				IsNullable:      "YES",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "legacy_flag",
+				DataType:        "boolean",
+				IsNullable:      "NO",
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"TestTable",
+			"// Excluded columns of table test_table: image_blob, legacy_flag\n\npackage dto\n\ntype TestTable struct {\nID int `db:\"id\"`\n}\n\nfunc (t TestTable) TableName() string {\n\treturn \"test_table\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_Strict(t *testing.T) {
+	s := settings.New()
+	s.Strict = true
+	assert.NoError(t, s.ColumnsExclude.Set("legacy_.*"))
+	db := database.New(s)
+
+	users := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+			{OrdinalPosition: 2, Name: "location", DataType: "geometry", IsNullable: "YES"},
+			{OrdinalPosition: 3, Name: "legacy_data", DataType: "xml", IsNullable: "YES"},
+		},
+	}
+	places := &database.Table{
+		Name: "places",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+			{OrdinalPosition: 2, Name: "kind", DataType: "USER-DEFINED", IsNullable: "NO", EnumValues: []string{"city", "town"}},
+			{OrdinalPosition: 3, Name: "area", DataType: "polygon", IsNullable: "NO"},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.On("GetTables").Return([]*database.Table{users, places}, nil)
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+	mdb.On("GetColumnsOfTable", users).Return(nil)
+	mdb.On("GetColumnsOfTable", places).Return(nil)
+
+	w := newMockWriter()
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.EqualError(t, err, "strict mode: 2 columns have types without mapping, exclude them or leave out -strict:\n"+
+		"  users.location (geometry)\n"+
+		"  places.area (polygon)")
+	w.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+
+	// all tables get written once the columns are excluded
+	assert.NoError(t, s.ColumnsExclude.Set("location"))
+	assert.NoError(t, s.ColumnsExclude.Set("area"))
+	w.On("Write", "Users", mock.Anything).Return(nil).Once()
+	w.On("Write", "Places", mock.Anything).Return(nil).Once()
+
+	err = Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_TypeMapping(t *testing.T) {
+	database.RegisterTypeMapping(database.TypeMappingFunc(func(db database.Database, column database.Column, _ *settings.Settings) (string, []string, bool) {
+		if column.DataType != "money" {
+			return "", nil, false
+		}
+		if db.IsNullable(column) {
+			return "decimal.NullDecimal", []string{"github.com/shopspring/decimal"}, true
+		}
+		return "decimal.Decimal", []string{"github.com/shopspring/decimal"}, true
+	}))
+
+	s := settings.New()
+	s.Strict = true
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "products",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+			{OrdinalPosition: 2, Name: "price", DataType: "money", IsNullable: "NO"},
+			{OrdinalPosition: 3, Name: "discount", DataType: "money", IsNullable: "YES"},
+			{OrdinalPosition: 4, Name: "stock", DataType: "integer", IsNullable: "YES"},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.On("GetTables").Return([]*database.Table{table}, nil)
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+	mdb.On("GetColumnsOfTable", table).Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Products",
+			"package dto\n\nimport (\n\t\"database/sql\"\n\t\n\"github.com/shopspring/decimal\"\n)\n\ntype Products struct {\nID int `db:\"id\"`\nPrice decimal.Decimal `db:\"price\"`\nDiscount decimal.NullDecimal `db:\"discount\"`\nStock sql.NullInt64 `db:\"stock\"`\n}\n\nfunc (p Products) TableName() string {\n\treturn \"products\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_TypeMap(t *testing.T) {
+	s := settings.New()
+	s.Strict = true
+	s.TypeMap = []settings.TypeMapRule{
+		{DbType: settings.DBTypeMySQL, Type: "uuid", GoType: "string", Nullable: "*string"},
+		{Type: "uuid", GoType: "uuid.UUID", Nullable: "uuid.NullUUID", Imports: []string{"github.com/google/uuid"}},
+		{Column: "accounts.settings", GoType: "json.RawMessage", Nullable: "json.RawMessage", Imports: []string{"encoding/json"}},
+	}
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "accounts",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "id", DataType: "uuid", IsNullable: "NO"},
+			{OrdinalPosition: 2, Name: "parent_id", DataType: "uuid", IsNullable: "YES"},
+			{OrdinalPosition: 3, Name: "settings", DataType: "jsonb", IsNullable: "YES"},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.On("GetTables").Return([]*database.Table{table}, nil)
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+	mdb.On("GetColumnsOfTable", table).Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Accounts",
+			"package dto\n\nimport (\n\t\n\"encoding/json\"\n\"github.com/google/uuid\"\n)\n\ntype Accounts struct {\nID uuid.UUID `db:\"id\"`\nParentID uuid.NullUUID `db:\"parent_id\"`\nSettings json.RawMessage `db:\"settings\"`\n}\n\nfunc (a Accounts) TableName() string {\n\treturn \"accounts\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_WithComments(t *testing.T) {
+	tests := []struct {
+		desc         string
+		withComments bool
+		expected     string
+	}{
+		{
+			desc:         "doc comments of table and columns",
+			withComments: true,
+			expected:     "package dto\n\n// Users are the registered accounts.\n//\n// Deleted users are kept.\ntype Users struct {\nID int `db:\"id\"`\n// Name is the display name.\nName string `db:\"name\"`\n}\n\nfunc (u Users) TableName() string {\n\treturn \"users\"\n}\n",
+		},
+		{
+			desc:     "comments left out by default",
+			expected: "package dto\n\ntype Users struct {\nID int `db:\"id\"`\nName string `db:\"name\"`\n}\n\nfunc (u Users) TableName() string {\n\treturn \"users\"\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.WithComments = test.withComments
+			db := database.New(s)
+
+			table := &database.Table{
+				Name:    "users",
+				Comment: sql.NullString{String: "Users are the registered accounts.  \n\nDeleted users are kept.\n", Valid: true},
+				Columns: []database.Column{
+					{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO", Comment: sql.NullString{String: " ", Valid: true}},
+					{OrdinalPosition: 2, Name: "name", DataType: "text", IsNullable: "NO", Comment: sql.NullString{String: "Name is the display name.", Valid: true}},
+				},
+			}
+
+			mdb := newMockDB(db)
+			mdb.On("GetTables").Return([]*database.Table{table}, nil)
+			mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+			mdb.On("GetColumnsOfTable", table).Return(nil)
+
+			w := newMockWriter()
+			w.On("Write", "Users", test.expected).Return(nil)
+
+			assert.NoError(t, Run(context.Background(), s, mdb, w))
+			w.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_StructNames(t *testing.T) {
+	s := settings.New()
+	s.StructNames = settings.MapFlag{"tbl_usr_acct": "UserAccount"}
+	db := database.New(s)
+
+	columns := []database.Column{
+		{
+			OrdinalPosition: 1,
+			Name:            "id",
+			DataType:        "integer",
+			IsNullable:      "NO",
+		},
+	}
+	account := &database.Table{Name: "tbl_usr_acct", Columns: columns}
+	group := &database.Table{Name: "tbl_grp", Columns: columns}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{account, group}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", account).
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", group).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"UserAccount",
+			"package dto\n\ntype UserAccount struct {\nID int `db:\"id\"`\n}\n\nfunc (u UserAccount) TableName() string {\n\treturn \"tbl_usr_acct\"\n}\n",
+		).
+		Return(nil)
+	w.
+		On(
+			"Write",
+			"TblGrp",
+			"package dto\n\ntype TblGrp struct {\nID int `db:\"id\"`\n}\n\nfunc (t TblGrp) TableName() string {\n\treturn \"tbl_grp\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_MultipleConstraints(t *testing.T) {
+	s := settings.New()
+	s.TagsNoDb = true
+	s.TagsGorm = true
+	db := database.New(s)
+
+	// the foreign key of the column comes before its primary key
+	table := &database.Table{
+		Name: "memberships",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "account_id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				ConstraintName:  sql.NullString{String: "memberships_account_id_fkey", Valid: true},
+				ConstraintType:  sql.NullString{String: "FOREIGN KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 1,
+				Name:            "account_id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				ConstraintName:  sql.NullString{String: "memberships_pkey", Valid: true},
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "user_id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				ConstraintName:  sql.NullString{String: "memberships_pkey", Valid: true},
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "user_id",
+				DataType:        "integer",
+				IsNullable:      "NO",
+				ConstraintName:  sql.NullString{String: "memberships_user_id_fkey", Valid: true},
+				ConstraintType:  sql.NullString{String: "FOREIGN KEY", Valid: true},
+			},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Memberships",
+			"package dto\n\ntype Memberships struct {\nAccountID int `gorm:\"column:account_id;primaryKey;not null\"`\nUserID int `gorm:\"column:user_id;primaryKey;not null\"`\n}\n\nfunc (m Memberships) TableName() string {\n\treturn \"memberships\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_FieldNameCollisions(t *testing.T) {
+	var warnings []string
+	SetHooks(Hooks{OnWarning: func(message string) { warnings = append(warnings, message) }})
+	defer SetHooks(Hooks{})
+
+	s := settings.New()
+	s.TagsNoDb = true
+	s.TagsGorm = true
+	db := database.New(s)
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "user id", DataType: "integer", IsNullable: "NO"},
+			{OrdinalPosition: 2, Name: "user_id", DataType: "integer", IsNullable: "NO"},
+			{OrdinalPosition: 3, Name: "user-id", DataType: "integer", IsNullable: "NO"},
+			{OrdinalPosition: 4, Name: "table_name", DataType: "integer", IsNullable: "NO"},
+		},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{table}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\ntype Users struct {\nUserID int `gorm:\"column:user id;not null\"`\nUserID2 int `gorm:\"column:user_id;not null\"`\nUserID3 int `gorm:\"column:user-id;not null\"`\nTableName2 int `gorm:\"column:table_name;not null\"`\n}\n\nfunc (u Users) TableName() string {\n\treturn \"users\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+	assert.Equal(t, []string{
+		`field UserID of column "user_id" of table "users" already taken, named it UserID2`,
+		`field UserID of column "user-id" of table "users" already taken, named it UserID3`,
+		`field TableName of column "table_name" of table "users" already taken, named it TableName2`,
+	}, warnings)
+}
+
+func TestRun_EmptyTable(t *testing.T) {
+	var warned []string
+	SetHooks(Hooks{OnWarning: func(message string) { warned = append(warned, message) }})
+	defer SetHooks(Hooks{})
+
+	s := settings.New()
+	s.Quiet = true
+	db := database.New(s)
+
+	// the columns of the secrets can not be read by the user
+	secrets := &database.Table{Name: "secrets"}
+	users := &database.Table{
+		Name:    "users",
+		Columns: []database.Column{{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"}},
+	}
+
+	mdb := newMockDB(db)
+	mdb.
+		On("GetTables").
+		Return([]*database.Table{secrets, users}, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", secrets).
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", users).
+		Return(nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\ntype Users struct {\nID int `db:\"id\"`\n}\n\nfunc (u Users) TableName() string {\n\treturn \"users\"\n}\n",
+		).
+		Return(nil)
+
+	err := Run(context.Background(), s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+	assert.Equal(t, []string{`skipped table "secrets", it has no columns, e.g. missing the privileges to read them`}, warned)
+	assert.Equal(t, warned, warnings.messages)
+}
+
+func TestOrderColumns(t *testing.T) {
+	t.Parallel()
+
+	primaryKey := sql.NullString{String: "PRIMARY KEY", Valid: true}
+	foreignKey := sql.NullString{String: "FOREIGN KEY", Valid: true}
+	columns := []database.Column{
+		{OrdinalPosition: 1, Name: "tenant_id", ConstraintType: foreignKey},
+		{OrdinalPosition: 1, Name: "tenant_id", ConstraintType: primaryKey},
+		{OrdinalPosition: 2, Name: "name"},
+		{OrdinalPosition: 3, Name: "ID", ConstraintType: primaryKey},
+		{OrdinalPosition: 4, Name: "created_at"},
+	}
+
+	tests := []struct {
+		desc     string
+		order    settings.FieldOrder
+		expected []string
+	}{
+		{
+			desc:     "ordinal keeps the order of the database",
+			order:    settings.FieldOrderOrdinal,
+			expected: []string{"tenant_id", "tenant_id", "name", "ID", "created_at"},
+		},
+		{
+			desc:     "alphabetical ignores the case",
+			order:    settings.FieldOrderAlphabetical,
+			expected: []string{"created_at", "ID", "name", "tenant_id", "tenant_id"},
+		},
+		{
+			desc:     "pk-first groups the primary key columns in their order",
+			order:    settings.FieldOrderPKFirst,
+			expected: []string{"tenant_id", "tenant_id", "ID", "name", "created_at"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			s := settings.New()
+			s.FieldOrder = test.order
+			db := database.New(s)
+
+			var actual []string
+			for _, column := range orderColumns(s, db, columns) {
+				actual = append(actual, column.Name)
+			}
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, "tenant_id", columns[0].Name, "columns of the table are not reordered")
+		})
+	}
+}
+
 func TestValidVariableName(t *testing.T) {
 	t.Parallel()
 
@@ -2033,8 +3308,15 @@ func TestFormatColumnName(t *testing.T) {
 			{"snakeCase", "my_column", "My_column", "MyColumn"},
 			{"titleSnake", "My_Column", "My_Column", "MyColumn"},
 			{"numbersOnly", "123", "X_123", "X123"},
-			{"nonEnglish", "火", "火", "火"},
+			{"nonEnglish", "火", "X_火", "X火"},
 			{"nonEnglishUpper", "Λλ", "Λλ", "Λλ"},
+			{"dashes", "order-id", "Order_ID", "OrderID"},
+			{"semicolons", "MyColumn;", "MyColumn", "MyColumn"},
+			{"brackets", "MyColumn()", "MyColumn", "MyColumn"},
+			{"symbols", "price (€)", "Price_", "Price"},
+			{"symbolsBetween", "a.b/c", "A_b_c", "ABC"},
+			{"leadingSymbol", "#count", "Count", "Count"},
+			{"symbolsOnly", "?!", "X_", "X"},
 		}
 
 		camelSettings := settings.New()
@@ -2068,23 +3350,32 @@ func TestFormatColumnName(t *testing.T) {
 		})
 	})
 
-	t.Run("fail", func(t *testing.T) {
+	t.Run("initialisms", func(t *testing.T) {
 		type testCase struct {
-			name  string
-			input string
+			name        string
+			initialisms settings.StringsFlag
+			only        bool
+			input       string
+			expected    string
 		}
 		tests := []testCase{
-			{"semicolons", "MyColumn;"},
-			{"brackets", "MyColumn()"},
+			{"defaults", nil, false, "user_api_id", "UserApiID"},
+			{"extended", settings.StringsFlag{"API", "SKU"}, false, "user_api_id", "UserAPIID"},
+			{"replaced", settings.StringsFlag{"API"}, true, "user_api_id", "UserAPIId"},
 		}
-		s := settings.New()
 		for _, tc := range tests {
 			t.Run(tc.name, func(t *testing.T) {
-				_, err := formatColumnName(s, tc.input, "MyTable")
-				if err == nil {
-					t.Errorf("formatColumnName(%q) should have thrown error but didn't", tc.input)
+				s := settings.New()
+				s.Initialisms = tc.initialisms
+				s.InitialismsOnly = tc.only
+				output, err := formatColumnName(s, tc.input, "MyTable")
+				if err != nil {
+					t.Error(err)
+				} else if output != tc.expected {
+					t.Errorf("initialisms format of %q = %q, expected %q", tc.input, output, tc.expected)
 				}
 			})
 		}
 	})
+
 }
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/tagger"
+)
+
+// structTemplate is the template of the settings rendering the structs, nil
+// renders them by the built-in layout.
+var structTemplate *template.Template
+
+// setStructTemplate parses the template of the settings, if any, rendering
+// the structs of the run.
+func setStructTemplate(s *settings.Settings) error {
+	structTemplate = nil
+	if s.Template == "" {
+		return nil
+	}
+
+	text, err := os.ReadFile(s.Template)
+	if err != nil {
+		return fmt.Errorf("could not read template: %w", err)
+	}
+	funcs := tagger.TemplateFuncs()
+	funcs["quote"] = strconv.Quote
+	funcs["join"] = strings.Join
+	tmpl, err := template.New(filepath.Base(s.Template)).Funcs(funcs).Parse(string(text))
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+	structTemplate = tmpl
+	return nil
+}
+
+// StructTemplateData is the data the template of the settings renders the
+// struct of a table with.
+type StructTemplateData struct {
+	// Package is the name of the package of the generated files
+	Package string
+	// Imports are the import paths the Go types of the fields need, sorted
+	Imports []string
+	// Table is the table with its columns and foreign keys
+	Table *database.Table
+	// StructName is the name of the struct of the table
+	StructName string
+	// Fields are the fields of the columns in the order of the settings
+	Fields []StructTemplateField
+	// Relations are the fields of the structs of the referenced tables of
+	// -relations fields
+	Relations []StructTemplateField
+}
+
+// StructTemplateField is a field of the struct rendered by the template.
+type StructTemplateField struct {
+	Name string
+	Type string
+	// Tag are the tags of the field with their backquotes, empty if none
+	Tag string
+	// Comment is the comment of the field, e.g. the referenced table and
+	// columns of -relations
+	Comment      string
+	Column       database.Column
+	IsPrimaryKey bool
+	IsNullable   bool
+}
+
+// fieldImports returns the sorted import paths the Go types of the fields
+// need.
+func fieldImports(s *settings.Settings, columnInfo columnInfo) []string {
+	var imports []string
+	if columnInfo.isNullable && s.IsNullTypeSQL() {
+		imports = append(imports, "database/sql")
+	}
+	if columnInfo.isNullable && s.IsNullTypeGuregu() {
+		imports = append(imports, gureguNullImport)
+	}
+	if columnInfo.isTemporal {
+		imports = append(imports, "time")
+	}
+	for _, imp := range columnInfo.imports {
+		if !slices.Contains(imports, imp) {
+			imports = append(imports, imp)
+		}
+	}
+	slices.Sort(imports)
+	return imports
+}
+
+// renderStructTemplate renders the struct of the data by the template of the
+// settings below the header of the generated files.
+func renderStructTemplate(header string, data StructTemplateData) (string, error) {
+	var content strings.Builder
+	content.WriteString(header)
+	if err := structTemplate.Execute(&content, data); err != nil {
+		return "", fmt.Errorf("could not execute template: %w", err)
+	}
+	return content.String(), nil
+}
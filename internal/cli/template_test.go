@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestRun_Template(t *testing.T) {
+	tests := []struct {
+		desc     string
+		template string
+		expected string
+		err      string
+	}{
+		{
+			desc: "fields with types and tags",
+			template: `package {{ .Package }}
+{{ if .Imports }}
+import (
+{{- range .Imports }}
+	{{ quote . }}
+{{- end }}
+)
+{{ end }}
+// {{ .StructName }} is the table {{ .Table.Name }}.
+type {{ .StructName }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .Type }} {{ .Tag }}{{ if .IsPrimaryKey }} // primary key{{ end }}
+{{- end }}
+	AuditFields
+}
+
+func ({{ slice .StructName 0 1 | lower }} {{ .StructName }}) Key() string {
+	return {{ quote .Table.Name }}
+}
+`,
+			expected: "package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\n" +
+				"// Users is the table users.\ntype Users struct {\n" +
+				"\tID int `db:\"id\"` // primary key\n" +
+				"\tName sql.NullString `db:\"name\"`\n" +
+				"\tCreatedAt time.Time `db:\"created_at\"`\n" +
+				"\tAuditFields\n}\n\n" +
+				"func (u Users) Key() string {\n\treturn \"users\"\n}\n",
+		},
+		{
+			desc:     "failing template",
+			template: `{{ .Unknown }}`,
+			err:      `table "users": could not execute template: template: struct.tmpl:1:3: executing "struct.tmpl" at <.Unknown>: can't evaluate field Unknown in type cli.StructTemplateData`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "struct.tmpl")
+			assert.NoError(t, os.WriteFile(file, []byte(test.template), 0666))
+
+			s := settings.New()
+			s.Template = file
+			db := database.New(s)
+
+			table := &database.Table{
+				Name: "users",
+				Columns: []database.Column{
+					{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO", ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true}},
+					{OrdinalPosition: 2, Name: "name", DataType: "text", IsNullable: "YES"},
+					{OrdinalPosition: 3, Name: "created_at", DataType: "timestamp", IsNullable: "NO"},
+				},
+			}
+
+			mdb := newMockDB(db)
+			mdb.On("GetTables").Return([]*database.Table{table}, nil)
+			mdb.On("PrepareGetColumnsOfTableStmt").Return(nil)
+			mdb.On("GetColumnsOfTable", table).Return(nil)
+
+			w := newMockWriter()
+			w.On("Write", "Users", test.expected).Return(nil)
+
+			err := Run(context.Background(), s, mdb, w)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			w.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_Template_Invalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "struct.tmpl")
+	assert.NoError(t, os.WriteFile(file, []byte("{{ .StructName "), 0666))
+
+	s := settings.New()
+	s.Template = file
+
+	err := Run(context.Background(), s, newMockDB(database.New(s)), newMockWriter())
+	assert.ErrorContains(t, err, "could not parse template: ")
+}
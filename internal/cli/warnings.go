@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// warningSummary collects the warnings of a run to print them at its end,
+// they would get lost in the logs otherwise.
+type warningSummary struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+// warnings are the warnings of the current run.
+var warnings warningSummary
+
+// reset forgets the warnings of the previous run.
+func (s *warningSummary) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = nil
+}
+
+// add collects the warning.
+func (s *warningSummary) add(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, message)
+}
+
+// write prints the warnings to w, if any, nothing if w is nil.
+func (s *warningSummary) write(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w == nil || len(s.messages) == 0 {
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, "%d warnings:\n", len(s.messages)); err != nil {
+		return err
+	}
+	for _, message := range s.messages {
+		if _, err := fmt.Fprintf(w, "  %s\n", message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarningSummary_write(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		messages []string
+		expected string
+	}{
+		{
+			desc:     "no warnings print nothing",
+			expected: "",
+		},
+		{
+			desc: "warnings get listed",
+			messages: []string{
+				`skipped table "secrets", it has no columns, e.g. missing the privileges to read them`,
+				"no mapping for the type of points.location (geometry), generated as string",
+			},
+			expected: "2 warnings:\n" +
+				`  skipped table "secrets", it has no columns, e.g. missing the privileges to read them` + "\n" +
+				"  no mapping for the type of points.location (geometry), generated as string\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var summary warningSummary
+			summary.add("of the previous run")
+			summary.reset()
+			for _, message := range test.messages {
+				summary.add(message)
+			}
+
+			var buf bytes.Buffer
+			assert.NoError(t, summary.write(&buf))
+			assert.Equal(t, test.expected, buf.String())
+			assert.NoError(t, summary.write(nil))
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/tagger"
+)
+
+// watcher keeps the state of the tables between the polls of the schema.
+type watcher struct {
+	settings *settings.Settings
+	db       database.Database
+	out      output.Writer
+
+	// fingerprints and files map the names of the generated tables to the
+	// fingerprint of their columns and the name of their file
+	fingerprints map[string]string
+	files        map[string]string
+
+	// selection are the names of the interactively selected tables
+	selection map[string]struct{}
+	prepared  bool
+}
+
+// Watch runs the transformations like Run and then polls the schema every
+// WatchInterval until the context is done. New and changed tables get
+// (re)generated, the files of dropped tables get removed if the writer
+// supports it.
+func Watch(ctx context.Context, settings *settings.Settings, db database.Database, out output.Writer) error {
+
+	if err := tagger.VerifyNames(settings.Tags); err != nil {
+		return err
+	}
+
+	taggers = tagger.NewTaggers(settings)
+	helpers = newHelperTypes()
+	if err := setStructTemplate(settings); err != nil {
+		return err
+	}
+
+	slog.Info("watching", "type", settings.DbType, "interval", settings.WatchInterval)
+
+	w := &watcher{
+		settings:     settings,
+		db:           db,
+		out:          out,
+		fingerprints: map[string]string{},
+		files:        map[string]string{},
+	}
+
+	ticker := time.NewTicker(settings.WatchInterval)
+	defer ticker.Stop()
+
+	for {
+		err := w.poll(ctx)
+		if ctx.Err() != nil {
+			// interrupted while polling, checked before the select which
+			// would pick the ticker at random
+			slog.Info("done")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("done")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll introspects the schema and regenerates the affected files.
+func (w *watcher) poll(ctx context.Context) error {
+	tables, err := w.db.GetTables(ctx, w.settings.Tables...)
+	if err != nil {
+		return fmt.Errorf("could not get tables: %w", err)
+	}
+	tables = filterTables(w.settings, tables)
+
+	if w.settings.Interactive {
+		if tables, err = w.selectTables(tables); err != nil {
+			return err
+		}
+	}
+
+	if !w.prepared {
+		if err = w.db.PrepareGetColumnsOfTableStmt(ctx); err != nil {
+			return fmt.Errorf("could not prepare the get-column-statement: %w", err)
+		}
+		w.prepared = true
+	}
+
+	setRelatedTables(tables)
+	columns, err := withForeignKeys(ctx, w.settings, w.db, tables, func(i int) error {
+		return w.db.GetColumnsOfTable(ctx, tables[i])
+	})
+	if err != nil {
+		return err
+	}
+	columns = withEnums(ctx, w.settings, w.db, tables, columns)
+
+	changed := false
+	seen := map[string]struct{}{}
+
+	for i, table := range tables {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		seen[table.Name] = struct{}{}
+
+		if err = columns(i); err != nil {
+			if !w.settings.Force {
+				return fmt.Errorf("could not get columns of table %q: %w", table.Name, err)
+			}
+			slog.Error("could not get columns of table", "table", table.Name, "error", err)
+			continue
+		}
+
+		fingerprint := fmt.Sprintf("%+v %+v", table.Columns, table.ForeignKeys)
+		previous, known := w.fingerprints[table.Name]
+		if known && previous == fingerprint {
+			continue
+		}
+
+		if known {
+			slog.Debug("changed table", "table", table.Name)
+		} else {
+			slog.Debug("new table", "table", table.Name)
+		}
+
+		fileName, err := writeTable(w.settings, w.db, w.out, table, nil)
+		if err != nil {
+			if !w.settings.Force {
+				return err
+			}
+			slog.Error("skipped table", "table", table.Name, "error", err)
+			continue
+		}
+		w.fingerprints[table.Name] = fingerprint
+		w.files[table.Name] = fileName
+		changed = true
+	}
+
+	for name, fileName := range w.files {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+
+		slog.Debug("dropped table", "table", name)
+
+		if remover, ok := w.out.(output.Remover); ok {
+			if err = remover.Remove(fileName); err != nil {
+				return fmt.Errorf("could not remove struct of dropped table %q: %w", name, err)
+			}
+		}
+		delete(w.fingerprints, name)
+		delete(w.files, name)
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return writePackageFiles(w.settings, w.out)
+}
+
+// selectTables lets the user select the tables on the first poll and keeps
+// the selected ones on the following polls.
+func (w *watcher) selectTables(tables []*database.Table) ([]*database.Table, error) {
+	if w.selection == nil {
+		selected, err := selectTables(os.Stdin, os.Stdout, tables)
+		if err != nil {
+			return nil, err
+		}
+		w.selection = map[string]struct{}{}
+		for _, table := range selected {
+			w.selection[table.Name] = struct{}{}
+		}
+		return selected, nil
+	}
+
+	selected := make([]*database.Table, 0, len(w.selection))
+	for _, table := range tables {
+		if _, ok := w.selection[table.Name]; ok {
+			selected = append(selected, table)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+type mockRemovingWriter struct {
+	mockWriter
+}
+
+func (w *mockRemovingWriter) Remove(tableName string) error {
+	args := w.Called(tableName)
+	return args.Error(0)
+}
+
+func TestWatch(t *testing.T) {
+	s := settings.New()
+	s.WatchInterval = time.Millisecond
+	db := database.New(s)
+
+	idColumn := database.Column{
+		OrdinalPosition: 1,
+		Name:            "id",
+		DataType:        "integer",
+		IsNullable:      "NO",
+	}
+	nameColumn := database.Column{
+		OrdinalPosition: 2,
+		Name:            "name",
+		DataType:        "text",
+		IsNullable:      "NO",
+	}
+
+	// first poll: users and groups
+	users1 := &database.Table{Name: "users"}
+	groups1 := &database.Table{Name: "groups"}
+	// second poll: users unchanged, groups changed, orders new
+	users2 := &database.Table{Name: "users"}
+	groups2 := &database.Table{Name: "groups"}
+	orders2 := &database.Table{Name: "orders"}
+	// third poll: groups dropped
+	users3 := &database.Table{Name: "users"}
+	orders3 := &database.Table{Name: "orders"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	withColumns := func(columns ...database.Column) func(mock.Arguments) {
+		return func(args mock.Arguments) {
+			args.Get(0).(*database.Table).Columns = columns
+		}
+	}
+
+	mdb := newMockDB(db)
+	mdb.On("GetTables").Return([]*database.Table{users1, groups1}, nil).Once()
+	mdb.On("GetTables").Return([]*database.Table{users2, groups2, orders2}, nil).Once()
+	mdb.On("GetTables").Return([]*database.Table{users3, orders3}, nil).Once()
+	mdb.On("PrepareGetColumnsOfTableStmt").Return(nil).Once()
+	for _, table := range []*database.Table{users1, groups1, users2, orders2, users3, orders3} {
+		mdb.On("GetColumnsOfTable", table).Return(nil).Run(withColumns(idColumn)).Once()
+	}
+	mdb.On("GetColumnsOfTable", groups2).Return(nil).Run(withColumns(idColumn, nameColumn)).Once()
+
+	w := &mockRemovingWriter{}
+	w.On("Write", "Users", mock.Anything).Return(nil).Once()
+	w.On("Write", "Groups", mock.Anything).Return(nil).Twice()
+	w.On("Write", "Orders", mock.Anything).Return(nil).Once()
+	w.On("Remove", "Groups").Return(nil).Once().
+		Run(func(mock.Arguments) { cancel() })
+
+	err := Watch(ctx, s, mdb, w)
+	assert.NoError(t, err)
+	mdb.AssertExpectations(t)
+	w.AssertExpectations(t)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// passwordPromptArgs removes -p from the arguments if it is given without a
+// value, i.e. as last argument or followed by another flag, and returns
+// whether it was removed to prompt for the password instead.
+func passwordPromptArgs(args []string) ([]string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg != "-p" && arg != "--p" {
+			continue
+		}
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			continue
+		}
+		return append(args[:i:i], args[i+1:]...), true
+	}
+	return args, false
+}
+
+// promptPassword reads the password from the terminal without echoing it or,
+// if stdin is no terminal, as the first line of stdin.
+func promptPassword(s *settings.Settings) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("could not read password from stdin: %w", err)
+		}
+		s.Pswd = strings.TrimRight(line, "\r\n")
+		return nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return fmt.Errorf("could not read password: %w", err)
+	}
+	s.Pswd = string(password)
+	return nil
+}
@@ -0,0 +1,162 @@
+// Package cache caches the introspected columns of the tables, so tools
+// generating repeatedly, e.g. language servers or IDE integrations, do not
+// query the metadata of unchanged tables again.
+//
+// The entries are keyed by the identity of the database and a version of its
+// schema given by the tool, e.g. the version of its migrations. A new version
+// misses the entries of the former ones:
+//
+//	c := cache.NewDir(filepath.Join(os.TempDir(), "tables-to-go"))
+//	db = cache.NewDatabase(db, c, cache.KeyOf(s, migrations.Version()))
+//	tables, err := tablestogo.Introspect(ctx, s, db)
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Key identifies the entries of a version of the schema of a database.
+type Key struct {
+	// Database is the identity of the database, its type, address, name and
+	// schema, without the credentials
+	Database string
+	// Version is the version of the schema, entries of other versions miss
+	Version string
+}
+
+// KeyOf returns the key of the database and schema of the settings with the
+// given version of the schema.
+func KeyOf(s *settings.Settings, version string) Key {
+	address := s.Host + ":" + s.Port
+	if s.Socket != "" {
+		address = s.Socket
+	}
+	if s.DSN != "" {
+		// the DSN may contain the password
+		sum := sha256.Sum256([]byte(s.DSN))
+		address = "dsn-" + hex.EncodeToString(sum[:8])
+	}
+	return Key{
+		Database: fmt.Sprintf("%s://%s/%s/%s", s.DbType, address, s.DbName, s.Schema),
+		Version:  version,
+	}
+}
+
+// Cache stores the introspected tables with their columns by the key.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the table of the given name, false if it is not cached.
+	Get(key Key, table string) (ir.Table, bool, error)
+	// Put stores the table.
+	Put(key Key, table ir.Table) error
+}
+
+// Memory is the Cache in memory, for the tools running for long.
+type Memory struct {
+	mu     sync.Mutex
+	tables map[Key]map[string]ir.Table
+}
+
+// NewMemory creates an empty Memory cache.
+func NewMemory() *Memory {
+	return &Memory{tables: map[Key]map[string]ir.Table{}}
+}
+
+// Get is the implementation of the Cache interface.
+func (m *Memory) Get(key Key, table string) (ir.Table, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tables[key][table]
+	return t, ok, nil
+}
+
+// Put is the implementation of the Cache interface.
+func (m *Memory) Put(key Key, table ir.Table) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tables[key] == nil {
+		m.tables[key] = map[string]ir.Table{}
+	}
+	m.tables[key][table.Name] = table
+	return nil
+}
+
+// Dir is the Cache on disk, shared by the runs of the tools. Every table is
+// a file of its representation in a directory of its key.
+type Dir struct {
+	path string
+}
+
+// NewDir creates the Dir cache in the given directory, it gets created on
+// the first Put.
+func NewDir(path string) *Dir {
+	return &Dir{path: path}
+}
+
+// Get is the implementation of the Cache interface.
+func (d *Dir) Get(key Key, table string) (ir.Table, bool, error) {
+	content, err := os.ReadFile(d.file(key, table))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ir.Table{}, false, nil
+	}
+	if err != nil {
+		return ir.Table{}, false, fmt.Errorf("could not read the cached table %q: %w", table, err)
+	}
+	var t ir.Table
+	if err = json.Unmarshal(content, &t); err != nil {
+		return ir.Table{}, false, fmt.Errorf("could not parse the cached table %q: %w", table, err)
+	}
+	return t, true, nil
+}
+
+// Put is the implementation of the Cache interface.
+func (d *Dir) Put(key Key, table ir.Table) error {
+	content, err := json.Marshal(table)
+	if err != nil {
+		return fmt.Errorf("could not cache table %q: %w", table.Name, err)
+	}
+	name := d.file(key, table.Name)
+	if err = os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return fmt.Errorf("could not create the cache directory: %w", err)
+	}
+	// written aside first to never leave a partially written table behind
+	tmp := name + ".tmp"
+	if err = os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("could not cache table %q: %w", table.Name, err)
+	}
+	if err = os.Rename(tmp, name); err != nil {
+		return fmt.Errorf("could not cache table %q: %w", table.Name, err)
+	}
+	return nil
+}
+
+// SchemaFile returns the file of the whole schema of the key next to the
+// directory of its tables, e.g. for the representation of an ir.Schema.
+func (d *Dir) SchemaFile(key Key) string {
+	return filepath.Join(d.path, d.name(key)+".json")
+}
+
+// file returns the name of the file of the table of the key.
+func (d *Dir) file(key Key, table string) string {
+	// escaped to a file name, also the names . and ..
+	return filepath.Join(d.path, d.name(key), strings.ReplaceAll(url.PathEscape(table), ".", "%2E")+".json")
+}
+
+// name returns the name of the entries of the key.
+func (d *Dir) name(key Key) string {
+	sum := sha256.Sum256([]byte(key.Database + "\x00" + key.Version))
+	return hex.EncodeToString(sum[:])
+}
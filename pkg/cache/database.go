@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+)
+
+// Database implements the Database interface by the wrapped one, the columns
+// of the tables are read from the cache and only the ones of the tables not
+// cached are queried and get cached. The tables themselves are always
+// queried, new tables show up and dropped ones disappear.
+type Database struct {
+	database.Database
+	cache Cache
+	key   Key
+
+	mu       sync.Mutex
+	prepared bool
+}
+
+// NewDatabase wraps the database by the cache, its entries of the key are
+// used.
+func NewDatabase(db database.Database, cache Cache, key Key) *Database {
+	return &Database{
+		Database: db,
+		cache:    cache,
+		key:      key,
+	}
+}
+
+// PrepareGetColumnsOfTableStmt does nothing, the statement of the wrapped
+// database gets prepared once a table is not cached.
+func (db *Database) PrepareGetColumnsOfTableStmt(context.Context) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.prepared = false
+	return nil
+}
+
+// GetColumnsOfTable sets the columns of the table from the cache, or queries
+// and caches them if it is not cached.
+func (db *Database) GetColumnsOfTable(ctx context.Context, table *database.Table) error {
+	cached, ok, err := db.cache.Get(db.key, table.Name)
+	if err != nil {
+		return err
+	}
+	if ok {
+		table.Columns = cached.DatabaseColumns()
+		return nil
+	}
+
+	if err = db.prepare(ctx); err != nil {
+		return err
+	}
+	if err = db.Database.GetColumnsOfTable(ctx, table); err != nil {
+		return err
+	}
+	return db.cache.Put(db.key, ir.TableOf(table))
+}
+
+// prepare prepares the statement of the wrapped database, if not yet.
+func (db *Database) prepare(ctx context.Context) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.prepared {
+		return nil
+	}
+	if err := db.Database.PrepareGetColumnsOfTableStmt(ctx); err != nil {
+		return err
+	}
+	db.prepared = true
+	return nil
+}
+
+// GetForeignKeysOfTable is the implementation of the ForeignKeysGetter
+// interface, the foreign keys are not cached but queried by the wrapped
+// database.
+func (db *Database) GetForeignKeysOfTable(ctx context.Context, table *database.Table) error {
+	getter, ok := database.As[database.ForeignKeysGetter](db.Database)
+	if !ok {
+		return fmt.Errorf("foreign keys not supported by the database")
+	}
+	return getter.GetForeignKeysOfTable(ctx, table)
+}
+
+// GetEnumsOfTable is the implementation of the EnumsGetter interface, the enum
+// types are not cached but queried by the wrapped database, if it has them.
+func (db *Database) GetEnumsOfTable(ctx context.Context, table *database.Table) error {
+	getter, ok := database.As[database.EnumsGetter](db.Database)
+	if !ok {
+		return nil
+	}
+	return getter.GetEnumsOfTable(ctx, table)
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// countingDB counts the queries of the columns, the columns of unknown tables
+// can not be read.
+type countingDB struct {
+	database.Database
+	columns  map[string][]database.Column
+	prepared int
+	queried  []string
+}
+
+func (db *countingDB) PrepareGetColumnsOfTableStmt(context.Context) error {
+	db.prepared++
+	return nil
+}
+
+func (db *countingDB) GetColumnsOfTable(_ context.Context, table *database.Table) error {
+	db.queried = append(db.queried, table.Name)
+	columns, ok := db.columns[table.Name]
+	if !ok {
+		return errors.New("permission denied")
+	}
+	table.Columns = columns
+	return nil
+}
+
+func TestDatabase(t *testing.T) {
+	columns := []database.Column{
+		{OrdinalPosition: 1, Name: "id", DataType: "int", IsNullable: "NO", ColumnKey: "PRI", Extra: "auto_increment"},
+		{
+			OrdinalPosition: 2, Name: "email", DataType: "varchar", IsNullable: "YES",
+			CharacterMaximumLength: sql.NullInt64{Int64: 255, Valid: true},
+		},
+	}
+	s := settings.New()
+	s.DbType = settings.DBTypeMySQL
+	inner := &countingDB{
+		Database: database.New(s),
+		columns:  map[string][]database.Column{"users": columns},
+	}
+	c := NewMemory()
+	ctx := context.Background()
+
+	// the first run queries and caches the columns
+	db := NewDatabase(inner, c, KeyOf(s, "1"))
+	assert.NoError(t, db.PrepareGetColumnsOfTableStmt(ctx))
+	table := &database.Table{Name: "users"}
+	assert.NoError(t, db.GetColumnsOfTable(ctx, table))
+	assert.Equal(t, columns, table.Columns)
+	assert.EqualError(t, db.GetColumnsOfTable(ctx, &database.Table{Name: "orders"}), "permission denied")
+	assert.Equal(t, 1, inner.prepared)
+	assert.Equal(t, []string{"users", "orders"}, inner.queried)
+
+	// the next run reads them from the cache and prepares nothing
+	inner.queried = nil
+	db = NewDatabase(inner, c, KeyOf(s, "1"))
+	assert.NoError(t, db.PrepareGetColumnsOfTableStmt(ctx))
+	table = &database.Table{Name: "users"}
+	assert.NoError(t, db.GetColumnsOfTable(ctx, table))
+	assert.Equal(t, columns, table.Columns)
+	assert.True(t, db.IsAutoIncrement(table.Columns[0]))
+	assert.Empty(t, inner.queried)
+	assert.Equal(t, 1, inner.prepared)
+
+	// a new version of the schema queries them again
+	db = NewDatabase(inner, c, KeyOf(s, "2"))
+	assert.NoError(t, db.PrepareGetColumnsOfTableStmt(ctx))
+	assert.NoError(t, db.GetColumnsOfTable(ctx, &database.Table{Name: "users"}))
+	assert.Equal(t, []string{"users"}, inner.queried)
+	assert.Equal(t, 2, inner.prepared)
+}
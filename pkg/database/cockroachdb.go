@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// CockroachDB implements the Database interface with help of Postgresql,
+// whose wire protocol and information_schema CockroachDB speaks.
+type CockroachDB struct {
+	*Postgresql
+}
+
+// NewCockroachDB creates a new CockroachDB database. The hidden rowid column
+// CockroachDB adds as primary key of the tables without one is no column of
+// the structs, the identity columns get the extra identity.
+func NewCockroachDB(s *settings.Settings) *CockroachDB {
+	pg := NewPostgresql(s)
+	pg.defaultUserName = "root"
+	pg.extraColumns = "CASE WHEN ic.is_identity = 'YES' THEN 'identity' ELSE '' END AS extra,"
+	pg.columnsFilter = "AND ic.is_hidden = 'NO'"
+	return &CockroachDB{Postgresql: pg}
+}
+
+// GetColumnsOfTable executes the statement for retrieving the columns of a
+// specific table in a given schema.
+func (c *CockroachDB) GetColumnsOfTable(ctx context.Context, table *Table) error {
+	if err := c.Postgresql.GetColumnsOfTable(ctx, table); err != nil {
+		return err
+	}
+	cockroachDefaults(table.Columns)
+	return nil
+}
+
+// GetColumnsOfTables is the implementation of the ColumnsBatchGetter
+// interface, it gets the columns of all tables in one query.
+func (c *CockroachDB) GetColumnsOfTables(ctx context.Context, tables []*Table) error {
+	if err := c.Postgresql.GetColumnsOfTables(ctx, tables); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		cockroachDefaults(table.Columns)
+	}
+	return nil
+}
+
+// IsAutoIncrement checks if the column is an auto increment column: one of a
+// sequence, of the unique_rowid() of SERIAL or an identity column.
+func (c *CockroachDB) IsAutoIncrement(column Column) bool {
+	return c.Postgresql.IsAutoIncrement(column) ||
+		strings.Contains(column.DefaultValue.String, "unique_rowid()") ||
+		column.Extra == "identity"
+}
+
+// cockroachDefaults rewrites the type annotations of the defaults like
+// 'foo':::STRING to the type casts of Postgres.
+func cockroachDefaults(columns []Column) {
+	for i := range columns {
+		columns[i].DefaultValue.String = strings.ReplaceAll(columns[i].DefaultValue.String, ":::", "::")
+	}
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestCockroachDB_IsAutoIncrement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		column   Column
+		expected bool
+	}{
+		{
+			desc:     "sequence",
+			column:   Column{DefaultValue: sql.NullString{String: "nextval('users_id_seq'::REGCLASS)", Valid: true}},
+			expected: true,
+		},
+		{
+			desc:     "SERIAL",
+			column:   Column{DefaultValue: sql.NullString{String: "unique_rowid()", Valid: true}},
+			expected: true,
+		},
+		{
+			desc:     "identity",
+			column:   Column{Extra: "identity"},
+			expected: true,
+		},
+		{
+			desc:     "plain default",
+			column:   Column{DefaultValue: sql.NullString{String: "'foo'::STRING", Valid: true}},
+			expected: false,
+		},
+	}
+
+	c := NewCockroachDB(settings.New())
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, c.IsAutoIncrement(test.column))
+		})
+	}
+}
+
+func TestCockroachDefaults(t *testing.T) {
+	t.Parallel()
+
+	columns := []Column{
+		{DefaultValue: sql.NullString{String: "'foo':::STRING", Valid: true}},
+		{DefaultValue: sql.NullString{String: "now():::TIMESTAMP", Valid: true}},
+		{},
+	}
+	cockroachDefaults(columns)
+
+	assert.Equal(t, sql.NullString{String: "'foo'::STRING", Valid: true}, columns[0].DefaultValue)
+	assert.Equal(t, sql.NullString{String: "now()::TIMESTAMP", Valid: true}, columns[1].DefaultValue)
+	assert.Equal(t, sql.NullString{}, columns[2].DefaultValue)
+}
+
+func TestCockroachDB_HiddenColumns(t *testing.T) {
+	t.Parallel()
+
+	conn := &queryConn{}
+	c := NewCockroachDB(settings.New())
+	c.DB = sqlx.NewDb(sql.OpenDB(queryConnector{conn: conn}), "postgres")
+	defer c.Close()
+
+	ctx := context.Background()
+	assert.NoError(t, c.PrepareGetColumnsOfTableStmt(ctx))
+	assert.NoError(t, c.GetColumnsOfTables(ctx, []*Table{{Name: "users"}}))
+
+	columnQueries := 0
+	for _, query := range conn.queries {
+		if !strings.Contains(query, "information_schema.columns") {
+			continue
+		}
+		columnQueries++
+		assert.Contains(t, query, "is_hidden = 'NO'")
+		assert.Contains(t, query, "is_identity")
+	}
+	assert.Equal(t, 2, columnQueries)
+}
@@ -1,10 +1,13 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -14,22 +17,34 @@ import (
 var (
 	// dbTypeToDriverMap maps the database type to the driver names.
 	dbTypeToDriverMap = map[settings.DBType]string{
-		settings.DBTypePostgresql: "postgres",
-		settings.DBTypeMySQL:      "mysql",
-		settings.DBTypeSQLite:     "sqlite3",
-		settings.DBTypeOracle:     "oracle",
+		settings.DBTypePostgresql:  "postgres",
+		settings.DBTypeMySQL:       "mysql",
+		settings.DBTypeSQLite:      "sqlite3",
+		settings.DBTypeOracle:      "oracle",
+		settings.DBTypeCockroachDB: "postgres",
+		settings.DBTypeMariaDB:     "mysql",
 	}
+
+	// dialects maps the database types registered by third parties to the
+	// factories of their databases.
+	dialects = map[settings.DBType]func(*settings.Settings) Database{}
 )
 
 // Database interface for the concrete databases.
+//
+// The methods querying the database take the context for their queries, which
+// run by the context variants of database/sql like QueryContext and
+// PrepareContext, so callers enforce deadlines and cancellation on slow
+// queries of the information schema. There are hence no separate context
+// variants of the methods.
 type Database interface {
 	DSN() string
-	Connect() error
+	Connect(ctx context.Context) error
 	Close() error
 
-	GetTables(tables ...string) ([]*Table, error)
-	PrepareGetColumnsOfTableStmt() error
-	GetColumnsOfTable(table *Table) error
+	GetTables(ctx context.Context, tables ...string) ([]*Table, error)
+	PrepareGetColumnsOfTableStmt(ctx context.Context) error
+	GetColumnsOfTable(ctx context.Context, table *Table) error
 
 	IsPrimaryKey(column Column) bool
 	IsAutoIncrement(column Column) bool
@@ -54,10 +69,160 @@ type Database interface {
 	// TODO mysql: bit, enums, set
 }
 
+// These are the types of the tables as named by the information_schema.
+const (
+	TableTypeBase             = "BASE TABLE"
+	TableTypeView             = "VIEW"
+	TableTypeMaterializedView = "MATERIALIZED VIEW"
+)
+
 // Table has a name and a set (slice) of columns.
 type Table struct {
-	Name    string `db:"table_name"`
+	Name    string         `db:"table_name"`
+	Comment sql.NullString `db:"table_comment"`
 	Columns []Column
+
+	// Type is the type of the table, one of the TableType constants, empty
+	// means a base table.
+	Type string `db:"table_type"`
+
+	// ForeignKeys are the foreign keys of the table, set by the
+	// ForeignKeysGetter
+	ForeignKeys []ForeignKey `db:"-"`
+}
+
+// IsView returns if the table is a view or a materialized view, which is
+// read-only.
+func (t *Table) IsView() bool {
+	return t.Type == TableTypeView || t.Type == TableTypeMaterializedView
+}
+
+// TableInfoGetter is implemented by databases able to describe a table by its
+// number of rows and its comment.
+type TableInfoGetter interface {
+	GetTableInfo(ctx context.Context, table *Table) (TableInfo, error)
+}
+
+// ForeignKeysGetter is implemented by databases able to get the foreign keys
+// of a table. GetForeignKeysOfTable sets the ForeignKeys of the table.
+type ForeignKeysGetter interface {
+	GetForeignKeysOfTable(ctx context.Context, table *Table) error
+}
+
+// EnumsGetter is implemented by databases whose enum columns are of enum
+// types declared apart from the columns. GetEnumsOfTable sets the EnumValues
+// and the EnumType of the enum columns of the table.
+type EnumsGetter interface {
+	GetEnumsOfTable(ctx context.Context, table *Table) error
+}
+
+// JobsLimiter is implemented by databases limiting the number of tables
+// whose columns get introspected in parallel, e.g. as their sessions are
+// expensive. MaxJobs returns the limit, 0 means none.
+type JobsLimiter interface {
+	MaxJobs() int
+}
+
+// Snapshotter is implemented by databases able to run the introspection in
+// one read-only transaction, so all its queries see the same state of the
+// schema even if migrations run concurrently. The queries between
+// BeginSnapshot and EndSnapshot run in the transaction, hence one after the
+// other on its connection.
+type Snapshotter interface {
+	BeginSnapshot(ctx context.Context) error
+	EndSnapshot() error
+}
+
+// ColumnsBatchGetter is implemented by databases able to get the columns of
+// many tables in one query instead of one query per table.
+type ColumnsBatchGetter interface {
+	GetColumnsOfTables(ctx context.Context, tables []*Table) error
+}
+
+// Wrapper is implemented by databases wrapping another one, e.g. the tunnels,
+// whose optional interfaces like the Snapshotter get found by As.
+type Wrapper interface {
+	Unwrap() Database
+}
+
+// As returns the database as the optional interface T, or the first database
+// wrapped by it implementing T, unwrapping the Wrappers like errors.As.
+func As[T any](db Database) (T, bool) {
+	for {
+		if t, ok := db.(T); ok {
+			return t, true
+		}
+		wrapper, ok := db.(Wrapper)
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		db = wrapper.Unwrap()
+	}
+}
+
+// tableColumn is a column with the name of its table, the row of the queries
+// getting the columns of many tables.
+type tableColumn struct {
+	TableName string `db:"table_name"`
+	Column
+}
+
+// groupColumns sets the columns of the tables to the ones of their rows in
+// the order of the rows.
+func groupColumns(tables []*Table, rows []tableColumn) {
+	byName := make(map[string]*Table, len(tables))
+	for _, table := range tables {
+		table.Columns = nil
+		byName[table.Name] = table
+	}
+	for _, row := range rows {
+		if table, ok := byName[row.TableName]; ok {
+			table.Columns = append(table.Columns, row.Column)
+		}
+	}
+}
+
+// tableTypes returns the types of the tables to get by the settings, the
+// ones of the database among them.
+func tableTypes(s *settings.Settings, supported ...string) []string {
+	var types []string
+	if !s.OnlyViews {
+		types = append(types, TableTypeBase)
+	}
+	if s.HasViews() {
+		types = append(types, TableTypeView, TableTypeMaterializedView)
+	}
+	return slices.DeleteFunc(types, func(typ string) bool {
+		return !slices.Contains(supported, typ)
+	})
+}
+
+// inTableTypes returns the condition of the field being one of the types,
+// the types are constants safe to quote into the SQL.
+func inTableTypes(field string, types []string) string {
+	quoted := make([]string, len(types))
+	for i, typ := range types {
+		quoted[i] = "'" + typ + "'"
+	}
+	return field + " IN (" + strings.Join(quoted, ", ") + ")"
+}
+
+// tableNames returns the names of the tables.
+func tableNames(tables []*Table) []string {
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = table.Name
+	}
+	return names
+}
+
+// TableInfo describes a table. Rows is the number of rows as known by the
+// statistics of the database, hence an estimate for most databases and
+// invalid if the table was never analyzed.
+type TableInfo struct {
+	Rows    sql.NullInt64  `db:"table_rows"`
+	Comment sql.NullString `db:"table_comment"`
 }
 
 // Column stores information about a column.
@@ -69,10 +234,131 @@ type Column struct {
 	IsNullable             string         `db:"is_nullable"`
 	CharacterMaximumLength sql.NullInt64  `db:"character_maximum_length"`
 	NumericPrecision       sql.NullInt64  `db:"numeric_precision"`
+	NumericScale           sql.NullInt64  `db:"numeric_scale"`   // oracle specific
+	ColumnType             string         `db:"column_type"`     // mysql specific
+	UdtName                string         `db:"udt_name"`        // pg specific
 	ColumnKey              string         `db:"column_key"`      // mysql specific
 	Extra                  string         `db:"extra"`           // mysql specific
 	ConstraintName         sql.NullString `db:"constraint_name"` // pg specific
 	ConstraintType         sql.NullString `db:"constraint_type"` // pg specific
+	Comment                sql.NullString `db:"column_comment"`
+
+	// EnumValues are the allowed values of enum columns.
+	EnumValues []string `db:"-"`
+	// EnumType is the name of the enum type of the column, if it is of a
+	// named one like the ones of CREATE TYPE ... AS ENUM of Postgres.
+	EnumType string `db:"-"`
+
+	// Constraints are all key constraints the column is part of, set by
+	// MergeColumns. ConstraintName and ConstraintType are the primary key
+	// among them, if any, or the first one.
+	Constraints []Constraint `db:"-"`
+}
+
+// Constraint is a key constraint of a column.
+type Constraint struct {
+	Name string
+	Type string
+}
+
+// ForeignKey is a foreign key of a table, its columns reference the columns
+// of the referenced table in the same order.
+type ForeignKey struct {
+	Name              string
+	Columns           []string
+	ReferencedTable   string
+	ReferencedColumns []string
+}
+
+// foreignKeyColumn is a column of a foreign key with the column it
+// references, the row of the queries getting the foreign keys.
+type foreignKeyColumn struct {
+	ConstraintName       string `db:"constraint_name"`
+	ColumnName           string `db:"column_name"`
+	ReferencedTableName  string `db:"referenced_table_name"`
+	ReferencedColumnName string `db:"referenced_column_name"`
+}
+
+// groupForeignKeys returns the foreign keys of the rows, which come ordered
+// by the constraint and the position of the column in it.
+func groupForeignKeys(rows []foreignKeyColumn) []ForeignKey {
+	var foreignKeys []ForeignKey
+	for _, row := range rows {
+		if n := len(foreignKeys); n == 0 || foreignKeys[n-1].Name != row.ConstraintName {
+			foreignKeys = append(foreignKeys, ForeignKey{Name: row.ConstraintName, ReferencedTable: row.ReferencedTableName})
+		}
+		foreignKey := &foreignKeys[len(foreignKeys)-1]
+		foreignKey.Columns = append(foreignKey.Columns, row.ColumnName)
+		foreignKey.ReferencedColumns = append(foreignKey.ReferencedColumns, row.ReferencedColumnName)
+	}
+	return foreignKeys
+}
+
+// enumValue is a value of the enum type of a column, the row of the queries
+// getting the enum types.
+type enumValue struct {
+	ColumnName string `db:"column_name"`
+	TypeName   string `db:"type_name"`
+	Value      string `db:"enum_value"`
+}
+
+// setEnums sets the enum types and their values of the rows, which come
+// ordered by the sort order of the values, to the columns of the table.
+func setEnums(table *Table, rows []enumValue) {
+	for i := range table.Columns {
+		column := &table.Columns[i]
+		column.EnumType, column.EnumValues = "", nil
+		for _, row := range rows {
+			if row.ColumnName == column.Name {
+				column.EnumType = row.TypeName
+				column.EnumValues = append(column.EnumValues, row.Value)
+			}
+		}
+	}
+}
+
+// MergeColumns returns the columns once each, in the order of their first
+// occurrence, with the constraints of all of their occurrences. Postgres
+// returns a column once per key constraint it is part of, e.g. a column of
+// the primary key referencing another table twice, so the merged column has
+// both constraints. Merged columns stay the same.
+func MergeColumns(columns []Column) []Column {
+	merged := make([]Column, 0, len(columns))
+	indices := make(map[string]int, len(columns))
+
+	for _, column := range columns {
+		constraints := slices.Clip(column.Constraints)
+		if column.ConstraintName.Valid || column.ConstraintType.Valid {
+			constraints = append(constraints, Constraint{Name: column.ConstraintName.String, Type: column.ConstraintType.String})
+		}
+
+		i, ok := indices[column.Name]
+		if !ok {
+			i = len(merged)
+			indices[column.Name] = i
+			column.Constraints = nil
+			merged = append(merged, column)
+		}
+		for _, constraint := range constraints {
+			merged[i].addConstraint(constraint)
+		}
+	}
+	return merged
+}
+
+// addConstraint adds the constraint to the ones of the column, unless it has
+// it already, and makes it the constraint of the column if it is the first
+// or the primary key.
+func (c *Column) addConstraint(constraint Constraint) {
+	if slices.Contains(c.Constraints, constraint) {
+		return
+	}
+	c.Constraints = append(c.Constraints, constraint)
+
+	if len(c.Constraints) == 1 || (constraint.Type == "PRIMARY KEY" && c.ConstraintType.String != "PRIMARY KEY") {
+		c.ConstraintName = sql.NullString{String: constraint.Name, Valid: constraint.Name != ""}
+		c.ConstraintType = sql.NullString{String: constraint.Type, Valid: constraint.Type != ""}
+	}
 }
 
 // GeneralDatabase represents a base "class" database - for all other concrete
@@ -82,11 +368,41 @@ type GeneralDatabase struct {
 	*sqlx.DB
 	*settings.Settings
 	driver string
+
+	// tx is the transaction of the snapshot, if any
+	tx *sqlx.Tx
+
+	// connected is true once connected, the operations failing by a dropped
+	// connection get run again on a new one then, see retry
+	connected bool
+	// stopKeepalive stops the pings of the keepalive, if any
+	stopKeepalive context.CancelFunc
+}
+
+// Register makes a dialect available by its name as database type, for the
+// DbType of the settings as well as for New which creates the database with
+// the factory. Register is meant to be called in the init function of the
+// package implementing the dialect and panics if the name is empty, the
+// factory is nil or the name is already registered.
+//
+// The dialect can build on GeneralDatabase by NewGeneralDatabase, its
+// databases connect on the default port of the settings if none is given,
+// which is empty for dialects.
+func Register(name string, factory func(*settings.Settings) Database) {
+	if factory == nil {
+		panic(fmt.Sprintf("database: factory of dialect %q is nil", name))
+	}
+	settings.RegisterDbType(settings.DBType(name), "")
+	dialects[settings.DBType(name)] = factory
 }
 
 // New creates a new Database based on the given type in the settings.
 func New(s *settings.Settings) Database {
 
+	if factory, ok := dialects[s.DbType]; ok {
+		return factory(s)
+	}
+
 	var db Database
 
 	switch s.DbType {
@@ -96,6 +412,10 @@ func New(s *settings.Settings) Database {
 		db = NewMySQL(s)
 	case settings.DBTypeOracle:
 		db = NewOracle(s)
+	case settings.DBTypeCockroachDB:
+		db = NewCockroachDB(s)
+	case settings.DBTypeMariaDB:
+		db = NewMariaDB(s)
 	case settings.DBTypePostgresql:
 		fallthrough
 	default:
@@ -105,26 +425,228 @@ func New(s *settings.Settings) Database {
 	return db
 }
 
+// NewGeneralDatabase creates a new GeneralDatabase connecting with the
+// database/sql driver of the given name, the base of registered dialects.
+func NewGeneralDatabase(s *settings.Settings, driver string) *GeneralDatabase {
+	return &GeneralDatabase{
+		Settings: s,
+		driver:   driver,
+	}
+}
+
 // Connect establishes a connection to the database with the given DSN.
-// It pings the database to ensure it is reachable.
-func (gdb *GeneralDatabase) Connect(dsn string) (err error) {
-	gdb.DB, err = sqlx.Connect(gdb.driver, dsn)
+// It pings the database to ensure it is reachable within the ConnectTimeout
+// and retries as configured. The Keepalive of the settings pings it
+// periodically until Close.
+func (gdb *GeneralDatabase) Connect(ctx context.Context, dsn string) (err error) {
+	err = gdb.retry(ctx, func() (err error) {
+		ctx, cancel := gdb.connectContext(ctx)
+		defer cancel()
+		if !gdb.LogQueries && gdb.Proxy == "" {
+			gdb.DB, err = sqlx.ConnectContext(ctx, gdb.driver, dsn)
+			return err
+		}
+		db, err := gdb.open(dsn)
+		if err != nil {
+			return err
+		}
+		gdb.DB = sqlx.NewDb(db, gdb.driver)
+		if err = gdb.DB.PingContext(ctx); err != nil {
+			gdb.DB.Close()
+		}
+		return err
+	})
+	if err != nil && gdb.Settings.DSN != "" {
+		// the DSN may contain the password
+		return fmt.Errorf("could not connect to database (type=%q, raw DSN): %w", gdb.DbType, err)
+	}
 	if err != nil {
 		usingPswd := "no"
 		if gdb.Settings.Pswd != "" {
 			usingPswd = "yes"
 		}
+		address := "host='" + gdb.Host + ":" + gdb.Port + "'"
+		if gdb.Socket != "" {
+			address = "socket='" + gdb.Socket + "'"
+		}
 		return fmt.Errorf(
-			"could not connect to database (type=%q, user=%q, database=%q, host='%v:%v', using password: %v): %w",
-			gdb.DbType, gdb.User, gdb.DbName, gdb.Host, gdb.Port, usingPswd, err,
+			"could not connect to database (type=%q, user=%q, database=%q, %s, using password: %v): %w",
+			gdb.DbType, gdb.User, gdb.DbName, address, usingPswd, err,
 		)
 	}
 
-	return gdb.Ping()
+	gdb.configurePool()
+	gdb.startKeepalive()
+	gdb.connected = true
+	return nil
+}
+
+// open opens the database of the dsn dialing through the Proxy of the
+// settings, if any, logging the queries if LogQueries.
+func (gdb *GeneralDatabase) open(dsn string) (*sql.DB, error) {
+	if gdb.Proxy == "" {
+		return openQueryLog(gdb.driver, dsn)
+	}
+	connector, err := proxyConnector(gdb.driver, dsn, gdb.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if gdb.LogQueries {
+		connector = queryLogConnector{connector}
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// connectContext returns the context of connecting or pinging limited by the
+// ConnectTimeout of the settings.
+func (gdb *GeneralDatabase) connectContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if gdb.ConnectTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, gdb.ConnectTimeout)
+}
+
+// startKeepalive pings the database in the Keepalive interval of the settings
+// until Close, connections idle for longer get closed by database/sql instead
+// of being used after an idle timeout dropped them.
+func (gdb *GeneralDatabase) startKeepalive() {
+	if gdb.stopKeepalive != nil {
+		gdb.stopKeepalive()
+		gdb.stopKeepalive = nil
+	}
+	if gdb.Keepalive <= 0 {
+		return
+	}
+
+	gdb.DB.SetConnMaxIdleTime(gdb.Keepalive)
+	ctx, cancel := context.WithCancel(context.Background())
+	gdb.stopKeepalive = cancel
+
+	db := gdb.DB
+	go func() {
+		ticker := time.NewTicker(gdb.Keepalive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			pingCtx, cancel := gdb.connectContext(ctx)
+			err := db.PingContext(pingCtx)
+			cancel()
+			if err != nil && ctx.Err() == nil {
+				slog.Warn("keepalive ping of the database failed", "error", err)
+			}
+		}
+	}()
+}
+
+// configurePool applies the settings of the connection pool, the ones left
+// zero keep the defaults of database/sql.
+func (gdb *GeneralDatabase) configurePool() {
+	if gdb.MaxOpenConns > 0 {
+		gdb.DB.SetMaxOpenConns(gdb.MaxOpenConns)
+	}
+	if gdb.MaxIdleConns > 0 {
+		gdb.DB.SetMaxIdleConns(gdb.MaxIdleConns)
+	}
+	if gdb.ConnMaxLifetime > 0 {
+		gdb.DB.SetConnMaxLifetime(gdb.ConnMaxLifetime)
+	}
+}
+
+// beginSnapshot begins the transaction of the snapshot with the options of
+// the dialect.
+func (gdb *GeneralDatabase) beginSnapshot(ctx context.Context, opts *sql.TxOptions) error {
+	if gdb.tx != nil {
+		return fmt.Errorf("snapshot already begun")
+	}
+	tx, err := gdb.DB.BeginTxx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("could not begin the snapshot: %w", err)
+	}
+	gdb.tx = tx
+	return nil
+}
+
+// EndSnapshot ends the snapshot begun by the BeginSnapshot of the dialect,
+// the statement prepared in it gets closed. Nothing got written, hence the
+// transaction gets rolled back.
+func (gdb *GeneralDatabase) EndSnapshot() error {
+	if gdb.tx == nil {
+		return nil
+	}
+	if gdb.GetColumnsOfTableStmt != nil {
+		if err := gdb.GetColumnsOfTableStmt.Close(); err != nil {
+			return err
+		}
+		gdb.GetColumnsOfTableStmt = nil
+	}
+	err := gdb.tx.Rollback()
+	gdb.tx = nil
+	if err != nil {
+		return fmt.Errorf("could not end the snapshot: %w", err)
+	}
+	return nil
+}
+
+// SelectContext runs the query like the one of sqlx.DB, in the snapshot if
+// one is begun.
+func (gdb *GeneralDatabase) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	if gdb.tx != nil {
+		return gdb.tx.SelectContext(ctx, dest, query, args...)
+	}
+	return gdb.DB.SelectContext(ctx, dest, query, args...)
+}
+
+// GetContext runs the query like the one of sqlx.DB, in the snapshot if one
+// is begun.
+func (gdb *GeneralDatabase) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	if gdb.tx != nil {
+		return gdb.tx.GetContext(ctx, dest, query, args...)
+	}
+	return gdb.DB.GetContext(ctx, dest, query, args...)
+}
+
+// QueryxContext runs the query like the one of sqlx.DB, in the snapshot if
+// one is begun.
+func (gdb *GeneralDatabase) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	if gdb.tx != nil {
+		return gdb.tx.QueryxContext(ctx, query, args...)
+	}
+	return gdb.DB.QueryxContext(ctx, query, args...)
+}
+
+// PreparexContext prepares the statement like the one of sqlx.DB, in the
+// snapshot if one is begun.
+func (gdb *GeneralDatabase) PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error) {
+	if gdb.tx != nil {
+		return gdb.tx.PreparexContext(ctx, query)
+	}
+	return gdb.DB.PreparexContext(ctx, query)
 }
 
-// Close closes the database connection.
+// Close stops the keepalive and closes the prepared statement and the
+// database connection.
 func (gdb *GeneralDatabase) Close() error {
+	if gdb.stopKeepalive != nil {
+		gdb.stopKeepalive()
+		gdb.stopKeepalive = nil
+	}
+	gdb.connected = false
+	if err := gdb.EndSnapshot(); err != nil {
+		return err
+	}
+	if gdb.GetColumnsOfTableStmt != nil {
+		if err := gdb.GetColumnsOfTableStmt.Close(); err != nil {
+			return err
+		}
+		gdb.GetColumnsOfTableStmt = nil
+	}
+	if gdb.DB == nil {
+		return nil
+	}
 	return gdb.DB.Close()
 }
 
@@ -133,6 +655,40 @@ func (gdb *GeneralDatabase) IsNullable(column Column) bool {
 	return column.IsNullable == "YES"
 }
 
+// parseEnumValues parses the values of an enum definition like
+// enum('a','b','c').
+func parseEnumValues(definition string) []string {
+	definition = strings.TrimSpace(definition)
+	if !strings.HasPrefix(strings.ToLower(definition), "enum(") || !strings.HasSuffix(definition, ")") {
+		return nil
+	}
+	definition = definition[len("enum(") : len(definition)-1]
+
+	var (
+		values  []string
+		value   strings.Builder
+		inQuote bool
+	)
+	for i := 0; i < len(definition); i++ {
+		c := definition[i]
+		switch {
+		case c == '\'' && inQuote && i+1 < len(definition) && definition[i+1] == '\'':
+			// escaped quote
+			value.WriteByte(c)
+			i++
+		case c == '\'':
+			if inQuote {
+				values = append(values, value.String())
+				value.Reset()
+			}
+			inQuote = !inQuote
+		case inQuote:
+			value.WriteByte(c)
+		}
+	}
+	return values
+}
+
 // isStringInSlice checks if needle (string) is in haystack ([]string).
 func isStringInSlice(needle string, haystack []string) bool {
 	for _, s := range haystack {
@@ -143,6 +699,47 @@ func isStringInSlice(needle string, haystack []string) bool {
 	return false
 }
 
+// UnquoteIdentifier returns the name of a table given in double quotes or
+// backticks without them and true, or the name as given and false. Quoted
+// names of tables to get match exactly, the others as the database folds
+// unquoted identifiers.
+func UnquoteIdentifier(name string) (string, bool) {
+	for _, quote := range []string{`"`, "`"} {
+		if len(name) >= 2 && strings.HasPrefix(name, quote) && strings.HasSuffix(name, quote) {
+			return strings.ReplaceAll(name[1:len(name)-1], quote+quote, quote), true
+		}
+	}
+	return name, false
+}
+
+// andTablesClause returns the condition for the tables to get by their names
+// by andInClause: the names folded by fold matched by foldedField, the quoted
+// ones without their quotes matched by exactField.
+func andTablesClause(andInClause func(string, []string, *[]any) string, foldedField, exactField string, fold func(string) string, tables []string, args *[]any) string {
+	var folded, exact []string
+	for _, table := range tables {
+		if name, quoted := UnquoteIdentifier(table); quoted {
+			exact = append(exact, name)
+		} else {
+			folded = append(folded, fold(name))
+		}
+	}
+
+	var conditions []string
+	for _, in := range []string{andInClause(foldedField, folded, args), andInClause(exactField, exact, args)} {
+		if in != "" {
+			conditions = append(conditions, strings.TrimPrefix(in, "AND "))
+		}
+	}
+	switch len(conditions) {
+	case 0:
+		return ""
+	case 1:
+		return "AND " + conditions[0]
+	}
+	return "AND (" + strings.Join(conditions, " OR ") + ")"
+}
+
 func (*GeneralDatabase) andInClause(field string, params []string, args *[]any) string {
 	if field == "" || len(params) == 0 {
 		return ""
@@ -1,9 +1,18 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
 )
 
 func TestGeneralDatabase_andInClause(t *testing.T) {
@@ -73,3 +82,494 @@ func TestGeneralDatabase_andInClause(t *testing.T) {
 		})
 	}
 }
+
+func TestUnquoteIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc           string
+		name           string
+		expected       string
+		expectedQuoted bool
+	}{
+		{
+			desc:     "unquoted",
+			name:     "OrderItems",
+			expected: "OrderItems",
+		},
+		{
+			desc:           "double quotes",
+			name:           `"OrderItems"`,
+			expected:       "OrderItems",
+			expectedQuoted: true,
+		},
+		{
+			desc:           "backticks",
+			name:           "`order`",
+			expected:       "order",
+			expectedQuoted: true,
+		},
+		{
+			desc:           "doubled quotes inside",
+			name:           `"say ""hi"""`,
+			expected:       `say "hi"`,
+			expectedQuoted: true,
+		},
+		{
+			desc:     "single quote only",
+			name:     `"`,
+			expected: `"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual, quoted := UnquoteIdentifier(test.name)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.expectedQuoted, quoted)
+		})
+	}
+}
+
+func TestAndTablesClause(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc         string
+		tables       []string
+		expected     string
+		expectedArgs []any
+	}{
+		{
+			desc:         "no tables",
+			expected:     "",
+			expectedArgs: []any{"public"},
+		},
+		{
+			desc:         "unquoted names folded",
+			tables:       []string{"Users", "orders"},
+			expected:     "AND LOWER(table_name) IN ($2,$3)",
+			expectedArgs: []any{"public", "users", "orders"},
+		},
+		{
+			desc:         "quoted names exactly",
+			tables:       []string{`"Users"`},
+			expected:     "AND table_name IN ($2)",
+			expectedArgs: []any{"public", "Users"},
+		},
+		{
+			desc:         "both",
+			tables:       []string{`"Users"`, "Orders", "`select`"},
+			expected:     "AND (LOWER(table_name) IN ($2) OR table_name IN ($3,$4))",
+			expectedArgs: []any{"public", "orders", "Users", "select"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			args := []any{"public"}
+			pg := &Postgresql{}
+			actual := andTablesClause(pg.andInClause, "LOWER(table_name)", "table_name", strings.ToLower, test.tables, &args)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.expectedArgs, args)
+		})
+	}
+}
+
+func TestTableTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc         string
+		includeViews bool
+		onlyViews    bool
+		supported    []string
+		expected     []string
+		expectedIn   string
+	}{
+		{
+			desc:       "tables by default",
+			supported:  []string{TableTypeBase, TableTypeView},
+			expected:   []string{TableTypeBase},
+			expectedIn: "table_type IN ('BASE TABLE')",
+		},
+		{
+			desc:         "include views",
+			includeViews: true,
+			supported:    []string{TableTypeBase, TableTypeView, TableTypeMaterializedView},
+			expected:     []string{TableTypeBase, TableTypeView, TableTypeMaterializedView},
+			expectedIn:   "table_type IN ('BASE TABLE', 'VIEW', 'MATERIALIZED VIEW')",
+		},
+		{
+			desc:       "only the supported views",
+			onlyViews:  true,
+			supported:  []string{TableTypeBase, TableTypeView},
+			expected:   []string{TableTypeView},
+			expectedIn: "table_type IN ('VIEW')",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.IncludeViews = test.includeViews
+			s.OnlyViews = test.onlyViews
+			actual := tableTypes(s, test.supported...)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.expectedIn, inTableTypes("table_type", actual))
+		})
+	}
+}
+
+func TestParseEnumValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected []string
+	}{
+		{
+			desc:     "no enum definition returns no values",
+			input:    "varchar(20)",
+			expected: nil,
+		},
+		{
+			desc:     "single value",
+			input:    "enum('a')",
+			expected: []string{"a"},
+		},
+		{
+			desc:     "multiple values",
+			input:    "enum('small','medium','large')",
+			expected: []string{"small", "medium", "large"},
+		},
+		{
+			desc:     "values with commas, spaces and escaped quotes",
+			input:    "ENUM('a,b','c d','it''s')",
+			expected: []string{"a,b", "c d", "it's"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual := parseEnumValues(tt.input)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+// fakeDialect is a dialect registered by a third party.
+type fakeDialect struct {
+	*SQLite
+}
+
+func TestRegister(t *testing.T) {
+	Register("fake", func(s *settings.Settings) Database {
+		return fakeDialect{NewSQLite(s)}
+	})
+
+	s := settings.New()
+	assert.NoError(t, s.DbType.Set("fake"))
+	assert.Contains(t, settings.SprintfSupportedDbTypes(), "fake")
+	assert.IsType(t, fakeDialect{}, New(s))
+
+	s.DbType = settings.DBTypeMySQL
+	assert.IsType(t, &MySQL{}, New(s))
+	s.DbType = settings.DBTypeCockroachDB
+	assert.IsType(t, &CockroachDB{}, New(s))
+	s.DbType = settings.DBTypeMariaDB
+	assert.IsType(t, &MariaDB{}, New(s))
+
+	assert.Panics(t, func() { Register("fake", New) })
+	assert.Panics(t, func() { Register(string(settings.DBTypePostgresql), New) })
+	assert.Panics(t, func() { Register("", New) })
+	assert.Panics(t, func() { Register("nil", nil) })
+}
+
+func TestAs(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	s.DbType = settings.DBTypeMySQL
+	mysql := NewMySQL(s)
+	tunnel := NewSSHTunnel(mysql, s)
+
+	snapshotter, ok := As[Snapshotter](tunnel)
+	assert.True(t, ok)
+	assert.Same(t, mysql, snapshotter)
+
+	wrapper, ok := As[Wrapper](tunnel)
+	assert.True(t, ok)
+	assert.Same(t, tunnel, wrapper)
+
+	_, ok = As[JobsLimiter](tunnel)
+	assert.False(t, ok)
+	_, ok = As[Snapshotter](NewSQLite(s))
+	assert.False(t, ok)
+}
+
+func TestGroupColumns(t *testing.T) {
+	t.Parallel()
+
+	users := &Table{Name: "users", Columns: []Column{{Name: "stale"}}}
+	orders := &Table{Name: "orders"}
+	empty := &Table{Name: "empty"}
+
+	groupColumns([]*Table{users, orders, empty}, []tableColumn{
+		{TableName: "orders", Column: Column{OrdinalPosition: 1, Name: "id"}},
+		{TableName: "orders", Column: Column{OrdinalPosition: 2, Name: "user_id"}},
+		{TableName: "payments", Column: Column{OrdinalPosition: 1, Name: "id"}},
+		{TableName: "users", Column: Column{OrdinalPosition: 1, Name: "id"}},
+	})
+
+	assert.Equal(t, []Column{{OrdinalPosition: 1, Name: "id"}}, users.Columns)
+	assert.Equal(t, []Column{{OrdinalPosition: 1, Name: "id"}, {OrdinalPosition: 2, Name: "user_id"}}, orders.Columns)
+	assert.Empty(t, empty.Columns)
+}
+
+func TestGroupForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, groupForeignKeys(nil))
+	assert.Equal(t, []ForeignKey{
+		{Name: "fk_author", Columns: []string{"author_id"}, ReferencedTable: "authors", ReferencedColumns: []string{"id"}},
+		{
+			Name: "fk_order_line", Columns: []string{"order_id", "line"},
+			ReferencedTable: "order_lines", ReferencedColumns: []string{"order_id", "position"},
+		},
+	}, groupForeignKeys([]foreignKeyColumn{
+		{ConstraintName: "fk_author", ColumnName: "author_id", ReferencedTableName: "authors", ReferencedColumnName: "id"},
+		{ConstraintName: "fk_order_line", ColumnName: "order_id", ReferencedTableName: "order_lines", ReferencedColumnName: "order_id"},
+		{ConstraintName: "fk_order_line", ColumnName: "line", ReferencedTableName: "order_lines", ReferencedColumnName: "position"},
+	}))
+}
+
+func TestMergeColumns(t *testing.T) {
+	t.Parallel()
+
+	valid := func(s string) sql.NullString {
+		return sql.NullString{String: s, Valid: true}
+	}
+	merged := []Column{
+		{
+			OrdinalPosition: 1, Name: "account_id", ConstraintName: valid("memberships_pkey"), ConstraintType: valid("PRIMARY KEY"),
+			Constraints: []Constraint{{Name: "memberships_account_id_fkey", Type: "FOREIGN KEY"}, {Name: "memberships_pkey", Type: "PRIMARY KEY"}},
+		},
+		{OrdinalPosition: 2, Name: "role"},
+	}
+
+	tests := []struct {
+		desc     string
+		columns  []Column
+		expected []Column
+	}{
+		{
+			desc: "once per constraint, the primary key preferred",
+			columns: []Column{
+				{OrdinalPosition: 1, Name: "account_id", ConstraintName: valid("memberships_account_id_fkey"), ConstraintType: valid("FOREIGN KEY")},
+				{OrdinalPosition: 1, Name: "account_id", ConstraintName: valid("memberships_pkey"), ConstraintType: valid("PRIMARY KEY")},
+				{OrdinalPosition: 2, Name: "role"},
+			},
+			expected: merged,
+		},
+		{
+			desc:     "merged columns stay the same",
+			columns:  merged,
+			expected: merged,
+		},
+		{
+			desc: "the same constraint once",
+			columns: []Column{
+				{OrdinalPosition: 1, Name: "id", ConstraintName: valid("users_pkey"), ConstraintType: valid("PRIMARY KEY")},
+				{OrdinalPosition: 1, Name: "id", ConstraintName: valid("users_pkey"), ConstraintType: valid("PRIMARY KEY")},
+			},
+			expected: []Column{
+				{
+					OrdinalPosition: 1, Name: "id", ConstraintName: valid("users_pkey"), ConstraintType: valid("PRIMARY KEY"),
+					Constraints: []Constraint{{Name: "users_pkey", Type: "PRIMARY KEY"}},
+				},
+			},
+		},
+		{
+			desc: "constraints without names",
+			columns: []Column{
+				{OrdinalPosition: 1, Name: "tenant_id", ConstraintType: valid("FOREIGN KEY")},
+				{OrdinalPosition: 1, Name: "tenant_id", ConstraintType: valid("PRIMARY KEY")},
+			},
+			expected: []Column{
+				{
+					OrdinalPosition: 1, Name: "tenant_id", ConstraintType: valid("PRIMARY KEY"),
+					Constraints: []Constraint{{Type: "FOREIGN KEY"}, {Type: "PRIMARY KEY"}},
+				},
+			},
+		},
+		{
+			desc: "keys of MySQL without constraints",
+			columns: []Column{
+				{OrdinalPosition: 1, Name: "id", ColumnKey: "PRI"},
+				{OrdinalPosition: 2, Name: "email", ColumnKey: "UNI"},
+			},
+			expected: []Column{
+				{OrdinalPosition: 1, Name: "id", ColumnKey: "PRI"},
+				{OrdinalPosition: 2, Name: "email", ColumnKey: "UNI"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, MergeColumns(test.columns))
+		})
+	}
+}
+
+func TestGeneralDatabase_Connect_pool(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	s.MaxOpenConns = 3
+	s.MaxIdleConns = 2
+	s.ConnMaxLifetime = time.Minute
+	gdb := &GeneralDatabase{Settings: s, driver: "querylog-test"}
+	assert.NoError(t, gdb.Connect(context.Background(), ""))
+	defer gdb.Close()
+
+	assert.Equal(t, 3, gdb.Stats().MaxOpenConnections)
+
+	// zero keeps the defaults
+	s = settings.New()
+	gdb = &GeneralDatabase{Settings: s, driver: "querylog-test"}
+	assert.NoError(t, gdb.Connect(context.Background(), ""))
+	defer gdb.Close()
+
+	assert.Equal(t, 0, gdb.Stats().MaxOpenConnections)
+}
+
+// pingConn counts the pings of its connections.
+type pingConn struct {
+	fakeConn
+	pings *atomic.Int64
+}
+
+func (c pingConn) Ping(context.Context) error {
+	c.pings.Add(1)
+	return nil
+}
+
+func TestGeneralDatabase_Connect_keepalive(t *testing.T) {
+	t.Parallel()
+
+	var pings atomic.Int64
+	s := settings.New()
+	s.Keepalive = 5 * time.Millisecond
+	gdb := &GeneralDatabase{Settings: s}
+	gdb.DB = sqlx.NewDb(sql.OpenDB(pingConnector{pings: &pings}), "ping-test")
+	gdb.startKeepalive()
+
+	assert.Eventually(t, func() bool { return pings.Load() >= 2 }, time.Second, time.Millisecond)
+	assert.NoError(t, gdb.Close())
+
+	// no pings after Close
+	closed := pings.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, closed, pings.Load())
+
+	// disabled by default
+	gdb = &GeneralDatabase{Settings: settings.New(), driver: "querylog-test"}
+	assert.NoError(t, gdb.Connect(context.Background(), ""))
+	assert.Nil(t, gdb.stopKeepalive)
+	assert.NoError(t, gdb.Close())
+}
+
+type pingConnector struct {
+	pings *atomic.Int64
+}
+
+func (c pingConnector) Connect(context.Context) (driver.Conn, error) {
+	return pingConn{pings: c.pings}, nil
+}
+
+func (pingConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+// txConn supports transactions and records the queries run in them.
+type txConn struct {
+	fakeConn
+
+	opts       driver.TxOptions
+	inTx       bool
+	queriesTx  int
+	rolledBack bool
+}
+
+func (c *txConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.opts = opts
+	c.inTx = true
+	return c, nil
+}
+
+func (c *txConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.inTx {
+		c.queriesTx++
+	}
+	return c.fakeConn.QueryContext(ctx, query, args)
+}
+
+func (c *txConn) Commit() error {
+	c.inTx = false
+	return nil
+}
+
+func (c *txConn) Rollback() error {
+	c.inTx = false
+	c.rolledBack = true
+	return nil
+}
+
+// txConnector always returns the same connection.
+type txConnector struct {
+	conn *txConn
+}
+
+func (c txConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c txConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+func TestMySQL_BeginSnapshot(t *testing.T) {
+	t.Parallel()
+
+	conn := &txConn{}
+	db := sql.OpenDB(txConnector{conn: conn})
+	db.SetMaxOpenConns(1)
+	mysql := NewMySQL(settings.New())
+	mysql.DB = sqlx.NewDb(db, "mysql")
+	defer mysql.Close()
+
+	ctx := context.Background()
+	var n int64
+	assert.NoError(t, mysql.GetContext(ctx, &n, "SELECT n FROM t"))
+	assert.Equal(t, 0, conn.queriesTx)
+
+	assert.NoError(t, mysql.BeginSnapshot(ctx))
+	assert.Equal(t, driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelRepeatableRead), ReadOnly: true}, conn.opts)
+	assert.EqualError(t, mysql.BeginSnapshot(ctx), "snapshot already begun")
+
+	// the queries run in the snapshot
+	assert.NoError(t, mysql.GetContext(ctx, &n, "SELECT n FROM t"))
+	var ns []int64
+	assert.NoError(t, mysql.SelectContext(ctx, &ns, "SELECT n FROM t WHERE a = ?", "users"))
+	assert.Equal(t, []int64{1}, ns)
+	assert.Equal(t, 2, conn.queriesTx)
+
+	assert.NoError(t, mysql.EndSnapshot())
+	assert.True(t, conn.rolledBack)
+	assert.NoError(t, mysql.EndSnapshot())
+
+	assert.NoError(t, mysql.GetContext(ctx, &n, "SELECT n FROM t"))
+	assert.Equal(t, 2, conn.queriesTx)
+}
@@ -0,0 +1,167 @@
+// Package fake implements the Database interface by tables given as
+// fixtures, so applications embedding tables-to-go, and its own tests, can
+// generate without any real database or driver.
+//
+//	s := settings.New()
+//	db := fake.New(s, &database.Table{
+//		Name: "users",
+//		Columns: []database.Column{
+//			{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+//		},
+//	})
+//	tables, err := tablestogo.Introspect(ctx, s, db)
+package fake
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Database implements the Database interface by the fixtures. It maps the
+// columns to Go types like the database type of the settings, the columns
+// are given as that database returns them: once per constraint they are part
+// of.
+type Database struct {
+	database.Database
+	tables []*database.Table
+
+	mu        sync.Mutex
+	errs      map[string]error
+	latency   time.Duration
+	connected bool
+}
+
+// New creates the Database of the tables, their columns are the fixtures of
+// their columns.
+func New(s *settings.Settings, tables ...*database.Table) *Database {
+	return &Database{
+		Database: database.New(s),
+		tables:   tables,
+		errs:     map[string]error{},
+	}
+}
+
+// FailColumns lets reading the columns of the table fail with the error,
+// e.g. to test the handling of missing permissions.
+func (db *Database) FailColumns(table string, err error) *Database {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.errs[table] = err
+	return db
+}
+
+// Latency delays reading the columns of every table by the duration, e.g. to
+// benchmark the introspection of remote databases.
+func (db *Database) Latency(latency time.Duration) *Database {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.latency = latency
+	return db
+}
+
+// Connected returns true between Connect and Close.
+func (db *Database) Connected() bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.connected
+}
+
+// DSN returns the name of the fake database.
+func (db *Database) DSN() string {
+	return "fake"
+}
+
+// Connect does nothing but marks the Database connected.
+func (db *Database) Connect(context.Context) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.connected = true
+	return nil
+}
+
+// Close does nothing but marks the Database not connected.
+func (db *Database) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.connected = false
+	return nil
+}
+
+// GetTables returns the tables, or only the given ones matched exactly,
+// quoted or not, without their columns.
+func (db *Database) GetTables(_ context.Context, tables ...string) ([]*database.Table, error) {
+	result := make([]*database.Table, 0, len(db.tables))
+	for _, table := range db.tables {
+		if len(tables) > 0 && !slices.ContainsFunc(tables, func(name string) bool {
+			name, _ = database.UnquoteIdentifier(name)
+			return name == table.Name
+		}) {
+			continue
+		}
+		result = append(result, &database.Table{
+			Name:    table.Name,
+			Comment: table.Comment,
+		})
+	}
+	return result, nil
+}
+
+// PrepareGetColumnsOfTableStmt does nothing, the columns are known.
+func (db *Database) PrepareGetColumnsOfTableStmt(context.Context) error {
+	return nil
+}
+
+// GetColumnsOfTable sets a copy of the columns of the table, or fails as
+// given by FailColumns, after the Latency.
+func (db *Database) GetColumnsOfTable(ctx context.Context, table *database.Table) error {
+	db.mu.Lock()
+	err := db.errs[table.Name]
+	latency := db.latency
+	db.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fixture, err := db.table(table.Name)
+	if err != nil {
+		return err
+	}
+	table.Columns = slices.Clone(fixture.Columns)
+	return nil
+}
+
+// GetTableInfo is the implementation of the TableInfoGetter interface, the
+// number of rows is unknown.
+func (db *Database) GetTableInfo(_ context.Context, table *database.Table) (database.TableInfo, error) {
+	fixture, err := db.table(table.Name)
+	if err != nil {
+		return database.TableInfo{}, err
+	}
+	return database.TableInfo{Rows: sql.NullInt64{}, Comment: fixture.Comment}, nil
+}
+
+// table returns the fixture of the table.
+func (db *Database) table(name string) (*database.Table, error) {
+	i := slices.IndexFunc(db.tables, func(t *database.Table) bool { return t.Name == name })
+	if i < 0 {
+		return nil, fmt.Errorf("table %q not found", name)
+	}
+	return db.tables[i], nil
+}
@@ -0,0 +1,82 @@
+package fake
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestDatabase(t *testing.T) {
+	columns := []database.Column{
+		{OrdinalPosition: 1, Name: "id", DataType: "int", IsNullable: "NO", ColumnKey: "PRI", Extra: "auto_increment"},
+		{OrdinalPosition: 2, Name: "email", DataType: "varchar", IsNullable: "YES"},
+	}
+	comment := sql.NullString{String: "users of the shop", Valid: true}
+
+	s := settings.New()
+	s.DbType = settings.DBTypeMySQL
+	db := New(s,
+		&database.Table{Name: "users", Comment: comment, Columns: columns},
+		&database.Table{Name: "audit_log"},
+	).FailColumns("audit_log", errors.New("permission denied"))
+
+	ctx := context.Background()
+	assert.False(t, db.Connected())
+	assert.NoError(t, db.Connect(ctx))
+	assert.True(t, db.Connected())
+
+	tables, err := db.GetTables(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*database.Table{{Name: "users", Comment: comment}, {Name: "audit_log"}}, tables)
+
+	tables, err = db.GetTables(ctx, "users", "orders")
+	assert.NoError(t, err)
+	assert.Equal(t, []*database.Table{{Name: "users", Comment: comment}}, tables)
+
+	assert.NoError(t, db.PrepareGetColumnsOfTableStmt(ctx))
+	assert.NoError(t, db.GetColumnsOfTable(ctx, tables[0]))
+	assert.Equal(t, columns, tables[0].Columns)
+
+	// the columns map like the ones of the database type
+	assert.True(t, db.IsAutoIncrement(tables[0].Columns[0]))
+	assert.True(t, db.IsString(tables[0].Columns[1]))
+
+	// the fixtures are not changed by the caller
+	tables[0].Columns[0].Name = "changed"
+	assert.Equal(t, "id", columns[0].Name)
+
+	err = db.GetColumnsOfTable(ctx, &database.Table{Name: "audit_log"})
+	assert.EqualError(t, err, "permission denied")
+	err = db.GetColumnsOfTable(ctx, &database.Table{Name: "orders"})
+	assert.EqualError(t, err, `table "orders" not found`)
+
+	info, err := db.GetTableInfo(ctx, &database.Table{Name: "users"})
+	assert.NoError(t, err)
+	assert.Equal(t, database.TableInfo{Comment: comment}, info)
+
+	assert.NoError(t, db.Close())
+	assert.False(t, db.Connected())
+}
+
+func TestDatabase_Latency(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	db := New(s, &database.Table{Name: "users"}).Latency(20 * time.Millisecond)
+
+	start := time.Now()
+	assert.NoError(t, db.GetColumnsOfTable(context.Background(), &database.Table{Name: "users"}))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := db.GetColumnsOfTable(ctx, &database.Table{Name: "users"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// MariaDB implements the Database interface with help of MySQL, whose
+// protocol and information_schema MariaDB speaks.
+type MariaDB struct {
+	*MySQL
+}
+
+// NewMariaDB creates a new MariaDB database.
+func NewMariaDB(s *settings.Settings) *MariaDB {
+	return &MariaDB{MySQL: NewMySQL(s)}
+}
+
+// GetColumnsOfTable executes the statement for retrieving the columns of a
+// specific table for a given database.
+func (m *MariaDB) GetColumnsOfTable(ctx context.Context, table *Table) error {
+	if err := m.MySQL.GetColumnsOfTable(ctx, table); err != nil {
+		return err
+	}
+	mariaDBDefaults(table.Columns)
+	return nil
+}
+
+// GetColumnsOfTables is the implementation of the ColumnsBatchGetter
+// interface, it gets the columns of all tables in one query.
+func (m *MariaDB) GetColumnsOfTables(ctx context.Context, tables []*Table) error {
+	if err := m.MySQL.GetColumnsOfTables(ctx, tables); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		mariaDBDefaults(table.Columns)
+	}
+	return nil
+}
+
+// IsAutoIncrement checks if the column is an auto increment column or takes
+// its default from a sequence.
+func (m *MariaDB) IsAutoIncrement(column Column) bool {
+	return m.MySQL.IsAutoIncrement(column) || strings.HasPrefix(strings.ToLower(column.DefaultValue.String), "nextval(")
+}
+
+// mariaDBDefaults unsets the defaults of the columns without one, MariaDB
+// reports them as the expression NULL. The other defaults are expressions as
+// well, string literals are quoted unlike the ones of MySQL.
+func mariaDBDefaults(columns []Column) {
+	for i := range columns {
+		if columns[i].DefaultValue.String == "NULL" {
+			columns[i].DefaultValue.Valid = false
+			columns[i].DefaultValue.String = ""
+		}
+	}
+}
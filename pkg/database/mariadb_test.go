@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestMariaDB_IsAutoIncrement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		column   Column
+		expected bool
+	}{
+		{
+			desc:     "auto_increment",
+			column:   Column{Extra: "auto_increment"},
+			expected: true,
+		},
+		{
+			desc:     "sequence",
+			column:   Column{DefaultValue: sql.NullString{String: "nextval(`db`.`users_seq`)", Valid: true}},
+			expected: true,
+		},
+		{
+			desc:     "plain default",
+			column:   Column{DefaultValue: sql.NullString{String: "'foo'", Valid: true}},
+			expected: false,
+		},
+	}
+
+	m := NewMariaDB(settings.New())
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, m.IsAutoIncrement(test.column))
+		})
+	}
+}
+
+func TestMariaDBDefaults(t *testing.T) {
+	t.Parallel()
+
+	columns := []Column{
+		{DefaultValue: sql.NullString{String: "NULL", Valid: true}},
+		{DefaultValue: sql.NullString{String: "'foo'", Valid: true}},
+		{},
+	}
+	mariaDBDefaults(columns)
+
+	assert.Equal(t, sql.NullString{}, columns[0].DefaultValue)
+	assert.Equal(t, sql.NullString{String: "'foo'", Valid: true}, columns[1].DefaultValue)
+	assert.Equal(t, sql.NullString{}, columns[2].DefaultValue)
+}
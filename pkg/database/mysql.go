@@ -1,7 +1,10 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
@@ -15,6 +18,9 @@ type MySQL struct {
 	*GeneralDatabase
 
 	defaultUserName string
+
+	// server is the version of the server connected to
+	server mysqlServer
 }
 
 // NewMySQL creates a new MySQL database.
@@ -30,92 +36,236 @@ func NewMySQL(s *settings.Settings) *MySQL {
 
 // Connect connects to the database by the given data source name (dsn) of the
 // concrete database.
-func (mysql *MySQL) Connect() error {
-	return mysql.GeneralDatabase.Connect(mysql.DSN())
+// The database of a raw DSN is taken over as the one to introspect.
+func (mysql *MySQL) Connect(ctx context.Context) error {
+	if err := registerMySQLTLS(mysql.Settings); err != nil {
+		return err
+	}
+
+	dsn := mysql.DSN()
+	if err := mysql.GeneralDatabase.Connect(ctx, dsn); err != nil {
+		return err
+	}
+
+	if err := mysql.detectServer(ctx, dsn); err != nil {
+		return err
+	}
+
+	if mysql.DbName == "" {
+		var dbName sql.NullString
+		if err := mysql.GetContext(ctx, &dbName, "SELECT DATABASE()"); err != nil {
+			return fmt.Errorf("could not get the database of the connection: %w", err)
+		}
+		mysql.DbName = dbName.String
+	}
+
+	return nil
 }
 
 // DSN creates the DSN String to connect to this database.
 func (mysql *MySQL) DSN() string {
+	if mysql.Settings.DSN != "" {
+		return mysql.Settings.DSN
+	}
 	user := mysql.defaultUserName
 	if mysql.Settings.User != "" {
 		user = mysql.Settings.User
 	}
 
+	var dsn string
 	if mysql.Settings.Socket != "" {
-		return fmt.Sprintf("%s:%s@unix(%s)/%s",
+		dsn = fmt.Sprintf("%s:%s@unix(%s)/%s",
 			user, mysql.Settings.Pswd, mysql.Settings.Socket, mysql.Settings.DbName)
+	} else {
+		dsn = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
+			user, mysql.Settings.Pswd, mysql.Settings.Host, mysql.Settings.Port, mysql.Settings.DbName)
+	}
+	if tls := mysqlTLS(mysql.Settings); tls != "" {
+		dsn = withDSNParam(dsn, "tls", tls)
 	}
-	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
-		user, mysql.Settings.Pswd, mysql.Settings.Host, mysql.Settings.Port, mysql.Settings.DbName)
+	if mysql.Settings.UsesAuthToken() {
+		// the token gets sent as the password, protected by TLS
+		dsn = withDSNParam(dsn, "allowCleartextPasswords", "true")
+	}
+	return dsn
 }
 
 // GetTables gets all tables for a given database by name.
-func (mysql *MySQL) GetTables(tables ...string) ([]*Table, error) {
+func (mysql *MySQL) GetTables(ctx context.Context, tables ...string) ([]*Table, error) {
 
+	// the collation of the information_schema decides on the case of the
+	// unquoted names
 	args := []any{mysql.DbName}
-	in := mysql.andInClause("table_name", tables, &args)
+	keep := func(name string) string { return name }
+	in := andTablesClause(mysql.andInClause, "table_name", "CAST(table_name AS BINARY)", keep, tables, &args)
 
 	var dbTables []*Table
-	err := mysql.Select(&dbTables, `
-		SELECT table_name AS table_name
-		FROM information_schema.tables
-		WHERE table_type = 'BASE TABLE'
-		AND table_schema = ?
-		`+in+`
-		ORDER BY table_name
-	`, args...)
-
-	if mysql.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> schema: %q\r\n", mysql.DbName)
-		}
+	err := mysql.retry(ctx, func() error {
+		dbTables = nil
+		return mysql.SelectContext(ctx, &dbTables, `
+			SELECT table_name AS table_name, table_type AS table_type, table_comment AS table_comment
+			FROM information_schema.tables
+			WHERE `+inTableTypes("table_type", tableTypes(mysql.Settings, TableTypeBase, TableTypeView))+`
+			AND table_schema = ?
+			`+in+`
+			ORDER BY table_name
+		`, args...)
+	})
+
+	if err != nil {
+		slog.Debug("could not get tables", "schema", mysql.DbName, "error", err)
 	}
 
 	return dbTables, err
 }
 
+// GetTableInfo gets the estimated number of rows and the comment of the table.
+func (mysql *MySQL) GetTableInfo(ctx context.Context, table *Table) (info TableInfo, err error) {
+	err = mysql.retry(ctx, func() error {
+		return mysql.GetContext(ctx, &info, `
+			SELECT table_rows AS table_rows, table_comment AS table_comment
+			FROM information_schema.tables
+			WHERE table_schema = ?
+			AND table_name = ?
+		`, mysql.DbName, table.Name)
+	})
+	if err != nil {
+		return info, fmt.Errorf("could not get info of table %q: %w", table.Name, err)
+	}
+	return info, nil
+}
+
+// GetForeignKeysOfTable is the implementation of the ForeignKeysGetter
+// interface.
+func (mysql *MySQL) GetForeignKeysOfTable(ctx context.Context, table *Table) error {
+	var rows []foreignKeyColumn
+	err := mysql.retry(ctx, func() error {
+		rows = nil
+		return mysql.SelectContext(ctx, &rows, `
+			SELECT
+				constraint_name AS constraint_name,
+				column_name AS column_name,
+				referenced_table_name AS referenced_table_name,
+				referenced_column_name AS referenced_column_name
+			FROM information_schema.key_column_usage
+			WHERE table_schema = ?
+			AND table_name = ?
+			AND referenced_table_name IS NOT NULL
+			ORDER BY constraint_name, ordinal_position
+		`, mysql.DbName, table.Name)
+	})
+	if err != nil {
+		return fmt.Errorf("could not get foreign keys of table %q: %w", table.Name, err)
+	}
+	table.ForeignKeys = groupForeignKeys(rows)
+	return nil
+}
+
 // PrepareGetColumnsOfTableStmt prepares the statement for retrieving the
-// columns of a specific table for a given database.
-func (mysql *MySQL) PrepareGetColumnsOfTableStmt() (err error) {
-
-	mysql.GetColumnsOfTableStmt, err = mysql.Preparex(`
-		SELECT
-		  ordinal_position AS ordinal_position,
-		  column_name AS column_name,
-		  data_type AS data_type,
-		  column_default AS column_default,
-		  is_nullable AS is_nullable,
-		  character_maximum_length AS character_maximum_length,
-		  numeric_precision AS numeric_precision,
-		  column_key AS column_key,
-		  extra AS extra
-		FROM information_schema.columns
-		WHERE table_name = ?
-		AND table_schema = ?
-		ORDER BY ordinal_position
-	`)
+// columns of a specific table for a given database. MySQL 5.x needs none.
+func (mysql *MySQL) PrepareGetColumnsOfTableStmt(ctx context.Context) (err error) {
+	if mysql.server.legacy() {
+		return nil
+	}
 
-	return err
+	return mysql.retry(ctx, func() (err error) {
+		mysql.GetColumnsOfTableStmt, err = mysql.PreparexContext(ctx, `
+			SELECT
+			  ordinal_position AS ordinal_position,
+			  column_name AS column_name,
+			  data_type AS data_type,
+			  column_default AS column_default,
+			  is_nullable AS is_nullable,
+			  character_maximum_length AS character_maximum_length,
+			  numeric_precision AS numeric_precision,
+			  column_type AS column_type,
+			  column_key AS column_key,
+			  extra AS extra,
+			  column_comment AS column_comment
+			FROM information_schema.columns
+			WHERE table_name = ?
+			AND table_schema = ?
+			ORDER BY ordinal_position
+		`)
+		return err
+	})
 }
 
 // GetColumnsOfTable executes the statement for retrieving the columns of a
-// specific table for a given database.
-func (mysql *MySQL) GetColumnsOfTable(table *Table) (err error) {
+// specific table for a given database, on MySQL 5.x SHOW FULL COLUMNS.
+func (mysql *MySQL) GetColumnsOfTable(ctx context.Context, table *Table) (err error) {
+	if mysql.server.legacy() {
+		return mysql.getColumnsOfTableByShow(ctx, table)
+	}
 
-	err = mysql.GetColumnsOfTableStmt.Select(&table.Columns, table.Name, mysql.DbName)
+	err = mysql.retry(ctx, func() error {
+		table.Columns = nil
+		return mysql.GetColumnsOfTableStmt.SelectContext(ctx, &table.Columns, table.Name, mysql.DbName)
+	})
 
-	if mysql.Settings.Verbose {
-		if err != nil {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> schema: %q\r\n", mysql.Schema)
-			fmt.Printf("> dbName: %q\r\n", mysql.DbName)
+	for i := range table.Columns {
+		if table.Columns[i].DataType == "enum" {
+			table.Columns[i].EnumValues = parseEnumValues(table.Columns[i].ColumnType)
 		}
 	}
 
+	if err != nil {
+		slog.Debug("could not get columns of table", "table", table.Name, "schema", mysql.Schema, "database", mysql.DbName, "error", err)
+	}
+
 	return err
 }
 
+// GetColumnsOfTables is the implementation of the ColumnsBatchGetter
+// interface, it gets the columns of all tables in one query.
+func (mysql *MySQL) GetColumnsOfTables(ctx context.Context, tables []*Table) error {
+
+	args := []any{mysql.DbName}
+	in := mysql.andInClause("table_name", tableNames(tables), &args)
+
+	var rows []tableColumn
+	err := mysql.retry(ctx, func() error {
+		rows = nil
+		return mysql.SelectContext(ctx, &rows, `
+			SELECT
+			  table_name AS table_name,
+			  ordinal_position AS ordinal_position,
+			  column_name AS column_name,
+			  data_type AS data_type,
+			  column_default AS column_default,
+			  is_nullable AS is_nullable,
+			  character_maximum_length AS character_maximum_length,
+			  numeric_precision AS numeric_precision,
+			  column_type AS column_type,
+			  column_key AS column_key,
+			  extra AS extra,
+			  column_comment AS column_comment
+			FROM information_schema.columns
+			WHERE table_schema = ?
+			`+in+`
+			ORDER BY table_name, ordinal_position
+		`, args...)
+	})
+	if err != nil {
+		slog.Debug("could not get columns of tables", "count", len(tables), "database", mysql.DbName, "error", err)
+		return err
+	}
+
+	for i := range rows {
+		if rows[i].DataType == "enum" {
+			rows[i].EnumValues = parseEnumValues(rows[i].ColumnType)
+		}
+	}
+	groupColumns(tables, rows)
+	return nil
+}
+
+// BeginSnapshot is the implementation of the Snapshotter interface, the
+// snapshot is a read-only transaction of isolation level repeatable read.
+func (mysql *MySQL) BeginSnapshot(ctx context.Context) error {
+	return mysql.beginSnapshot(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
 // IsPrimaryKey checks if the column belongs to the primary key.
 func (mysql *MySQL) IsPrimaryKey(column Column) bool {
 	return strings.Contains(column.ColumnKey, "PRI")
@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// mysqlStatsExpiry is the expiry of the cached statistics of the
+// information_schema in seconds used if the session refreshes them on every
+// query, it is the default of MySQL 8.
+const mysqlStatsExpiry = "86400"
+
+// mysqlServer is the version of the server, it decides how the metadata get
+// read. The zero mysqlServer is a server of unknown version.
+type mysqlServer struct {
+	major   int
+	minor   int
+	mariaDB bool
+}
+
+// parseMySQLServer parses the version of the server as returned by VERSION(),
+// e.g. "8.0.35", "5.7.44-log" or "10.11.2-MariaDB".
+func parseMySQLServer(version string) mysqlServer {
+	server := mysqlServer{mariaDB: strings.Contains(strings.ToLower(version), "mariadb")}
+	number, _, _ := strings.Cut(version, "-")
+	parts := strings.Split(number, ".")
+	server.major, _ = strconv.Atoi(parts[0])
+	if len(parts) > 1 {
+		server.minor, _ = strconv.Atoi(parts[1])
+	}
+	return server
+}
+
+// cachesStatistics returns true for MySQL 8 and later, which cache the
+// statistics of the tables in the information_schema.
+func (s mysqlServer) cachesStatistics() bool {
+	return !s.mariaDB && s.major >= 8
+}
+
+// legacy returns true for MySQL 5.x, whose columns get read by SHOW FULL
+// COLUMNS instead of the information_schema.
+func (s mysqlServer) legacy() bool {
+	return !s.mariaDB && s.major > 0 && s.major < 8
+}
+
+// detectServer reads the version of the server. MySQL 8 sessions refreshing
+// the statistics of every table on every query of the information_schema,
+// as set by managed variants like RDS, get reconnected with the statistics
+// cached.
+func (mysql *MySQL) detectServer(ctx context.Context, dsn string) error {
+	var version string
+	if err := mysql.GetContext(ctx, &version, "SELECT VERSION()"); err != nil {
+		// the metadata get read the same way for all versions then
+		slog.Debug("could not get the version of the server", "error", err)
+		return nil
+	}
+	mysql.server = parseMySQLServer(version)
+	// proxies in front of MariaDB may report a version of their own
+	mysql.server.mariaDB = mysql.server.mariaDB || mysql.DbType == settings.DBTypeMariaDB
+	slog.Debug("connected to server", "version", version)
+
+	if !mysql.server.cachesStatistics() {
+		return nil
+	}
+	var expiry int64
+	if err := mysql.GetContext(ctx, &expiry, "SELECT @@SESSION.information_schema_stats_expiry"); err != nil || expiry != 0 {
+		return nil
+	}
+
+	slog.Debug("reconnecting with the statistics of the information_schema cached", "expiry", mysqlStatsExpiry)
+	if err := mysql.DB.Close(); err != nil {
+		return err
+	}
+	return mysql.GeneralDatabase.Connect(ctx, withDSNParam(dsn, "information_schema_stats_expiry", mysqlStatsExpiry))
+}
+
+// withDSNParam returns the DSN with the parameter, the driver sets unknown
+// parameters as system variables of every connection.
+func withDSNParam(dsn, name, value string) string {
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return dsn + separator + name + "=" + value
+}
+
+// mysqlShowColumn is a column as returned by SHOW FULL COLUMNS.
+type mysqlShowColumn struct {
+	Field      string         `db:"Field"`
+	Type       string         `db:"Type"`
+	Collation  sql.NullString `db:"Collation"`
+	Null       string         `db:"Null"`
+	Key        string         `db:"Key"`
+	Default    sql.NullString `db:"Default"`
+	Extra      string         `db:"Extra"`
+	Privileges string         `db:"Privileges"`
+	Comment    string         `db:"Comment"`
+}
+
+// getColumnsOfTableByShow gets the columns of the table by SHOW FULL COLUMNS,
+// which MySQL 5.x answers from the definition of the table instead of
+// scanning the information_schema.
+func (mysql *MySQL) getColumnsOfTableByShow(ctx context.Context, table *Table) error {
+	query := fmt.Sprintf("SHOW FULL COLUMNS FROM %s FROM %s", quoteMySQLIdentifier(table.Name), quoteMySQLIdentifier(mysql.DbName))
+
+	var rows []mysqlShowColumn
+	err := mysql.retry(ctx, func() error {
+		rows = nil
+		return mysql.SelectContext(ctx, &rows, query)
+	})
+	if err != nil {
+		slog.Debug("could not get columns of table", "table", table.Name, "database", mysql.DbName, "error", err)
+		return err
+	}
+
+	table.Columns = make([]Column, len(rows))
+	for i, row := range rows {
+		table.Columns[i] = row.column(i + 1)
+	}
+	return nil
+}
+
+// column returns the column as the information_schema returns it.
+func (c mysqlShowColumn) column(position int) Column {
+	columnType := strings.ToLower(c.Type)
+	dataType, size, _ := strings.Cut(columnType, "(")
+	dataType, _, _ = strings.Cut(dataType, " ")
+	size, _, _ = strings.Cut(size, ")")
+
+	column := Column{
+		OrdinalPosition: position,
+		Name:            c.Field,
+		DataType:        dataType,
+		DefaultValue:    c.Default,
+		IsNullable:      c.Null,
+		ColumnType:      c.Type,
+		ColumnKey:       c.Key,
+		Extra:           c.Extra,
+		Comment:         sql.NullString{String: c.Comment, Valid: true},
+	}
+
+	switch dataType {
+	case "char", "varchar", "binary", "varbinary":
+		column.CharacterMaximumLength = parseNullInt64(size)
+	case "tinytext", "tinyblob":
+		column.CharacterMaximumLength = sql.NullInt64{Int64: 255, Valid: true}
+	case "text", "blob":
+		column.CharacterMaximumLength = sql.NullInt64{Int64: 65535, Valid: true}
+	case "mediumtext", "mediumblob":
+		column.CharacterMaximumLength = sql.NullInt64{Int64: 16777215, Valid: true}
+	case "longtext", "longblob":
+		column.CharacterMaximumLength = sql.NullInt64{Int64: 4294967295, Valid: true}
+	case "enum":
+		column.EnumValues = parseEnumValues(c.Type)
+	}
+
+	switch dataType {
+	case "tinyint":
+		column.NumericPrecision = sql.NullInt64{Int64: 3, Valid: true}
+	case "smallint":
+		column.NumericPrecision = sql.NullInt64{Int64: 5, Valid: true}
+	case "mediumint":
+		column.NumericPrecision = sql.NullInt64{Int64: 7, Valid: true}
+	case "int":
+		column.NumericPrecision = sql.NullInt64{Int64: 10, Valid: true}
+	case "bigint":
+		precision := int64(19)
+		if strings.Contains(columnType, "unsigned") {
+			precision = 20
+		}
+		column.NumericPrecision = sql.NullInt64{Int64: precision, Valid: true}
+	case "float":
+		column.NumericPrecision = sql.NullInt64{Int64: 12, Valid: true}
+	case "double":
+		column.NumericPrecision = sql.NullInt64{Int64: 22, Valid: true}
+	case "decimal":
+		precision, _, _ := strings.Cut(size, ",")
+		column.NumericPrecision = parseNullInt64(precision)
+	}
+
+	return column
+}
+
+// parseNullInt64 parses the number, it is invalid if it is no number.
+func parseNullInt64(s string) sql.NullInt64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: n, Valid: true}
+}
+
+// quoteMySQLIdentifier quotes the name of a table or database.
+func quoteMySQLIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
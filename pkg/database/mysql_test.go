@@ -1,8 +1,13 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
 	"testing"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
@@ -45,6 +50,50 @@ func TestMySQL_DSN(t *testing.T) {
 				return "admin:mysecretpassword@unix(/tmp/mysql.sock)/my-cool-db"
 			},
 		},
+		{
+			desc: "RDS IAM authentication",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.User = "shop"
+				s.Pswd = "token"
+				s.DbName = "shop"
+				s.Port = "3306"
+				s.SSLMode = "require"
+				s.Auth = settings.AuthModeRDSIAM
+				return s
+			},
+			expected: func(*settings.Settings) string {
+				return "shop:token@tcp(127.0.0.1:3306)/shop?tls=skip-verify&allowCleartextPasswords=true"
+			},
+		},
+		{
+			desc: "with CA certificates",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.DbName = "shop"
+				s.Port = "3306"
+				s.SSLRootCert = "/etc/ssl/ca.pem"
+				return s
+			},
+			expected: func(*settings.Settings) string {
+				return "root:@tcp(127.0.0.1:3306)/shop?tls=tables-to-go"
+			},
+		},
+		{
+			desc: "raw DSN bypasses the builder",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypeMySQL
+				s.User = "admin"
+				s.DSN = "admin:secret@tcp(db1:3306)/shop?tls=custom&readTimeout=30s"
+				return s
+			},
+			expected: func(*settings.Settings) string {
+				return "admin:secret@tcp(db1:3306)/shop?tls=custom&readTimeout=30s"
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
@@ -54,3 +103,137 @@ func TestMySQL_DSN(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMySQLServer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version  string
+		expected mysqlServer
+		stats    bool
+		legacy   bool
+	}{
+		{version: "8.0.35", expected: mysqlServer{major: 8, minor: 0}, stats: true},
+		{version: "8.4.0-commercial", expected: mysqlServer{major: 8, minor: 4}, stats: true},
+		{version: "5.7.44-log", expected: mysqlServer{major: 5, minor: 7}, legacy: true},
+		{version: "10.11.2-MariaDB-1:10.11.2+maria~ubu2204", expected: mysqlServer{major: 10, minor: 11, mariaDB: true}},
+		{version: "5.5.5-10.1.48-MariaDB", expected: mysqlServer{major: 5, minor: 5, mariaDB: true}},
+		{version: "", expected: mysqlServer{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			server := parseMySQLServer(test.version)
+			assert.Equal(t, test.expected, server)
+			assert.Equal(t, test.stats, server.cachesStatistics())
+			assert.Equal(t, test.legacy, server.legacy())
+		})
+	}
+}
+
+func TestWithDSNParam(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "root:@tcp(db:3306)/shop?a=1", withDSNParam("root:@tcp(db:3306)/shop", "a", "1"))
+	assert.Equal(t, "root:@tcp(db:3306)/shop?parseTime=true&a=1", withDSNParam("root:@tcp(db:3306)/shop?parseTime=true", "a", "1"))
+}
+
+// showConn answers SHOW FULL COLUMNS by its rows.
+type showConn struct {
+	fakeConn
+	query string
+	rows  [][]driver.Value
+}
+
+func (c *showConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.query = query
+	return &showRows{rows: c.rows}, nil
+}
+
+type showRows struct {
+	rows [][]driver.Value
+}
+
+func (*showRows) Columns() []string {
+	return []string{"Field", "Type", "Collation", "Null", "Key", "Default", "Extra", "Privileges", "Comment"}
+}
+
+func (*showRows) Close() error {
+	return nil
+}
+
+func (r *showRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type showConnector struct {
+	conn *showConn
+}
+
+func (c showConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c showConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+func TestMySQL_GetColumnsOfTable_legacy(t *testing.T) {
+	t.Parallel()
+
+	conn := &showConn{rows: [][]driver.Value{
+		{"id", "int(10) unsigned", nil, "NO", "PRI", nil, "auto_increment", "select", ""},
+		{"email", "varchar(255)", "utf8mb4_general_ci", "YES", "UNI", nil, "", "select", "login"},
+		{"price", "decimal(10,2)", nil, "NO", "", "0.00", "", "select", ""},
+		{"state", "enum('new','paid')", "utf8mb4_general_ci", "NO", "", "new", "", "select", ""},
+		{"notes", "text", "utf8mb4_general_ci", "YES", "", nil, "", "select", ""},
+	}}
+	s := settings.New()
+	s.DbType = settings.DBTypeMySQL
+	s.DbName = "sh`op"
+	mysql := NewMySQL(s)
+	mysql.DB = sqlx.NewDb(sql.OpenDB(showConnector{conn: conn}), "mysql")
+	mysql.server = parseMySQLServer("5.7.44")
+	defer mysql.Close()
+
+	ctx := context.Background()
+	assert.NoError(t, mysql.PrepareGetColumnsOfTableStmt(ctx))
+	assert.Nil(t, mysql.GetColumnsOfTableStmt)
+
+	table := &Table{Name: "users"}
+	assert.NoError(t, mysql.GetColumnsOfTable(ctx, table))
+	assert.Equal(t, "SHOW FULL COLUMNS FROM `users` FROM `sh``op`", conn.query)
+
+	valid := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+	size := func(n int64) sql.NullInt64 { return sql.NullInt64{Int64: n, Valid: true} }
+	assert.Equal(t, []Column{
+		{
+			OrdinalPosition: 1, Name: "id", DataType: "int", IsNullable: "NO", NumericPrecision: size(10),
+			ColumnType: "int(10) unsigned", ColumnKey: "PRI", Extra: "auto_increment", Comment: valid(""),
+		},
+		{
+			OrdinalPosition: 2, Name: "email", DataType: "varchar", IsNullable: "YES", CharacterMaximumLength: size(255),
+			ColumnType: "varchar(255)", ColumnKey: "UNI", Comment: valid("login"),
+		},
+		{
+			OrdinalPosition: 3, Name: "price", DataType: "decimal", DefaultValue: valid("0.00"), IsNullable: "NO",
+			NumericPrecision: size(10), ColumnType: "decimal(10,2)", Comment: valid(""),
+		},
+		{
+			OrdinalPosition: 4, Name: "state", DataType: "enum", DefaultValue: valid("new"), IsNullable: "NO",
+			ColumnType: "enum('new','paid')", Comment: valid(""), EnumValues: []string{"new", "paid"},
+		},
+		{
+			OrdinalPosition: 5, Name: "notes", DataType: "text", IsNullable: "YES", CharacterMaximumLength: size(65535),
+			ColumnType: "text", Comment: valid(""),
+		},
+	}, table.Columns)
+
+	assert.True(t, mysql.IsPrimaryKey(table.Columns[0]))
+	assert.True(t, mysql.IsAutoIncrement(table.Columns[0]))
+}
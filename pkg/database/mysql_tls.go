@@ -0,0 +1,72 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// mysqlTLSConfig is the name the TLS config of the certificates of the
+// settings is registered by at the driver, referenced by the DSN.
+const mysqlTLSConfig = "tables-to-go"
+
+// hasMySQLTLS reports whether the settings give a client certificate or CA
+// certificates.
+func hasMySQLTLS(s *settings.Settings) bool {
+	return s.SSLCert != "" || s.SSLRootCert != ""
+}
+
+// mysqlTLS returns the value of the tls parameter of the DSN: the TLS config
+// of the certificates of the settings, if any, else a built-in one of the
+// driver for the authentication by a token, which needs TLS.
+func mysqlTLS(s *settings.Settings) string {
+	switch {
+	case hasMySQLTLS(s):
+		return mysqlTLSConfig
+	case !s.UsesAuthToken():
+		return ""
+	case s.SSLMode == "require":
+		return "skip-verify"
+	default:
+		return "true"
+	}
+}
+
+// registerMySQLTLS registers the TLS config of the certificates of the
+// settings at the driver, if any. The server certificate gets verified by
+// the CA certificates, or the ones of the system without them, along with
+// the host name, unless the sslmode is require without CA certificates.
+func registerMySQLTLS(s *settings.Settings) error {
+	if !hasMySQLTLS(s) {
+		return nil
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: s.SSLMode == "require" && s.SSLRootCert == "",
+	}
+	if s.SSLRootCert != "" {
+		pem, err := os.ReadFile(s.SSLRootCert)
+		if err != nil {
+			return fmt.Errorf("could not read CA certificates: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("could not read CA certificates: no PEM certificates in %s", s.SSLRootCert)
+		}
+	}
+	if s.SSLCert != "" {
+		cert, err := tls.LoadX509KeyPair(s.SSLCert, s.SSLKey)
+		if err != nil {
+			return fmt.Errorf("could not read client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return mysql.RegisterTLSConfig(mysqlTLSConfig, config)
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// writeCertificate writes a self-signed certificate and its key as PEM files
+// to the directory and returns their paths.
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "tables-to-go"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestRegisterMySQLTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+	noPEM := filepath.Join(dir, "empty.pem")
+	assert.NoError(t, os.WriteFile(noPEM, []byte("no certificate"), 0600))
+
+	tests := []struct {
+		desc     string
+		settings func(s *settings.Settings)
+		expected string
+	}{
+		{
+			desc: "no certificates",
+		},
+		{
+			desc: "client certificate and CA certificates",
+			settings: func(s *settings.Settings) {
+				s.SSLCert = certFile
+				s.SSLKey = keyFile
+				s.SSLRootCert = certFile
+			},
+		},
+		{
+			desc: "missing CA certificates",
+			settings: func(s *settings.Settings) {
+				s.SSLRootCert = filepath.Join(dir, "missing.pem")
+			},
+			expected: "could not read CA certificates: open " + filepath.Join(dir, "missing.pem") + ": no such file or directory",
+		},
+		{
+			desc: "no PEM certificates",
+			settings: func(s *settings.Settings) {
+				s.SSLRootCert = noPEM
+			},
+			expected: "could not read CA certificates: no PEM certificates in " + noPEM,
+		},
+		{
+			desc: "key without PEM data",
+			settings: func(s *settings.Settings) {
+				s.SSLCert = certFile
+				s.SSLKey = noPEM
+			},
+			expected: "could not read client certificate: tls: failed to find any PEM data in key input",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = settings.DBTypeMySQL
+			if test.settings != nil {
+				test.settings(s)
+			}
+			err := registerMySQLTLS(s)
+			if test.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
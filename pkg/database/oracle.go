@@ -1,7 +1,11 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -32,6 +36,9 @@ func NewOracle(s *settings.Settings) *Oracle {
 // Example format: user/password@host:port/servicename
 // Adjust as needed for your driver or TNS usage.
 func (o *Oracle) DSN() string {
+	if o.Settings.DSN != "" {
+		return o.Settings.DSN
+	}
 	user := o.defaultUserName
 	if o.Settings.User != "" {
 		user = o.Settings.User
@@ -50,24 +57,14 @@ func (o *Oracle) DSN() string {
 }
 
 // Connect connects to the database using the DSN generated above.
-func (o *Oracle) Connect() error {
-	return o.GeneralDatabase.Connect(o.DSN())
-}
-
-// Close closes the database connection.
-func (o *Oracle) Close() error {
-	return o.DB.Close()
+func (o *Oracle) Connect(ctx context.Context) error {
+	return o.GeneralDatabase.Connect(ctx, o.DSN())
 }
 
 // GetTables retrieves all tables for the current (or specified) schema.
 // If `tables...` is provided, it filters by those table names.
-func (o *Oracle) GetTables(tables ...string) ([]*Table, error) {
-	// If the user didn't supply a specific schema, assume the connected user
-	owner := o.Settings.Schema
-	if owner == "" {
-		owner = o.Settings.User
-	}
-	owner = strings.ToUpper(owner)
+func (o *Oracle) GetTables(ctx context.Context, tables ...string) ([]*Table, error) {
+	owner := o.owner()
 
 	args := []any{owner}
 	inClause := ""
@@ -75,70 +72,208 @@ func (o *Oracle) GetTables(tables ...string) ([]*Table, error) {
 		placeholders := make([]string, 0, len(tables))
 		for i, tbl := range tables {
 			placeholders = append(placeholders, ":v"+strconv.Itoa(i))
-			args = append(args, strings.ToUpper(tbl))
+			// unquoted identifiers are folded to upper case
+			name, quoted := UnquoteIdentifier(tbl)
+			if !quoted {
+				name = strings.ToUpper(name)
+			}
+			args = append(args, name)
 		}
-		inClause = "AND OBJECT_NAME IN (" + strings.Join(placeholders, ",") + ")"
+		inClause = "AND o.OBJECT_NAME IN (" + strings.Join(placeholders, ",") + ")"
+	}
+
+	// the object types are the ones of the information_schema except for
+	// the tables, a materialized view has a table of its name too
+	types := tableTypes(o.Settings, TableTypeBase, TableTypeView, TableTypeMaterializedView)
+	for i, typ := range types {
+		if typ == TableTypeBase {
+			types[i] = "TABLE"
+		}
+	}
+	if slices.Contains(types, TableTypeMaterializedView) {
+		inClause += `
+AND NOT (o.OBJECT_TYPE = 'TABLE' AND EXISTS (SELECT 1 FROM ALL_MVIEWS m WHERE m.OWNER = o.OWNER AND m.MVIEW_NAME = o.OBJECT_NAME))`
 	}
 
 	query := fmt.Sprintf(`
-SELECT DISTINCT OBJECT_NAME as "table_name"
-FROM ALL_OBJECTS
-WHERE OBJECT_TYPE = 'TABLE'
-AND OWNER = :owner
+SELECT DISTINCT o.OBJECT_NAME as "table_name",
+CASE o.OBJECT_TYPE WHEN 'TABLE' THEN '%s' ELSE o.OBJECT_TYPE END as "table_type",
+c.COMMENTS as "table_comment"
+FROM ALL_OBJECTS o
+LEFT JOIN ALL_TAB_COMMENTS c ON c.OWNER = o.OWNER AND c.TABLE_NAME = o.OBJECT_NAME
+WHERE %s
+AND o.OWNER = :owner
 %s
-ORDER BY OBJECT_NAME
-	`, inClause)
+ORDER BY o.OBJECT_NAME
+	`, TableTypeBase, inTableTypes("o.OBJECT_TYPE", types), inClause)
 
 	var dbTables []*Table
-	err := o.Select(&dbTables, query, args...)
-	if err != nil && o.Settings.Verbose {
-		fmt.Println("> Error at GetTables()")
-		fmt.Printf("> owner: %q\n", owner)
+	err := o.retry(ctx, func() error {
+		dbTables = nil
+		return o.SelectContext(ctx, &dbTables, query, args...)
+	})
+	if err != nil {
+		slog.Debug("could not get tables", "owner", owner, "error", err)
 	}
 	return dbTables, err
 }
 
-// PrepareGetColumnsOfTableStmt prepares a statement to retrieve columns
-// (including information about primary keys) for a specific table.
-func (o *Oracle) PrepareGetColumnsOfTableStmt() error {
-	// We use a LEFT JOIN to label columns in the primary key as "PRI" in column_key.
-	query := `
+// GetTableInfo gets the number of rows of the last statistics and the comment
+// of the table.
+func (o *Oracle) GetTableInfo(ctx context.Context, table *Table) (info TableInfo, err error) {
+	err = o.retry(ctx, func() error {
+		return o.GetContext(ctx, &info, `
+SELECT t.NUM_ROWS AS "table_rows", c.COMMENTS AS "table_comment"
+FROM ALL_TABLES t
+LEFT JOIN ALL_TAB_COMMENTS c ON c.OWNER = t.OWNER AND c.TABLE_NAME = t.TABLE_NAME
+WHERE t.OWNER = :owner
+AND t.TABLE_NAME = :name
+	`, o.owner(), table.Name)
+	})
+	if err != nil {
+		return info, fmt.Errorf("could not get info of table %q: %w", table.Name, err)
+	}
+	return info, nil
+}
+
+// GetForeignKeysOfTable is the implementation of the ForeignKeysGetter
+// interface, the referenced columns are the ones of the referenced primary
+// or unique key at the same position.
+func (o *Oracle) GetForeignKeysOfTable(ctx context.Context, table *Table) error {
+	var rows []foreignKeyColumn
+	err := o.retry(ctx, func() error {
+		rows = nil
+		return o.SelectContext(ctx, &rows, `
+SELECT
+    c.constraint_name AS "constraint_name",
+    cc.column_name AS "column_name",
+    r.table_name AS "referenced_table_name",
+    rc.column_name AS "referenced_column_name"
+FROM ALL_CONSTRAINTS c
+JOIN ALL_CONS_COLUMNS cc ON cc.owner = c.owner AND cc.constraint_name = c.constraint_name
+JOIN ALL_CONSTRAINTS r ON r.owner = c.r_owner AND r.constraint_name = c.r_constraint_name
+JOIN ALL_CONS_COLUMNS rc ON rc.owner = r.owner AND rc.constraint_name = r.constraint_name AND rc.position = cc.position
+WHERE c.constraint_type = 'R'
+AND c.owner = :owner
+AND c.table_name = :name
+ORDER BY c.constraint_name, cc.position
+	`, o.owner(), table.Name)
+	})
+	if err != nil {
+		return fmt.Errorf("could not get foreign keys of table %q: %w", table.Name, err)
+	}
+	table.ForeignKeys = groupForeignKeys(rows)
+	return nil
+}
+
+// owner returns the owner of the tables: the schema or, if not given, the
+// connected user.
+func (o *Oracle) owner() string {
+	owner := o.Settings.Schema
+	if owner == "" {
+		owner = o.Settings.User
+	}
+	return strings.ToUpper(owner)
+}
+
+// oracleColumnsQuery retrieves the columns of a specific table of an owner,
+// the columns of the primary key with the column key PRI and the identity
+// columns with the extra identity. The constraints are joined by their owner
+// as well, tables of different owners may share their names and the names of
+// their constraints. The column type of NUMBER columns of a scale is written
+// like NUMBER(10,2), or NUMBER(*,0) for INTEGER, to match them in a type map.
+const oracleColumnsQuery = `
 SELECT
     c.column_id AS "ordinal_position",
     c.column_name AS "column_name",
     c.data_type AS "data_type",
     c.data_default AS "column_default",
-    c.nullable AS "is_nullable",
+    DECODE(c.nullable, 'Y', 'YES', 'NO') AS "is_nullable",
     c.data_length AS "character_maximum_length",
-    c.data_precision AS "numeric_precision"
-FROM USER_TAB_COLUMNS c
-WHERE table_name = :name
+    c.data_precision AS "numeric_precision",
+    c.data_scale AS "numeric_scale",
+    CASE WHEN c.data_type = 'NUMBER' AND c.data_scale IS NOT NULL
+        THEN 'NUMBER(' || NVL(TO_CHAR(c.data_precision), '*') || ',' || c.data_scale || ')'
+    END AS "column_type",
+    NVL2(pk.column_name, 'PRI', NULL) AS "column_key",
+    DECODE(c.identity_column, 'YES', 'identity') AS "extra",
+    cc.comments AS "column_comment"
+FROM ALL_TAB_COLUMNS c
+LEFT JOIN ALL_COL_COMMENTS cc ON cc.owner = c.owner AND cc.table_name = c.table_name AND cc.column_name = c.column_name
+LEFT JOIN (
+    SELECT acc.owner, acc.table_name, acc.column_name
+    FROM ALL_CONSTRAINTS ac
+    JOIN ALL_CONS_COLUMNS acc ON acc.owner = ac.owner AND acc.constraint_name = ac.constraint_name AND acc.table_name = ac.table_name
+    WHERE ac.constraint_type = 'P'
+) pk ON pk.owner = c.owner AND pk.table_name = c.table_name AND pk.column_name = c.column_name
+WHERE c.table_name = :name
+AND c.owner = :owner
+ORDER BY c.column_id
 `
-	var err error
-	o.GetColumnsOfTableStmt, err = o.Preparex(query)
-	return err
+
+// oracleColumn is a column as oracleColumnsQuery returns it, Oracle returns
+// the empty column type, column key and extra as NULL.
+type oracleColumn struct {
+	Column
+	ColumnType sql.NullString `db:"column_type"`
+	ColumnKey  sql.NullString `db:"column_key"`
+	Extra      sql.NullString `db:"extra"`
+}
+
+// PrepareGetColumnsOfTableStmt prepares a statement to retrieve columns
+// (including information about primary keys) for a specific table.
+func (o *Oracle) PrepareGetColumnsOfTableStmt(ctx context.Context) (err error) {
+	return o.retry(ctx, func() (err error) {
+		o.GetColumnsOfTableStmt, err = o.PreparexContext(ctx, oracleColumnsQuery)
+		return err
+	})
 }
 
 // GetColumnsOfTable executes the prepared statement to retrieve column metadata.
-func (o *Oracle) GetColumnsOfTable(table *Table) error {
+func (o *Oracle) GetColumnsOfTable(ctx context.Context, table *Table) error {
+	return o.retry(ctx, func() error {
+		table.Columns = nil
+		return o.getColumnsOfTable(ctx, table)
+	})
+}
 
-	// not recreating the prepared statement seems to cause a "ORA-01002: fetch out of sequence" error
+// getColumnsOfTable prepares and executes the statement to retrieve the column
+// metadata once.
+func (o *Oracle) getColumnsOfTable(ctx context.Context, table *Table) error {
+
+	// not recreating the prepared statement seems to cause a "ORA-01002: fetch out of sequence" error,
+	// hence every call prepares its own one, which also allows parallel calls
 	// FIXME: see if theres a proper solution
-	if err := o.PrepareGetColumnsOfTableStmt(); err != nil {
+	stmt, err := o.PreparexContext(ctx, oracleColumnsQuery)
+	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
-	defer func() {
-		if o.GetColumnsOfTableStmt != nil {
-			o.GetColumnsOfTableStmt.Close()
-			o.GetColumnsOfTableStmt = nil
-		}
-	}()
+	defer stmt.Close()
 
-	err := o.GetColumnsOfTableStmt.Select(
-		&table.Columns,
-		table.Name,
-	)
-	return err
+	var rows []oracleColumn
+	if err := stmt.SelectContext(ctx, &rows, table.Name, o.owner()); err != nil {
+		slog.Debug("could not get columns of table", "table", table.Name, "owner", o.owner(), "error", err)
+		return err
+	}
+
+	table.Columns = make([]Column, len(rows))
+	for i, row := range rows {
+		table.Columns[i] = row.Column
+		table.Columns[i].ColumnType = row.ColumnType.String
+		table.Columns[i].ColumnKey = row.ColumnKey.String
+		table.Columns[i].Extra = row.Extra.String
+	}
+	return nil
+}
+
+// oracleMaxJobs is the number of tables whose columns get introspected in
+// parallel at most, every job holds a session and prepares its statements.
+const oracleMaxJobs = 4
+
+// MaxJobs is the implementation of the JobsLimiter interface, Oracle sessions
+// are expensive.
+func (o *Oracle) MaxJobs() int {
+	return oracleMaxJobs
 }
 
 // IsPrimaryKey checks if a column belongs to the primary key.
@@ -146,15 +281,10 @@ func (o *Oracle) IsPrimaryKey(column Column) bool {
 	return strings.Contains(column.ColumnKey, "PRI")
 }
 
-// IsAutoIncrement checks if a column is auto-increment. Oracle does not have
-// the same concept; typically sequences & triggers are used, so this is false.
+// IsAutoIncrement checks if a column is an identity column. Columns filled by
+// sequences and triggers can not be detected.
 func (o *Oracle) IsAutoIncrement(column Column) bool {
-	return false
-}
-
-// IsNullable returns whether the column is nullable ('Y' for Oracle).
-func (o *Oracle) IsNullable(column Column) bool {
-	return column.IsNullable == "Y"
+	return column.Extra == "identity"
 }
 
 // GetStringDatatypes returns which datatypes Oracle generally treats as "string".
@@ -186,16 +316,20 @@ func (o *Oracle) IsText(column Column) bool {
 }
 
 // GetIntegerDatatypes returns which datatypes Oracle generally treats as "integer".
+// NUMBER depends on its scale, see IsInteger.
 func (o *Oracle) GetIntegerDatatypes() []string {
 	return []string{
-		"NUMBER",   // often an integer if scale=0, but naive check here
 		"INTEGER",  // Oracle synonym
 		"SMALLINT", // Oracle synonym
 	}
 }
 
-// IsInteger checks if a column is treated as an integer type in Oracle.
+// IsInteger checks if a column is treated as an integer type in Oracle:
+// NUMBER columns need a scale of 0, like the ones of NUMBER(10) or INTEGER.
 func (o *Oracle) IsInteger(column Column) bool {
+	if isOracleNumber(column) {
+		return column.NumericScale.Valid && column.NumericScale.Int64 == 0
+	}
 	return isStringInSlice(strings.ToUpper(column.DataType), o.GetIntegerDatatypes())
 }
 
@@ -206,17 +340,25 @@ func (o *Oracle) GetFloatDatatypes() []string {
 		"BINARY_FLOAT",
 		"BINARY_DOUBLE",
 		"DECIMAL",
-		"NUMBER",
 		"REAL",
 		"DOUBLE PRECISION",
 	}
 }
 
-// IsFloat checks if a column is treated as a floating-point type in Oracle.
+// IsFloat checks if a column is treated as a floating-point type in Oracle,
+// including the NUMBER columns of a scale and the ones without any.
 func (o *Oracle) IsFloat(column Column) bool {
+	if isOracleNumber(column) {
+		return !o.IsInteger(column)
+	}
 	return isStringInSlice(strings.ToUpper(column.DataType), o.GetFloatDatatypes())
 }
 
+// isOracleNumber returns true if the column is of the NUMBER type.
+func isOracleNumber(column Column) bool {
+	return strings.EqualFold(column.DataType, "NUMBER")
+}
+
 // GetTemporalDatatypes returns which datatypes Oracle generally treats as "temporal".
 func (o *Oracle) GetTemporalDatatypes() []string {
 	return []string{
@@ -0,0 +1,253 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// columnsConn answers the prepared statements and the queries by its rows and
+// records the arguments they are queried by.
+type columnsConn struct {
+	fakeConn
+	query   string
+	args    []driver.Value
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (c *columnsConn) Prepare(query string) (driver.Stmt, error) {
+	c.query = query
+	return columnsStmt{conn: c}, nil
+}
+
+func (c *columnsConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.query = query
+	c.args = nil
+	for _, arg := range args {
+		c.args = append(c.args, arg.Value)
+	}
+	return &columnsRows{columns: c.columns, rows: c.rows}, nil
+}
+
+type columnsStmt struct {
+	fakeStmt
+	conn *columnsConn
+}
+
+func (s columnsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.conn.args = args
+	return &columnsRows{columns: s.conn.columns, rows: s.conn.rows}, nil
+}
+
+type columnsRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *columnsRows) Columns() []string {
+	return r.columns
+}
+
+func (*columnsRows) Close() error {
+	return nil
+}
+
+func (r *columnsRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type columnsConnector struct {
+	conn *columnsConn
+}
+
+func (c columnsConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c columnsConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+func TestOracle_GetColumnsOfTable(t *testing.T) {
+	t.Parallel()
+
+	columns := []string{
+		"ordinal_position", "column_name", "data_type", "column_default", "is_nullable",
+		"character_maximum_length", "numeric_precision", "numeric_scale", "column_type", "column_key", "extra",
+		"column_comment",
+	}
+
+	tests := []struct {
+		desc                  string
+		schema                string
+		user                  string
+		rows                  [][]driver.Value
+		expectedOwner         string
+		expectedKeys          []string
+		expectedAutoIncrement []bool
+	}{
+		{
+			desc:   "primary key of the schema",
+			schema: "shop",
+			user:   "app",
+			rows: [][]driver.Value{
+				{int64(1), "ID", "NUMBER", nil, "NO", int64(22), int64(10), int64(0), "NUMBER(10,0)", "PRI", "identity", nil},
+				{int64(2), "EMAIL", "VARCHAR2", nil, "YES", int64(255), nil, nil, nil, nil, nil, "login"},
+			},
+			expectedOwner:         "SHOP",
+			expectedKeys:          []string{"PRI", ""},
+			expectedAutoIncrement: []bool{true, false},
+		},
+		{
+			desc: "composite primary key of the user",
+			user: "app",
+			rows: [][]driver.Value{
+				{int64(1), "ORDER_ID", "NUMBER", nil, "NO", int64(22), int64(10), int64(0), "NUMBER(10,0)", "PRI", nil, nil},
+				{int64(2), "LINE", "NUMBER", nil, "NO", int64(22), int64(5), int64(0), "NUMBER(5,0)", "PRI", nil, nil},
+				{int64(3), "AMOUNT", "NUMBER", "0", "NO", int64(22), int64(12), int64(2), "NUMBER(12,2)", nil, nil, nil},
+			},
+			expectedOwner:         "APP",
+			expectedKeys:          []string{"PRI", "PRI", ""},
+			expectedAutoIncrement: []bool{false, false, false},
+		},
+		{
+			desc:                  "table without primary key",
+			user:                  "app",
+			rows:                  [][]driver.Value{{int64(1), "MESSAGE", "CLOB", nil, "YES", int64(4000), nil, nil, nil, nil, nil, nil}},
+			expectedOwner:         "APP",
+			expectedKeys:          []string{""},
+			expectedAutoIncrement: []bool{false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			conn := &columnsConn{columns: columns, rows: test.rows}
+			s := settings.New()
+			s.DbType = settings.DBTypeOracle
+			s.Schema = test.schema
+			s.User = test.user
+			o := NewOracle(s)
+			o.DB = sqlx.NewDb(sql.OpenDB(columnsConnector{conn: conn}), "oracle")
+			defer o.Close()
+
+			table := &Table{Name: "ORDERS"}
+			assert.NoError(t, o.GetColumnsOfTable(context.Background(), table))
+
+			assert.Contains(t, conn.query, "ALL_CONS_COLUMNS")
+			assert.Equal(t, []driver.Value{"ORDERS", test.expectedOwner}, conn.args)
+
+			var keys []string
+			var autoIncrement []bool
+			for _, column := range table.Columns {
+				keys = append(keys, column.ColumnKey)
+				autoIncrement = append(autoIncrement, o.IsAutoIncrement(column))
+				assert.Equal(t, column.ColumnKey == "PRI", o.IsPrimaryKey(column))
+			}
+			assert.Equal(t, test.expectedKeys, keys)
+			assert.Equal(t, test.expectedAutoIncrement, autoIncrement)
+		})
+	}
+}
+
+func TestOracle_IsInteger(t *testing.T) {
+	t.Parallel()
+
+	scale := func(i int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: i, Valid: true}
+	}
+
+	tests := []struct {
+		desc            string
+		column          Column
+		expectedInteger bool
+		expectedFloat   bool
+	}{
+		{
+			desc:            "NUMBER of a scale of 0 is an integer",
+			column:          Column{DataType: "NUMBER", NumericPrecision: scale(10), NumericScale: scale(0)},
+			expectedInteger: true,
+		},
+		{
+			desc:            "INTEGER reported as NUMBER without precision is an integer",
+			column:          Column{DataType: "NUMBER", NumericScale: scale(0)},
+			expectedInteger: true,
+		},
+		{
+			desc:          "NUMBER of a scale is a float",
+			column:        Column{DataType: "NUMBER", NumericPrecision: scale(12), NumericScale: scale(2)},
+			expectedFloat: true,
+		},
+		{
+			desc:          "NUMBER without precision and scale is a float",
+			column:        Column{DataType: "NUMBER"},
+			expectedFloat: true,
+		},
+		{
+			desc:          "BINARY_DOUBLE is a float",
+			column:        Column{DataType: "BINARY_DOUBLE"},
+			expectedFloat: true,
+		},
+		{
+			desc:   "VARCHAR2 is neither",
+			column: Column{DataType: "VARCHAR2"},
+		},
+	}
+
+	s := settings.New()
+	s.DbType = settings.DBTypeOracle
+	o := NewOracle(s)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expectedInteger, o.IsInteger(test.column))
+			assert.Equal(t, test.expectedFloat, o.IsFloat(test.column))
+		})
+	}
+}
+
+func TestOracle_GetForeignKeysOfTable(t *testing.T) {
+	t.Parallel()
+
+	conn := &columnsConn{
+		columns: []string{"constraint_name", "column_name", "referenced_table_name", "referenced_column_name"},
+		rows: [][]driver.Value{
+			{"FK_CUSTOMER", "CUSTOMER_ID", "CUSTOMERS", "ID"},
+			{"FK_LINE", "ORDER_ID", "ORDER_LINES", "ORDER_ID"},
+			{"FK_LINE", "LINE", "ORDER_LINES", "POSITION"},
+		},
+	}
+	s := settings.New()
+	s.DbType = settings.DBTypeOracle
+	s.Schema = ""
+	s.User = "app"
+	o := NewOracle(s)
+	o.DB = sqlx.NewDb(sql.OpenDB(columnsConnector{conn: conn}), "oracle")
+	defer o.Close()
+
+	table := &Table{Name: "ORDERS"}
+	assert.NoError(t, o.GetForeignKeysOfTable(context.Background(), table))
+
+	assert.Contains(t, conn.query, "c.constraint_type = 'R'")
+	assert.Equal(t, []driver.Value{"APP", "ORDERS"}, conn.args)
+	assert.Equal(t, []ForeignKey{
+		{Name: "FK_CUSTOMER", Columns: []string{"CUSTOMER_ID"}, ReferencedTable: "CUSTOMERS", ReferencedColumns: []string{"ID"}},
+		{
+			Name: "FK_LINE", Columns: []string{"ORDER_ID", "LINE"},
+			ReferencedTable: "ORDER_LINES", ReferencedColumns: []string{"ORDER_ID", "POSITION"},
+		},
+	}, table.ForeignKeys)
+}
@@ -1,10 +1,17 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"log/slog"
+	"net/url"
+	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
 
@@ -17,6 +24,14 @@ type Postgresql struct {
 	*GeneralDatabase
 
 	defaultUserName string
+
+	// extraColumns are selected from the information_schema along with the
+	// columns and columnsFilter restricts them, for variants like CockroachDB
+	extraColumns  string
+	columnsFilter string
+
+	mu          sync.Mutex
+	constraints pgConstraints
 }
 
 // NewPostgresql creates a new Postgresql database.
@@ -32,94 +47,314 @@ func NewPostgresql(s *settings.Settings) *Postgresql {
 
 // Connect connects to the database by the given data source name (dsn) of the
 // concrete database.
-func (pg *Postgresql) Connect() error {
-	return pg.GeneralDatabase.Connect(pg.DSN())
+func (pg *Postgresql) Connect(ctx context.Context) error {
+	return pg.GeneralDatabase.Connect(ctx, pg.DSN())
 }
 
 // DSN creates the DSN String to connect to this database.
 func (pg *Postgresql) DSN() string {
+	if pg.Settings.DSN != "" {
+		return pg.Settings.DSN
+	}
 	user := pg.defaultUserName
 	if pg.Settings.User != "" {
 		user = pg.Settings.User
 	}
+	// the tokens of the RDS IAM authentication contain reserved characters
+	userinfo := url.UserPassword(user, pg.Settings.Pswd).String()
 	if pg.Settings.Socket != "" {
-		return fmt.Sprintf("postgres://%s:%s@?%s&%s&sslmode=%s",
-			user, pg.Settings.Pswd, pg.Settings.Socket, pg.Settings.Port, pg.Settings.SSLMode)
+		dir, port := pgSocket(pg.Settings.Socket, pg.Settings.Port)
+		return fmt.Sprintf("postgres://%s@/%s?host=%s&port=%s&sslmode=%s",
+			userinfo, pg.Settings.DbName, url.QueryEscape(dir), port, pg.Settings.SSLMode) + pg.params()
+	}
+	return fmt.Sprintf("postgres://%s@%s:%s/%s?sslmode=%s",
+		userinfo, pg.Settings.Host, pg.Settings.Port, pg.Settings.DbName, pg.Settings.SSLMode) + pg.params()
+}
+
+// pgSocketFile matches the names of the socket files of Postgres servers in
+// their socket directory, .s.PGSQL.<port>.
+var pgSocketFile = regexp.MustCompile(`^\.s\.PGSQL\.([0-9]+)$`)
+
+// pgSocket returns the socket directory and the port of the socket, which
+// is the directory or the socket file in it. The driver expects the
+// directory and finds the file by the port.
+func pgSocket(socket, port string) (string, string) {
+	if m := pgSocketFile.FindStringSubmatch(filepath.Base(socket)); m != nil {
+		return filepath.Dir(socket), m[1]
+	}
+	return socket, port
+}
+
+// params returns the further parameters of the DSN: the client certificate,
+// its key and the CA certificates, and the Kerberos service name or SPN of
+// the settings, if any.
+func (pg *Postgresql) params() string {
+	var params strings.Builder
+	for _, param := range []struct{ name, value string }{
+		{"sslcert", pg.Settings.SSLCert},
+		{"sslkey", pg.Settings.SSLKey},
+		{"sslrootcert", pg.Settings.SSLRootCert},
+		{"krbsrvname", pg.Settings.KrbSrvName},
+		{"krbspn", pg.Settings.KrbSPN},
+	} {
+		if param.value != "" {
+			params.WriteString("&" + param.name + "=" + url.QueryEscape(param.value))
+		}
 	}
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		user, pg.Settings.Pswd, pg.Settings.Host, pg.Settings.Port, pg.Settings.DbName, pg.Settings.SSLMode)
+	return params.String()
 }
 
 // GetTables gets all tables for a given schema by name.
-func (pg *Postgresql) GetTables(tables ...string) ([]*Table, error) {
+func (pg *Postgresql) GetTables(ctx context.Context, tables ...string) ([]*Table, error) {
+	if pg.PgCatalog {
+		return pg.getTablesByCatalog(ctx, tables...)
+	}
 
 	args := []any{pg.Schema}
-	in := pg.andInClause("LOWER(table_name)", tables, &args)
+	in := andTablesClause(pg.andInClause, "LOWER(table_name)", "table_name", strings.ToLower, tables, &args)
+
+	// the materialized views are not in the information_schema
+	types := tableTypes(pg.Settings, TableTypeBase, TableTypeView, TableTypeMaterializedView)
+	query := `
+			SELECT
+				table_name,
+				table_type,
+				obj_description((quote_ident(table_schema) || '.' || quote_ident(table_name))::regclass, 'pg_class') AS table_comment
+			FROM information_schema.tables
+			WHERE ` + inTableTypes("table_type", slices.DeleteFunc(slices.Clone(types), func(typ string) bool { return typ == TableTypeMaterializedView })) + `
+			AND table_schema = $1
+			` + in
+	if slices.Contains(types, TableTypeMaterializedView) {
+		query += `
+			UNION ALL
+			SELECT
+				matviewname AS table_name,
+				'` + TableTypeMaterializedView + `' AS table_type,
+				obj_description((quote_ident(schemaname) || '.' || quote_ident(matviewname))::regclass, 'pg_class') AS table_comment
+			FROM pg_matviews
+			WHERE schemaname = $1
+			` + andTablesClause(pg.andInClause, "LOWER(matviewname)", "matviewname", strings.ToLower, tables, &args)
+	}
+	query += `
+			ORDER BY table_name
+		`
 
 	var dbTables []*Table
-	err := pg.Select(&dbTables, `
-		SELECT table_name
-		FROM information_schema.tables
-		WHERE table_type = 'BASE TABLE'
-		AND table_schema = $1
-		`+in+`
-		ORDER BY table_name
-	`, args...)
-
-	if pg.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> schema: %q\r\n", pg.Schema)
-		}
+	err := pg.retry(ctx, func() error {
+		dbTables = nil
+		return pg.SelectContext(ctx, &dbTables, query, args...)
+	})
+
+	if err != nil {
+		slog.Debug("could not get tables", "schema", pg.Schema, "error", err)
+		return nil, err
+	}
+
+	return dbTables, pg.loadConstraints(ctx, tables...)
+}
+
+// GetTableInfo gets the estimated number of rows and the comment of the table.
+func (pg *Postgresql) GetTableInfo(ctx context.Context, table *Table) (info TableInfo, err error) {
+	err = pg.retry(ctx, func() error {
+		return pg.GetContext(ctx, &info, `
+			SELECT
+				CASE WHEN c.reltuples < 0 THEN NULL ELSE c.reltuples::bigint END AS table_rows,
+				obj_description(c.oid, 'pg_class') AS table_comment
+			FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = $1
+			AND c.relname = $2
+		`, pg.Schema, table.Name)
+	})
+	if err != nil {
+		return info, fmt.Errorf("could not get info of table %q: %w", table.Name, err)
+	}
+	return info, nil
+}
+
+// GetForeignKeysOfTable is the implementation of the ForeignKeysGetter
+// interface, the columns of a composite foreign key pair up by their
+// position in the constraint.
+func (pg *Postgresql) GetForeignKeysOfTable(ctx context.Context, table *Table) error {
+	var rows []foreignKeyColumn
+	err := pg.retry(ctx, func() error {
+		rows = nil
+		return pg.SelectContext(ctx, &rows, `
+			SELECT
+				con.conname AS constraint_name,
+				a.attname AS column_name,
+				rt.relname AS referenced_table_name,
+				ra.attname AS referenced_column_name
+			FROM pg_constraint con
+			JOIN pg_class t ON t.oid = con.conrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			JOIN pg_class rt ON rt.oid = con.confrelid
+			CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, refattnum, position)
+			JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+			JOIN pg_attribute ra ON ra.attrelid = con.confrelid AND ra.attnum = k.refattnum
+			WHERE con.contype = 'f'
+			AND n.nspname = $1
+			AND t.relname = $2
+			ORDER BY con.conname, k.position
+		`, pg.Schema, table.Name)
+	})
+	if err != nil {
+		return fmt.Errorf("could not get foreign keys of table %q: %w", table.Name, err)
 	}
+	table.ForeignKeys = groupForeignKeys(rows)
+	return nil
+}
 
-	return dbTables, err
+// GetEnumsOfTable is the implementation of the EnumsGetter interface, the
+// columns of enum types get the values in their sort order.
+func (pg *Postgresql) GetEnumsOfTable(ctx context.Context, table *Table) error {
+	var rows []enumValue
+	err := pg.retry(ctx, func() error {
+		rows = nil
+		return pg.SelectContext(ctx, &rows, `
+			SELECT
+				a.attname AS column_name,
+				et.typname AS type_name,
+				e.enumlabel AS enum_value
+			FROM pg_attribute a
+			JOIN pg_class t ON t.oid = a.attrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			JOIN pg_type et ON et.oid = a.atttypid
+			JOIN pg_enum e ON e.enumtypid = et.oid
+			WHERE a.attnum > 0
+			AND NOT a.attisdropped
+			AND n.nspname = $1
+			AND t.relname = $2
+			ORDER BY a.attnum, e.enumsortorder
+		`, pg.Schema, table.Name)
+	})
+	if err != nil {
+		return fmt.Errorf("could not get enum types of table %q: %w", table.Name, err)
+	}
+	setEnums(table, rows)
+	return nil
 }
 
 // PrepareGetColumnsOfTableStmt prepares the statement for retrieving the
 // columns of a specific table for a given database.
-func (pg *Postgresql) PrepareGetColumnsOfTableStmt() (err error) {
-
-	pg.GetColumnsOfTableStmt, err = pg.Preparex(`
-		SELECT
-			ic.ordinal_position,
-			ic.column_name,
-			ic.data_type,
-			ic.column_default,
-			ic.is_nullable,
-			ic.character_maximum_length,
-			ic.numeric_precision,
-			itc.constraint_name,
-			itc.constraint_type
-		FROM information_schema.columns AS ic
-			LEFT JOIN information_schema.key_column_usage AS ikcu ON ic.table_name = ikcu.table_name
-			AND ic.table_schema = ikcu.table_schema
-			AND ic.column_name = ikcu.column_name
-			LEFT JOIN information_schema.table_constraints AS itc ON ic.table_name = itc.table_name
-			AND ic.table_schema = itc.table_schema
-			AND ikcu.constraint_name = itc.constraint_name
-		WHERE ic.table_name = $1
-		AND ic.table_schema = $2
-		ORDER BY ic.ordinal_position
-	`)
-
-	return err
+func (pg *Postgresql) PrepareGetColumnsOfTableStmt(ctx context.Context) (err error) {
+	if pg.PgCatalog {
+		return pg.prepareGetColumnsOfTableStmtByCatalog(ctx)
+	}
+
+	return pg.retry(ctx, func() (err error) {
+		pg.GetColumnsOfTableStmt, err = pg.PreparexContext(ctx, `
+			SELECT
+				ic.ordinal_position,
+				ic.column_name,
+				ic.data_type,
+				ic.udt_name,
+				ic.column_default,
+				ic.is_nullable,
+				ic.character_maximum_length,
+				ic.numeric_precision,
+				`+pg.extraColumns+`
+				col_description((quote_ident(ic.table_schema) || '.' || quote_ident(ic.table_name))::regclass, ic.ordinal_position::int) AS column_comment
+			FROM information_schema.columns AS ic
+			WHERE ic.table_name = $1
+			AND ic.table_schema = $2
+			`+pg.columnsFilter+`
+			ORDER BY ic.ordinal_position
+		`)
+		return err
+	})
 }
 
 // GetColumnsOfTable executes the statement for retrieving the columns of a
 // specific table in a given schema.
-func (pg *Postgresql) GetColumnsOfTable(table *Table) (err error) {
+func (pg *Postgresql) GetColumnsOfTable(ctx context.Context, table *Table) (err error) {
+	// the materialized views are not in the information_schema
+	if !pg.PgCatalog && table.Type == TableTypeMaterializedView {
+		return pg.getColumnsOfTablesByCatalog(ctx, []*Table{table})
+	}
 
-	err = pg.GetColumnsOfTableStmt.Select(&table.Columns, table.Name, pg.Schema)
+	err = pg.retry(ctx, func() error {
+		table.Columns = nil
+		return pg.GetColumnsOfTableStmt.SelectContext(ctx, &table.Columns, table.Name, pg.Schema)
+	})
 
-	if pg.Verbose {
-		if err != nil {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> schema: %q\r\n", pg.Schema)
-		}
+	if err != nil {
+		slog.Debug("could not get columns of table", "table", table.Name, "schema", pg.Schema, "error", err)
+		return err
 	}
+	if pg.PgCatalog {
+		table.Columns = MergeColumns(table.Columns)
+		return nil
+	}
+
+	constraints, err := pg.keyConstraints(ctx)
+	if err != nil {
+		return err
+	}
+	table.Columns = constraints.columns(table.Name, table.Columns)
+	return nil
+}
+
+// GetColumnsOfTables is the implementation of the ColumnsBatchGetter
+// interface, it gets the columns of all tables in one query.
+func (pg *Postgresql) GetColumnsOfTables(ctx context.Context, tables []*Table) error {
+	if pg.PgCatalog {
+		return pg.getColumnsOfTablesByCatalog(ctx, tables)
+	}
+
+	args := []any{pg.Schema}
+	in := pg.andInClause("ic.table_name", tableNames(tables), &args)
+
+	var rows []tableColumn
+	err := pg.retry(ctx, func() error {
+		rows = nil
+		return pg.SelectContext(ctx, &rows, `
+			SELECT
+				ic.table_name,
+				ic.ordinal_position,
+				ic.column_name,
+				ic.data_type,
+				ic.udt_name,
+				ic.column_default,
+				ic.is_nullable,
+				ic.character_maximum_length,
+				ic.numeric_precision,
+				`+pg.extraColumns+`
+				col_description((quote_ident(ic.table_schema) || '.' || quote_ident(ic.table_name))::regclass, ic.ordinal_position::int) AS column_comment
+			FROM information_schema.columns AS ic
+			WHERE ic.table_schema = $1
+			`+in+`
+			`+pg.columnsFilter+`
+			ORDER BY ic.table_name, ic.ordinal_position
+		`, args...)
+	})
+	if err != nil {
+		slog.Debug("could not get columns of tables", "count", len(tables), "schema", pg.Schema, "error", err)
+		return err
+	}
+	constraints, err := pg.keyConstraints(ctx)
+	if err != nil {
+		return err
+	}
+
+	groupColumns(tables, rows)
+	for _, table := range tables {
+		table.Columns = constraints.columns(table.Name, table.Columns)
+	}
+
+	materializedViews := slices.DeleteFunc(slices.Clone(tables), func(table *Table) bool {
+		return table.Type != TableTypeMaterializedView
+	})
+	if len(materializedViews) > 0 {
+		return pg.getColumnsOfTablesByCatalog(ctx, materializedViews)
+	}
+	return nil
+}
 
-	return err
+// BeginSnapshot is the implementation of the Snapshotter interface, the
+// snapshot is a read-only transaction of isolation level repeatable read.
+func (pg *Postgresql) BeginSnapshot(ctx context.Context) error {
+	return pg.beginSnapshot(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
 }
 
 // IsPrimaryKey checks if the column belongs to the primary key.
@@ -230,7 +465,7 @@ func (*Postgresql) andInClause(field string, params []string, args *[]any) strin
 
 	*args = slices.Grow(*args, nparam)
 	for i := range params {
-		*args = append(*args, strings.ToLower(params[i]))
+		*args = append(*args, params[i])
 	}
 
 	return "AND " + field + " IN (" + sb.String() + ")"
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// The introspection by the system catalogs, enabled by PgCatalog of the
+// settings. The views of the information_schema join the catalogs of all
+// objects visible to the user before filtering them, which is slow on
+// databases with tens of thousands of objects. The catalogs get queried by
+// the schema and the names of the tables directly instead, returning the
+// columns as the information_schema does, so the generated code stays the
+// same.
+
+// pgCatalogColumns selects the columns of tables like information_schema
+// columns joined with the key constraints: once per primary key, unique and
+// foreign key constraint they are part of, to merge by MergeColumns. Domains
+// are reported by their base type, arrays as ARRAY with the name of the array
+// type like _int4 and types not in pg_catalog as USER-DEFINED. The schema and
+// the tables are conditions to add.
+const pgCatalogColumns = `
+				a.attnum AS ordinal_position,
+				a.attname AS column_name,
+				CASE
+					WHEN bt.typelem <> 0 AND bt.typlen = -1 THEN 'ARRAY'
+					WHEN bn.nspname = 'pg_catalog' THEN format_type(bt.oid, NULL)
+					ELSE 'USER-DEFINED'
+				END AS data_type,
+				bt.typname AS udt_name,
+				pg_get_expr(ad.adbin, ad.adrelid) AS column_default,
+				CASE WHEN a.attnotnull OR (t.typtype = 'd' AND t.typnotnull) THEN 'NO' ELSE 'YES' END AS is_nullable,
+				CASE
+					WHEN m.typmod = -1 THEN NULL
+					WHEN bt.oid IN (1042, 1043) THEN m.typmod - 4
+					WHEN bt.oid IN (1560, 1562) THEN m.typmod
+				END AS character_maximum_length,
+				CASE bt.oid
+					WHEN 21 THEN 16
+					WHEN 23 THEN 32
+					WHEN 20 THEN 64
+					WHEN 700 THEN 24
+					WHEN 701 THEN 53
+					WHEN 1700 THEN CASE WHEN m.typmod <> -1 THEN ((m.typmod - 4) >> 16) & 65535 END
+				END AS numeric_precision,
+				con.conname AS constraint_name,
+				CASE con.contype
+					WHEN 'p' THEN 'PRIMARY KEY'
+					WHEN 'u' THEN 'UNIQUE'
+					WHEN 'f' THEN 'FOREIGN KEY'
+				END AS constraint_type,
+				col_description(c.oid, a.attnum) AS column_comment
+			FROM pg_catalog.pg_attribute AS a
+				JOIN pg_catalog.pg_class AS c ON c.oid = a.attrelid
+				JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+				JOIN pg_catalog.pg_type AS t ON t.oid = a.atttypid
+				JOIN pg_catalog.pg_type AS bt ON bt.oid = CASE WHEN t.typtype = 'd' THEN t.typbasetype ELSE t.oid END
+				JOIN pg_catalog.pg_namespace AS bn ON bn.oid = bt.typnamespace
+				CROSS JOIN LATERAL (SELECT CASE WHEN t.typtype = 'd' THEN t.typtypmod ELSE a.atttypmod END AS typmod) AS m
+				LEFT JOIN pg_catalog.pg_attrdef AS ad ON ad.adrelid = a.attrelid
+				AND ad.adnum = a.attnum
+				LEFT JOIN pg_catalog.pg_constraint AS con ON con.conrelid = a.attrelid
+				AND con.contype IN ('p', 'u', 'f')
+				AND a.attnum = ANY (con.conkey)
+			WHERE a.attnum > 0
+			AND NOT a.attisdropped
+`
+
+// pgRelkinds returns the kinds of relations of pg_class of the types of the
+// tables to get by the settings, quoted.
+func pgRelkinds(s *settings.Settings) string {
+	relkinds := map[string][]string{
+		TableTypeBase:             {"'r'", "'p'"},
+		TableTypeView:             {"'v'"},
+		TableTypeMaterializedView: {"'m'"},
+	}
+	var kinds []string
+	for _, typ := range tableTypes(s, TableTypeBase, TableTypeView, TableTypeMaterializedView) {
+		kinds = append(kinds, relkinds[typ]...)
+	}
+	return strings.Join(kinds, ", ")
+}
+
+// getTablesByCatalog is GetTables by the system catalogs.
+func (pg *Postgresql) getTablesByCatalog(ctx context.Context, tables ...string) ([]*Table, error) {
+
+	args := []any{pg.Schema}
+	in := andTablesClause(pg.andInClause, "LOWER(c.relname)", "c.relname", strings.ToLower, tables, &args)
+
+	var dbTables []*Table
+	err := pg.retry(ctx, func() error {
+		dbTables = nil
+		return pg.SelectContext(ctx, &dbTables, `
+			SELECT
+				c.relname AS table_name,
+				CASE c.relkind
+					WHEN 'v' THEN '`+TableTypeView+`'
+					WHEN 'm' THEN '`+TableTypeMaterializedView+`'
+					ELSE '`+TableTypeBase+`'
+				END AS table_type,
+				obj_description(c.oid, 'pg_class') AS table_comment
+			FROM pg_catalog.pg_class AS c
+				JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+			WHERE c.relkind IN (`+pgRelkinds(pg.Settings)+`)
+			AND n.nspname = $1
+			`+in+`
+			ORDER BY c.relname
+		`, args...)
+	})
+
+	if err != nil {
+		slog.Debug("could not get tables", "schema", pg.Schema, "error", err)
+	}
+
+	return dbTables, err
+}
+
+// prepareGetColumnsOfTableStmtByCatalog is PrepareGetColumnsOfTableStmt by
+// the system catalogs.
+func (pg *Postgresql) prepareGetColumnsOfTableStmtByCatalog(ctx context.Context) error {
+	return pg.retry(ctx, func() (err error) {
+		pg.GetColumnsOfTableStmt, err = pg.PreparexContext(ctx, `
+			SELECT`+pgCatalogColumns+`
+			AND c.relname = $1
+			AND n.nspname = $2
+			ORDER BY a.attnum, con.conname
+		`)
+		return err
+	})
+}
+
+// getColumnsOfTablesByCatalog is GetColumnsOfTables by the system catalogs.
+func (pg *Postgresql) getColumnsOfTablesByCatalog(ctx context.Context, tables []*Table) error {
+
+	args := []any{pg.Schema}
+	in := pg.andInClause("c.relname", tableNames(tables), &args)
+
+	var rows []tableColumn
+	err := pg.retry(ctx, func() error {
+		rows = nil
+		return pg.SelectContext(ctx, &rows, `
+			SELECT
+				c.relname AS table_name,`+pgCatalogColumns+`
+			AND n.nspname = $1
+			`+in+`
+			ORDER BY c.relname, a.attnum, con.conname
+		`, args...)
+	})
+	if err != nil {
+		slog.Debug("could not get columns of tables", "count", len(tables), "schema", pg.Schema, "error", err)
+		return err
+	}
+
+	groupColumns(tables, rows)
+	for _, table := range tables {
+		table.Columns = MergeColumns(table.Columns)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// pgConstraint is a key constraint of a column, its name and type are
+// invalid if the table_constraints do not show it to the user.
+type pgConstraint struct {
+	TableName  string         `db:"table_name"`
+	ColumnName string         `db:"column_name"`
+	Name       sql.NullString `db:"constraint_name"`
+	Type       sql.NullString `db:"constraint_type"`
+}
+
+// pgConstraints are the key constraints of the columns by the names of their
+// table and column. They get read once per schema instead of joining the
+// views key_column_usage and table_constraints for every single table, which
+// dominates the run time on large schemas.
+type pgConstraints map[string]map[string][]pgConstraint
+
+// newPgConstraints groups the constraints by table and column, keeping their
+// order.
+func newPgConstraints(rows []pgConstraint) pgConstraints {
+	constraints := pgConstraints{}
+	for _, row := range rows {
+		if constraints[row.TableName] == nil {
+			constraints[row.TableName] = map[string][]pgConstraint{}
+		}
+		constraints[row.TableName][row.ColumnName] = append(constraints[row.TableName][row.ColumnName], row)
+	}
+	return constraints
+}
+
+// columns returns the columns of the table with the key constraints they are
+// part of, see MergeColumns.
+func (c pgConstraints) columns(table string, columns []Column) []Column {
+	byColumn := c[table]
+	if len(byColumn) == 0 {
+		return columns
+	}
+
+	columns = slices.Clone(columns)
+	for i := range columns {
+		for _, constraint := range byColumn[columns[i].Name] {
+			if constraint.Name.Valid {
+				columns[i].addConstraint(Constraint{Name: constraint.Name.String, Type: constraint.Type.String})
+			}
+		}
+	}
+	return columns
+}
+
+// loadConstraints reads the key constraints of the tables of the schema, or
+// only of the given ones, for the columns read afterwards.
+func (pg *Postgresql) loadConstraints(ctx context.Context, tables ...string) error {
+
+	args := []any{pg.Schema}
+	in := andTablesClause(pg.andInClause, "LOWER(ikcu.table_name)", "ikcu.table_name", strings.ToLower, tables, &args)
+
+	var rows []pgConstraint
+	err := pg.retry(ctx, func() error {
+		rows = nil
+		return pg.SelectContext(ctx, &rows, `
+			SELECT
+				ikcu.table_name,
+				ikcu.column_name,
+				itc.constraint_name,
+				itc.constraint_type
+			FROM information_schema.key_column_usage AS ikcu
+				LEFT JOIN information_schema.table_constraints AS itc ON ikcu.table_name = itc.table_name
+				AND ikcu.table_schema = itc.table_schema
+				AND ikcu.constraint_name = itc.constraint_name
+			WHERE ikcu.table_schema = $1
+			`+in+`
+			ORDER BY ikcu.table_name, ikcu.column_name, itc.constraint_name
+		`, args...)
+	})
+	if err != nil {
+		slog.Debug("could not get constraints", "schema", pg.Schema, "error", err)
+		return err
+	}
+
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+	pg.constraints = newPgConstraints(rows)
+	return nil
+}
+
+// keyConstraints returns the key constraints read by GetTables, or reads the
+// ones of the whole schema if GetTables did not.
+func (pg *Postgresql) keyConstraints(ctx context.Context) (pgConstraints, error) {
+	pg.mu.Lock()
+	constraints := pg.constraints
+	pg.mu.Unlock()
+	if constraints != nil {
+		return constraints, nil
+	}
+
+	if err := pg.loadConstraints(ctx); err != nil {
+		return nil, err
+	}
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+	return pg.constraints, nil
+}
@@ -0,0 +1,23 @@
+//go:build kerberos
+
+// Package database/postgresql_kerberos.go registers the Kerberos (GSSAPI)
+// provider of the Postgres driver. It will get only included in the build if
+// the tag `kerberos` is specified, its module is not vendored:
+//
+//	go get github.com/lib/pq/auth/kerberos
+//	go {install/build} -tags kerberos .
+//
+// Alternative the Makefile can be used which is an alias for the go commands
+// above:
+//
+//	make kerberos
+package database
+
+import (
+	"github.com/lib/pq"
+	"github.com/lib/pq/auth/kerberos"
+)
+
+func init() {
+	pq.RegisterGSSProvider(func() (pq.GSS, error) { return kerberos.NewGSS() })
+}
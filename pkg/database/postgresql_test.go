@@ -1,8 +1,13 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
 	"testing"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
@@ -56,11 +61,86 @@ func TestPostgresql_DSN(t *testing.T) {
 				return s
 			},
 			expected: func(s *settings.Settings) string {
-				expected := "postgres://my_custom_user:mysecretpassword@"
-				expected += "?" + s.Socket + "&" + s.Port + "&sslmode=" + s.SSLMode
+				expected := "postgres://my_custom_user:mysecretpassword@/"
+				expected += s.DbName + "?host=%2Ftmp&port=" + s.Port + "&sslmode=" + s.SSLMode
 				return expected
 			},
 		},
+		{
+			desc: "with socket file, its port wins",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypePostgresql
+				s.DbName = "shop"
+				s.Port = "5432"
+				s.SSLMode = "disable"
+				s.Socket = "/var/run/postgresql/.s.PGSQL.5433"
+				return s
+			},
+			expected: func(*settings.Settings) string {
+				return "postgres://postgres:@/shop?host=%2Fvar%2Frun%2Fpostgresql&port=5433&sslmode=disable"
+			},
+		},
+		{
+			desc: "RDS IAM auth token gets escaped",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypePostgresql
+				s.User = "shop"
+				s.Pswd = "db.rds.amazonaws.com:5432/?Action=connect&DBUser=shop&X-Amz-Signature=abc"
+				s.Port = "5432"
+				s.SSLMode = "require"
+				return s
+			},
+			expected: func(*settings.Settings) string {
+				return "postgres://shop:db.rds.amazonaws.com%3A5432%2F%3FAction=connect&DBUser=shop&X-Amz-Signature=abc@127.0.0.1:5432/postgres?sslmode=require"
+			},
+		},
+		{
+			desc: "with TLS certificates",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypePostgresql
+				s.Port = "5432"
+				s.SSLMode = "verify-full"
+				s.SSLCert = "/etc/ssl/client.pem"
+				s.SSLKey = "/etc/ssl/client key.pem"
+				s.SSLRootCert = "/etc/ssl/ca.pem"
+				return s
+			},
+			expected: func(*settings.Settings) string {
+				return "postgres://postgres:@127.0.0.1:5432/postgres?sslmode=verify-full" +
+					"&sslcert=%2Fetc%2Fssl%2Fclient.pem&sslkey=%2Fetc%2Fssl%2Fclient+key.pem&sslrootcert=%2Fetc%2Fssl%2Fca.pem"
+			},
+		},
+		{
+			desc: "with Kerberos service name",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypePostgresql
+				s.User = "shop@EXAMPLE.COM"
+				s.Port = "5432"
+				s.SSLMode = "require"
+				s.KrbSrvName = "pgsql"
+				return s
+			},
+			expected: func(*settings.Settings) string {
+				return "postgres://shop%40EXAMPLE.COM:@127.0.0.1:5432/postgres?sslmode=require&krbsrvname=pgsql"
+			},
+		},
+		{
+			desc: "raw DSN bypasses the builder",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.DbType = settings.DBTypePostgresql
+				s.User = "my_custom_user"
+				s.DSN = "host=db.local user=shop dbname=shop sslmode=verify-full sslrootcert=/etc/ssl/db.pem"
+				return s
+			},
+			expected: func(s *settings.Settings) string {
+				return s.DSN
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
@@ -138,3 +218,187 @@ func TestPostgresql_andInClause(t *testing.T) {
 		})
 	}
 }
+
+// queryConn records the prepared and queried statements and returns no rows.
+type queryConn struct {
+	fakeConn
+	queries []string
+}
+
+func (c *queryConn) Prepare(query string) (driver.Stmt, error) {
+	c.queries = append(c.queries, query)
+	return fakeStmt{}, nil
+}
+
+func (c *queryConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.queries = append(c.queries, query)
+	return noRows{}, nil
+}
+
+type noRows struct{}
+
+func (noRows) Columns() []string {
+	return []string{"table_name"}
+}
+
+func (noRows) Close() error {
+	return nil
+}
+
+func (noRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+// queryConnector always returns the same connection.
+type queryConnector struct {
+	conn *queryConn
+}
+
+func (c queryConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c queryConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+func TestPostgresql_PgCatalog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc      string
+		pgCatalog bool
+		expected  string
+		queries   int
+	}{
+		{
+			desc:      "information_schema by default, the constraints read once",
+			pgCatalog: false,
+			expected:  "information_schema.",
+			queries:   4,
+		},
+		{
+			desc:      "system catalogs",
+			pgCatalog: true,
+			expected:  "pg_catalog.pg_class",
+			queries:   3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			conn := &queryConn{}
+			s := settings.New()
+			s.PgCatalog = test.pgCatalog
+			pg := NewPostgresql(s)
+			pg.DB = sqlx.NewDb(sql.OpenDB(queryConnector{conn: conn}), "postgres")
+			defer pg.Close()
+
+			ctx := context.Background()
+			_, err := pg.GetTables(ctx, "users")
+			assert.NoError(t, err)
+			assert.NoError(t, pg.PrepareGetColumnsOfTableStmt(ctx))
+			assert.NoError(t, pg.GetColumnsOfTables(ctx, []*Table{{Name: "users"}}))
+
+			assert.Len(t, conn.queries, test.queries)
+			for _, query := range conn.queries {
+				assert.Contains(t, query, test.expected)
+				if test.pgCatalog {
+					assert.NotContains(t, query, "information_schema")
+				}
+			}
+		})
+	}
+}
+
+func TestPostgresql_GetEnumsOfTable(t *testing.T) {
+	t.Parallel()
+
+	conn := &columnsConn{
+		columns: []string{"column_name", "type_name", "enum_value"},
+		rows: [][]driver.Value{
+			{"status", "order_status", "pending"},
+			{"status", "order_status", "shipped"},
+			{"channel", "channel", "web"},
+		},
+	}
+	s := settings.New()
+	pg := NewPostgresql(s)
+	pg.DB = sqlx.NewDb(sql.OpenDB(columnsConnector{conn: conn}), "postgres")
+	defer pg.Close()
+
+	table := &Table{Name: "orders", Columns: []Column{
+		{Name: "id", DataType: "integer"},
+		{Name: "status", DataType: "USER-DEFINED"},
+		{Name: "channel", DataType: "USER-DEFINED"},
+	}}
+	assert.NoError(t, pg.GetEnumsOfTable(context.Background(), table))
+
+	assert.Contains(t, conn.query, "pg_enum")
+	assert.Equal(t, []driver.Value{"public", "orders"}, conn.args)
+	assert.Equal(t, []Column{
+		{Name: "id", DataType: "integer"},
+		{Name: "status", DataType: "USER-DEFINED", EnumType: "order_status", EnumValues: []string{"pending", "shipped"}},
+		{Name: "channel", DataType: "USER-DEFINED", EnumType: "channel", EnumValues: []string{"web"}},
+	}, table.Columns)
+}
+
+func TestPgConstraints_columns(t *testing.T) {
+	t.Parallel()
+
+	valid := func(s string) sql.NullString {
+		return sql.NullString{String: s, Valid: true}
+	}
+	constraints := newPgConstraints([]pgConstraint{
+		{TableName: "orders", ColumnName: "id", Name: valid("orders_pkey"), Type: valid("PRIMARY KEY")},
+		{TableName: "orders", ColumnName: "user_id", Name: valid("orders_user_id_fkey"), Type: valid("FOREIGN KEY")},
+		{TableName: "orders", ColumnName: "user_id", Name: valid("orders_user_id_key"), Type: valid("UNIQUE")},
+		{TableName: "users", ColumnName: "id", Name: valid("users_pkey"), Type: valid("PRIMARY KEY")},
+	})
+	columns := []Column{{OrdinalPosition: 1, Name: "id"}, {OrdinalPosition: 2, Name: "user_id"}, {OrdinalPosition: 3, Name: "note"}}
+
+	tests := []struct {
+		desc     string
+		table    string
+		expected []Column
+	}{
+		{
+			desc:  "once with all constraints",
+			table: "orders",
+			expected: []Column{
+				{
+					OrdinalPosition: 1, Name: "id", ConstraintName: valid("orders_pkey"), ConstraintType: valid("PRIMARY KEY"),
+					Constraints: []Constraint{{Name: "orders_pkey", Type: "PRIMARY KEY"}},
+				},
+				{
+					OrdinalPosition: 2, Name: "user_id", ConstraintName: valid("orders_user_id_fkey"), ConstraintType: valid("FOREIGN KEY"),
+					Constraints: []Constraint{{Name: "orders_user_id_fkey", Type: "FOREIGN KEY"}, {Name: "orders_user_id_key", Type: "UNIQUE"}},
+				},
+				{OrdinalPosition: 3, Name: "note"},
+			},
+		},
+		{
+			desc:  "only the constraints of the table",
+			table: "users",
+			expected: []Column{
+				{
+					OrdinalPosition: 1, Name: "id", ConstraintName: valid("users_pkey"), ConstraintType: valid("PRIMARY KEY"),
+					Constraints: []Constraint{{Name: "users_pkey", Type: "PRIMARY KEY"}},
+				},
+				{OrdinalPosition: 2, Name: "user_id"},
+				{OrdinalPosition: 3, Name: "note"},
+			},
+		},
+		{
+			desc:     "table without constraints",
+			table:    "logs",
+			expected: columns,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, constraints.columns(test.table, columns))
+		})
+	}
+}
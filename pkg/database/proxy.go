@@ -0,0 +1,314 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// mysqlProxyNet is the network the dial function of the proxy is registered
+// by at the MySQL driver.
+const mysqlProxyNet = "tables-to-go-proxy"
+
+// proxyConnector returns the connector of the driver of the given name for
+// the dsn, whose connections get dialed through the proxy of the URL.
+func proxyConnector(driverName, dsn, proxyURL string) (driver.Connector, error) {
+	dialer, err := newProxyDialer(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch driverName {
+	case "postgres":
+		connector, err := pq.NewConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+		connector.Dialer(dialer)
+		return connector, nil
+	case "mysql":
+		cfg, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Net != "tcp" {
+			return nil, fmt.Errorf("proxy supports tcp connections only, not %s", cfg.Net)
+		}
+		mysql.RegisterDialContext(mysqlProxyNet, func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", addr)
+		})
+		cfg.Net = mysqlProxyNet
+		return mysql.NewConnector(cfg)
+	}
+	return nil, fmt.Errorf("proxy not supported by driver %s", driverName)
+}
+
+// proxyDialer dials the connections through a SOCKS5 proxy (socks5, or
+// socks5h resolving the host names by the proxy) or an HTTP proxy by CONNECT
+// (http), authenticating by the user and password of the URL, if any. It
+// implements the Dialer of the Postgres driver.
+type proxyDialer struct {
+	proxy  *url.URL
+	dialer net.Dialer
+}
+
+// newProxyDialer returns the dialer of the proxy of the URL.
+func newProxyDialer(proxyURL string) (*proxyDialer, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "socks5", "socks5h", "http":
+	default:
+		return nil, fmt.Errorf("proxy scheme %q not supported, use socks5, socks5h or http", u.Scheme)
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("proxy URL %q needs the host and port of the proxy", u.Redacted())
+	}
+	return &proxyDialer{proxy: u}, nil
+}
+
+// Dial connects to the address through the proxy.
+func (d *proxyDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialTimeout connects to the address through the proxy within the timeout.
+func (d *proxyDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return d.DialContext(ctx, network, address)
+}
+
+// DialContext connects to the address through the proxy, the handshake with
+// the proxy is limited by the deadline of the context.
+func (d *proxyDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if network != "tcp" {
+		return nil, fmt.Errorf("proxy supports tcp connections only, not %s", network)
+	}
+
+	conn, err := d.dialer.DialContext(ctx, "tcp", d.proxy.Host)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to proxy %s: %w", d.proxy.Host, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	proxied := conn
+	if d.proxy.Scheme == "http" {
+		proxied, err = d.connectHTTP(conn, address)
+	} else {
+		err = d.connectSOCKS5(ctx, conn, address)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not connect to %s through proxy %s: %w", address, d.proxy.Host, err)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+	return proxied, nil
+}
+
+// connectHTTP lets the HTTP proxy connect to the address by CONNECT.
+func (d *proxyDialer) connectHTTP(conn net.Conn, address string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+	if user := d.proxy.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy responded %s", resp.Status)
+	}
+	// the database may send its greeting right away, e.g. MySQL, which the
+	// reader may have buffered already
+	return bufferedConn{Conn: conn, r: r}, nil
+}
+
+// bufferedConn reads the connection by the reader buffering it.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// The constants of the SOCKS5 protocol, RFC 1928 and RFC 1929.
+const (
+	socks5Version          = 0x05
+	socks5NoAuth           = 0x00
+	socks5UserPassAuth     = 0x02
+	socks5NoAcceptableAuth = 0xff
+	socks5Connect          = 0x01
+	socks5IPv4             = 0x01
+	socks5DomainName       = 0x03
+	socks5IPv6             = 0x04
+	socks5Succeeded        = 0x00
+)
+
+// socks5Replies are the failures of the replies of SOCKS5 proxies.
+var socks5Replies = map[byte]string{
+	0x01: "general SOCKS server failure",
+	0x02: "connection not allowed by ruleset",
+	0x03: "network unreachable",
+	0x04: "host unreachable",
+	0x05: "connection refused",
+	0x06: "TTL expired",
+	0x07: "command not supported",
+	0x08: "address type not supported",
+}
+
+// connectSOCKS5 lets the SOCKS5 proxy connect to the address.
+func (d *proxyDialer) connectSOCKS5(ctx context.Context, conn net.Conn, address string) error {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port %q", portStr)
+	}
+
+	methods := []byte{socks5NoAuth}
+	if d.proxy.User != nil {
+		methods = append(methods, socks5UserPassAuth)
+	}
+	if _, err = conn.Write(append([]byte{socks5Version, byte(len(methods))}, methods...)); err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	if _, err = io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[0] != socks5Version {
+		return fmt.Errorf("no SOCKS5 proxy, version %d", reply[0])
+	}
+	switch reply[1] {
+	case socks5NoAuth:
+	case socks5UserPassAuth:
+		if err = d.authenticateSOCKS5(conn); err != nil {
+			return err
+		}
+	case socks5NoAcceptableAuth:
+		return errors.New("proxy accepts none of the authentication methods")
+	default:
+		return fmt.Errorf("proxy chose unsupported authentication method %d", reply[1])
+	}
+
+	req := []byte{socks5Version, socks5Connect, 0x00}
+	ip := net.ParseIP(host)
+	if ip == nil && d.proxy.Scheme == "socks5" {
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+		if err != nil {
+			return err
+		}
+		ip = ips[0]
+	}
+	switch {
+	case ip == nil:
+		if len(host) > 255 {
+			return fmt.Errorf("host name %q too long", host)
+		}
+		req = append(req, socks5DomainName, byte(len(host)))
+		req = append(req, host...)
+	case ip.To4() != nil:
+		req = append(req, socks5IPv4)
+		req = append(req, ip.To4()...)
+	default:
+		req = append(req, socks5IPv6)
+		req = append(req, ip.To16()...)
+	}
+	req = binary.BigEndian.AppendUint16(req, uint16(port))
+	if _, err = conn.Write(req); err != nil {
+		return err
+	}
+
+	header := make([]byte, 4)
+	if _, err = io.ReadFull(conn, header); err != nil {
+		return err
+	}
+	if header[1] != socks5Succeeded {
+		if msg, ok := socks5Replies[header[1]]; ok {
+			return errors.New(msg)
+		}
+		return fmt.Errorf("proxy failed with reply %d", header[1])
+	}
+
+	// skip the address bound by the proxy
+	var n int
+	switch header[3] {
+	case socks5IPv4:
+		n = net.IPv4len
+	case socks5IPv6:
+		n = net.IPv6len
+	case socks5DomainName:
+		length := make([]byte, 1)
+		if _, err = io.ReadFull(conn, length); err != nil {
+			return err
+		}
+		n = int(length[0])
+	default:
+		return fmt.Errorf("proxy replied unknown address type %d", header[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, n+2))
+	return err
+}
+
+// authenticateSOCKS5 authenticates at the SOCKS5 proxy by the user and
+// password of the URL.
+func (d *proxyDialer) authenticateSOCKS5(conn net.Conn) error {
+	user := d.proxy.User.Username()
+	password, _ := d.proxy.User.Password()
+	if len(user) > 255 || len(password) > 255 {
+		return errors.New("proxy user and password can not be longer than 255 bytes")
+	}
+
+	req := []byte{0x01, byte(len(user))}
+	req = append(req, user...)
+	req = append(req, byte(len(password)))
+	req = append(req, password...)
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[1] != 0x00 {
+		return errors.New("proxy rejected the user and password")
+	}
+	return nil
+}
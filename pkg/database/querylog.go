@@ -0,0 +1,213 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// openQueryLog opens the database like sql.Open but logs every statement
+// executed on its connections with the arguments, the duration and the error.
+func openQueryLog(driverName, dsn string) (*sql.DB, error) {
+	connector, err := openConnector(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(queryLogConnector{connector}), nil
+}
+
+// openConnector returns the connector of the driver of the given name for
+// the dsn, like the one sql.Open uses.
+func openConnector(driverName, dsn string) (driver.Connector, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	drv := db.Driver()
+	if err = db.Close(); err != nil {
+		return nil, err
+	}
+
+	if drvCtx, ok := drv.(driver.DriverContext); ok {
+		return drvCtx.OpenConnector(dsn)
+	}
+	return dsnConnector{dsn: dsn, driver: drv}, nil
+}
+
+// logQuery logs the statement executed with the arguments since start.
+// Statements get logged on one line, the durations of queries are the ones
+// until the first row is available.
+func logQuery(query string, args []driver.NamedValue, start time.Time, err error) {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	attrs := []any{"query", strings.Join(strings.Fields(query), " "), "args", values, "duration", time.Since(start)}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	slog.Info("query", attrs...)
+}
+
+// dsnConnector is the connector of drivers without their own, like the one
+// used by sql.Open.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// queryLogConnector wraps the connections of the connector with queryLogConn.
+type queryLogConnector struct {
+	driver.Connector
+}
+
+func (c queryLogConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return queryLogConn{conn}, nil
+}
+
+// queryLogConn logs the statements executed on the connection. The optional
+// interfaces of database/sql/driver are forwarded, if the connection does
+// not implement one, database/sql falls back as it would without logging.
+type queryLogConn struct {
+	driver.Conn
+}
+
+func (c queryLogConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c queryLogConn) PrepareContext(ctx context.Context, query string) (stmt driver.Stmt, err error) {
+	if prep, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = prep.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return queryLogStmt{Stmt: stmt, query: query}, nil
+}
+
+func (c queryLogConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if !errors.Is(err, driver.ErrSkip) {
+		logQuery(query, args, start, err)
+	}
+	return rows, err
+}
+
+func (c queryLogConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	if !errors.Is(err, driver.ErrSkip) {
+		logQuery(query, args, start, err)
+	}
+	return result, err
+}
+
+func (c queryLogConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c queryLogConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c queryLogConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+func (c queryLogConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c queryLogConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// queryLogStmt logs the executions of the prepared statement.
+type queryLogStmt struct {
+	driver.Stmt
+	query string
+}
+
+func (s queryLogStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (rows driver.Rows, err error) {
+	start := time.Now()
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			rows, err = s.Stmt.Query(values)
+		}
+	}
+	logQuery(s.query, args, start, err)
+	return rows, err
+}
+
+func (s queryLogStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (result driver.Result, err error) {
+	start := time.Now()
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			result, err = s.Stmt.Exec(values)
+		}
+	}
+	logQuery(s.query, args, start, err)
+	return result, err
+}
+
+// namedValuesToValues converts the arguments for the statements of drivers
+// not supporting named arguments.
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("driver does not support named arguments")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
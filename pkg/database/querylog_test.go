@@ -0,0 +1,114 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	sql.Register("querylog-test", fakeDriver{})
+}
+
+// fakeDriver returns a single row holding the number of arguments for every
+// query.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) {
+	return fakeStmt{}, nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if query == "FAIL" {
+		return nil, errors.New("syntax error")
+	}
+	return &fakeRows{n: int64(len(args))}, nil
+}
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error {
+	return nil
+}
+
+func (fakeStmt) NumInput() int {
+	return -1
+}
+
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.ResultNoRows, nil
+}
+
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{n: int64(len(args))}, nil
+}
+
+type fakeRows struct {
+	n    int64
+	done bool
+}
+
+func (*fakeRows) Columns() []string {
+	return []string{"n"}
+}
+
+func (*fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.n
+	return nil
+}
+
+func TestOpenQueryLog(t *testing.T) {
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	db, err := openQueryLog("querylog-test", "")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var n int64
+	assert.NoError(t, db.QueryRow("SELECT n\n\t\tFROM t\n\t\tWHERE a = ? AND b = ?", "users", "public").Scan(&n))
+	assert.Equal(t, int64(2), n)
+	assert.Contains(t, buf.String(), `msg=query query="SELECT n FROM t WHERE a = ? AND b = ?" args="[users public]" duration=`)
+
+	buf.Reset()
+	stmt, err := db.Prepare("SELECT n FROM columns WHERE table_name = ?")
+	assert.NoError(t, err)
+	defer stmt.Close()
+	assert.NoError(t, stmt.QueryRow("orders").Scan(&n))
+	assert.Equal(t, int64(1), n)
+	assert.Contains(t, buf.String(), `msg=query query="SELECT n FROM columns WHERE table_name = ?" args=[orders] duration=`)
+
+	buf.Reset()
+	assert.Error(t, db.QueryRow("FAIL").Scan(&n))
+	assert.Contains(t, buf.String(), `error="syntax error"`)
+}
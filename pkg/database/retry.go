@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/sijms/go-ora/v2/network"
+)
+
+// maxRetryDelay caps the doubled delay between two retries.
+const maxRetryDelay = 30 * time.Second
+
+// maxReconnects limits how often an operation gets run again on a new
+// connection, besides the retries.
+const maxReconnects = 3
+
+// retry runs op and retries it as configured by the settings as long as it
+// fails. Authentication errors are not retried as they will not go away by
+// waiting, neither are cancellations of ctx. Once connected, operations
+// failing by a dropped connection get run again on a new one right away, see
+// reconnecting.
+func (gdb *GeneralDatabase) retry(ctx context.Context, op func() error) error {
+	delay := gdb.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err := gdb.reconnecting(ctx, op)
+		if err == nil || attempt > gdb.Retries || isAuthError(err) || ctx.Err() != nil {
+			return err
+		}
+
+		slog.Warn("database operation failed, retrying",
+			"attempt", attempt, "retries", gdb.Retries, "delay", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		delay = min(2*delay, maxRetryDelay)
+	}
+}
+
+// reconnecting runs op, and runs it again if it failed by a dropped
+// connection, e.g. closed by the idle timeout of a cloud database during a
+// long run. The idle connections get closed as they likely dropped as well,
+// database/sql opens new ones and prepares the statements on them again. The
+// connection of the snapshot can not be replaced, its transaction is gone.
+func (gdb *GeneralDatabase) reconnecting(ctx context.Context, op func() error) error {
+	for reconnects := 0; ; reconnects++ {
+		err := op()
+		if err == nil || !gdb.connected || gdb.tx != nil || reconnects == maxReconnects ||
+			ctx.Err() != nil || !isConnectionError(err) {
+			return err
+		}
+
+		slog.Warn("connection to the database dropped, reconnecting", "reconnect", reconnects+1, "error", err)
+		gdb.closeIdleConns()
+	}
+}
+
+// closeIdleConns closes the idle connections of the pool, keeping the number
+// of idle connections of the settings.
+func (gdb *GeneralDatabase) closeIdleConns() {
+	if gdb.DB == nil {
+		return
+	}
+	idle := gdb.MaxIdleConns
+	if idle <= 0 {
+		// the default of database/sql
+		idle = 2
+	}
+	gdb.DB.SetMaxIdleConns(0)
+	gdb.DB.SetMaxIdleConns(idle)
+}
+
+// isConnectionError returns true if err is caused by a dropped connection to
+// the database.
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// isAuthError returns true if err is caused by an invalid user or password or
+// missing privileges to connect.
+func isAuthError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// class 28: invalid authorization specification
+		return pqErr.Code.Class() == "28"
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case 1044, // access denied to the database
+			1045, // access denied for the user
+			1698: // access denied without password
+			return true
+		}
+		return false
+	}
+
+	var oraErr *network.OracleError
+	if errors.As(err, &oraErr) {
+		switch oraErr.ErrCode {
+		case 1017, // invalid username/password
+			1045,  // user lacks CREATE SESSION privilege
+			28000, // account is locked
+			28001: // password has expired
+			return true
+		}
+		return false
+	}
+
+	return false
+}
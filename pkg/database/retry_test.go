@@ -0,0 +1,290 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sijms/go-ora/v2/network"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestGeneralDatabase_retry(t *testing.T) {
+	t.Parallel()
+
+	errTransient := errors.New("connection refused")
+	errAuth := &pq.Error{Code: "28P01"}
+
+	tests := []struct {
+		desc          string
+		retries       int
+		errs          []error
+		expected      error
+		expectedCalls int
+	}{
+		{
+			desc:          "success is not retried",
+			retries:       3,
+			errs:          []error{nil},
+			expected:      nil,
+			expectedCalls: 1,
+		},
+		{
+			desc:          "no retries by default",
+			retries:       0,
+			errs:          []error{errTransient, nil},
+			expected:      errTransient,
+			expectedCalls: 1,
+		},
+		{
+			desc:          "transient errors are retried until success",
+			retries:       3,
+			errs:          []error{errTransient, errTransient, nil},
+			expected:      nil,
+			expectedCalls: 3,
+		},
+		{
+			desc:          "last error is returned when the retries are used up",
+			retries:       2,
+			errs:          []error{errTransient, errTransient, errTransient, nil},
+			expected:      errTransient,
+			expectedCalls: 3,
+		},
+		{
+			desc:          "auth errors fail fast",
+			retries:       3,
+			errs:          []error{errAuth, nil},
+			expected:      errAuth,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			s := settings.New()
+			s.Retries = test.retries
+			s.RetryDelay = time.Millisecond
+			gdb := &GeneralDatabase{Settings: s}
+
+			calls := 0
+			err := gdb.retry(context.Background(), func() error {
+				err := test.errs[calls]
+				calls++
+				return err
+			})
+
+			assert.Equal(t, test.expected, err)
+			assert.Equal(t, test.expectedCalls, calls)
+		})
+	}
+}
+
+func TestGeneralDatabase_retry_Cancel(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	s.Retries = 3
+	s.RetryDelay = time.Hour
+	gdb := &GeneralDatabase{Settings: s}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errTransient := errors.New("connection refused")
+
+	calls := 0
+	err := gdb.retry(ctx, func() error {
+		calls++
+		time.AfterFunc(10*time.Millisecond, cancel)
+		return errTransient
+	})
+
+	assert.Equal(t, errTransient, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestGeneralDatabase_retry_Reconnect(t *testing.T) {
+	t.Parallel()
+
+	errDropped := fmt.Errorf("could not get columns: %w", io.ErrUnexpectedEOF)
+	errSyntax := errors.New("syntax error")
+
+	tests := []struct {
+		desc          string
+		disconnected  bool
+		snapshot      bool
+		errs          []error
+		expected      error
+		expectedCalls int
+	}{
+		{
+			desc:          "dropped connection is replaced without retries",
+			errs:          []error{errDropped, nil},
+			expected:      nil,
+			expectedCalls: 2,
+		},
+		{
+			desc:          "reconnects are limited",
+			errs:          []error{errDropped, errDropped, errDropped, errDropped, nil},
+			expected:      errDropped,
+			expectedCalls: maxReconnects + 1,
+		},
+		{
+			desc:          "other errors do not reconnect",
+			errs:          []error{errSyntax, nil},
+			expected:      errSyntax,
+			expectedCalls: 1,
+		},
+		{
+			desc:          "connecting does not reconnect",
+			disconnected:  true,
+			errs:          []error{errDropped, nil},
+			expected:      errDropped,
+			expectedCalls: 1,
+		},
+		{
+			desc:          "snapshot is gone with its connection",
+			snapshot:      true,
+			errs:          []error{errDropped, nil},
+			expected:      errDropped,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gdb := &GeneralDatabase{Settings: settings.New(), driver: "querylog-test"}
+			assert.NoError(t, gdb.Connect(context.Background(), ""))
+			defer gdb.Close()
+			gdb.connected = !test.disconnected
+			if test.snapshot {
+				gdb.tx = &sqlx.Tx{}
+				defer func() { gdb.tx = nil }()
+			}
+
+			calls := 0
+			err := gdb.retry(context.Background(), func() error {
+				err := test.errs[calls]
+				calls++
+				return err
+			})
+
+			assert.Equal(t, test.expected, err)
+			assert.Equal(t, test.expectedCalls, calls)
+		})
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		err      error
+		expected bool
+	}{
+		{
+			desc:     "bad connection",
+			err:      driver.ErrBadConn,
+			expected: true,
+		},
+		{
+			desc:     "connection closed by the server",
+			err:      fmt.Errorf("could not get tables: %w", io.ErrUnexpectedEOF),
+			expected: true,
+		},
+		{
+			desc:     "connection reset",
+			err:      &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			expected: true,
+		},
+		{
+			desc:     "mysql invalid connection",
+			err:      mysql.ErrInvalidConn,
+			expected: true,
+		},
+		{
+			desc:     "syntax error",
+			err:      &pq.Error{Code: "42601"},
+			expected: false,
+		},
+		{
+			desc:     "timeout of the run",
+			err:      context.DeadlineExceeded,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, isConnectionError(test.err))
+		})
+	}
+}
+
+func TestIsAuthError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		err      error
+		expected bool
+	}{
+		{
+			desc:     "postgres invalid password",
+			err:      &pq.Error{Code: "28P01"},
+			expected: true,
+		},
+		{
+			desc:     "postgres database starting up",
+			err:      &pq.Error{Code: "57P03"},
+			expected: false,
+		},
+		{
+			desc:     "mysql access denied",
+			err:      &mysql.MySQLError{Number: 1045},
+			expected: true,
+		},
+		{
+			desc:     "mysql too many connections",
+			err:      &mysql.MySQLError{Number: 1040},
+			expected: false,
+		},
+		{
+			desc:     "oracle invalid username or password",
+			err:      &network.OracleError{ErrCode: 1017},
+			expected: true,
+		},
+		{
+			desc:     "wrapped auth error",
+			err:      fmt.Errorf("could not connect: %w", &mysql.MySQLError{Number: 1044}),
+			expected: true,
+		},
+		{
+			desc:     "network error",
+			err:      errors.New("dial tcp 127.0.0.1:5432: connect: connection refused"),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, isAuthError(test.err))
+		})
+	}
+}
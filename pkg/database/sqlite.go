@@ -1,11 +1,16 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"net/url"
+	"strconv"
 	"strings"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
 )
 
@@ -26,12 +31,15 @@ func NewSQLite(s *settings.Settings) *SQLite {
 
 // Connect connects to the database by the given data source name (dsn) of the
 // concrete database.
-func (s *SQLite) Connect() (err error) {
-	return s.GeneralDatabase.Connect(s.DSN())
+func (s *SQLite) Connect(ctx context.Context) (err error) {
+	return s.GeneralDatabase.Connect(ctx, s.DSN())
 }
 
 // DSN creates the DSN String to connect to this database.
 func (s *SQLite) DSN() string {
+	if s.Settings.DSN != "" {
+		return s.Settings.DSN
+	}
 	if s.Settings.User == "" && s.Settings.Pswd == "" {
 		return s.Settings.DbName
 	}
@@ -50,47 +58,140 @@ func (s *SQLite) DSN() string {
 	return strings.ReplaceAll(u.RequestURI(), "_auth=&", "_auth&")
 }
 
-func (s *SQLite) GetTables(tables ...string) ([]*Table, error) {
+// sqliteTypes maps the types of the tables to the ones of sqlite_master.
+var sqliteTypes = map[string]string{
+	TableTypeBase: "table",
+	TableTypeView: "view",
+}
+
+func (s *SQLite) GetTables(ctx context.Context, tables ...string) ([]*Table, error) {
 
 	var args []any
-	in := s.andInClause("name", tables, &args)
+	in := andTablesClause(s.andInClause, "LOWER(name)", "name", strings.ToLower, tables, &args)
+
+	var types []string
+	for _, typ := range tableTypes(s.Settings, TableTypeBase, TableTypeView) {
+		types = append(types, sqliteTypes[typ])
+	}
 
 	var dbTables []*Table
-	err := s.Select(&dbTables, `
-		SELECT name AS table_name
-		FROM sqlite_master
-		WHERE type = 'table'
-		AND name NOT LIKE 'sqlite?_%' ESCAPE '?'
-		`+in+`
-	`, args...)
-
-	if s.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> database: %q\r\n", s.DbName)
-		}
+	err := s.retry(ctx, func() error {
+		dbTables = nil
+		return s.SelectContext(ctx, &dbTables, `
+			SELECT name AS table_name,
+				CASE type WHEN 'view' THEN '`+TableTypeView+`' ELSE '`+TableTypeBase+`' END AS table_type
+			FROM sqlite_master
+			WHERE `+inTableTypes("type", types)+`
+			AND name NOT LIKE 'sqlite?_%' ESCAPE '?'
+			`+in+`
+			ORDER BY name
+		`, args...)
+	})
+
+	if err != nil {
+		slog.Debug("could not get tables", "database", s.DbName, "error", err)
 	}
 
 	return dbTables, err
 }
 
-func (s *SQLite) PrepareGetColumnsOfTableStmt() (err error) {
-	return nil
+// GetTableInfo counts the rows of the table, SQLite has no table comments.
+func (s *SQLite) GetTableInfo(ctx context.Context, table *Table) (info TableInfo, err error) {
+	err = s.retry(ctx, func() error {
+		return s.GetContext(ctx, &info.Rows, `SELECT COUNT(*) FROM "`+strings.ReplaceAll(table.Name, `"`, `""`)+`"`)
+	})
+	if err != nil {
+		return info, fmt.Errorf("could not get info of table %q: %w", table.Name, err)
+	}
+	return info, nil
 }
 
-func (s *SQLite) GetColumnsOfTable(table *Table) (err error) {
+// GetForeignKeysOfTable is the implementation of the ForeignKeysGetter
+// interface. SQLite names no foreign keys, they are numbered by their id,
+// and references without columns reference the primary key.
+func (s *SQLite) GetForeignKeysOfTable(ctx context.Context, table *Table) error {
+	type foreignKey struct {
+		ID   int            `db:"id"`
+		Seq  int            `db:"seq"`
+		From string         `db:"from"`
+		Ref  string         `db:"table"`
+		To   sql.NullString `db:"to"`
+	}
 
-	rows, err := s.Queryx(`
-		SELECT * 
-		FROM PRAGMA_TABLE_INFO('` + table.Name + `')
-	`)
+	var keys []foreignKey
+	err := s.retry(ctx, func() error {
+		keys = nil
+		return s.SelectContext(ctx, &keys, `
+			SELECT id, seq, "table", "from", "to"
+			FROM PRAGMA_FOREIGN_KEY_LIST(?)
+			ORDER BY id, seq
+		`, table.Name)
+	})
 	if err != nil {
-		if s.Verbose {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> database: %q\r\n", s.DbName)
+		return fmt.Errorf("could not get foreign keys of table %q: %w", table.Name, err)
+	}
+
+	rows := make([]foreignKeyColumn, 0, len(keys))
+	primaryKeys := map[string][]string{}
+	for _, key := range keys {
+		to := key.To.String
+		if !key.To.Valid {
+			primaryKey, ok := primaryKeys[key.Ref]
+			if !ok {
+				if primaryKey, err = s.primaryKeyOf(ctx, key.Ref); err != nil {
+					return fmt.Errorf("could not get foreign keys of table %q: %w", table.Name, err)
+				}
+				primaryKeys[key.Ref] = primaryKey
+			}
+			if key.Seq < len(primaryKey) {
+				to = primaryKey[key.Seq]
+			}
 		}
+		rows = append(rows, foreignKeyColumn{
+			ConstraintName:       strconv.Itoa(key.ID),
+			ColumnName:           key.From,
+			ReferencedTableName:  key.Ref,
+			ReferencedColumnName: to,
+		})
+	}
+	table.ForeignKeys = groupForeignKeys(rows)
+	return nil
+}
+
+// primaryKeyOf returns the columns of the primary key of the table in their
+// order in the key.
+func (s *SQLite) primaryKeyOf(ctx context.Context, table string) (columns []string, err error) {
+	err = s.retry(ctx, func() error {
+		columns = nil
+		return s.SelectContext(ctx, &columns, `
+			SELECT name
+			FROM PRAGMA_TABLE_INFO(?)
+			WHERE pk > 0
+			ORDER BY pk
+		`, table)
+	})
+	return columns, err
+}
+
+func (s *SQLite) PrepareGetColumnsOfTableStmt(ctx context.Context) (err error) {
+	return nil
+}
+
+func (s *SQLite) GetColumnsOfTable(ctx context.Context, table *Table) (err error) {
+
+	var rows *sqlx.Rows
+	err = s.retry(ctx, func() (err error) {
+		rows, err = s.QueryxContext(ctx, `
+			SELECT *
+			FROM PRAGMA_TABLE_INFO(?)
+		`, table.Name)
+		return err
+	})
+	if err != nil {
+		slog.Debug("could not get columns of table", "table", table.Name, "database", s.DbName, "error", err)
 		return err
 	}
+	defer rows.Close()
 
 	type column struct {
 		CID          int            `db:"cid"`
@@ -134,7 +235,7 @@ func (s *SQLite) GetColumnsOfTable(table *Table) (err error) {
 		})
 	}
 
-	return nil
+	return rows.Err()
 }
 
 func (s *SQLite) IsPrimaryKey(column Column) bool {
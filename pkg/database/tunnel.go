@@ -0,0 +1,181 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// sshCommand creates the command of the ssh client, replaced by the tests.
+var sshCommand = exec.Command
+
+// SSHTunnel implements the Database interface by the wrapped one, connecting
+// through an SSH tunnel to the SSHHost of the settings, e.g. to databases only
+// reachable via a bastion host. The tunnel is a local port forwarded by the
+// ssh client of the system, so its config (~/.ssh/config), agent and known
+// hosts apply. It runs in batch mode and never prompts, keys with a
+// passphrase need the agent.
+type SSHTunnel struct {
+	Database
+	settings *settings.Settings
+
+	cmd    *exec.Cmd
+	stderr bytes.Buffer
+	exited chan struct{}
+	err    error
+}
+
+// NewSSHTunnel wraps the database by the SSH tunnel of the settings.
+func NewSSHTunnel(db Database, s *settings.Settings) *SSHTunnel {
+	return &SSHTunnel{
+		Database: db,
+		settings: s,
+	}
+}
+
+// Connect opens the tunnel and connects the wrapped database through it. The
+// host and port of the settings point to the local end of the tunnel only
+// while connecting, its DSN is kept by the connection pool.
+func (t *SSHTunnel) Connect(ctx context.Context) error {
+	local, err := t.open(ctx)
+	if err != nil {
+		return err
+	}
+
+	host, port := t.settings.Host, t.settings.Port
+	t.settings.Host, t.settings.Port, _ = net.SplitHostPort(local)
+	err = t.Database.Connect(ctx)
+	t.settings.Host, t.settings.Port = host, port
+	if err != nil {
+		return errors.Join(fmt.Errorf("through ssh tunnel %s: %w", t.settings.SSHHost, err), t.close())
+	}
+	return nil
+}
+
+// Close closes the wrapped database and the tunnel.
+func (t *SSHTunnel) Close() error {
+	return errors.Join(t.Database.Close(), t.close())
+}
+
+// Unwrap returns the wrapped database, its optional interfaces apply to the
+// tunnel.
+func (t *SSHTunnel) Unwrap() Database {
+	return t.Database
+}
+
+// open starts the ssh client forwarding a free local port to the database
+// and waits until it listens on it, at most the ConnectTimeout of the
+// settings. It returns the address of the local port.
+func (t *SSHTunnel) open(ctx context.Context) (string, error) {
+	local, err := freeLocalAddress()
+	if err != nil {
+		return "", fmt.Errorf("could not find a free local port for the ssh tunnel: %w", err)
+	}
+
+	t.stderr.Reset()
+	t.cmd = sshCommand("ssh", sshArgs(t.settings, local)...)
+	t.cmd.Stderr = &t.stderr
+	if err = t.cmd.Start(); err != nil {
+		t.cmd = nil
+		return "", fmt.Errorf("could not start ssh tunnel to %s: %w", t.settings.SSHHost, err)
+	}
+	t.exited = make(chan struct{})
+	go func(cmd *exec.Cmd) {
+		t.err = cmd.Wait()
+		close(t.exited)
+	}(t.cmd)
+
+	if t.settings.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.settings.ConnectTimeout)
+		defer cancel()
+	}
+	if err = t.await(ctx, local); err != nil {
+		return "", errors.Join(err, t.close())
+	}
+	return local, nil
+}
+
+// await polls the local port until the ssh client listens on it, which it
+// does after logging in to the SSH host.
+func (t *SSHTunnel) await(ctx context.Context, local string) error {
+	var dialer net.Dialer
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", local)
+		if err == nil {
+			return conn.Close()
+		}
+
+		select {
+		case <-t.exited:
+			msg := strings.TrimSpace(t.stderr.String())
+			if msg == "" {
+				msg = fmt.Sprint(t.err)
+			}
+			return fmt.Errorf("could not open ssh tunnel to %s: %s", t.settings.SSHHost, msg)
+		case <-ctx.Done():
+			return fmt.Errorf("could not open ssh tunnel to %s: %w", t.settings.SSHHost, ctx.Err())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// close stops the ssh client, if running.
+func (t *SSHTunnel) close() error {
+	if t.cmd == nil {
+		return nil
+	}
+	select {
+	case <-t.exited:
+	default:
+		if err := t.cmd.Process.Kill(); err != nil {
+			return fmt.Errorf("could not stop ssh tunnel: %w", err)
+		}
+		<-t.exited
+	}
+	t.cmd = nil
+	return nil
+}
+
+// sshArgs returns the arguments of the ssh client forwarding the local
+// address to the host and port of the database, as seen from the SSH host.
+// The host comes after --, so it is never taken for an option.
+func sshArgs(s *settings.Settings, local string) []string {
+	args := []string{
+		"-N",
+		"-o", "BatchMode=yes",
+		"-o", "ExitOnForwardFailure=yes",
+		"-L", local + ":" + net.JoinHostPort(s.Host, s.Port),
+	}
+
+	host := s.SSHHost
+	if h, port, err := net.SplitHostPort(s.SSHHost); err == nil {
+		host = h
+		args = append(args, "-p", port)
+	}
+	if s.SSHUser != "" {
+		args = append(args, "-l", s.SSHUser)
+	}
+	if s.SSHKey != "" {
+		args = append(args, "-i", s.SSHKey, "-o", "IdentitiesOnly=yes")
+	}
+	return append(args, "--", host)
+}
+
+// freeLocalAddress returns an address of the loopback interface with a port
+// not in use.
+func freeLocalAddress() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+	return l.Addr().String(), nil
+}
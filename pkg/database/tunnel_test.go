@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// TestSSHHelperProcess is not a real test, it is the ssh client run by the
+// tests of the tunnel: it listens on the local address of -L like ssh does
+// once logged in, unless the user is "denied".
+func TestSSHHelperProcess(t *testing.T) {
+	if os.Getenv("TABLES_TO_GO_SSH_HELPER") != "1" {
+		return
+	}
+
+	var local string
+	args := os.Args
+	for i, arg := range args {
+		switch {
+		case arg == "-l" && args[i+1] == "denied":
+			fmt.Fprintln(os.Stderr, "denied@bastion: Permission denied (publickey).")
+			os.Exit(255)
+		case arg == "-L":
+			parts := strings.SplitN(args[i+1], ":", 3)
+			local = parts[0] + ":" + parts[1]
+		}
+	}
+
+	l, err := net.Listen("tcp", local)
+	if err != nil {
+		os.Exit(1)
+	}
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			os.Exit(1)
+		}
+		conn.Close()
+	}
+}
+
+// helperSSHCommand runs TestSSHHelperProcess instead of the ssh client.
+func helperSSHCommand(_ string, args ...string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], append([]string{"-test.run=TestSSHHelperProcess", "--"}, args...)...)
+	cmd.Env = append(os.Environ(), "TABLES_TO_GO_SSH_HELPER=1")
+	return cmd
+}
+
+// addressDatabase records the host and port of the settings it connects to.
+type addressDatabase struct {
+	Database
+	settings  *settings.Settings
+	connected string
+	closed    bool
+}
+
+func (db *addressDatabase) Connect(context.Context) error {
+	db.connected = net.JoinHostPort(db.settings.Host, db.settings.Port)
+	return nil
+}
+
+func (db *addressDatabase) Close() error {
+	db.closed = true
+	return nil
+}
+
+func TestSSHTunnel(t *testing.T) {
+	sshCommand = helperSSHCommand
+	defer func() { sshCommand = exec.Command }()
+
+	s := settings.New()
+	s.Host = "db.internal"
+	s.Port = "5432"
+	s.SSHHost = "bastion"
+	db := &addressDatabase{settings: s}
+	tunnel := NewSSHTunnel(db, s)
+
+	assert.NoError(t, tunnel.Connect(context.Background()))
+	assert.True(t, strings.HasPrefix(db.connected, "127.0.0.1:"), db.connected)
+	assert.Equal(t, "db.internal", s.Host)
+	assert.Equal(t, "5432", s.Port)
+
+	conn, err := net.Dial("tcp", db.connected)
+	if assert.NoError(t, err) {
+		conn.Close()
+	}
+
+	assert.NoError(t, tunnel.Close())
+	assert.True(t, db.closed)
+	_, err = net.DialTimeout("tcp", db.connected, time.Second)
+	assert.Error(t, err)
+}
+
+func TestSSHTunnel_Denied(t *testing.T) {
+	sshCommand = helperSSHCommand
+	defer func() { sshCommand = exec.Command }()
+
+	s := settings.New()
+	s.SSHHost = "bastion"
+	s.SSHUser = "denied"
+	db := &addressDatabase{settings: s}
+
+	err := NewSSHTunnel(db, s).Connect(context.Background())
+	assert.EqualError(t, err, "could not open ssh tunnel to bastion: denied@bastion: Permission denied (publickey).")
+	assert.Empty(t, db.connected)
+}
+
+func TestSSHArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func(s *settings.Settings)
+		expected []string
+	}{
+		{
+			desc: "host only",
+			settings: func(s *settings.Settings) {
+				s.SSHHost = "bastion"
+			},
+			expected: []string{"--", "bastion"},
+		},
+		{
+			desc: "host with port, user and key",
+			settings: func(s *settings.Settings) {
+				s.SSHHost = "bastion:2222"
+				s.SSHUser = "deploy"
+				s.SSHKey = "/keys/deploy"
+			},
+			expected: []string{"-p", "2222", "-l", "deploy", "-i", "/keys/deploy", "-o", "IdentitiesOnly=yes", "--", "bastion"},
+		},
+		{
+			desc: "user in host",
+			settings: func(s *settings.Settings) {
+				s.SSHHost = "deploy@bastion"
+			},
+			expected: []string{"--", "deploy@bastion"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Host = "db.internal"
+			s.Port = "3306"
+			test.settings(s)
+
+			forward := []string{
+				"-N", "-o", "BatchMode=yes", "-o", "ExitOnForwardFailure=yes",
+				"-L", "127.0.0.1:15432:db.internal:3306",
+			}
+			assert.Equal(t, append(forward, test.expected...), sshArgs(s, "127.0.0.1:15432"))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// typeMappings are the registered type mappings in the order of their
+// registration.
+var typeMappings []TypeMapping
+
+// TypeMapping maps the type of a column to a Go type. MapType returns the Go
+// type with the import paths it needs and whether the mapping handles the
+// column at all; if not, the next mapping is asked and finally the built-in
+// mapping of tables-to-go maps the column.
+type TypeMapping interface {
+	MapType(db Database, column Column, s *settings.Settings) (goType string, imports []string, handled bool)
+}
+
+// TypeMappingFunc is an adapter to use a function as TypeMapping.
+type TypeMappingFunc func(db Database, column Column, s *settings.Settings) (goType string, imports []string, handled bool)
+
+// MapType calls f(db, column, s).
+func (f TypeMappingFunc) MapType(db Database, column Column, s *settings.Settings) (string, []string, bool) {
+	return f(db, column, s)
+}
+
+// RegisterTypeMapping adds the mapping to the end of the chain of type
+// mappings asked before the built-in mapping. RegisterTypeMapping is meant to
+// be called in an init function and panics if the mapping is nil.
+func RegisterTypeMapping(mapping TypeMapping) {
+	if mapping == nil {
+		panic("database: type mapping is nil")
+	}
+	typeMappings = append(typeMappings, mapping)
+}
+
+// MapType asks the registered type mappings in the order of their
+// registration for the Go type of the column and returns the one of the
+// first mapping handling it. It returns false if none handles the column.
+func MapType(db Database, column Column, s *settings.Settings) (goType string, imports []string, handled bool) {
+	for _, mapping := range typeMappings {
+		if goType, imports, handled = mapping.MapType(db, column, s); handled {
+			return goType, imports, true
+		}
+	}
+	return "", nil, false
+}
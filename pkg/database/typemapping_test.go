@@ -0,0 +1,40 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestMapType(t *testing.T) {
+	s := settings.New()
+	db := New(s)
+
+	_, _, handled := MapType(db, Column{DataType: "uuid"}, s)
+	assert.False(t, handled)
+
+	RegisterTypeMapping(TypeMappingFunc(func(_ Database, column Column, _ *settings.Settings) (string, []string, bool) {
+		return "uuid.UUID", []string{"github.com/google/uuid"}, column.DataType == "uuid"
+	}))
+	RegisterTypeMapping(TypeMappingFunc(func(_ Database, column Column, _ *settings.Settings) (string, []string, bool) {
+		return "string", nil, column.DataType == "uuid" || column.DataType == "inet"
+	}))
+
+	// the first mapping handling the column wins
+	goType, imports, handled := MapType(db, Column{DataType: "uuid"}, s)
+	assert.True(t, handled)
+	assert.Equal(t, "uuid.UUID", goType)
+	assert.Equal(t, []string{"github.com/google/uuid"}, imports)
+
+	goType, imports, handled = MapType(db, Column{DataType: "inet"}, s)
+	assert.True(t, handled)
+	assert.Equal(t, "string", goType)
+	assert.Empty(t, imports)
+
+	_, _, handled = MapType(db, Column{DataType: "integer"}, s)
+	assert.False(t, handled)
+
+	assert.Panics(t, func() { RegisterTypeMapping(nil) })
+}
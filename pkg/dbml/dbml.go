@@ -0,0 +1,61 @@
+// Package dbml reads the tables of a database from their definition in DBML,
+// the language of dbdiagram.io, instead of introspecting the database. The
+// tables, enums, indexes, notes and relationships of the definition are
+// turned into the SQL statements creating them in the dialect of the
+// database type and read by the package ddl, hence the types of the columns
+// are the ones of that dialect, e.g. varchar(255) or timestamptz.
+package dbml
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ddl"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Open parses the file of FromDBML of the settings and creates the Database
+// of its tables.
+func Open(s *settings.Settings) (*ir.Database, error) {
+	tables, err := ParseFile(s, s.FromDBML)
+	if err != nil {
+		return nil, err
+	}
+	return ir.NewDatabase(s, ir.FromTables(s, tables))
+}
+
+// ParseFile parses the DBML of the file and returns the tables of the schema
+// of the settings sorted by name.
+func ParseFile(s *settings.Settings, path string) ([]*database.Table, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := Parse(s, string(content))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	return tables, nil
+}
+
+// Parse parses the DBML and returns the tables of the schema of the settings
+// sorted by name.
+func Parse(s *settings.Settings, dbml string) ([]*database.Table, error) {
+	statements, err := ToSQL(s.DbType.Dialect(), dbml)
+	if err != nil {
+		return nil, err
+	}
+	return ddl.Parse(s, statements)
+}
+
+// ToSQL returns the statements creating the tables of the DBML in the dialect
+// of the database type.
+func ToSQL(dbType settings.DBType, dbml string) (string, error) {
+	schema, err := parse(dbml)
+	if err != nil {
+		return "", err
+	}
+	return schema.sql(dbType), nil
+}
@@ -0,0 +1,185 @@
+package dbml
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+const shop = `
+Project shop {
+  database_type: 'PostgreSQL'
+}
+
+Enum order_status {
+  new
+  "in progress" [note: 'being packed']
+}
+
+// the users
+Table users as U [headercolor: #3498DB, note: 'The users'] {
+  id integer [pk, increment]
+  email varchar(255) [not null, unique, note: 'it\'s the login']
+  created_at timestamptz [default: ` + "`now()`" + `]
+}
+
+Table orders {
+  id bigint [primary key]
+  user_id int [not null, ref: > U.id]
+  status order_status [default: 'new']
+  Note {
+    '''
+    Orders of the users
+    '''
+  }
+  indexes {
+    (user_id, status) [unique, name: 'orders_user_status']
+    ` + "`lower(status)`" + `
+  }
+}
+
+Ref: orders.id - invoices.order_id
+Ref { invoices.order_id <> users.id }
+
+Table invoices {
+  order_id bigint
+}
+
+TableGroup sales { orders invoices }
+`
+
+func TestToSQL(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		dbml     string
+		expected string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:   "postgres",
+			dbType: settings.DBTypePostgresql,
+			dbml:   shop,
+			expected: `CREATE TYPE "order_status" AS ENUM ('new', 'in progress');
+CREATE TABLE "users" (
+  "id" serial,
+  "email" varchar(255) NOT NULL UNIQUE,
+  "created_at" timestamptz DEFAULT now(),
+  PRIMARY KEY ("id")
+);
+COMMENT ON TABLE "users" IS 'The users';
+COMMENT ON COLUMN "users"."email" IS 'it''s the login';
+CREATE TABLE "orders" (
+  "id" bigint,
+  "user_id" int NOT NULL,
+  "status" "order_status" DEFAULT 'new',
+  PRIMARY KEY ("id"),
+  CONSTRAINT "orders_user_status" UNIQUE ("user_id", "status")
+);
+COMMENT ON TABLE "orders" IS 'Orders of the users';
+CREATE TABLE "invoices" (
+  "order_id" bigint
+);
+ALTER TABLE "orders" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "invoices" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id");
+`,
+			isError: assert.NoError,
+		},
+		{
+			desc:   "mysql",
+			dbType: settings.DBTypeMySQL,
+			dbml: `
+				Enum status { new done }
+				Table users [note: 'The users'] {
+				  id int [pk, increment]
+				  status status
+				  name varchar(50) [note: 'full name']
+				  indexes {
+				    name
+				  }
+				}`,
+			expected: "CREATE TABLE `users` (\n" +
+				"  `id` int AUTO_INCREMENT,\n" +
+				"  `status` enum('new','done'),\n" +
+				"  `name` varchar(50) COMMENT 'full name',\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY (`name`)\n" +
+				") COMMENT='The users';\n",
+			isError: assert.NoError,
+		},
+		{
+			desc:    "unterminated table produces error",
+			dbType:  settings.DBTypePostgresql,
+			dbml:    "Table users {\n  id int",
+			isError: assert.Error,
+		},
+		{
+			desc:    "relationship without column produces error",
+			dbType:  settings.DBTypePostgresql,
+			dbml:    "Ref: users > orders",
+			isError: assert.Error,
+		},
+		{
+			desc:    "relationship of columns without table produces error",
+			dbType:  settings.DBTypePostgresql,
+			dbml:    "Ref: (a) > b.c",
+			isError: assert.Error,
+		},
+		{
+			desc:    "inline relationship of columns without table produces error",
+			dbType:  settings.DBTypePostgresql,
+			dbml:    "Table A{A A[ref:0(A)]}",
+			isError: assert.Error,
+		},
+		{
+			desc:    "inline relationship without endpoint produces error",
+			dbType:  settings.DBTypePostgresql,
+			dbml:    "Table users {\n  group_id int [ref: > (id)]\n}",
+			isError: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual, err := ToSQL(tt.dbType, tt.dbml)
+			tt.isError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	s := settings.New()
+	tables, err := Parse(s, shop)
+	assert.NoError(t, err)
+
+	names := make([]string, 0, len(tables))
+	for _, table := range tables {
+		names = append(names, table.Name)
+	}
+	assert.Equal(t, []string{"invoices", "orders", "users"}, names)
+
+	users := tables[2]
+	assert.Equal(t, sql.NullString{String: "The users", Valid: true}, users.Comment)
+	assert.Equal(t, database.Column{
+		OrdinalPosition:  1,
+		Name:             "id",
+		DataType:         "integer",
+		IsNullable:       "NO",
+		DefaultValue:     sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+		NumericPrecision: sql.NullInt64{Int64: 32, Valid: true},
+		ConstraintName:   sql.NullString{String: "users_pkey", Valid: true},
+		ConstraintType:   sql.NullString{String: "PRIMARY KEY", Valid: true},
+		Constraints:      []database.Constraint{{Name: "users_pkey", Type: "PRIMARY KEY"}},
+	}, users.Columns[0])
+
+	orders := tables[1]
+	status := orders.Columns[len(orders.Columns)-1]
+	assert.Equal(t, "status", status.Name)
+	assert.Equal(t, "USER-DEFINED", status.DataType)
+	assert.Equal(t, []string{"new", "in progress"}, status.EnumValues)
+}
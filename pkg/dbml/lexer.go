@@ -0,0 +1,153 @@
+package dbml
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind is the kind of a token of a DBML source.
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenQuoted
+	tokenString
+	tokenExpression
+	tokenNumber
+	tokenPunct
+	tokenEOF
+)
+
+// token is a token of the source with the line it starts at.
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// is returns true if the token is the given keyword or punctuation, keywords
+// compare case-insensitive.
+func (t token) is(s string) bool {
+	switch t.kind {
+	case tokenWord:
+		return strings.EqualFold(t.text, s)
+	case tokenPunct:
+		return t.text == s
+	}
+	return false
+}
+
+// tokenize splits the source into its tokens, comments are left out. The
+// last token is always of kind tokenEOF.
+func tokenize(src string) ([]token, error) {
+	var (
+		tokens []token
+		line   = 1
+	)
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case strings.HasPrefix(src[i:], "'''"):
+			end := strings.Index(src[i+3:], "'''")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			text := src[i+3 : i+3+end]
+			tokens = append(tokens, token{kind: tokenString, text: multiLine(text), line: line})
+			line += strings.Count(text, "\n")
+			i += end + 6
+		case c == '\'' || c == '"' || c == '`':
+			text, end, err := quoted(src, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			kind := map[byte]tokenKind{'\'': tokenString, '"': tokenQuoted, '`': tokenExpression}[c]
+			tokens = append(tokens, token{kind: kind, text: text, line: line})
+			line += strings.Count(src[i:end], "\n")
+			i = end
+		case c >= '0' && c <= '9' || c == '-' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			end := i + 1
+			for end < len(src) && (src[end] >= '0' && src[end] <= '9' || src[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[i:end], line: line})
+			i = end
+		case unicode.IsLetter(rune(c)) || c == '_' || c >= 0x80:
+			end := i
+			for end < len(src) && (unicode.IsLetter(rune(src[end])) || unicode.IsDigit(rune(src[end])) || src[end] == '_' || src[end] >= 0x80) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: src[i:end], line: line})
+			i = end
+		case strings.HasPrefix(src[i:], "<>"):
+			tokens = append(tokens, token{kind: tokenPunct, text: "<>", line: line})
+			i += 2
+		default:
+			tokens = append(tokens, token{kind: tokenPunct, text: string(c), line: line})
+			i++
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, line: line}), nil
+}
+
+// quoted returns the text of the quoted token starting at i and its end,
+// escaped characters are unescaped.
+func quoted(src string, i int) (string, int, error) {
+	quote := src[i]
+	var b strings.Builder
+	for j := i + 1; j < len(src); j++ {
+		switch {
+		case src[j] == '\\' && j+1 < len(src):
+			j++
+			b.WriteByte(src[j])
+		case src[j] == quote:
+			return b.String(), j + 1, nil
+		default:
+			b.WriteByte(src[j])
+		}
+	}
+	return "", len(src), fmt.Errorf("unterminated quote %c", quote)
+}
+
+// multiLine removes the indentation of the lines of a multi-line string and
+// its leading and trailing empty lines.
+func multiLine(s string) string {
+	lines := strings.Split(s, "\n")
+	indent := -1
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		n := len(l) - len(strings.TrimLeft(l, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, l := range lines {
+		if len(l) >= indent && indent > 0 {
+			lines[i] = l[indent:]
+		}
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
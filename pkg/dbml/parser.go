@@ -0,0 +1,604 @@
+package dbml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// schema is the content of a DBML source.
+type schema struct {
+	tables []*table
+	enums  []*enum
+	refs   []*ref
+}
+
+// table is a table of the source.
+type table struct {
+	schema  string
+	name    string
+	alias   string
+	note    *string
+	columns []*column
+	indexes []*index
+}
+
+// column is a column of a table.
+type column struct {
+	name string
+	// typeSchema and typeName are the name of the type and typeSuffix its
+	// arguments and array brackets as written, e.g. (255) of varchar(255)
+	typeSchema string
+	typeName   string
+	typeSuffix string
+	pk         bool
+	unique     bool
+	notNull    bool
+	increment  bool
+	// def is the default value as SQL
+	def  *string
+	note *string
+}
+
+// index is an index of a table.
+type index struct {
+	name    string
+	columns []string
+	pk      bool
+	unique  bool
+}
+
+// enum is an enum type with its values.
+type enum struct {
+	schema string
+	name   string
+	values []string
+}
+
+// ref is a relationship, the columns of from reference the ones of to.
+type ref struct {
+	name string
+	from endpoint
+	to   endpoint
+}
+
+// endpoint are the columns of a table of a relationship.
+type endpoint struct {
+	schema  string
+	table   string
+	columns []string
+}
+
+// parser parses a DBML source.
+type parser struct {
+	tokens []token
+	pos    int
+	schema *schema
+}
+
+// parse parses the source.
+func parse(src string) (*schema, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, schema: &schema{}}
+	for p.peek().kind != tokenEOF {
+		if err = p.element(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.peek().line, err)
+		}
+	}
+	p.resolveAliases()
+	return p.schema, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the current token if it is the given keyword or
+// punctuation.
+func (p *parser) accept(s string) bool {
+	if p.peek().is(s) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(s string) error {
+	if !p.accept(s) {
+		return fmt.Errorf("unexpected %s, expected %q", describe(p.peek()), s)
+	}
+	return nil
+}
+
+func describe(tok token) string {
+	if tok.kind == tokenEOF {
+		return "end of file"
+	}
+	return fmt.Sprintf("%q", tok.text)
+}
+
+// element parses a top-level element, unknown ones like Project and
+// TableGroup are skipped.
+func (p *parser) element() error {
+	switch {
+	case p.accept("Table"):
+		return p.table()
+	case p.accept("Enum"):
+		return p.enum()
+	case p.accept("Ref"):
+		return p.ref()
+	}
+	// skip the element up to the end of its block
+	for p.peek().kind != tokenEOF && !p.peek().is("{") {
+		p.next()
+	}
+	return p.skipBlock()
+}
+
+// skipBlock skips the block starting at the current token.
+func (p *parser) skipBlock() error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for depth := 1; depth > 0; {
+		tok := p.next()
+		switch {
+		case tok.kind == tokenEOF:
+			return fmt.Errorf("missing }")
+		case tok.is("{"):
+			depth++
+		case tok.is("}"):
+			depth--
+		}
+	}
+	return nil
+}
+
+// identifier parses a name, quoted or not.
+func (p *parser) identifier() (string, error) {
+	tok := p.next()
+	if tok.kind != tokenWord && tok.kind != tokenQuoted {
+		return "", fmt.Errorf("unexpected %s, expected name", describe(tok))
+	}
+	return tok.text, nil
+}
+
+// name parses a name possibly qualified by a schema.
+func (p *parser) name() (string, string, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return "", "", err
+	}
+	if !p.accept(".") {
+		return "", name, nil
+	}
+	qualified, err := p.identifier()
+	return name, qualified, err
+}
+
+// table parses a table with its columns, indexes and note.
+func (p *parser) table() error {
+	schemaName, name, err := p.name()
+	if err != nil {
+		return err
+	}
+	t := &table{schema: schemaName, name: name}
+	if p.accept("as") {
+		if t.alias, err = p.identifier(); err != nil {
+			return err
+		}
+	}
+	err = p.settings(func(key string, value token) error {
+		if key == "note" {
+			t.note = &value.text
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = p.expect("{"); err != nil {
+		return err
+	}
+	for !p.accept("}") {
+		switch {
+		case p.peek().kind == tokenEOF:
+			return fmt.Errorf("table %q: missing }", name)
+		case p.peek().is("Note") && (p.tokens[p.pos+1].is(":") || p.tokens[p.pos+1].is("{")):
+			p.next()
+			note, err := p.note()
+			if err != nil {
+				return err
+			}
+			t.note = &note
+		case p.peek().is("indexes") && p.tokens[p.pos+1].is("{"):
+			p.next()
+			if err = p.indexes(t); err != nil {
+				return fmt.Errorf("table %q: %w", name, err)
+			}
+		default:
+			if err = p.column(t); err != nil {
+				return fmt.Errorf("table %q: %w", name, err)
+			}
+		}
+	}
+
+	p.schema.tables = append(p.schema.tables, t)
+	return nil
+}
+
+// note parses the value of a note given as Note: 'text' or Note { 'text' }.
+func (p *parser) note() (string, error) {
+	block := !p.accept(":")
+	if block {
+		if err := p.expect("{"); err != nil {
+			return "", err
+		}
+	}
+	tok := p.next()
+	if tok.kind != tokenString {
+		return "", fmt.Errorf("unexpected %s, expected note", describe(tok))
+	}
+	if block {
+		if err := p.expect("}"); err != nil {
+			return "", err
+		}
+	}
+	return tok.text, nil
+}
+
+// column parses a column with its type and settings.
+func (p *parser) column(t *table) error {
+	name, err := p.identifier()
+	if err != nil {
+		return err
+	}
+	col := &column{name: name}
+
+	if err = p.columnType(col); err != nil {
+		return fmt.Errorf("column %q: %w", name, err)
+	}
+
+	err = p.settings(func(key string, value token) error {
+		switch key {
+		case "pk", "primary key":
+			col.pk = true
+		case "unique":
+			col.unique = true
+		case "not null":
+			col.notNull = true
+		case "null":
+			col.notNull = false
+		case "increment":
+			col.increment = true
+		case "note":
+			col.note = &value.text
+		case "default":
+			def := defaultValue(value)
+			col.def = &def
+		}
+		return nil
+	}, func(value []token) error {
+		// inline relationship like ref: > users.id
+		r, err := inlineRef(t, col, value)
+		if err != nil {
+			return fmt.Errorf("column %q: %w", name, err)
+		}
+		if r != nil {
+			p.schema.refs = append(p.schema.refs, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	t.columns = append(t.columns, col)
+	return nil
+}
+
+// columnType parses the type of a column, e.g. varchar(255), int[] or a
+// quoted type name.
+func (p *parser) columnType(col *column) error {
+	var err error
+	if col.typeSchema, col.typeName, err = p.name(); err != nil {
+		return err
+	}
+
+	var suffix strings.Builder
+	if p.accept("(") {
+		suffix.WriteString("(")
+		for !p.accept(")") {
+			tok := p.next()
+			switch {
+			case tok.kind == tokenEOF:
+				return fmt.Errorf("missing ) after type arguments")
+			case tok.kind == tokenString:
+				suffix.WriteString(quote(tok.text, '\''))
+			case tok.is(","):
+				suffix.WriteString(", ")
+			default:
+				suffix.WriteString(tok.text)
+			}
+		}
+		suffix.WriteString(")")
+	}
+	for p.peek().is("[") && p.tokens[p.pos+1].is("]") {
+		p.pos += 2
+		suffix.WriteString("[]")
+	}
+	col.typeSuffix = suffix.String()
+	return nil
+}
+
+// settings parses the optional settings in brackets like [pk, note: 'id'].
+// The keys are lower case, multiple words joined by a space. The values of
+// refs go to the optional second function as their tokens.
+func (p *parser) settings(set func(key string, value token) error, refs ...func(value []token) error) error {
+	if !p.accept("[") {
+		return nil
+	}
+	for !p.accept("]") {
+		var words []string
+		for p.peek().kind == tokenWord {
+			words = append(words, strings.ToLower(p.next().text))
+		}
+		if len(words) == 0 {
+			return fmt.Errorf("unexpected %s in settings", describe(p.peek()))
+		}
+		key := strings.Join(words, " ")
+
+		var value token
+		if p.accept(":") {
+			if key == "ref" {
+				var tokens []token
+				for p.peek().kind != tokenEOF && !p.peek().is(",") && !p.peek().is("]") {
+					tokens = append(tokens, p.next())
+				}
+				for _, ref := range refs {
+					if err := ref(tokens); err != nil {
+						return err
+					}
+				}
+			} else {
+				value = p.next()
+				// values like #3498db or now() in expressions
+				for p.peek().kind != tokenEOF && !p.peek().is(",") && !p.peek().is("]") {
+					p.next()
+				}
+			}
+		}
+		if err := set(key, value); err != nil {
+			return err
+		}
+		if !p.accept(",") && !p.peek().is("]") {
+			return fmt.Errorf("unexpected %s in settings", describe(p.peek()))
+		}
+	}
+	return nil
+}
+
+// defaultValue returns the default value as SQL: strings are quoted,
+// numbers, expressions and keywords like true or null taken as written.
+func defaultValue(value token) string {
+	if value.kind == tokenString {
+		return quote(value.text, '\'')
+	}
+	return value.text
+}
+
+// indexes parses the indexes block of a table.
+func (p *parser) indexes(t *table) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.accept("}") {
+		idx := &index{}
+		switch tok := p.peek(); {
+		case tok.kind == tokenEOF:
+			return fmt.Errorf("missing } of indexes")
+		case p.accept("("):
+			for !p.accept(")") {
+				tok := p.next()
+				switch {
+				case tok.kind == tokenEOF:
+					return fmt.Errorf("missing ) of index")
+				case tok.kind == tokenWord || tok.kind == tokenQuoted:
+					idx.columns = append(idx.columns, tok.text)
+				}
+			}
+		case tok.kind == tokenExpression:
+			// indexes of expressions cover no columns
+			p.next()
+		default:
+			name, err := p.identifier()
+			if err != nil {
+				return err
+			}
+			idx.columns = []string{name}
+		}
+
+		err := p.settings(func(key string, value token) error {
+			switch key {
+			case "pk", "primary key":
+				idx.pk = true
+			case "unique":
+				idx.unique = true
+			case "name":
+				idx.name = value.text
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(idx.columns) > 0 {
+			t.indexes = append(t.indexes, idx)
+		}
+	}
+	return nil
+}
+
+// enum parses an enum type with its values.
+func (p *parser) enum() error {
+	schemaName, name, err := p.name()
+	if err != nil {
+		return err
+	}
+	e := &enum{schema: schemaName, name: name}
+	if err = p.expect("{"); err != nil {
+		return err
+	}
+	for !p.accept("}") {
+		value, err := p.identifier()
+		if err != nil {
+			return fmt.Errorf("enum %q: %w", name, err)
+		}
+		e.values = append(e.values, value)
+		if err = p.settings(func(string, token) error { return nil }); err != nil {
+			return err
+		}
+	}
+	p.schema.enums = append(p.schema.enums, e)
+	return nil
+}
+
+// ref parses a relationship in its short form Ref: a.b > c.d or its long form
+// Ref { a.b > c.d }.
+func (p *parser) ref() error {
+	var name string
+	if !p.peek().is(":") && !p.peek().is("{") {
+		var err error
+		if name, err = p.identifier(); err != nil {
+			return err
+		}
+	}
+
+	block := !p.accept(":")
+	if block {
+		if err := p.expect("{"); err != nil {
+			return err
+		}
+	}
+	for {
+		left, err := p.endpoint()
+		if err != nil {
+			return err
+		}
+		op := p.next()
+		right, err := p.endpoint()
+		if err != nil {
+			return err
+		}
+		if err = p.settings(func(string, token) error { return nil }); err != nil {
+			return err
+		}
+		if r := newRef(name, left, op.text, right); r != nil {
+			p.schema.refs = append(p.schema.refs, r)
+		}
+		if !block || p.accept("}") {
+			return nil
+		}
+	}
+}
+
+// endpoint parses the columns of a table of a relationship like
+// schema.table.column or table.(a, b).
+func (p *parser) endpoint() (endpoint, error) {
+	var (
+		parts   []string
+		columns []string
+	)
+	for {
+		if p.accept("(") {
+			for !p.accept(")") {
+				tok := p.next()
+				switch {
+				case tok.kind == tokenEOF:
+					return endpoint{}, fmt.Errorf("missing ) of columns")
+				case tok.kind == tokenWord || tok.kind == tokenQuoted:
+					columns = append(columns, tok.text)
+				}
+			}
+			break
+		}
+		part, err := p.identifier()
+		if err != nil {
+			return endpoint{}, err
+		}
+		parts = append(parts, part)
+		if !p.accept(".") {
+			break
+		}
+	}
+	if columns == nil {
+		if len(parts) < 2 {
+			return endpoint{}, fmt.Errorf("relationship needs table and column")
+		}
+		columns = parts[len(parts)-1:]
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) == 0 {
+		return endpoint{}, fmt.Errorf("relationship needs table and column")
+	}
+	e := endpoint{table: parts[len(parts)-1], columns: columns}
+	if len(parts) > 1 {
+		e.schema = parts[len(parts)-2]
+	}
+	return e, nil
+}
+
+// newRef returns the relationship of the operator with the referencing
+// columns first as dbml2sql places the foreign key: > many-to-one references
+// the right, < one-to-many and - one-to-one the left. Many-to-many
+// relationships need a table in between and are left out.
+func newRef(name string, left endpoint, op string, right endpoint) *ref {
+	switch op {
+	case ">":
+		return &ref{name: name, from: left, to: right}
+	case "<", "-":
+		return &ref{name: name, from: right, to: left}
+	}
+	return nil
+}
+
+// inlineRef returns the relationship of the column given in its settings.
+func inlineRef(t *table, col *column, value []token) (*ref, error) {
+	if len(value) == 0 {
+		return nil, fmt.Errorf("empty ref")
+	}
+	tokens := make([]token, 0, len(value))
+	tokens = append(tokens, value[1:]...)
+	sub := &parser{tokens: append(tokens, token{kind: tokenEOF})}
+	other, err := sub.endpoint()
+	if err != nil {
+		return nil, err
+	}
+	self := endpoint{schema: t.schema, table: t.name, columns: []string{col.name}}
+	return newRef("", self, value[0].text, other), nil
+}
+
+// resolveAliases replaces the aliases of tables in the relationships by the
+// names of the tables.
+func (p *parser) resolveAliases() {
+	for _, r := range p.schema.refs {
+		for _, e := range []*endpoint{&r.from, &r.to} {
+			for _, t := range p.schema.tables {
+				if t.alias != "" && e.schema == "" && e.table == t.alias {
+					e.schema, e.table = t.schema, t.name
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package dbml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// serialTypes are the serial types of Postgres of the integer types for
+// columns with increment.
+var serialTypes = map[string]string{
+	"smallint": "smallserial",
+	"int2":     "smallserial",
+	"int":      "serial",
+	"int4":     "serial",
+	"integer":  "serial",
+	"bigint":   "bigserial",
+	"int8":     "bigserial",
+}
+
+// quote quotes the text, quotes inside it are doubled.
+func quote(s string, q byte) string {
+	return string(q) + strings.ReplaceAll(s, string(q), string(q)+string(q)) + string(q)
+}
+
+// sqlWriter writes the statements of a schema in the dialect of a database
+// type.
+type sqlWriter struct {
+	strings.Builder
+	dbType settings.DBType
+}
+
+// identifier returns the quoted identifier.
+func (w *sqlWriter) identifier(name string) string {
+	if w.dbType == settings.DBTypeMySQL {
+		return quote(name, '`')
+	}
+	return quote(name, '"')
+}
+
+// name returns the quoted name qualified by the schema if given.
+func (w *sqlWriter) name(schema, name string) string {
+	if schema == "" {
+		return w.identifier(name)
+	}
+	return w.identifier(schema) + "." + w.identifier(name)
+}
+
+// identifiers returns the quoted identifiers as a parenthesized list.
+func (w *sqlWriter) identifiers(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, w.identifier(name))
+	}
+	return "(" + strings.Join(quoted, ", ") + ")"
+}
+
+// sql returns the statements creating the tables of the schema in the
+// dialect of the database type.
+func (s *schema) sql(dbType settings.DBType) string {
+	w := &sqlWriter{dbType: dbType}
+
+	if dbType != settings.DBTypeMySQL {
+		for _, e := range s.enums {
+			values := make([]string, 0, len(e.values))
+			for _, value := range e.values {
+				values = append(values, quote(value, '\''))
+			}
+			fmt.Fprintf(w, "CREATE TYPE %s AS ENUM (%s);\n", w.name(e.schema, e.name), strings.Join(values, ", "))
+		}
+	}
+
+	for _, t := range s.tables {
+		s.writeTable(w, t)
+	}
+
+	for _, r := range s.refs {
+		fmt.Fprintf(w, "ALTER TABLE %s ADD ", w.name(r.from.schema, r.from.table))
+		if r.name != "" {
+			fmt.Fprintf(w, "CONSTRAINT %s ", w.identifier(r.name))
+		}
+		fmt.Fprintf(w, "FOREIGN KEY %s REFERENCES %s %s;\n",
+			w.identifiers(r.from.columns), w.name(r.to.schema, r.to.table), w.identifiers(r.to.columns))
+	}
+
+	return w.String()
+}
+
+// writeTable writes the statements creating the table and, for Postgres,
+// its comments.
+func (s *schema) writeTable(w *sqlWriter, t *table) {
+	var (
+		elements []string
+		pk       []string
+	)
+	for _, col := range t.columns {
+		elements = append(elements, s.columnDefinition(w, col))
+		if col.pk {
+			pk = append(pk, col.name)
+		}
+	}
+	if len(pk) > 0 {
+		elements = append(elements, "PRIMARY KEY "+w.identifiers(pk))
+	}
+	for _, idx := range t.indexes {
+		var element string
+		switch {
+		case idx.pk:
+			element = "PRIMARY KEY "
+		case idx.unique:
+			element = "UNIQUE "
+		case w.dbType == settings.DBTypeMySQL:
+			element = "KEY "
+		default:
+			// indexes are no constraints of Postgres
+			continue
+		}
+		if idx.name != "" && !idx.pk {
+			element = "CONSTRAINT " + w.identifier(idx.name) + " " + element
+		}
+		elements = append(elements, element+w.identifiers(idx.columns))
+	}
+
+	fmt.Fprintf(w, "CREATE TABLE %s (\n  %s\n)", w.name(t.schema, t.name), strings.Join(elements, ",\n  "))
+	if w.dbType == settings.DBTypeMySQL && t.note != nil {
+		fmt.Fprintf(w, " COMMENT=%s", quote(*t.note, '\''))
+	}
+	w.WriteString(";\n")
+
+	if w.dbType == settings.DBTypeMySQL {
+		return
+	}
+	if t.note != nil {
+		fmt.Fprintf(w, "COMMENT ON TABLE %s IS %s;\n", w.name(t.schema, t.name), quote(*t.note, '\''))
+	}
+	for _, col := range t.columns {
+		if col.note != nil {
+			fmt.Fprintf(w, "COMMENT ON COLUMN %s.%s IS %s;\n",
+				w.name(t.schema, t.name), w.identifier(col.name), quote(*col.note, '\''))
+		}
+	}
+}
+
+// columnDefinition returns the definition of the column.
+func (s *schema) columnDefinition(w *sqlWriter, col *column) string {
+	def := w.identifier(col.name) + " " + s.columnType(w, col)
+	if col.notNull {
+		def += " NOT NULL"
+	}
+	if col.unique {
+		def += " UNIQUE"
+	}
+	if col.def != nil {
+		def += " DEFAULT " + *col.def
+	}
+	if col.increment {
+		switch {
+		case w.dbType == settings.DBTypeMySQL:
+			def += " AUTO_INCREMENT"
+		case serialTypes[strings.ToLower(col.typeName)] == "":
+			def += " GENERATED BY DEFAULT AS IDENTITY"
+		}
+	}
+	if w.dbType == settings.DBTypeMySQL && col.note != nil {
+		def += " COMMENT " + quote(*col.note, '\'')
+	}
+	return def
+}
+
+// columnType returns the type of the column: the enum types are the ones of
+// the schema, MySQL lists their values, and the integer types with increment
+// the serial types of Postgres.
+func (s *schema) columnType(w *sqlWriter, col *column) string {
+	i := slices.IndexFunc(s.enums, func(e *enum) bool {
+		return e.name == col.typeName && (e.schema == col.typeSchema || col.typeSchema == "")
+	})
+	switch {
+	case i >= 0 && w.dbType == settings.DBTypeMySQL:
+		values := make([]string, 0, len(s.enums[i].values))
+		for _, value := range s.enums[i].values {
+			values = append(values, quote(value, '\''))
+		}
+		return "enum(" + strings.Join(values, ",") + ")"
+	case i >= 0:
+		return w.name(col.typeSchema, col.typeName) + col.typeSuffix
+	case col.increment && w.dbType != settings.DBTypeMySQL && serialTypes[strings.ToLower(col.typeName)] != "":
+		return serialTypes[strings.ToLower(col.typeName)]
+	}
+
+	typ := col.typeName
+	if col.typeSchema != "" {
+		typ = col.typeSchema + "." + typ
+	}
+	return typ + col.typeSuffix
+}
@@ -0,0 +1,142 @@
+package ddl
+
+// cursor walks through the tokens of a statement.
+type cursor struct {
+	statement
+	pos int
+}
+
+// done returns true if all tokens are consumed.
+func (c *cursor) done() bool {
+	return c.pos >= len(c.tokens)
+}
+
+// current returns the current token, an empty one if all are consumed.
+func (c *cursor) current() token {
+	if c.done() {
+		return token{kind: tokenPunct}
+	}
+	return c.tokens[c.pos]
+}
+
+// next consumes the current token and returns it.
+func (c *cursor) next() (token, bool) {
+	if c.done() {
+		return token{}, false
+	}
+	c.pos++
+	return c.tokens[c.pos-1], true
+}
+
+// skip consumes the current token.
+func (c *cursor) skip() {
+	if !c.done() {
+		c.pos++
+	}
+}
+
+// peek returns true if the current token is the given keyword or punctuation.
+func (c *cursor) peek(s string) bool {
+	return c.current().is(s)
+}
+
+// peekAny returns true if the current token is one of the given keywords or
+// punctuations.
+func (c *cursor) peekAny(words ...string) bool {
+	for _, word := range words {
+		if c.peek(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// accept consumes the tokens if they are the given keywords or punctuations
+// in that order and returns true, else it consumes nothing.
+func (c *cursor) accept(words ...string) bool {
+	if c.pos+len(words) > len(c.tokens) {
+		return false
+	}
+	for i, word := range words {
+		if !c.tokens[c.pos+i].is(word) {
+			return false
+		}
+	}
+	c.pos += len(words)
+	return true
+}
+
+// acceptAny consumes the current token if it is one of the given keywords or
+// punctuations.
+func (c *cursor) acceptAny(words ...string) bool {
+	for _, word := range words {
+		if c.accept(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipGroup consumes the current token if it opens a parenthesized group up
+// to the end of the group.
+func (c *cursor) skipGroup() {
+	if !c.peek("(") {
+		return
+	}
+	depth := 0
+	for !c.done() {
+		tok, _ := c.next()
+		switch {
+		case tok.is("("):
+			depth++
+		case tok.is(")"):
+			depth--
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// skipUntil consumes the tokens up to one of the given punctuations outside
+// of parenthesized groups.
+func (c *cursor) skipUntil(puncts ...string) {
+	for !c.done() && !c.peekAny(puncts...) {
+		if c.peek("(") {
+			c.skipGroup()
+			continue
+		}
+		c.skip()
+	}
+}
+
+// skipUntilWord consumes the tokens up to one of the given keywords outside
+// of parenthesized groups or the end of the element.
+func (c *cursor) skipUntilWord(words ...string) {
+	for !c.done() && !c.peekAny(words...) && !c.peek(",") && !c.peek(")") {
+		if c.peek("(") {
+			c.skipGroup()
+			continue
+		}
+		c.skip()
+	}
+}
+
+// string consumes the current token if it is a string and returns its value.
+func (c *cursor) string() string {
+	if tok := c.current(); tok.kind == tokenString {
+		c.skip()
+		return tok.text
+	}
+	return ""
+}
+
+// nullableString consumes a string or NULL and returns its value, nil for
+// NULL.
+func (c *cursor) nullableString() *string {
+	if c.accept("NULL") {
+		return nil
+	}
+	s := c.string()
+	return &s
+}
@@ -0,0 +1,280 @@
+// Package ddl reads the tables of a database from the SQL statements
+// creating them, e.g. the migrations of a project, instead of introspecting
+// the database. The statements are applied in their order: CREATE TABLE,
+// ALTER TABLE, DROP TABLE, COMMENT ON and CREATE TYPE AS ENUM, others are
+// skipped. The tables come out as the database would return them.
+//
+// The plain SQL of pg_dump --schema-only is read as well: its tables with
+// the keys added by ALTER TABLE afterwards, partitions, inheriting tables and
+// the defaults of sequences.
+package ddl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// SupportedDbTypes are the database types whose dialects can be parsed.
+var SupportedDbTypes = []settings.DBType{settings.DBTypePostgresql, settings.DBTypeMySQL}
+
+// Open parses the files of FromSQL of the settings and creates the Database
+// of their tables.
+func Open(s *settings.Settings) (*ir.Database, error) {
+	tables, err := ParseFiles(s, s.FromSQL)
+	if err != nil {
+		return nil, err
+	}
+	return ir.NewDatabase(s, ir.FromTables(s, tables))
+}
+
+// ParseFiles parses the statements of the files in the dialect of the
+// database type of the settings and returns the tables of the schema of the
+// settings sorted by name. Directories are read in the order of the names of
+// their .sql files, leaving out the .down.sql files of migrations.
+func ParseFiles(s *settings.Settings, paths []string) ([]*database.Table, error) {
+	p, err := newParser(s)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		files, err := sqlFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(string(content), "PGDMP") {
+				return nil, fmt.Errorf("could not parse %s: dumps of the custom format are not supported, dump with --format=plain or convert with pg_restore --schema-only --file", file)
+			}
+			if err = p.parse(string(content)); err != nil {
+				return nil, fmt.Errorf("could not parse %s: %w", file, err)
+			}
+		}
+	}
+
+	return p.tables(), nil
+}
+
+// Parse parses the statements in the dialect of the database type of the
+// settings and returns the tables of the schema of the settings sorted by
+// name.
+func Parse(s *settings.Settings, ddl string) ([]*database.Table, error) {
+	p, err := newParser(s)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.parse(ddl); err != nil {
+		return nil, err
+	}
+	return p.tables(), nil
+}
+
+// sqlFiles returns the file of the path or the .sql files of the directory.
+func sqlFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") || strings.HasSuffix(name, ".down.sql") {
+			continue
+		}
+		files = append(files, filepath.Join(path, name))
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// table is a table created by the statements.
+type table struct {
+	name        string
+	comment     *string
+	columns     []*database.Column
+	constraints []*constraint
+}
+
+// constraint is a constraint or an index of a table, foreign keys with the
+// table and columns they reference. Foreign keys without referenced columns
+// reference the primary key.
+type constraint struct {
+	name    string
+	typ     string
+	columns []string
+
+	references        string
+	referencedColumns []string
+}
+
+// These types of constraints are known.
+const (
+	constraintPrimaryKey = "PRIMARY KEY"
+	constraintUnique     = "UNIQUE"
+	constraintForeignKey = "FOREIGN KEY"
+	constraintIndex      = "INDEX"
+)
+
+func (t *table) column(name string) (int, *database.Column) {
+	i := slices.IndexFunc(t.columns, func(c *database.Column) bool { return c.Name == name })
+	if i < 0 {
+		return i, nil
+	}
+	return i, t.columns[i]
+}
+
+// addConstraint adds the constraint, named as the database names it if it
+// has no name.
+func (t *table) addConstraint(c *constraint, dbType settings.DBType) {
+	if c.name == "" {
+		c.name = constraintName(t.name, c, dbType)
+	}
+	if c.typ == constraintPrimaryKey {
+		for _, name := range c.columns {
+			if _, col := t.column(name); col != nil {
+				col.IsNullable = "NO"
+			}
+		}
+	}
+	t.constraints = append(t.constraints, c)
+}
+
+// foreignKeys returns the foreign keys of the table, the ones referencing
+// the primary key with its columns. The ones referencing unknown tables
+// without their columns are left out.
+func (p *parser) foreignKeys(t *table) []database.ForeignKey {
+	var foreignKeys []database.ForeignKey
+	for _, con := range t.constraints {
+		if con.typ != constraintForeignKey || con.references == "" {
+			continue
+		}
+		referenced := con.referencedColumns
+		if len(referenced) == 0 {
+			if ref := p.table(con.references); ref != nil {
+				referenced = ref.primaryKey()
+			}
+		}
+		if len(referenced) != len(con.columns) {
+			continue
+		}
+		foreignKeys = append(foreignKeys, database.ForeignKey{
+			Name:              con.name,
+			Columns:           slices.Clone(con.columns),
+			ReferencedTable:   con.references,
+			ReferencedColumns: slices.Clone(referenced),
+		})
+	}
+	return foreignKeys
+}
+
+// primaryKey returns the columns of the primary key of the table.
+func (t *table) primaryKey() []string {
+	for _, con := range t.constraints {
+		if con.typ == constraintPrimaryKey {
+			return con.columns
+		}
+	}
+	return nil
+}
+
+// dropColumn removes the column and its constraints.
+func (t *table) dropColumn(name string) {
+	i, _ := t.column(name)
+	if i < 0 {
+		return
+	}
+	t.columns = slices.Delete(t.columns, i, i+1)
+	t.constraints = slices.DeleteFunc(t.constraints, func(c *constraint) bool {
+		return slices.Contains(c.columns, name)
+	})
+}
+
+// constraintName returns the name the database gives the constraint.
+func constraintName(table string, c *constraint, dbType settings.DBType) string {
+	if dbType == settings.DBTypeMySQL {
+		if c.typ == constraintPrimaryKey {
+			return "PRIMARY"
+		}
+		return c.columns[0]
+	}
+
+	switch c.typ {
+	case constraintPrimaryKey:
+		return table + "_pkey"
+	case constraintForeignKey:
+		return table + "_" + strings.Join(c.columns, "_") + "_fkey"
+	case constraintIndex:
+		return table + "_" + strings.Join(c.columns, "_") + "_idx"
+	}
+	return table + "_" + strings.Join(c.columns, "_") + "_key"
+}
+
+// rows returns the columns of the table as the database returns them, with
+// their constraints merged.
+func (t *table) rows(dbType settings.DBType) []database.Column {
+	var rows []database.Column
+	for i, col := range t.columns {
+		c := *col
+		c.OrdinalPosition = i + 1
+
+		if dbType == settings.DBTypeMySQL {
+			c.ColumnKey = t.columnKey(c.Name)
+			rows = append(rows, c)
+			continue
+		}
+
+		// the information schema of Postgres returns a column once per key
+		// it is part of, merged below
+		n := len(rows)
+		for _, con := range t.constraints {
+			if con.typ == constraintIndex || !slices.Contains(con.columns, c.Name) {
+				continue
+			}
+			row := c
+			row.ConstraintName.String, row.ConstraintName.Valid = con.name, true
+			row.ConstraintType.String, row.ConstraintType.Valid = con.typ, true
+			rows = append(rows, row)
+		}
+		if len(rows) == n {
+			rows = append(rows, c)
+		}
+	}
+	return database.MergeColumns(rows)
+}
+
+// columnKey returns the key of the column as MySQL reports it: PRI for the
+// primary key, UNI for unique columns and MUL for the first column of other
+// indexes.
+func (t *table) columnKey(name string) string {
+	key := ""
+	for _, con := range t.constraints {
+		switch {
+		case con.typ == constraintPrimaryKey && slices.Contains(con.columns, name):
+			return "PRI"
+		case con.typ == constraintUnique && len(con.columns) == 1 && con.columns[0] == name:
+			key = "UNI"
+		case con.columns[0] == name && key == "":
+			key = "MUL"
+		}
+	}
+	return key
+}
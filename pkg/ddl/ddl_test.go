@@ -0,0 +1,435 @@
+package ddl
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: true}
+}
+
+func nullInt64(i int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: i, Valid: true}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		ddl      string
+		expected []*database.Table
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:   "postgres table with keys and comments",
+			dbType: settings.DBTypePostgresql,
+			ddl: `
+				CREATE TYPE mood AS ENUM ('happy', 'sad');
+				CREATE TABLE public.Users (
+					id serial PRIMARY KEY,
+					email varchar(255) NOT NULL UNIQUE, -- login
+					mood mood DEFAULT 'happy',
+					CHECK (length(email) > 3)
+				);
+				COMMENT ON TABLE users IS 'the users';
+				COMMENT ON COLUMN users.email IS 'login; unique';
+				CREATE TABLE other.users (id int);`,
+			expected: []*database.Table{
+				{
+					Name:    "users",
+					Comment: nullString("the users"),
+					Columns: []database.Column{
+						{
+							OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO",
+							DefaultValue:     nullString("nextval('users_id_seq'::regclass)"),
+							NumericPrecision: nullInt64(32),
+							ConstraintName:   nullString("users_pkey"),
+							ConstraintType:   nullString("PRIMARY KEY"),
+							Constraints:      []database.Constraint{{Name: "users_pkey", Type: "PRIMARY KEY"}},
+						},
+						{
+							OrdinalPosition: 2, Name: "email", DataType: "character varying", IsNullable: "NO",
+							CharacterMaximumLength: nullInt64(255),
+							Comment:                nullString("login; unique"),
+							ConstraintName:         nullString("users_email_key"),
+							ConstraintType:         nullString("UNIQUE"),
+							Constraints:            []database.Constraint{{Name: "users_email_key", Type: "UNIQUE"}},
+						},
+						{
+							OrdinalPosition: 3, Name: "mood", DataType: "USER-DEFINED", IsNullable: "YES",
+							DefaultValue: nullString("'happy'"),
+							EnumValues:   []string{"happy", "sad"},
+							EnumType:     "mood",
+						},
+					},
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:   "postgres foreign key altered afterwards",
+			dbType: settings.DBTypePostgresql,
+			ddl: `
+				CREATE TABLE orders (
+					id bigint,
+					user_id int CONSTRAINT orders_user_fk REFERENCES users (id),
+					note text,
+					CONSTRAINT orders_pk PRIMARY KEY (id)
+				);
+				ALTER TABLE ONLY orders
+					ALTER COLUMN user_id SET NOT NULL,
+					ADD COLUMN total numeric(10, 2) DEFAULT 0,
+					DROP COLUMN note;
+				CREATE TABLE tmp (id int);
+				DROP TABLE IF EXISTS tmp;`,
+			expected: []*database.Table{
+				{
+					Name: "orders",
+					Columns: []database.Column{
+						{
+							OrdinalPosition: 1, Name: "id", DataType: "bigint", IsNullable: "NO",
+							NumericPrecision: nullInt64(64),
+							ConstraintName:   nullString("orders_pk"),
+							ConstraintType:   nullString("PRIMARY KEY"),
+							Constraints:      []database.Constraint{{Name: "orders_pk", Type: "PRIMARY KEY"}},
+						},
+						{
+							OrdinalPosition: 2, Name: "user_id", DataType: "integer", IsNullable: "NO",
+							NumericPrecision: nullInt64(32),
+							ConstraintName:   nullString("orders_user_fk"),
+							ConstraintType:   nullString("FOREIGN KEY"),
+							Constraints:      []database.Constraint{{Name: "orders_user_fk", Type: "FOREIGN KEY"}},
+						},
+						{
+							OrdinalPosition: 3, Name: "total", DataType: "numeric", IsNullable: "YES",
+							DefaultValue:     nullString("0"),
+							NumericPrecision: nullInt64(10),
+						},
+					},
+					ForeignKeys: []database.ForeignKey{
+						{Name: "orders_user_fk", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+					},
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:   "postgres schema-only dump",
+			dbType: settings.DBTypePostgresql,
+			ddl: `
+				\restrict 3fGx9kV0
+				SET statement_timeout = 0;
+				SELECT pg_catalog.set_config('search_path', '', false);
+
+				CREATE FUNCTION public.touch() RETURNS trigger
+					LANGUAGE plpgsql
+					AS $$
+				BEGIN
+					NEW.updated_at = now();
+					RETURN NEW;
+				END;
+				$$;
+
+				CREATE TABLE public.users (
+					id integer NOT NULL,
+					email character varying(255) NOT NULL
+				);
+				ALTER TABLE public.users OWNER TO postgres;
+				COMMENT ON COLUMN public.users.email IS 'login';
+
+				CREATE SEQUENCE public.users_id_seq
+					AS integer
+					START WITH 1
+					CACHE 1;
+				ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+
+				CREATE TABLE public.admins (
+					level integer
+				)
+				INHERITS (public.users);
+
+				ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+				ALTER TABLE ONLY public.users
+					ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+				CREATE INDEX users_email_idx ON public.users USING btree (email);
+				CREATE TRIGGER users_touch BEFORE UPDATE ON public.users FOR EACH ROW EXECUTE FUNCTION public.touch();
+				\unrestrict 3fGx9kV0`,
+			expected: []*database.Table{
+				{
+					Name: "admins",
+					Columns: []database.Column{
+						{
+							OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO",
+							NumericPrecision: nullInt64(32),
+						},
+						{
+							OrdinalPosition: 2, Name: "email", DataType: "character varying", IsNullable: "NO",
+							CharacterMaximumLength: nullInt64(255),
+						},
+						{
+							OrdinalPosition: 3, Name: "level", DataType: "integer", IsNullable: "YES",
+							NumericPrecision: nullInt64(32),
+						},
+					},
+				},
+				{
+					Name: "users",
+					Columns: []database.Column{
+						{
+							OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO",
+							DefaultValue:     nullString("nextval('public.users_id_seq'::regclass)"),
+							NumericPrecision: nullInt64(32),
+							ConstraintName:   nullString("users_pkey"),
+							ConstraintType:   nullString("PRIMARY KEY"),
+							Constraints:      []database.Constraint{{Name: "users_pkey", Type: "PRIMARY KEY"}},
+						},
+						{
+							OrdinalPosition: 2, Name: "email", DataType: "character varying", IsNullable: "NO",
+							CharacterMaximumLength: nullInt64(255),
+							Comment:                nullString("login"),
+						},
+					},
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:   "postgres partitions with the columns of their parent",
+			dbType: settings.DBTypePostgresql,
+			ddl: `
+				CREATE TABLE events (id bigint NOT NULL, at date) PARTITION BY RANGE (at);
+				CREATE TABLE events_2025 PARTITION OF events (
+					CONSTRAINT events_2025_pkey PRIMARY KEY (id)
+				) FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');`,
+			expected: []*database.Table{
+				{
+					Name: "events",
+					Columns: []database.Column{
+						{OrdinalPosition: 1, Name: "id", DataType: "bigint", IsNullable: "NO", NumericPrecision: nullInt64(64)},
+						{OrdinalPosition: 2, Name: "at", DataType: "date", IsNullable: "YES"},
+					},
+				},
+				{
+					Name: "events_2025",
+					Columns: []database.Column{
+						{
+							OrdinalPosition: 1, Name: "id", DataType: "bigint", IsNullable: "NO",
+							NumericPrecision: nullInt64(64),
+							ConstraintName:   nullString("events_2025_pkey"),
+							ConstraintType:   nullString("PRIMARY KEY"),
+							Constraints:      []database.Constraint{{Name: "events_2025_pkey", Type: "PRIMARY KEY"}},
+						},
+						{OrdinalPosition: 2, Name: "at", DataType: "date", IsNullable: "YES"},
+					},
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:   "mysql table with keys and options",
+			dbType: settings.DBTypeMySQL,
+			ddl: "CREATE TABLE `users` (\n" +
+				"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `email` varchar(255) CHARACTER SET utf8mb4 NOT NULL COMMENT 'login',\n" +
+				"  `status` enum('new','Done') DEFAULT 'new',\n" +
+				"  `created` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  UNIQUE KEY `email_uq` (`email`),\n" +
+				"  KEY `idx_created` (`created`)\n" +
+				") ENGINE=InnoDB COMMENT='all users';\n" +
+				"ALTER TABLE users ADD COLUMN active bool;",
+			expected: []*database.Table{
+				{
+					Name:    "users",
+					Comment: nullString("all users"),
+					Columns: []database.Column{
+						{
+							OrdinalPosition: 1, Name: "id", DataType: "int", ColumnType: "int(11) unsigned",
+							IsNullable: "NO", ColumnKey: "PRI", Extra: "auto_increment",
+						},
+						{
+							OrdinalPosition: 2, Name: "email", DataType: "varchar", ColumnType: "varchar(255)",
+							IsNullable: "NO", ColumnKey: "UNI",
+							CharacterMaximumLength: nullInt64(255),
+							Comment:                nullString("login"),
+						},
+						{
+							OrdinalPosition: 3, Name: "status", DataType: "enum", ColumnType: "enum('new','Done')",
+							IsNullable:   "YES",
+							DefaultValue: nullString("new"),
+							EnumValues:   []string{"new", "Done"},
+						},
+						{
+							OrdinalPosition: 4, Name: "created", DataType: "datetime", ColumnType: "datetime",
+							IsNullable: "NO", ColumnKey: "MUL",
+							DefaultValue: nullString("CURRENT_TIMESTAMP"),
+						},
+						{
+							OrdinalPosition: 5, Name: "active", DataType: "tinyint", ColumnType: "tinyint(1)",
+							IsNullable: "YES",
+						},
+					},
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:    "table created from a query produces error",
+			dbType:  settings.DBTypePostgresql,
+			ddl:     "CREATE TABLE copy AS SELECT * FROM users;",
+			isError: assert.Error,
+		},
+		{
+			desc:    "sqlite produces error",
+			dbType:  settings.DBTypeSQLite,
+			ddl:     "CREATE TABLE users (id integer);",
+			isError: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = tt.dbType
+
+			tables, err := Parse(s, tt.ddl)
+			tt.isError(t, err)
+			assert.Equal(t, tt.expected, tables)
+		})
+	}
+}
+
+func TestParse_ForeignKeys(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		ddl      string
+		expected map[string][]database.ForeignKey
+	}{
+		{
+			desc:   "postgres foreign keys of the primary key, composite and renamed",
+			dbType: settings.DBTypePostgresql,
+			ddl: `
+				CREATE TABLE customers (id int PRIMARY KEY);
+				CREATE TABLE carts (shop int, no int, PRIMARY KEY (shop, no));
+				CREATE TABLE items (
+					customer int REFERENCES customers ON DELETE CASCADE,
+					shop int,
+					cart int,
+					FOREIGN KEY (shop, cart) REFERENCES carts (shop, no),
+					other int REFERENCES unknown
+				);
+				ALTER TABLE customers RENAME TO clients;
+				ALTER TABLE carts RENAME COLUMN no TO number;`,
+			expected: map[string][]database.ForeignKey{
+				"items": {
+					{Name: "items_customer_fkey", Columns: []string{"customer"}, ReferencedTable: "clients", ReferencedColumns: []string{"id"}},
+					{
+						Name: "items_shop_cart_fkey", Columns: []string{"shop", "cart"},
+						ReferencedTable: "carts", ReferencedColumns: []string{"shop", "number"},
+					},
+				},
+			},
+		},
+		{
+			desc:   "mysql foreign keys added afterwards and dropped",
+			dbType: settings.DBTypeMySQL,
+			ddl: `
+				CREATE TABLE users (id int PRIMARY KEY);
+				CREATE TABLE orders (id int PRIMARY KEY, user_id int, buyer int);
+				ALTER TABLE orders ADD CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users (id);
+				ALTER TABLE orders ADD FOREIGN KEY fk_buyer (buyer) REFERENCES shop.users (id);
+				ALTER TABLE orders DROP FOREIGN KEY fk_buyer;`,
+			expected: map[string][]database.ForeignKey{
+				"orders": {
+					{Name: "fk_user", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = tt.dbType
+
+			tables, err := Parse(s, tt.ddl)
+			assert.NoError(t, err)
+			actual := map[string][]database.ForeignKey{}
+			for _, table := range tables {
+				if len(table.ForeignKeys) > 0 {
+					actual[table.Name] = table.ForeignKeys
+				}
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParse_Arrays(t *testing.T) {
+	s := settings.New()
+	s.DbType = settings.DBTypePostgresql
+
+	tables, err := Parse(s, `
+		CREATE TYPE mood AS ENUM ('happy', 'sad');
+		CREATE TABLE posts (
+			ids int[],
+			tags varchar(20)[] NOT NULL,
+			moods mood[],
+			scores double precision ARRAY,
+			data jsonb
+		);`)
+	assert.NoError(t, err)
+	if !assert.Len(t, tables, 1) {
+		return
+	}
+
+	actual := map[string][2]string{}
+	for _, column := range tables[0].Columns {
+		actual[column.Name] = [2]string{column.DataType, column.UdtName}
+	}
+	assert.Equal(t, map[string][2]string{
+		"ids":    {"ARRAY", "_int4"},
+		"tags":   {"ARRAY", "_varchar"},
+		"moods":  {"ARRAY", "_mood"},
+		"scores": {"ARRAY", "_float8"},
+		"data":   {"jsonb", ""},
+	}, actual)
+}
+
+func TestParseFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"001_users.up.sql":   "CREATE TABLE users (id int PRIMARY KEY);",
+		"001_users.down.sql": "DROP TABLE users;",
+		"002_name.up.sql":    "ALTER TABLE users ADD name text NOT NULL;",
+		"README.md":          "DROP TABLE users;",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	s := settings.New()
+	tables, err := ParseFiles(s, []string{dir})
+	assert.NoError(t, err)
+	if assert.Len(t, tables, 1) {
+		assert.Equal(t, "users", tables[0].Name)
+		assert.Len(t, tables[0].Columns, 2)
+	}
+
+	_, err = ParseFiles(s, []string{filepath.Join(dir, "missing.sql")})
+	assert.Error(t, err)
+
+	custom := filepath.Join(t.TempDir(), "shop.dump")
+	assert.NoError(t, os.WriteFile(custom, []byte("PGDMP\x01\x0e"), 0o600))
+	_, err = ParseFiles(s, []string{custom})
+	assert.ErrorContains(t, err, "custom format")
+}
@@ -0,0 +1,197 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind is the kind of a token of a statement.
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenQuoted
+	tokenString
+	tokenNumber
+	tokenPunct
+)
+
+// token is a token of a statement with its position in the source.
+type token struct {
+	kind  tokenKind
+	text  string
+	start int
+	end   int
+}
+
+// is returns true if the token is the given keyword or punctuation, keywords
+// compare case-insensitive.
+func (t token) is(s string) bool {
+	switch t.kind {
+	case tokenWord:
+		return strings.EqualFold(t.text, s)
+	case tokenPunct:
+		return t.text == s
+	}
+	return false
+}
+
+// statement is a statement of the source split into its tokens.
+type statement struct {
+	src    string
+	tokens []token
+	line   int
+}
+
+// text returns the source of the tokens from i to j, the one of j excluded.
+func (s statement) text(i, j int) string {
+	if i >= j {
+		return ""
+	}
+	return s.src[s.tokens[i].start:s.tokens[j-1].end]
+}
+
+// split splits the source into its statements, comments and the
+// meta-commands of psql are left out.
+func split(src string) ([]statement, error) {
+	var (
+		statements []statement
+		current    []token
+		line       = 1
+		start      = 1
+	)
+
+	flush := func() {
+		if len(current) > 0 {
+			statements = append(statements, statement{src: src, tokens: current, line: start})
+		}
+		current = nil
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(src[i:], "--") || c == '#' || c == '\\' && len(current) == 0:
+			// comments and the meta-commands of psql like \connect in dumps
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case c == ';':
+			flush()
+			i++
+		default:
+			if len(current) == 0 {
+				start = line
+			}
+			tok, err := scan(src, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			line += strings.Count(src[tok.start:tok.end], "\n")
+			current = append(current, tok)
+			i = tok.end
+		}
+	}
+	flush()
+
+	return statements, nil
+}
+
+// scan scans the token starting at i.
+func scan(src string, i int) (token, error) {
+	c := src[i]
+	switch {
+	case c == '\'':
+		end, err := closing(src, i, '\'')
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokenString, text: unquote(src[i:end], '\''), start: i, end: end}, nil
+	case c == '"' || c == '`':
+		end, err := closing(src, i, c)
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokenQuoted, text: unquote(src[i:end], c), start: i, end: end}, nil
+	case c == '$':
+		// dollar quoted string like $$text$$ or $body$text$body$
+		tag := src[i : i+1+strings.IndexByte(src[i+1:], '$')+1]
+		if len(tag) > 1 && isTag(tag[1:len(tag)-1]) {
+			end := strings.Index(src[i+len(tag):], tag)
+			if end < 0 {
+				return token{}, fmt.Errorf("unterminated string %s", tag)
+			}
+			end += i + 2*len(tag)
+			return token{kind: tokenString, text: src[i+len(tag) : end-len(tag)], start: i, end: end}, nil
+		}
+		return token{kind: tokenPunct, text: "$", start: i, end: i + 1}, nil
+	case c >= '0' && c <= '9':
+		end := i
+		for end < len(src) && (src[end] >= '0' && src[end] <= '9' || src[end] == '.') {
+			end++
+		}
+		return token{kind: tokenNumber, text: src[i:end], start: i, end: end}, nil
+	case isWordStart(rune(c)):
+		end := i
+		for end < len(src) && isWordPart(rune(src[end])) {
+			end++
+		}
+		return token{kind: tokenWord, text: src[i:end], start: i, end: end}, nil
+	}
+	return token{kind: tokenPunct, text: string(c), start: i, end: i + 1}, nil
+}
+
+// closing returns the end of the quoted text starting at i, quotes inside it
+// are doubled.
+func closing(src string, i int, quote byte) (int, error) {
+	for j := i + 1; j < len(src); j++ {
+		switch {
+		case src[j] == '\\' && quote == '\'':
+			j++
+		case src[j] == quote && j+1 < len(src) && src[j+1] == quote:
+			j++
+		case src[j] == quote:
+			return j + 1, nil
+		}
+	}
+	return len(src), fmt.Errorf("unterminated quote %c", quote)
+}
+
+// unquote removes the quotes of the quoted text and undoubles the ones inside.
+func unquote(quoted string, quote byte) string {
+	s := quoted[1 : len(quoted)-1]
+	q := string(quote)
+	return strings.ReplaceAll(s, q+q, q)
+}
+
+func isTag(s string) bool {
+	for _, r := range s {
+		if !isWordPart(r) || r == '$' {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_' || r >= 0x80
+}
+
+func isWordPart(r rune) bool {
+	return isWordStart(r) || unicode.IsDigit(r) || r == '$'
+}
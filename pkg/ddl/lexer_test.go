@@ -0,0 +1,77 @@
+package ddl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		desc     string
+		src      string
+		expected [][]string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "statements without comments",
+			src:      "-- users\nCREATE TABLE t (id int); /* ; */ # mysql\nDROP TABLE t;",
+			expected: [][]string{{"CREATE", "TABLE", "t", "(", "id", "int", ")"}, {"DROP", "TABLE", "t"}},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "strings and quoted identifiers",
+			src:      `COMMENT ON TABLE "My ""T""" IS 'it''s; ok'`,
+			expected: [][]string{{"COMMENT", "ON", "TABLE", `My "T"`, "IS", "it's; ok"}},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "dollar quoted function body",
+			src:      "CREATE FUNCTION f() AS $body$ BEGIN; END; $body$; SELECT 1",
+			expected: [][]string{{"CREATE", "FUNCTION", "f", "(", ")", "AS", " BEGIN; END; "}, {"SELECT", "1"}},
+			isError:  assert.NoError,
+		},
+		{
+			desc:    "unterminated string produces error",
+			src:     "COMMENT ON TABLE t IS 'text",
+			isError: assert.Error,
+		},
+		{
+			desc:    "unterminated quote as last byte produces error",
+			src:     `"`,
+			isError: assert.Error,
+		},
+		{
+			desc:    "unterminated quoted identifier at the end produces error",
+			src:     "CREATE TABLE t (id int); `",
+			isError: assert.Error,
+		},
+		{
+			desc:    "unterminated string at the end produces error",
+			src:     "CREATE TABLE t (id int); '",
+			isError: assert.Error,
+		},
+		{
+			desc:    "unterminated comment produces error",
+			src:     "CREATE TABLE t (id int); /* text",
+			isError: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			statements, err := split(tt.src)
+			tt.isError(t, err)
+
+			var actual [][]string
+			for _, stmt := range statements {
+				var texts []string
+				for _, tok := range stmt.tokens {
+					texts = append(texts, tok.text)
+				}
+				actual = append(actual, texts)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,737 @@
+package ddl
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// parser applies the statements to the tables of the schema.
+type parser struct {
+	dbType settings.DBType
+	schema string
+
+	created []*table
+	// enums are the enum types created by the statements with their values
+	enums map[string][]string
+}
+
+func newParser(s *settings.Settings) (*parser, error) {
+	if !slices.Contains(SupportedDbTypes, s.DbType.Dialect()) {
+		return nil, fmt.Errorf("SQL files of database type %q not supported, must be one of: %v", s.DbType, SupportedDbTypes)
+	}
+	return &parser{
+		dbType: s.DbType.Dialect(),
+		schema: s.Schema,
+		enums:  map[string][]string{},
+	}, nil
+}
+
+// tables returns the created tables sorted by name.
+func (p *parser) tables() []*database.Table {
+	tables := make([]*database.Table, 0, len(p.created))
+	for _, t := range p.created {
+		table := &database.Table{Name: t.name, Columns: t.rows(p.dbType), ForeignKeys: p.foreignKeys(t)}
+		if t.comment != nil {
+			table.Comment.String, table.Comment.Valid = *t.comment, true
+		}
+		tables = append(tables, table)
+	}
+	slices.SortFunc(tables, func(a, b *database.Table) int { return strings.Compare(a.Name, b.Name) })
+	return tables
+}
+
+func (p *parser) table(name string) *table {
+	i := slices.IndexFunc(p.created, func(t *table) bool { return t.name == name })
+	if i < 0 {
+		return nil
+	}
+	return p.created[i]
+}
+
+// parse applies the statements of the source.
+func (p *parser) parse(src string) error {
+	statements, err := split(src)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range statements {
+		c := &cursor{statement: stmt}
+		if err = p.apply(c); err != nil {
+			return fmt.Errorf("line %d: %w", stmt.line, err)
+		}
+	}
+	return nil
+}
+
+// apply applies the statement, unknown statements are skipped.
+func (p *parser) apply(c *cursor) error {
+	switch {
+	case c.accept("CREATE"):
+		c.accept("OR", "REPLACE")
+		for c.acceptAny("GLOBAL", "LOCAL", "TEMP", "TEMPORARY", "UNLOGGED") {
+		}
+		switch {
+		case c.accept("TABLE"):
+			return p.createTable(c)
+		case c.accept("TYPE"):
+			return p.createType(c)
+		}
+	case c.accept("ALTER", "TABLE"):
+		return p.alterTable(c)
+	case c.accept("DROP", "TABLE"):
+		return p.dropTable(c)
+	case c.accept("COMMENT", "ON"):
+		return p.commentOn(c)
+	}
+	return nil
+}
+
+// name parses a possibly qualified name and returns it and whether it belongs
+// to the schema.
+func (p *parser) name(c *cursor) (string, bool, error) {
+	parts := []string{}
+	for {
+		part, err := p.identifier(c)
+		if err != nil {
+			return "", false, err
+		}
+		parts = append(parts, part)
+		if !c.accept(".") {
+			break
+		}
+	}
+	name := parts[len(parts)-1]
+	// MySQL qualifies by the database, the schema applies to Postgres only
+	inSchema := len(parts) == 1 || p.dbType == settings.DBTypeMySQL || parts[len(parts)-2] == p.schema
+	return name, inSchema, nil
+}
+
+// identifier parses an identifier, Postgres folds unquoted ones to lower case.
+func (p *parser) identifier(c *cursor) (string, error) {
+	tok, ok := c.next()
+	switch {
+	case !ok:
+		return "", fmt.Errorf("missing name")
+	case tok.kind == tokenQuoted:
+		return tok.text, nil
+	case tok.kind != tokenWord:
+		return "", fmt.Errorf("unexpected %q, expected name", tok.text)
+	case p.dbType == settings.DBTypePostgresql:
+		return strings.ToLower(tok.text), nil
+	}
+	return tok.text, nil
+}
+
+// identifiers parses a parenthesized list of identifiers, the lengths and
+// orders of index columns are skipped.
+func (p *parser) identifiers(c *cursor) ([]string, error) {
+	if !c.accept("(") {
+		return nil, fmt.Errorf("missing list of columns")
+	}
+	var names []string
+	for {
+		name, err := p.identifier(c)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		c.skipUntil(",", ")")
+		if !c.accept(",") {
+			break
+		}
+	}
+	if !c.accept(")") {
+		return nil, fmt.Errorf("missing ) after list of columns")
+	}
+	return names, nil
+}
+
+// createTable parses CREATE TABLE.
+func (p *parser) createTable(c *cursor) error {
+	c.accept("IF", "NOT", "EXISTS")
+	name, inSchema, err := p.name(c)
+	if err != nil {
+		return err
+	}
+	if !inSchema {
+		return nil
+	}
+	t := &table{name: name}
+	switch {
+	case c.accept("PARTITION", "OF"):
+		// partitions have the columns of their parent and may add
+		// constraints
+		parent, err := p.parent(c)
+		if err != nil {
+			return fmt.Errorf("table %q: %w", name, err)
+		}
+		if parent != nil {
+			t.columns = copyColumns(parent.columns)
+		}
+		if c.accept("(") {
+			for !c.accept(")") && !c.done() {
+				con, _, err := p.tableConstraint(c)
+				if err != nil {
+					return fmt.Errorf("table %q: %w", name, err)
+				}
+				if con != nil {
+					t.addConstraint(con, p.dbType)
+				}
+				c.skipUntil(",", ")")
+				c.accept(",")
+			}
+		}
+	case c.accept("AS") || !c.accept("("):
+		return fmt.Errorf("table %q: only tables with a list of columns are supported", name)
+	default:
+		for !c.accept(")") {
+			if err = p.tableElement(c, t); err != nil {
+				return fmt.Errorf("table %q: %w", name, err)
+			}
+			if !c.accept(",") && !c.peek(")") {
+				return fmt.Errorf("table %q: unexpected %q", name, c.current().text)
+			}
+		}
+	}
+
+	// table options
+	for !c.done() {
+		switch {
+		case c.accept("COMMENT"):
+			c.accept("=")
+			comment := c.string()
+			t.comment = &comment
+		case c.accept("INHERITS", "("):
+			// the columns of the parents come first
+			var inherited []*database.Column
+			for !c.done() && !c.accept(")") {
+				parent, err := p.parent(c)
+				if err != nil {
+					return fmt.Errorf("table %q: %w", name, err)
+				}
+				if parent != nil {
+					inherited = append(inherited, copyColumns(parent.columns)...)
+				}
+				c.accept(",")
+			}
+			for _, col := range t.columns {
+				if i := slices.IndexFunc(inherited, func(c *database.Column) bool { return c.Name == col.Name }); i >= 0 {
+					inherited[i] = col
+					continue
+				}
+				inherited = append(inherited, col)
+			}
+			t.columns = inherited
+		default:
+			c.skip()
+		}
+	}
+
+	if i := slices.IndexFunc(p.created, func(existing *table) bool { return existing.name == name }); i >= 0 {
+		p.created[i] = t
+	} else {
+		p.created = append(p.created, t)
+	}
+	return nil
+}
+
+// parent parses the name of the parent of a partition or an inheriting table,
+// it returns nil for tables of other schemas or not created by the
+// statements.
+func (p *parser) parent(c *cursor) (*table, error) {
+	name, inSchema, err := p.name(c)
+	if err != nil || !inSchema {
+		return nil, err
+	}
+	return p.table(name), nil
+}
+
+// copyColumns returns copies of the columns without their comments, which
+// are not inherited.
+func copyColumns(columns []*database.Column) []*database.Column {
+	copied := make([]*database.Column, 0, len(columns))
+	for _, col := range columns {
+		cp := *col
+		cp.Comment.String, cp.Comment.Valid = "", false
+		copied = append(copied, &cp)
+	}
+	return copied
+}
+
+// tableElement parses a column or a constraint of the table.
+func (p *parser) tableElement(c *cursor, t *table) error {
+	con, ok, err := p.tableConstraint(c)
+	if err != nil || ok {
+		if con != nil {
+			t.addConstraint(con, p.dbType)
+		}
+		return err
+	}
+	if c.accept("LIKE") || c.accept("EXCLUDE") || c.accept("PERIOD") {
+		c.skipUntil(",", ")")
+		return nil
+	}
+	return p.columnDefinition(c, t)
+}
+
+// tableConstraint parses a constraint or index of the table, it returns false
+// if there is none. CHECK constraints are skipped and returned as nil.
+func (p *parser) tableConstraint(c *cursor) (*constraint, bool, error) {
+	con := &constraint{}
+	named := c.accept("CONSTRAINT")
+	if named {
+		name, err := p.identifier(c)
+		if err != nil {
+			return nil, false, err
+		}
+		con.name = name
+	}
+
+	switch {
+	case c.accept("PRIMARY", "KEY"):
+		con.typ = constraintPrimaryKey
+	case c.accept("UNIQUE"):
+		con.typ = constraintUnique
+		p.indexName(c, con)
+	case c.accept("FOREIGN", "KEY"):
+		con.typ = constraintForeignKey
+		p.indexName(c, con)
+	case c.accept("CHECK"):
+		c.skipUntil(",", ")")
+		return nil, true, nil
+	case !named && (c.peek("KEY") || c.peek("INDEX") || c.peek("FULLTEXT") || c.peek("SPATIAL")):
+		c.skip()
+		c.acceptAny("KEY", "INDEX")
+		con.typ = constraintIndex
+		p.indexName(c, con)
+	default:
+		if named {
+			return nil, false, fmt.Errorf("unexpected %q after constraint name", c.current().text)
+		}
+		return nil, false, nil
+	}
+
+	columns, err := p.identifiers(c)
+	if err != nil {
+		return nil, false, err
+	}
+	con.columns = columns
+	if con.typ == constraintForeignKey && c.accept("REFERENCES") {
+		if err = p.references(c, con); err != nil {
+			return nil, false, err
+		}
+	}
+	c.skipUntil(",", ")")
+	return con, true, nil
+}
+
+// references parses the table and the optional columns referenced by the
+// foreign key.
+func (p *parser) references(c *cursor, con *constraint) error {
+	name, _, err := p.name(c)
+	if err != nil {
+		return err
+	}
+	con.references = name
+	if c.peek("(") {
+		if con.referencedColumns, err = p.identifiers(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexName parses the optional name of a MySQL index.
+func (p *parser) indexName(c *cursor, con *constraint) {
+	c.acceptAny("KEY", "INDEX")
+	if !c.peek("(") && !c.peek("USING") {
+		if name, err := p.identifier(c); err == nil && con.name == "" {
+			con.name = name
+		}
+	}
+	if c.accept("USING") {
+		c.skip()
+	}
+}
+
+// columnDefinition parses the definition of a column and adds it to the
+// table.
+func (p *parser) columnDefinition(c *cursor, t *table) error {
+	name, err := p.identifier(c)
+	if err != nil {
+		return err
+	}
+	col := &database.Column{Name: name, IsNullable: "YES"}
+
+	typ, err := p.dataType(c)
+	if err != nil {
+		return fmt.Errorf("column %q: %w", name, err)
+	}
+	p.setType(t.name, col, typ)
+
+	var (
+		constraints []*constraint
+		// the name of the following constraint
+		constraintName string
+	)
+	for !c.done() && !c.peek(",") && !c.peek(")") {
+		switch {
+		case c.accept("NOT", "NULL"):
+			col.IsNullable = "NO"
+		case c.accept("NULL"):
+			col.IsNullable = "YES"
+		case c.accept("DEFAULT"):
+			p.defaultValue(c, col)
+		case c.accept("CONSTRAINT"):
+			if constraintName, err = p.identifier(c); err != nil {
+				return err
+			}
+			continue
+		case c.accept("PRIMARY", "KEY"):
+			constraints = append(constraints, &constraint{name: constraintName, typ: constraintPrimaryKey, columns: []string{name}})
+		case c.accept("UNIQUE"):
+			c.accept("KEY")
+			constraints = append(constraints, &constraint{name: constraintName, typ: constraintUnique, columns: []string{name}})
+		case c.accept("REFERENCES"):
+			con := &constraint{name: constraintName, typ: constraintForeignKey, columns: []string{name}}
+			if err = p.references(c, con); err != nil {
+				return err
+			}
+			constraints = append(constraints, con)
+		case c.accept("AUTO_INCREMENT"):
+			col.Extra = "auto_increment"
+		case c.accept("GENERATED"):
+			// identity columns are not null, generated ones computed
+			if c.skipUntilWord("IDENTITY", "AS"); c.accept("IDENTITY") {
+				col.IsNullable = "NO"
+				c.skipGroup()
+			} else {
+				c.accept("AS")
+				c.skipGroup()
+			}
+		case c.accept("COMMENT"):
+			comment := c.string()
+			col.Comment.String, col.Comment.Valid = comment, true
+		case c.accept("CHECK"):
+			c.skipGroup()
+		default:
+			c.skip()
+			c.skipGroup()
+		}
+		constraintName = ""
+	}
+
+	if col.Extra == "auto_increment" {
+		col.IsNullable = "NO"
+	}
+
+	t.columns = append(t.columns, col)
+	for _, con := range constraints {
+		t.addConstraint(con, p.dbType)
+	}
+	return nil
+}
+
+// defaultValue parses the default value of the column, NULL is none. MySQL
+// returns the values of string literals without their quotes.
+func (p *parser) defaultValue(c *cursor, col *database.Column) {
+	if c.accept("NULL") {
+		col.DefaultValue.Valid = false
+		return
+	}
+	if tok := c.current(); p.dbType == settings.DBTypeMySQL && tok.kind == tokenString {
+		c.skip()
+		col.DefaultValue.String, col.DefaultValue.Valid = tok.text, true
+		return
+	}
+
+	start := c.pos
+	for !c.done() && !c.peek(",") && !c.peek(")") && !c.peekAny(columnKeywords...) {
+		c.skip()
+		c.skipGroup()
+	}
+	col.DefaultValue.String, col.DefaultValue.Valid = c.text(start, c.pos), true
+}
+
+// columnKeywords end the default value of a column.
+var columnKeywords = []string{
+	"NOT", "NULL", "CONSTRAINT", "PRIMARY", "UNIQUE", "REFERENCES", "CHECK", "COLLATE", "GENERATED",
+	"AUTO_INCREMENT", "COMMENT", "ON", "INVISIBLE", "VISIBLE", "STORAGE", "COLUMN_FORMAT",
+}
+
+// createType parses CREATE TYPE, enum types are known to columns later.
+func (p *parser) createType(c *cursor) error {
+	name, _, err := p.name(c)
+	if err != nil {
+		return err
+	}
+	if !c.accept("AS", "ENUM") || !c.accept("(") {
+		return nil
+	}
+	values := []string{}
+	for !c.done() && !c.accept(")") {
+		if tok, _ := c.next(); tok.kind == tokenString {
+			values = append(values, tok.text)
+		}
+	}
+	p.enums[name] = values
+	return nil
+}
+
+// alterTable parses ALTER TABLE.
+func (p *parser) alterTable(c *cursor) error {
+	c.accept("IF", "EXISTS")
+	c.accept("ONLY")
+	name, inSchema, err := p.name(c)
+	if err != nil || !inSchema {
+		return err
+	}
+	t := p.table(name)
+	if t == nil {
+		return nil
+	}
+
+	for !c.done() {
+		if err = p.alterAction(c, t); err != nil {
+			return fmt.Errorf("table %q: %w", name, err)
+		}
+		c.skipUntil(",")
+		c.accept(",")
+	}
+	return nil
+}
+
+// alterAction parses an action of ALTER TABLE, unknown actions are skipped.
+func (p *parser) alterAction(c *cursor, t *table) error {
+	switch {
+	case c.accept("ADD"):
+		if con, ok, err := p.tableConstraint(c); err != nil || ok {
+			if con != nil {
+				t.addConstraint(con, p.dbType)
+			}
+			return err
+		}
+		c.accept("COLUMN")
+		c.accept("IF", "NOT", "EXISTS")
+		return p.columnDefinition(c, t)
+	case c.accept("DROP"):
+		switch {
+		case c.accept("CONSTRAINT") || c.accept("INDEX") || c.accept("KEY") || c.accept("FOREIGN", "KEY"):
+			c.accept("IF", "EXISTS")
+			name, err := p.identifier(c)
+			if err != nil {
+				return err
+			}
+			t.constraints = slices.DeleteFunc(t.constraints, func(con *constraint) bool { return con.name == name })
+		case c.accept("PRIMARY", "KEY"):
+			t.constraints = slices.DeleteFunc(t.constraints, func(con *constraint) bool { return con.typ == constraintPrimaryKey })
+		default:
+			c.accept("COLUMN")
+			c.accept("IF", "EXISTS")
+			name, err := p.identifier(c)
+			if err != nil {
+				return err
+			}
+			t.dropColumn(name)
+		}
+	case c.accept("ALTER"):
+		return p.alterColumn(c, t)
+	case c.accept("MODIFY"):
+		c.accept("COLUMN")
+		pos := c.pos
+		name, err := p.identifier(c)
+		if err != nil {
+			return err
+		}
+		c.pos = pos
+		return p.redefineColumn(c, t, name)
+	case c.accept("CHANGE"):
+		c.accept("COLUMN")
+		name, err := p.identifier(c)
+		if err != nil {
+			return err
+		}
+		return p.redefineColumn(c, t, name)
+	case c.accept("RENAME"):
+		return p.rename(c, t)
+	}
+	return nil
+}
+
+// redefineColumn replaces the column of the given name by the following
+// definition, for MODIFY and CHANGE of MySQL.
+func (p *parser) redefineColumn(c *cursor, t *table, name string) error {
+	i, _ := t.column(name)
+	if i < 0 {
+		return nil
+	}
+	redefined := &table{name: t.name}
+	if err := p.columnDefinition(c, redefined); err != nil {
+		return err
+	}
+	col := redefined.columns[0]
+	t.columns[i] = col
+	for _, con := range t.constraints {
+		for j, column := range con.columns {
+			if column == name {
+				con.columns[j] = col.Name
+			}
+		}
+	}
+	for _, con := range redefined.constraints {
+		t.addConstraint(con, p.dbType)
+	}
+	return nil
+}
+
+// alterColumn parses ALTER COLUMN.
+func (p *parser) alterColumn(c *cursor, t *table) error {
+	c.accept("COLUMN")
+	name, err := p.identifier(c)
+	if err != nil {
+		return err
+	}
+	_, col := t.column(name)
+	if col == nil {
+		return nil
+	}
+
+	switch {
+	case c.accept("SET", "DEFAULT"):
+		p.defaultValue(c, col)
+	case c.accept("DROP", "DEFAULT"):
+		col.DefaultValue.Valid = false
+	case c.accept("SET", "NOT", "NULL"):
+		col.IsNullable = "NO"
+	case c.accept("DROP", "NOT", "NULL"):
+		col.IsNullable = "YES"
+	case c.accept("SET", "DATA", "TYPE") || c.accept("TYPE"):
+		typ, err := p.dataType(c)
+		if err != nil {
+			return err
+		}
+		p.setType(t.name, col, typ)
+	}
+	return nil
+}
+
+// rename parses RENAME of a column or of the table.
+func (p *parser) rename(c *cursor, t *table) error {
+	if c.accept("TO") || c.accept("AS") {
+		name, _, err := p.name(c)
+		if err != nil {
+			return err
+		}
+		p.renameReferences(t.name, name, "", "")
+		t.name = name
+		return nil
+	}
+	if c.accept("CONSTRAINT") || c.accept("INDEX") || c.accept("KEY") {
+		return nil
+	}
+	c.accept("COLUMN")
+	from, err := p.identifier(c)
+	if err != nil {
+		return err
+	}
+	if !c.accept("TO") {
+		return fmt.Errorf("missing TO in RENAME")
+	}
+	to, err := p.identifier(c)
+	if err != nil {
+		return err
+	}
+	if _, col := t.column(from); col != nil {
+		col.Name = to
+	}
+	for _, con := range t.constraints {
+		for i, name := range con.columns {
+			if name == from {
+				con.columns[i] = to
+			}
+		}
+	}
+	p.renameReferences(t.name, t.name, from, to)
+	return nil
+}
+
+// renameReferences renames the referenced table of the foreign keys of the
+// tables from the table to the name and the referenced column from to to, if
+// given.
+func (p *parser) renameReferences(table, name, from, to string) {
+	for _, t := range p.created {
+		for _, con := range t.constraints {
+			if con.typ != constraintForeignKey || con.references != table {
+				continue
+			}
+			con.references = name
+			for i, column := range con.referencedColumns {
+				if from != "" && column == from {
+					con.referencedColumns[i] = to
+				}
+			}
+		}
+	}
+}
+
+// dropTable parses DROP TABLE.
+func (p *parser) dropTable(c *cursor) error {
+	c.accept("IF", "EXISTS")
+	for !c.done() {
+		name, inSchema, err := p.name(c)
+		if err != nil {
+			return err
+		}
+		if inSchema {
+			p.created = slices.DeleteFunc(p.created, func(t *table) bool { return t.name == name })
+		}
+		if !c.accept(",") {
+			break
+		}
+	}
+	return nil
+}
+
+// commentOn parses COMMENT ON TABLE and COMMENT ON COLUMN of Postgres.
+func (p *parser) commentOn(c *cursor) error {
+	switch {
+	case c.accept("TABLE"):
+		name, inSchema, err := p.name(c)
+		if err != nil || !inSchema {
+			return err
+		}
+		if t := p.table(name); t != nil && c.accept("IS") {
+			t.comment = c.nullableString()
+		}
+	case c.accept("COLUMN"):
+		var parts []string
+		for {
+			part, err := p.identifier(c)
+			if err != nil {
+				return err
+			}
+			parts = append(parts, part)
+			if !c.accept(".") {
+				break
+			}
+		}
+		if len(parts) < 2 || len(parts) > 2 && parts[len(parts)-3] != p.schema {
+			return nil
+		}
+		t := p.table(parts[len(parts)-2])
+		if t == nil || !c.accept("IS") {
+			return nil
+		}
+		if _, col := t.column(parts[len(parts)-1]); col != nil {
+			col.Comment.Valid = false
+			if comment := c.nullableString(); comment != nil {
+				col.Comment.String, col.Comment.Valid = *comment, true
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package ddl
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// dataType is the data type of a column as written in the statement.
+type dataType struct {
+	// name are the lower case words of the type without its arguments,
+	// e.g. "timestamp with time zone"
+	name string
+	// args are the arguments of the type as written, e.g. the length
+	args []string
+	// modifiers are the lower case words following the arguments
+	modifiers []string
+	array     bool
+}
+
+// typeWords are the words continuing the name of a type.
+var typeWords = []string{"varying", "precision", "with", "without", "time", "zone"}
+
+// typeModifiers are the words following the type of MySQL.
+var typeModifiers = []string{"unsigned", "signed", "zerofill"}
+
+// dataType parses the data type of a column.
+func (p *parser) dataType(c *cursor) (dataType, error) {
+	var (
+		typ   dataType
+		words []string
+	)
+
+	tok := c.current()
+	if tok.kind != tokenWord && tok.kind != tokenQuoted {
+		return typ, fmt.Errorf("missing data type")
+	}
+	c.skip()
+	if tok.kind == tokenWord {
+		tok.text = strings.ToLower(tok.text)
+	}
+	words = append(words, tok.text)
+	if c.accept(".") {
+		// a type of a schema
+		name, err := p.identifier(c)
+		if err != nil {
+			return typ, err
+		}
+		words = []string{name}
+	}
+
+	for !c.done() {
+		switch {
+		case c.peek("(") && typ.args == nil:
+			typ.args = p.typeArgs(c)
+		case c.accept("["):
+			c.skipUntil("]")
+			c.accept("]")
+			typ.array = true
+		case c.accept("ARRAY"):
+			typ.array = true
+		case c.peekAny(typeWords...):
+			word, _ := c.next()
+			words = append(words, strings.ToLower(word.text))
+		case c.peekAny(typeModifiers...):
+			word, _ := c.next()
+			typ.modifiers = append(typ.modifiers, strings.ToLower(word.text))
+		default:
+			typ.name = strings.Join(words, " ")
+			return typ, nil
+		}
+	}
+	typ.name = strings.Join(words, " ")
+	return typ, nil
+}
+
+// typeArgs parses the parenthesized arguments of a type.
+func (p *parser) typeArgs(c *cursor) []string {
+	c.accept("(")
+	args := []string{}
+	start := c.pos
+	for !c.done() && !c.peek(")") {
+		if c.peek(",") {
+			args = append(args, c.text(start, c.pos))
+			c.skip()
+			start = c.pos
+			continue
+		}
+		c.skip()
+		c.skipGroup()
+	}
+	args = append(args, c.text(start, c.pos))
+	c.accept(")")
+	return args
+}
+
+// intArg returns the argument of the index as number.
+func (t dataType) intArg(i int) (int64, bool) {
+	if i >= len(t.args) {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(t.args[i], 10, 64)
+	return n, err == nil
+}
+
+// setType sets the data type of the column as the database reports it.
+func (p *parser) setType(table string, col *database.Column, typ dataType) {
+	col.CharacterMaximumLength.Valid = false
+	col.NumericPrecision.Valid = false
+	col.EnumValues = nil
+	col.EnumType = ""
+	col.UdtName = ""
+
+	if p.dbType == settings.DBTypeMySQL {
+		setMySQLType(col, typ)
+		return
+	}
+	p.setPostgresType(table, col, typ)
+}
+
+func (p *parser) setPostgresType(table string, col *database.Column, typ dataType) {
+	setPrecision := func(precision int64) {
+		col.NumericPrecision.Int64, col.NumericPrecision.Valid = precision, true
+	}
+	setLength := func(defaultLength int64, ok bool) {
+		length, has := typ.intArg(0)
+		if !has {
+			length, has = defaultLength, ok
+		}
+		col.CharacterMaximumLength.Int64, col.CharacterMaximumLength.Valid = length, has
+	}
+	serial := func(dataType string, precision int64) {
+		col.DataType = dataType
+		setPrecision(precision)
+		col.IsNullable = "NO"
+		col.DefaultValue.String = "nextval('" + table + "_" + col.Name + "_seq'::regclass)"
+		col.DefaultValue.Valid = true
+	}
+
+	if typ.array {
+		elem := database.Column{Name: col.Name}
+		typ.array = false
+		p.setPostgresType(table, &elem, typ)
+		col.DataType = "ARRAY"
+		col.UdtName = "_" + pgUdtName(elem)
+		return
+	}
+	if values, ok := p.enums[typ.name]; ok {
+		col.DataType = "USER-DEFINED"
+		col.EnumValues = slices.Clone(values)
+		col.EnumType = typ.name
+		return
+	}
+
+	switch typ.name {
+	case "smallint", "int2":
+		col.DataType = "smallint"
+		setPrecision(16)
+	case "integer", "int", "int4":
+		col.DataType = "integer"
+		setPrecision(32)
+	case "bigint", "int8":
+		col.DataType = "bigint"
+		setPrecision(64)
+	case "smallserial", "serial2":
+		serial("smallint", 16)
+	case "serial", "serial4":
+		serial("integer", 32)
+	case "bigserial", "serial8":
+		serial("bigint", 64)
+	case "real", "float4":
+		col.DataType = "real"
+		setPrecision(24)
+	case "double precision", "float8":
+		col.DataType = "double precision"
+		setPrecision(53)
+	case "float":
+		col.DataType = "double precision"
+		setPrecision(53)
+		if precision, ok := typ.intArg(0); ok && precision <= 24 {
+			col.DataType = "real"
+			setPrecision(24)
+		}
+	case "numeric", "decimal":
+		col.DataType = "numeric"
+		if precision, ok := typ.intArg(0); ok {
+			setPrecision(precision)
+		}
+	case "character varying", "varchar":
+		col.DataType = "character varying"
+		setLength(0, false)
+	case "character", "char", "bpchar":
+		col.DataType = "character"
+		setLength(1, true)
+	case "bool", "boolean":
+		col.DataType = "boolean"
+	case "timestamp", "timestamp without time zone":
+		col.DataType = "timestamp without time zone"
+	case "timestamptz", "timestamp with time zone":
+		col.DataType = "timestamp with time zone"
+	case "time", "time without time zone":
+		col.DataType = "time without time zone"
+	case "timetz", "time with time zone":
+		col.DataType = "time with time zone"
+	default:
+		col.DataType = typ.name
+	}
+}
+
+// pgUdtNames maps the data types of Postgres to the names of the types, the
+// ones named differently.
+var pgUdtNames = map[string]string{
+	"smallint":                    "int2",
+	"integer":                     "int4",
+	"bigint":                      "int8",
+	"real":                        "float4",
+	"double precision":            "float8",
+	"character varying":           "varchar",
+	"character":                   "bpchar",
+	"boolean":                     "bool",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamptz",
+	"time without time zone":      "time",
+	"time with time zone":         "timetz",
+}
+
+// pgUdtName returns the name of the type of the column as Postgres reports it
+// as udt_name, e.g. int4 of integer.
+func pgUdtName(col database.Column) string {
+	if col.EnumType != "" {
+		return col.EnumType
+	}
+	if name, ok := pgUdtNames[col.DataType]; ok {
+		return name
+	}
+	return col.DataType
+}
+
+// mysqlTypes maps the synonyms of MySQL types to the types it reports.
+var mysqlTypes = map[string]string{
+	"integer":           "int",
+	"int4":              "int",
+	"int8":              "bigint",
+	"dec":               "decimal",
+	"numeric":           "decimal",
+	"fixed":             "decimal",
+	"real":              "double",
+	"double precision":  "double",
+	"float8":            "double",
+	"float4":            "float",
+	"character":         "char",
+	"character varying": "varchar",
+	"nchar":             "char",
+	"nvarchar":          "varchar",
+}
+
+func setMySQLType(col *database.Column, typ dataType) {
+	name, modifiers := typ.name, typ.modifiers
+	if mapped, ok := mysqlTypes[name]; ok {
+		name = mapped
+	}
+
+	args := typ.args
+	switch name {
+	case "bool", "boolean":
+		name, args = "tinyint", []string{"1"}
+	case "serial":
+		name, modifiers = "bigint", []string{"unsigned"}
+		col.IsNullable = "NO"
+		col.Extra = "auto_increment"
+	}
+
+	columnType := name
+	if len(args) > 0 {
+		columnType += "(" + strings.Join(args, ",") + ")"
+	}
+	if len(modifiers) > 0 {
+		columnType += " " + strings.Join(modifiers, " ")
+	}
+
+	col.DataType = name
+	col.ColumnType = columnType
+
+	typ.args = args
+	switch name {
+	case "char", "varchar", "binary", "varbinary":
+		if length, ok := typ.intArg(0); ok {
+			col.CharacterMaximumLength.Int64, col.CharacterMaximumLength.Valid = length, true
+		}
+	case "decimal":
+		if precision, ok := typ.intArg(0); ok {
+			col.NumericPrecision.Int64, col.NumericPrecision.Valid = precision, true
+		}
+	case "enum":
+		for _, arg := range args {
+			col.EnumValues = append(col.EnumValues, unquote(strings.TrimSpace(arg), '\''))
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package ir
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Database implements the Database interface by the tables of a schema
+// instead of querying a database. It maps the columns to Go types like the
+// database the schema was introspected from, it never connects.
+type Database struct {
+	database.Database
+	settings *settings.Settings
+	schema   *Schema
+}
+
+// Open reads the schema of the file given by FromIR of the settings and
+// creates its Database. The database type of the settings becomes the one of
+// the schema.
+func Open(s *settings.Settings) (*Database, error) {
+	schema, err := ReadFile(s.FromIR)
+	if err != nil {
+		return nil, err
+	}
+	return NewDatabase(s, schema)
+}
+
+// NewDatabase creates the Database of the schema. The database type of the
+// settings becomes the one of the schema.
+func NewDatabase(s *settings.Settings, schema *Schema) (*Database, error) {
+	if !settings.SupportedDbTypes[schema.DbType] {
+		return nil, fmt.Errorf("database type %q of the schema representation not supported, must be one of: %v",
+			schema.DbType, settings.SprintfSupportedDbTypes())
+	}
+	s.DbType = schema.DbType
+	return &Database{
+		Database: database.New(s),
+		settings: s,
+		schema:   schema,
+	}, nil
+}
+
+// Connect does nothing, the Database needs no connection.
+func (db *Database) Connect(context.Context) error {
+	return nil
+}
+
+// Close does nothing, the Database needs no connection.
+func (db *Database) Close() error {
+	return nil
+}
+
+// GetTables returns the tables of the schema, or only the given ones matched
+// exactly, quoted or not, without their columns. The views are returned by
+// the settings only.
+func (db *Database) GetTables(_ context.Context, tables ...string) ([]*database.Table, error) {
+	result := make([]*database.Table, 0, len(db.schema.Tables))
+	for _, table := range db.schema.Tables {
+		if len(tables) > 0 && !slices.ContainsFunc(tables, func(name string) bool {
+			name, _ = database.UnquoteIdentifier(name)
+			return name == table.Name
+		}) {
+			continue
+		}
+		t := &database.Table{
+			Name:    table.Name,
+			Comment: toNullString(table.Comment),
+			Type:    table.Type,
+		}
+		if t.IsView() && !db.settings.HasViews() || !t.IsView() && db.settings.OnlyViews {
+			continue
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// PrepareGetColumnsOfTableStmt does nothing, the columns are known.
+func (db *Database) PrepareGetColumnsOfTableStmt(context.Context) error {
+	return nil
+}
+
+// GetColumnsOfTable sets the columns of the table with their constraints,
+// see database.MergeColumns.
+func (db *Database) GetColumnsOfTable(_ context.Context, table *database.Table) error {
+	i := slices.IndexFunc(db.schema.Tables, func(t Table) bool { return t.Name == table.Name })
+	if i < 0 {
+		return fmt.Errorf("table %q not in the schema representation", table.Name)
+	}
+	table.Columns = db.schema.Tables[i].DatabaseColumns()
+	return nil
+}
+
+// GetForeignKeysOfTable is the implementation of the ForeignKeysGetter
+// interface, the foreign keys are the ones of the schema.
+func (db *Database) GetForeignKeysOfTable(_ context.Context, table *database.Table) error {
+	i := slices.IndexFunc(db.schema.Tables, func(t Table) bool { return t.Name == table.Name })
+	if i < 0 {
+		return fmt.Errorf("table %q not in the schema representation", table.Name)
+	}
+	table.ForeignKeys = nil
+	for _, foreignKey := range db.schema.Tables[i].ForeignKeys {
+		table.ForeignKeys = append(table.ForeignKeys, database.ForeignKey(foreignKey))
+	}
+	return nil
+}
+
+// DatabaseColumns returns the columns of the table with their constraints,
+// see database.MergeColumns.
+func (t Table) DatabaseColumns() []database.Column {
+	columns := make([]database.Column, 0, len(t.Columns))
+	for _, column := range t.Columns {
+		c := column.databaseColumn()
+		for _, name := range column.Constraints {
+			constraint := database.Constraint{Name: name}
+			if j := slices.IndexFunc(t.Constraints, func(c Constraint) bool { return c.Name == name }); j >= 0 {
+				constraint.Type = t.Constraints[j].Type
+			}
+			c.Constraints = append(c.Constraints, constraint)
+		}
+		columns = append(columns, c)
+	}
+	// sets the primary key or first constraint of the columns
+	return database.MergeColumns(columns)
+}
+
+func (c Column) databaseColumn() database.Column {
+	isNullable := "NO"
+	if c.Nullable {
+		isNullable = "YES"
+	}
+	return database.Column{
+		OrdinalPosition:        c.OrdinalPosition,
+		Name:                   c.Name,
+		DataType:               c.DataType,
+		DefaultValue:           toNullString(c.Default),
+		IsNullable:             isNullable,
+		CharacterMaximumLength: toNullInt64(c.CharacterMaximumLength),
+		NumericPrecision:       toNullInt64(c.NumericPrecision),
+		NumericScale:           toNullInt64(c.NumericScale),
+		ColumnType:             c.ColumnType,
+		UdtName:                c.UdtName,
+		ColumnKey:              c.ColumnKey,
+		Extra:                  c.Extra,
+		Comment:                toNullString(c.Comment),
+		EnumValues:             c.EnumValues,
+		EnumType:               c.EnumType,
+	}
+}
+
+func toNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func toNullInt64(i *int64) sql.NullInt64 {
+	if i == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *i, Valid: true}
+}
@@ -0,0 +1,173 @@
+package ir
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestDatabase(t *testing.T) {
+	columns := []database.Column{
+		{
+			OrdinalPosition: 1, Name: "id", DataType: "int", ColumnType: "int(11)", IsNullable: "NO",
+			ColumnKey: "PRI", Extra: "auto_increment",
+		},
+		{
+			OrdinalPosition: 2, Name: "status", DataType: "enum", ColumnType: "enum('open','paid')", IsNullable: "YES",
+			DefaultValue: sql.NullString{String: "open", Valid: true}, EnumValues: []string{"open", "paid"}, EnumType: "order_status",
+			Comment: sql.NullString{String: "state of the order", Valid: true},
+		},
+		{
+			OrdinalPosition: 3, Name: "user_id", DataType: "int", IsNullable: "NO",
+			ConstraintName: sql.NullString{String: "orders_pkey", Valid: true},
+			ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true},
+		},
+		{
+			OrdinalPosition: 3, Name: "user_id", DataType: "int", IsNullable: "NO",
+			ConstraintName: sql.NullString{String: "orders_user_fkey", Valid: true},
+			ConstraintType: sql.NullString{String: "FOREIGN KEY", Valid: true},
+		},
+	}
+	foreignKeys := []database.ForeignKey{
+		{Name: "orders_user_fkey", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+	}
+	tables := []*database.Table{
+		{
+			Name: "orders", Comment: sql.NullString{String: "orders of users", Valid: true}, Columns: columns,
+			ForeignKeys: foreignKeys,
+		},
+		{Name: "users", Columns: []database.Column{{OrdinalPosition: 1, Name: "id", DataType: "int", IsNullable: "NO"}}},
+	}
+
+	s := settings.New()
+	s.DbType = settings.DBTypeMySQL
+	name := filepath.Join(t.TempDir(), "schema.json")
+	assert.NoError(t, FromTables(s, tables).WriteFile(name))
+
+	s = settings.New()
+	s.FromIR = name
+	db, err := Open(s)
+	assert.NoError(t, err)
+	assert.Equal(t, settings.DBTypeMySQL, s.DbType)
+	assert.NoError(t, db.Connect(context.Background()))
+	defer db.Close()
+
+	// the tables come back as read from the database, the columns merged
+	actual, err := db.GetTables(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []*database.Table{
+		{Name: "orders", Comment: sql.NullString{String: "orders of users", Valid: true}},
+		{Name: "users"},
+	}, actual)
+	assert.NoError(t, db.PrepareGetColumnsOfTableStmt(context.Background()))
+	assert.NoError(t, db.GetColumnsOfTable(context.Background(), actual[0]))
+	assert.Equal(t, database.MergeColumns(columns), actual[0].Columns)
+	assert.NoError(t, db.GetForeignKeysOfTable(context.Background(), actual[0]))
+	assert.Equal(t, foreignKeys, actual[0].ForeignKeys)
+
+	// the columns map like the ones of the database
+	assert.True(t, db.IsPrimaryKey(actual[0].Columns[0]))
+	assert.True(t, db.IsAutoIncrement(actual[0].Columns[0]))
+
+	actual, err = db.GetTables(context.Background(), "users")
+	assert.NoError(t, err)
+	assert.Equal(t, []*database.Table{{Name: "users"}}, actual)
+	actual, err = db.GetTables(context.Background(), `"users"`, `"Orders"`)
+	assert.NoError(t, err)
+	assert.Equal(t, []*database.Table{{Name: "users"}}, actual)
+
+	err = db.GetColumnsOfTable(context.Background(), &database.Table{Name: "payments"})
+	assert.EqualError(t, err, `table "payments" not in the schema representation`)
+}
+
+func TestDatabase_views(t *testing.T) {
+	tables := []*database.Table{
+		{Name: "users", Type: database.TableTypeBase},
+		{Name: "active_users", Type: database.TableTypeView},
+		{Name: "user_stats", Type: database.TableTypeMaterializedView},
+	}
+	s := settings.New()
+	s.DbType = settings.DBTypePostgresql
+	name := filepath.Join(t.TempDir(), "schema.json")
+	assert.NoError(t, FromTables(s, tables).WriteFile(name))
+
+	tests := []struct {
+		desc         string
+		includeViews bool
+		onlyViews    bool
+		expected     []string
+	}{
+		{
+			desc:     "tables only by default",
+			expected: []string{"users"},
+		},
+		{
+			desc:         "include views",
+			includeViews: true,
+			expected:     []string{"users", "active_users", "user_stats"},
+		},
+		{
+			desc:      "only views",
+			onlyViews: true,
+			expected:  []string{"active_users", "user_stats"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.FromIR = name
+			s.IncludeViews = test.includeViews
+			s.OnlyViews = test.onlyViews
+			db, err := Open(s)
+			assert.NoError(t, err)
+
+			actual, err := db.GetTables(context.Background())
+			assert.NoError(t, err)
+			var names []string
+			for _, table := range actual {
+				names = append(names, table.Name)
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		desc     string
+		content  string
+		expected string
+	}{
+		{
+			desc:     "no JSON produces error",
+			content:  "tables:",
+			expected: "could not parse the schema representation",
+		},
+		{
+			desc:     "other version produces error",
+			content:  `{"version": 2, "db_type": "pg", "tables": []}`,
+			expected: "version 2 of the schema representation",
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			name := filepath.Join(dir, string(rune('a'+i))+".json")
+			assert.NoError(t, os.WriteFile(name, []byte(test.content), 0644))
+
+			_, err := ReadFile(name)
+			assert.ErrorContains(t, err, test.expected)
+		})
+	}
+
+	_, err := NewDatabase(settings.New(), &Schema{Version: Version, DbType: "db2"})
+	assert.ErrorContains(t, err, `database type "db2" of the schema representation not supported`)
+}
@@ -0,0 +1,195 @@
+// Package ir is the intermediate representation of an introspected schema,
+// the tables with their columns and constraints as read from the database.
+// It is serializable as JSON to let other tools consume the schema without
+// querying the database again.
+package ir
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Version is the version of the format of the representation, it changes
+// with incompatible changes only.
+const Version = 1
+
+// Schema is an introspected schema with its tables in the order of the
+// introspection.
+type Schema struct {
+	Version   int    `json:"version"`
+	Generator string `json:"generator"`
+	// DbType is the type of the database the schema was introspected from,
+	// its dialect decides how the columns map to Go types
+	DbType   settings.DBType `json:"db_type"`
+	Database string          `json:"database"`
+	Schema   string          `json:"schema,omitempty"`
+	Tables   []Table         `json:"tables"`
+}
+
+// Table is an introspected table.
+type Table struct {
+	Name        string       `json:"name"`
+	Type        string       `json:"type,omitempty"`
+	Comment     *string      `json:"comment,omitempty"`
+	Columns     []Column     `json:"columns"`
+	Constraints []Constraint `json:"constraints,omitempty"`
+	ForeignKeys []ForeignKey `json:"foreign_keys,omitempty"`
+}
+
+// Column is an introspected column. The fields hold the values as read from
+// the database, the ones specific to a database are left out if empty.
+type Column struct {
+	Name                   string   `json:"name"`
+	OrdinalPosition        int      `json:"ordinal_position"`
+	DataType               string   `json:"data_type"`
+	ColumnType             string   `json:"column_type,omitempty"`
+	UdtName                string   `json:"udt_name,omitempty"`
+	Nullable               bool     `json:"nullable"`
+	Default                *string  `json:"default,omitempty"`
+	CharacterMaximumLength *int64   `json:"character_maximum_length,omitempty"`
+	NumericPrecision       *int64   `json:"numeric_precision,omitempty"`
+	NumericScale           *int64   `json:"numeric_scale,omitempty"`
+	ColumnKey              string   `json:"column_key,omitempty"`
+	Extra                  string   `json:"extra,omitempty"`
+	Comment                *string  `json:"comment,omitempty"`
+	EnumValues             []string `json:"enum_values,omitempty"`
+	EnumType               string   `json:"enum_type,omitempty"`
+	// Constraints are the names of the constraints of the table the column
+	// is part of
+	Constraints []string `json:"constraints,omitempty"`
+}
+
+// Constraint is a constraint of a table, e.g. its primary key.
+type Constraint struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type,omitempty"`
+	Columns []string `json:"columns"`
+}
+
+// ForeignKey is a foreign key of a table, its columns reference the columns
+// of the referenced table in the same order.
+type ForeignKey struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referenced_table"`
+	ReferencedColumns []string `json:"referenced_columns"`
+}
+
+// FromTables creates the representation of the introspected tables of the
+// database and schema of the settings. The databases return a column once
+// per constraint it is part of, the representation holds it once with the
+// constraints of the table.
+func FromTables(s *settings.Settings, tables []*database.Table) *Schema {
+	schema := &Schema{
+		Version:   Version,
+		Generator: s.GeneratorVersion,
+		DbType:    s.DbType,
+		Database:  s.DbName,
+		Schema:    s.Schema,
+		Tables:    make([]Table, 0, len(tables)),
+	}
+	for _, table := range tables {
+		schema.Tables = append(schema.Tables, TableOf(table))
+	}
+	return schema
+}
+
+// TableOf creates the representation of the introspected table.
+func TableOf(table *database.Table) Table {
+	t := Table{
+		Name:    table.Name,
+		Type:    table.Type,
+		Comment: fromNullString(table.Comment),
+		Columns: []Column{},
+	}
+
+	for _, column := range database.MergeColumns(table.Columns) {
+		c := fromColumn(column)
+		for _, constraint := range column.Constraints {
+			if constraint.Name == "" {
+				continue
+			}
+			c.Constraints = append(c.Constraints, constraint.Name)
+			j := slices.IndexFunc(t.Constraints, func(other Constraint) bool { return other.Name == constraint.Name })
+			if j < 0 {
+				j = len(t.Constraints)
+				t.Constraints = append(t.Constraints, Constraint{Name: constraint.Name, Type: constraint.Type})
+			}
+			t.Constraints[j].Columns = append(t.Constraints[j].Columns, column.Name)
+		}
+		t.Columns = append(t.Columns, c)
+	}
+
+	for _, foreignKey := range table.ForeignKeys {
+		t.ForeignKeys = append(t.ForeignKeys, ForeignKey(foreignKey))
+	}
+	return t
+}
+
+func fromColumn(column database.Column) Column {
+	return Column{
+		Name:                   column.Name,
+		OrdinalPosition:        column.OrdinalPosition,
+		DataType:               column.DataType,
+		ColumnType:             column.ColumnType,
+		UdtName:                column.UdtName,
+		Nullable:               column.IsNullable == "YES",
+		Default:                fromNullString(column.DefaultValue),
+		CharacterMaximumLength: fromNullInt64(column.CharacterMaximumLength),
+		NumericPrecision:       fromNullInt64(column.NumericPrecision),
+		NumericScale:           fromNullInt64(column.NumericScale),
+		ColumnKey:              column.ColumnKey,
+		Extra:                  column.Extra,
+		Comment:                fromNullString(column.Comment),
+		EnumValues:             column.EnumValues,
+		EnumType:               column.EnumType,
+	}
+}
+
+func fromNullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func fromNullInt64(i sql.NullInt64) *int64 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int64
+}
+
+// WriteFile writes the schema as indented JSON to the file.
+func (s *Schema) WriteFile(name string) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not create the schema representation: %w", err)
+	}
+	if err = os.WriteFile(name, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("could not write the schema representation: %w", err)
+	}
+	return nil
+}
+
+// ReadFile reads the schema written by WriteFile.
+func ReadFile(name string) (*Schema, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the schema representation: %w", err)
+	}
+	var schema Schema
+	if err = json.Unmarshal(content, &schema); err != nil {
+		return nil, fmt.Errorf("could not parse the schema representation %q: %w", name, err)
+	}
+	if schema.Version != Version {
+		return nil, fmt.Errorf("version %d of the schema representation %q not supported, must be %d", schema.Version, name, Version)
+	}
+	return &schema, nil
+}
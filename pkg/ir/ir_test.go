@@ -0,0 +1,99 @@
+package ir
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestFromTables(t *testing.T) {
+	s := settings.New()
+	s.DbName = "shop"
+	s.GeneratorVersion = "v2.1.0"
+
+	// the account_id is part of the primary key and a foreign key, hence
+	// returned twice
+	tables := []*database.Table{
+		{
+			Name:    "memberships",
+			Comment: sql.NullString{String: "members of accounts", Valid: true},
+			Columns: []database.Column{
+				{
+					OrdinalPosition: 1, Name: "account_id", DataType: "integer", IsNullable: "NO",
+					ConstraintName: sql.NullString{String: "memberships_pkey", Valid: true},
+					ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true},
+				},
+				{
+					OrdinalPosition: 1, Name: "account_id", DataType: "integer", IsNullable: "NO",
+					ConstraintName: sql.NullString{String: "memberships_account_id_fkey", Valid: true},
+					ConstraintType: sql.NullString{String: "FOREIGN KEY", Valid: true},
+				},
+				{
+					OrdinalPosition: 2, Name: "user_id", DataType: "integer", IsNullable: "NO",
+					ConstraintName: sql.NullString{String: "memberships_pkey", Valid: true},
+					ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true},
+				},
+				{
+					OrdinalPosition: 3, Name: "role", DataType: "character varying", IsNullable: "YES",
+					DefaultValue:           sql.NullString{String: "'member'::character varying", Valid: true},
+					CharacterMaximumLength: sql.NullInt64{Int64: 32, Valid: true},
+				},
+			},
+		},
+	}
+
+	schema := FromTables(s, tables)
+
+	member := "members of accounts"
+	role := "'member'::character varying"
+	length := int64(32)
+	assert.Equal(t, &Schema{
+		Version:   Version,
+		Generator: "v2.1.0",
+		DbType:    settings.DBTypePostgresql,
+		Database:  "shop",
+		Schema:    "public",
+		Tables: []Table{
+			{
+				Name:    "memberships",
+				Comment: &member,
+				Columns: []Column{
+					{
+						Name: "account_id", OrdinalPosition: 1, DataType: "integer",
+						Constraints: []string{"memberships_pkey", "memberships_account_id_fkey"},
+					},
+					{
+						Name: "user_id", OrdinalPosition: 2, DataType: "integer",
+						Constraints: []string{"memberships_pkey"},
+					},
+					{
+						Name: "role", OrdinalPosition: 3, DataType: "character varying", Nullable: true,
+						Default: &role, CharacterMaximumLength: &length,
+					},
+				},
+				Constraints: []Constraint{
+					{Name: "memberships_pkey", Type: "PRIMARY KEY", Columns: []string{"account_id", "user_id"}},
+					{Name: "memberships_account_id_fkey", Type: "FOREIGN KEY", Columns: []string{"account_id"}},
+				},
+			},
+		},
+	}, schema)
+
+	name := filepath.Join(t.TempDir(), "schema.json")
+	assert.NoError(t, schema.WriteFile(name))
+
+	content, err := os.ReadFile(name)
+	assert.NoError(t, err)
+	var written Schema
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, *schema, written)
+	assert.Contains(t, string(content), `"db_type": "pg"`)
+	assert.NotContains(t, string(content), `"column_key"`)
+}
@@ -0,0 +1,99 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+)
+
+// Change represents what writing the content of a table would do to its file.
+type Change string
+
+// These are the changes recorded by the DryRunWriter.
+const (
+	ChangeCreate    Change = "create"
+	ChangeUpdate    Change = "update"
+	ChangeUnchanged Change = "unchanged"
+	ChangeDelete    Change = "delete"
+)
+
+// DryRunWriter is a writer that decorates the content like the FileWriter but
+// instead of writing the files it records whether they would be created,
+// updated, left unchanged or deleted, and the content they would get.
+type DryRunWriter struct {
+	FileWriter
+	changes  map[string]Change
+	contents map[string]string
+}
+
+// NewDryRunWriter constructs a new DryRunWriter comparing the content with the
+// files in the given path.
+func NewDryRunWriter(path string) *DryRunWriter {
+	return &DryRunWriter{
+		FileWriter: *NewFileWriter(path),
+		changes:    map[string]Change{},
+		contents:   map[string]string{},
+	}
+}
+
+// Write is the implementation of the Writer interface. The DryRunWriter
+// records the change of the file specified by the given path and table name.
+func (w *DryRunWriter) Write(tableName string, content string) error {
+	decorated, err := w.decorate(tableName, content)
+	if err != nil {
+		return err
+	}
+
+	existing, err := os.ReadFile(path.Join(w.path, tableName+FileWriterExtension))
+	switch {
+	case os.IsNotExist(err):
+		w.changes[tableName] = ChangeCreate
+	case err != nil:
+		return err
+	case bytes.Equal(existing, []byte(decorated)):
+		w.changes[tableName] = ChangeUnchanged
+	default:
+		w.changes[tableName] = ChangeUpdate
+	}
+	w.contents[tableName] = decorated
+	return nil
+}
+
+// WriteRaw is the implementation of the RawWriter interface. The changes of
+// the DryRunWriter are the ones of the Go files, hence it never writes the
+// files of other formatters but returns an error.
+func (w *DryRunWriter) WriteRaw(name string, _ []byte) error {
+	return fmt.Errorf("dry run supports the Go files only, can not compare %s", name)
+}
+
+// Remove is the implementation of the Remover interface. The DryRunWriter
+// records the deletion of the file specified by the given path and table name,
+// if existing.
+func (w *DryRunWriter) Remove(tableName string) error {
+	_, err := os.Stat(path.Join(w.path, tableName+FileWriterExtension))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	w.changes[tableName] = ChangeDelete
+	return nil
+}
+
+// Changes returns the recorded changes by the names of the files.
+func (w *DryRunWriter) Changes() map[string]Change {
+	changes := make(map[string]Change, len(w.changes))
+	for name, change := range w.changes {
+		changes[name] = change
+	}
+	return changes
+}
+
+// Content returns the decorated content the file of the table name would get
+// and if it was written at all.
+func (w *DryRunWriter) Content(tableName string) (string, bool) {
+	content, ok := w.contents[tableName]
+	return content, ok
+}
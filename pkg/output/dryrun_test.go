@@ -0,0 +1,46 @@
+package output
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRunWriter(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	content := "package dto\n\ntype Bar struct {\n\tID int `db:\"id\"`\n}\n"
+	for _, name := range []string{"Unchanged", "Updated", "Dropped"} {
+		assert.NoError(t, os.WriteFile(path.Join(dir, name+FileWriterExtension), []byte(content), 0666))
+	}
+
+	w := NewDryRunWriter(dir)
+	assert.NoError(t, w.Write("Created", content))
+	assert.NoError(t, w.Write("Unchanged", content))
+	assert.NoError(t, w.Write("Updated", "package dto\ntype Bar struct {\nName string\n}"))
+	assert.NoError(t, w.Remove("Dropped"))
+	assert.NoError(t, w.Remove("Missing"))
+	assert.Error(t, w.Write("Invalid", "Lorem ipsum dolor sit amet"))
+
+	expected := map[string]Change{
+		"Created":   ChangeCreate,
+		"Unchanged": ChangeUnchanged,
+		"Updated":   ChangeUpdate,
+		"Dropped":   ChangeDelete,
+	}
+	assert.Equal(t, expected, w.Changes())
+
+	actual, ok := w.Content("Unchanged")
+	assert.True(t, ok)
+	assert.Equal(t, content, actual)
+	_, ok = w.Content("Dropped")
+	assert.False(t, ok)
+
+	// nothing got written or removed
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
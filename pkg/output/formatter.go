@@ -0,0 +1,75 @@
+package output
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// formatters are the registered formatters by their names.
+var formatters = map[string]OutputFormatter{}
+
+// FormattedFile is a file produced by an OutputFormatter.
+type FormattedFile struct {
+	// Name is the name of the file in the output path with its extension,
+	// e.g. users.proto
+	Name string
+	// Content is the content of the file as it gets written
+	Content []byte
+}
+
+// OutputFormatter turns the introspected schema into the files of a target
+// language, e.g. Go structs, proto messages or TypeScript interfaces.
+type OutputFormatter interface {
+	Format(s *settings.Settings, schema *ir.Schema) ([]FormattedFile, error)
+}
+
+// FormatterFunc is a function implementing the OutputFormatter interface.
+type FormatterFunc func(s *settings.Settings, schema *ir.Schema) ([]FormattedFile, error)
+
+// Format is the implementation of the OutputFormatter interface.
+func (f FormatterFunc) Format(s *settings.Settings, schema *ir.Schema) ([]FormattedFile, error) {
+	return f(s, schema)
+}
+
+// RegisterFormatter makes the formatter available by its name for the
+// Formatter of the settings. The Go structs are the built-in formatter of the
+// name settings.FormatterGo. RegisterFormatter is meant to be called in the
+// init function of the package implementing the formatter and panics if the
+// name is empty or already registered, or if the formatter is nil.
+func RegisterFormatter(name string, formatter OutputFormatter) {
+	if formatter == nil {
+		panic(fmt.Sprintf("output: formatter %q is nil", name))
+	}
+	if name == "" {
+		panic("output: formatter name is empty")
+	}
+	if _, ok := formatters[name]; ok {
+		panic(fmt.Sprintf("output: formatter %q is already registered", name))
+	}
+	formatters[name] = formatter
+}
+
+// LookupFormatter returns the formatter registered by the name, or an error
+// if there is none.
+func LookupFormatter(name string) (OutputFormatter, error) {
+	formatter, ok := formatters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown formatter %q, must be one of: %v", name, FormatterNames())
+	}
+	return formatter, nil
+}
+
+// FormatterNames returns the names of the registered formatters in order.
+func FormatterNames() []string {
+	return slices.Sorted(maps.Keys(formatters))
+}
+
+// RawWriter is implemented by writers able to write the files of formatters
+// as they are, without decorating them as Go source.
+type RawWriter interface {
+	WriteRaw(name string, content []byte) error
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestRegisterFormatter(t *testing.T) {
+	defer delete(formatters, "names")
+
+	names := FormatterFunc(func(_ *settings.Settings, schema *ir.Schema) ([]FormattedFile, error) {
+		var sb strings.Builder
+		for _, table := range schema.Tables {
+			sb.WriteString(table.Name + "\n")
+		}
+		return []FormattedFile{{Name: "tables.txt", Content: []byte(sb.String())}}, nil
+	})
+	RegisterFormatter("names", names)
+
+	formatter, err := LookupFormatter("names")
+	assert.NoError(t, err)
+	files, err := formatter.Format(settings.New(), &ir.Schema{Tables: []ir.Table{{Name: "users"}, {Name: "orders"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []FormattedFile{{Name: "tables.txt", Content: []byte("users\norders\n")}}, files)
+	assert.Contains(t, FormatterNames(), "names")
+
+	_, err = LookupFormatter("graphql")
+	assert.ErrorContains(t, err, `unknown formatter "graphql"`)
+
+	assert.Panics(t, func() { RegisterFormatter("names", names) })
+	assert.Panics(t, func() { RegisterFormatter("", names) })
+	assert.Panics(t, func() { RegisterFormatter("nil", nil) })
+}
+
+func TestWriteRaw(t *testing.T) {
+	t.Parallel()
+
+	target := NewMemoryTarget()
+	assert.NoError(t, NewTargetWriter(target).WriteRaw("users.proto", []byte("message Users {}")))
+	assert.Equal(t, map[string][]byte{"users.proto": []byte("message Users {}")}, target.Files())
+
+	assert.Error(t, NewDryRunWriter(t.TempDir()).WriteRaw("users.proto", []byte("message Users {}")))
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// postProcessors are the registered post-processors in the order of their
+// registration.
+var postProcessors []PostProcessor
+
+// File is a generated file about to be written.
+type File struct {
+	// Name is the name of the file without the extension, e.g. the one of
+	// the table
+	Name string
+	// Path is the path the file gets written to
+	Path string
+	// Source is the formatted source of the file
+	Source []byte
+}
+
+// Parse parses the source of the file for rewrites on the syntax tree.
+func (f File) Parse() (*token.FileSet, *ast.File, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, f.Path, f.Source, parser.ParseComments)
+	return fset, file, err
+}
+
+// PostProcessor rewrites a generated file before it gets written and returns
+// its new source, e.g. with additional methods or annotations. The returned
+// source gets formatted again.
+type PostProcessor func(file File) ([]byte, error)
+
+// RegisterPostProcessor adds the post-processor to the ones every generated
+// file passes in the order of their registration before it gets written by
+// the FileWriter or compared by the DryRunWriter. RegisterPostProcessor is
+// meant to be called in an init function and panics if the post-processor is
+// nil.
+func RegisterPostProcessor(postProcessor PostProcessor) {
+	if postProcessor == nil {
+		panic("output: post-processor is nil")
+	}
+	postProcessors = append(postProcessors, postProcessor)
+}
+
+// postProcess passes the decorated content of the file at the given path to
+// the registered post-processors.
+func postProcess(name, path, content string) (string, error) {
+	if len(postProcessors) == 0 {
+		return content, nil
+	}
+
+	source := []byte(content)
+	for _, postProcessor := range postProcessors {
+		processed, err := postProcessor(File{Name: name, Path: path, Source: source})
+		if err != nil {
+			return content, fmt.Errorf("could not post-process %s: %w", path, err)
+		}
+		source = processed
+	}
+	return FormatDecorator{}.Decorate(string(source))
+}
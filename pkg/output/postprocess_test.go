@@ -0,0 +1,59 @@
+package output
+
+import (
+	"errors"
+	"go/ast"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterPostProcessor(t *testing.T) {
+	defer func() { postProcessors = nil }()
+
+	// adds a comment to the struct via the syntax tree
+	RegisterPostProcessor(func(file File) ([]byte, error) {
+		fset, f, err := file.Parse()
+		if err != nil {
+			return nil, err
+		}
+		ast.Inspect(f, func(node ast.Node) bool {
+			if decl, ok := node.(*ast.GenDecl); ok && decl.Doc == nil {
+				decl.Doc = &ast.CommentGroup{List: []*ast.Comment{{Slash: decl.Pos() - 1, Text: "// " + file.Name + " is generated."}}}
+			}
+			return true
+		})
+		var sb strings.Builder
+		err = format.Node(&sb, fset, f)
+		return []byte(sb.String()), err
+	})
+	// adds a method to the source
+	RegisterPostProcessor(func(file File) ([]byte, error) {
+		return append(file.Source, "func (Bar) Audited() bool { return true }"...), nil
+	})
+
+	dir := t.TempDir()
+	w := NewFileWriter(dir)
+	assert.NoError(t, w.Write("Bar", "package dto\ntype Bar struct {\nID int `db:\"id\"`\n}"))
+
+	written, err := os.ReadFile(filepath.Join(dir, "Bar.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package dto\n\n// Bar is generated.\ntype Bar struct {\n\tID int `db:\"id\"`\n}\n\nfunc (Bar) Audited() bool { return true }\n", string(written))
+
+	// the dry run compares the post-processed content
+	dryRun := NewDryRunWriter(dir)
+	assert.NoError(t, dryRun.Write("Bar", "package dto\ntype Bar struct {\nID int `db:\"id\"`\n}"))
+	assert.Equal(t, ChangeUnchanged, dryRun.Changes()["Bar"])
+
+	RegisterPostProcessor(func(File) ([]byte, error) {
+		return nil, errors.New("broken")
+	})
+	err = w.Write("Bar", "package dto\ntype Bar struct {\nID int `db:\"id\"`\n}")
+	assert.EqualError(t, err, "could not post-process "+filepath.Join(dir, "Bar.go")+": broken")
+
+	assert.Panics(t, func() { RegisterPostProcessor(nil) })
+}
@@ -0,0 +1,153 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"sync"
+	"testing/fstest"
+)
+
+// Target is where the generated files get written to.
+type Target interface {
+	// WriteFile writes the content of the file of the given name.
+	WriteFile(name string, content []byte) error
+	// RemoveFile removes the file of the given name, if existing.
+	RemoveFile(name string) error
+}
+
+// DirTarget is a target writing the files into the directory of its path.
+type DirTarget string
+
+// WriteFile is the implementation of the Target interface. The content gets
+// written to a temporary file first which then replaces the file, so an
+// interrupted run never leaves a partially written file.
+func (d DirTarget) WriteFile(name string, content []byte) (err error) {
+	fileName := path.Join(string(d), name)
+
+	tmp, err := os.CreateTemp(string(d), "."+name+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(content); err != nil {
+		return err
+	}
+	// temporary files are private, the generated ones are not
+	mode := os.FileMode(0644)
+	if info, statErr := os.Stat(fileName); statErr == nil {
+		mode = info.Mode().Perm()
+	}
+	if err = tmp.Chmod(mode); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), fileName)
+}
+
+// RemoveFile is the implementation of the Target interface.
+func (d DirTarget) RemoveFile(name string) error {
+	err := os.Remove(path.Join(string(d), name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// MemoryTarget is a target keeping the files in memory, e.g. to capture the
+// generated code in tests or tools without touching the disk. It is safe for
+// concurrent use.
+type MemoryTarget struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemoryTarget constructs a new empty MemoryTarget.
+func NewMemoryTarget() *MemoryTarget {
+	return &MemoryTarget{files: map[string][]byte{}}
+}
+
+// WriteFile is the implementation of the Target interface.
+func (m *MemoryTarget) WriteFile(name string, content []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[name] = slices.Clone(content)
+	return nil
+}
+
+// RemoveFile is the implementation of the Target interface.
+func (m *MemoryTarget) RemoveFile(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.files, name)
+	return nil
+}
+
+// Files returns the contents of the written files by their names.
+func (m *MemoryTarget) Files() map[string][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	files := make(map[string][]byte, len(m.files))
+	for name, content := range m.files {
+		files[name] = slices.Clone(content)
+	}
+	return files
+}
+
+// FS returns the written files as a file system, e.g. to read them with
+// fs.ReadFile or to compare them with fs.WalkDir. Later writes are not
+// reflected in it.
+func (m *MemoryTarget) FS() fs.FS {
+	fsys := fstest.MapFS{}
+	for name, content := range m.Files() {
+		fsys[name] = &fstest.MapFile{Data: content, Mode: 0644}
+	}
+	return fsys
+}
+
+// StreamTarget is a target writing the files one after the other to a
+// stream, e.g. stdout, each preceded by a comment with its name and
+// separated by an empty line.
+type StreamTarget struct {
+	mu      sync.Mutex
+	w       io.Writer
+	written bool
+}
+
+// NewStreamTarget constructs a new StreamTarget writing to the given writer.
+func NewStreamTarget(w io.Writer) *StreamTarget {
+	return &StreamTarget{w: w}
+}
+
+// WriteFile is the implementation of the Target interface.
+func (s *StreamTarget) WriteFile(name string, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	separator := ""
+	if s.written {
+		separator = "\n"
+	}
+	if _, err := fmt.Fprintf(s.w, "%s// %s\n", separator, name); err != nil {
+		return err
+	}
+	s.written = true
+	_, err := s.w.Write(content)
+	return err
+}
+
+// RemoveFile is the implementation of the Target interface. Written files
+// can not be removed from a stream, hence it does nothing.
+func (s *StreamTarget) RemoveFile(string) error {
+	return nil
+}
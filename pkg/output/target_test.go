@@ -0,0 +1,61 @@
+package output
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirTarget(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	target := DirTarget(dir)
+
+	assert.NoError(t, target.WriteFile("users.go", []byte("package dto\n")))
+	content, err := os.ReadFile(filepath.Join(dir, "users.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package dto\n", string(content))
+
+	assert.NoError(t, target.RemoveFile("users.go"))
+	assert.NoError(t, target.RemoveFile("users.go"))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestMemoryTarget(t *testing.T) {
+	t.Parallel()
+
+	target := NewMemoryTarget()
+	w := NewTargetWriter(target)
+
+	assert.NoError(t, w.Write("Users", "package dto\ntype Users struct {\nID int\n}"))
+	assert.NoError(t, w.Write("Orders", "package dto\ntype Orders struct {\nID int\n}"))
+	assert.NoError(t, w.Remove("Orders"))
+
+	content, err := fs.ReadFile(target.FS(), "Users.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package dto\n\ntype Users struct {\n\tID int\n}\n", string(content))
+
+	_, err = fs.Stat(target.FS(), "Orders.go")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.Equal(t, map[string][]byte{"Users.go": content}, target.Files())
+}
+
+func TestStreamTarget(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewTargetWriter(NewStreamTarget(&buf))
+
+	assert.NoError(t, w.Write("Users", "package dto\ntype Users struct {}"))
+	assert.NoError(t, w.Write("Orders", "package dto\ntype Orders struct {}"))
+	assert.NoError(t, w.Remove("Users"))
+
+	assert.Equal(t, "// Users.go\npackage dto\n\ntype Users struct{}\n\n// Orders.go\npackage dto\n\ntype Orders struct{}\n", buf.String())
+}
@@ -1,7 +1,6 @@
 package output
 
 import (
-	"os"
 	"path"
 )
 
@@ -15,16 +14,32 @@ type Writer interface {
 	Write(tableName string, content string) error
 }
 
-// FileWriter is a writer that writes to a file given by the path and the table name.
+// Remover is implemented by writers able to remove the produced content of a
+// table, e.g. if the table got dropped.
+type Remover interface {
+	Remove(tableName string) error
+}
+
+// FileWriter is a writer that writes to a file given by the path and the
+// table name, or to the file of the table name of its target.
 type FileWriter struct {
 	path       string
+	target     Target
 	decorators []Decorator
 }
 
 // NewFileWriter constructs a new FileWriter.
 func NewFileWriter(path string) *FileWriter {
+	w := NewTargetWriter(DirTarget(path))
+	w.path = path
+	return w
+}
+
+// NewTargetWriter constructs a new FileWriter writing the files to the given
+// target instead of a path, e.g. a MemoryTarget or a StreamTarget.
+func NewTargetWriter(target Target) *FileWriter {
 	return &FileWriter{
-		path: path,
+		target: target,
 		decorators: []Decorator{
 			FormatDecorator{},
 			ImportDecorator{},
@@ -35,18 +50,28 @@ func NewFileWriter(path string) *FileWriter {
 // Write is the implementation of the Writer interface. The FilerWriter writes
 // decorated content to the file specified by the given path and table name.
 func (w FileWriter) Write(tableName string, content string) error {
-	fileName := path.Join(w.path, tableName+FileWriterExtension)
-
-	decorated, err := w.decorate(content)
+	decorated, err := w.decorate(tableName, content)
 	if err != nil {
 		return err
 	}
+	return w.target.WriteFile(tableName+FileWriterExtension, []byte(decorated))
+}
+
+// WriteRaw is the implementation of the RawWriter interface. The FileWriter
+// writes the content to the file of the given name with its extension.
+func (w FileWriter) WriteRaw(name string, content []byte) error {
+	return w.target.WriteFile(name, content)
+}
 
-	return os.WriteFile(fileName, []byte(decorated), 0666)
+// Remove is the implementation of the Remover interface. The FileWriter
+// removes the file specified by the given path and table name, if existing.
+func (w FileWriter) Remove(tableName string) error {
+	return w.target.RemoveFile(tableName + FileWriterExtension)
 }
 
-// decorate applies some decorations like formatting and empty import removal.
-func (w FileWriter) decorate(content string) (decorated string, err error) {
+// decorate applies some decorations like formatting and empty import removal
+// and the registered post-processors to the content of the given table.
+func (w FileWriter) decorate(tableName string, content string) (decorated string, err error) {
 	for _, decorator := range w.decorators {
 		content, err = decorator.Decorate(content)
 		if err != nil {
@@ -54,5 +79,5 @@ func (w FileWriter) decorate(content string) (decorated string, err error) {
 		}
 	}
 
-	return content, nil
+	return postProcess(tableName, path.Join(w.path, tableName+FileWriterExtension), content)
 }
@@ -57,3 +57,62 @@ func TestFileWriter_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestFileWriter_Remove(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc   string
+		exists bool
+	}{
+		{
+			desc:   "existing file gets removed",
+			exists: true,
+		},
+		{
+			desc:   "missing file is no error",
+			exists: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			file := path.Join(dir, "Bar"+FileWriterExtension)
+			if test.exists {
+				assert.NoError(t, os.WriteFile(file, []byte("package dto\n"), 0666))
+			}
+
+			fw := NewFileWriter(dir)
+			assert.NoError(t, fw.Remove("Bar"))
+
+			_, err := os.Stat(file)
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestFileWriter_Write_Atomic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := path.Join(dir, "Bar"+FileWriterExtension)
+	assert.NoError(t, os.WriteFile(file, []byte("package dto\n"), 0600))
+
+	fw := NewFileWriter(dir)
+	assert.NoError(t, fw.Write("Bar", "package dto\ntype Bar struct {\nID int\n}"))
+	assert.Error(t, fw.Write("Bar", "Lorem ipsum dolor sit amet"))
+
+	// the failed write neither touched the file nor left a temporary one
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "package dto\n\ntype Bar struct {\n\tID int\n}\n", string(content))
+
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,151 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"os"
+	"os/exec"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Database implements the Database interface by a plugin introspecting the
+// database. It maps the columns to Go types like the database type the
+// plugin follows.
+type Database struct {
+	database.Database
+	settings *settings.Settings
+
+	client *rpc.Client
+	// stop waits for the plugin to exit once the connection got closed
+	stop func() error
+}
+
+// Open starts the plugin given by Plugin of the settings and creates its
+// Database. The database type of the settings becomes the one the plugin
+// follows.
+func Open(s *settings.Settings) (*Database, error) {
+	cmd := exec.Command(s.Plugin)
+	cmd.Env = append(os.Environ(), CookieKey+"="+CookieValue)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("could not start plugin %q: %w", s.Plugin, err)
+	}
+
+	db, err := NewDatabase(s, stdio{ReadCloser: stdout, WriteCloser: stdin}, cmd.Wait)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %q: %w", s.Plugin, err)
+	}
+	return db, nil
+}
+
+// NewDatabase creates the Database of the plugin connected by conn and
+// shakes hands with it, stop waits for the plugin to exit once conn got
+// closed. The database type of the settings becomes the one the plugin
+// follows.
+func NewDatabase(s *settings.Settings, conn io.ReadWriteCloser, stop func() error) (*Database, error) {
+	db := &Database{
+		settings: s,
+		client:   rpc.NewClient(conn),
+		stop:     stop,
+	}
+
+	var handshake HandshakeReply
+	err := db.client.Call(serviceName+".Handshake", Empty{}, &handshake)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("handshake failed: %w", err)
+	case handshake.ProtocolVersion != ProtocolVersion:
+		err = fmt.Errorf("protocol version %d not supported, must be %d", handshake.ProtocolVersion, ProtocolVersion)
+	case !settings.SupportedDbTypes[handshake.Types]:
+		err = fmt.Errorf("database type %q not supported, must be one of: %v", handshake.Types, settings.SprintfSupportedDbTypes())
+	}
+	if err != nil {
+		return nil, errors.Join(err, db.shutdown())
+	}
+
+	s.DbType = handshake.Types
+	db.Database = database.New(s)
+	return db, nil
+}
+
+// Connect lets the plugin connect to the database. The plugin gets stopped
+// if it fails.
+func (db *Database) Connect(ctx context.Context) error {
+	if err := db.call(ctx, "Connect", connectArgsOf(db.settings), &Empty{}); err != nil {
+		return errors.Join(err, db.shutdown())
+	}
+	return nil
+}
+
+// Close lets the plugin close the connection to the database and stops it.
+func (db *Database) Close() error {
+	err := db.call(context.Background(), "Close", Empty{}, &Empty{})
+	return errors.Join(err, db.shutdown())
+}
+
+// GetTables returns the tables of the database, or only the given ones,
+// without their columns.
+func (db *Database) GetTables(ctx context.Context, tables ...string) ([]*database.Table, error) {
+	var reply TablesReply
+	if err := db.call(ctx, "GetTables", TablesArgs{Tables: tables}, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Tables, nil
+}
+
+// PrepareGetColumnsOfTableStmt lets the plugin prepare the introspection of
+// the columns.
+func (db *Database) PrepareGetColumnsOfTableStmt(ctx context.Context) error {
+	return db.call(ctx, "PrepareGetColumnsOfTableStmt", Empty{}, &Empty{})
+}
+
+// GetColumnsOfTable sets the columns of the table.
+func (db *Database) GetColumnsOfTable(ctx context.Context, table *database.Table) error {
+	var reply ColumnsReply
+	if err := db.call(ctx, "GetColumnsOfTable", ColumnsArgs{Table: database.Table{Name: table.Name, Comment: table.Comment}}, &reply); err != nil {
+		return err
+	}
+	table.Columns = reply.Columns
+	return nil
+}
+
+// call calls the method of the plugin, it stops waiting for the reply once
+// the context is done.
+func (db *Database) call(ctx context.Context, method string, args, reply any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	call := db.client.Go(serviceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-call.Done:
+		return call.Error
+	}
+}
+
+// shutdown closes the connection to the plugin and waits for it to exit.
+func (db *Database) shutdown() error {
+	err := db.client.Close()
+	if errors.Is(err, rpc.ErrShutdown) {
+		// already shut down
+		return nil
+	}
+	if db.stop != nil {
+		err = errors.Join(err, db.stop())
+	}
+	return err
+}
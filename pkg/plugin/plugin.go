@@ -0,0 +1,186 @@
+// Package plugin runs dialects as external plugin binaries, so proprietary
+// database connectors which can not be open-sourced can still be used with
+// the stock tables-to-go binary by -plugin.
+//
+// tables-to-go starts the plugin and speaks a small net/rpc protocol with it
+// over the stdin and stdout of the plugin: the plugin connects to the
+// database and introspects its tables and columns, tables-to-go maps the
+// column types to Go types like the built-in database type the plugin
+// follows. The plugin must not write anything else to stdout, its stderr is
+// passed through.
+//
+// A plugin serves a dialect by the same factory database.Register takes:
+//
+//	func main() {
+//		err := plugin.Serve(settings.DBTypePostgresql, func(s *settings.Settings) database.Database {
+//			return NewAcme(s)
+//		})
+//		if err != nil {
+//			fmt.Fprintln(os.Stderr, err)
+//			os.Exit(1)
+//		}
+//	}
+package plugin
+
+import (
+	"io"
+	"time"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+const (
+	// ProtocolVersion is the version of the protocol between tables-to-go
+	// and its plugins, it changes with incompatible changes only.
+	ProtocolVersion = 1
+
+	// CookieKey and CookieValue are set in the environment of the plugins
+	// started by tables-to-go, Serve refuses to run without them, e.g. if
+	// the plugin got started by hand.
+	CookieKey   = "TABLES_TO_GO_PLUGIN"
+	CookieValue = "dialect"
+)
+
+// serviceName is the name of the RPC service of the plugins.
+const serviceName = "Dialect"
+
+// Empty are the arguments and replies of calls without any.
+type Empty struct{}
+
+// HandshakeReply is the reply of the plugin to the first call.
+type HandshakeReply struct {
+	ProtocolVersion int
+	// Types is the built-in database type whose mapping of the column types
+	// to Go types the dialect follows
+	Types settings.DBType
+}
+
+// ConnectArgs are the settings of the connection to the database.
+type ConnectArgs struct {
+	User       string
+	Pswd       string
+	DbName     string
+	Schema     string
+	Host       string
+	Port       string
+	SSLMode    string
+	Socket     string
+	DSN        string
+	Retries    int
+	RetryDelay time.Duration
+	LogQueries bool
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnectTimeout  time.Duration
+	Keepalive       time.Duration
+
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+	Auth        settings.AuthMode
+	KrbSrvName  string
+	KrbSPN      string
+	Proxy       string
+}
+
+// connectArgsOf returns the settings of the connection of the settings.
+func connectArgsOf(s *settings.Settings) ConnectArgs {
+	return ConnectArgs{
+		User:       s.User,
+		Pswd:       s.Pswd,
+		DbName:     s.DbName,
+		Schema:     s.Schema,
+		Host:       s.Host,
+		Port:       s.Port,
+		SSLMode:    s.SSLMode,
+		Socket:     s.Socket,
+		DSN:        s.DSN,
+		Retries:    s.Retries,
+		RetryDelay: s.RetryDelay,
+		LogQueries: s.LogQueries,
+
+		MaxOpenConns:    s.MaxOpenConns,
+		MaxIdleConns:    s.MaxIdleConns,
+		ConnMaxLifetime: s.ConnMaxLifetime,
+		ConnectTimeout:  s.ConnectTimeout,
+		Keepalive:       s.Keepalive,
+
+		SSLCert:     s.SSLCert,
+		SSLKey:      s.SSLKey,
+		SSLRootCert: s.SSLRootCert,
+		Auth:        s.Auth,
+		KrbSrvName:  s.KrbSrvName,
+		KrbSPN:      s.KrbSPN,
+		Proxy:       s.Proxy,
+	}
+}
+
+// settings returns the settings of the connection for the given database
+// type.
+func (a ConnectArgs) settings(types settings.DBType) *settings.Settings {
+	s := settings.New()
+	s.DbType = types
+	s.User = a.User
+	s.Pswd = a.Pswd
+	s.DbName = a.DbName
+	s.Schema = a.Schema
+	s.Host = a.Host
+	s.Port = a.Port
+	s.SSLMode = a.SSLMode
+	s.Socket = a.Socket
+	s.DSN = a.DSN
+	s.SSLCert = a.SSLCert
+	s.SSLKey = a.SSLKey
+	s.SSLRootCert = a.SSLRootCert
+	s.Auth = a.Auth
+	s.KrbSrvName = a.KrbSrvName
+	s.KrbSPN = a.KrbSPN
+	s.Proxy = a.Proxy
+	s.Retries = a.Retries
+	s.RetryDelay = a.RetryDelay
+	s.LogQueries = a.LogQueries
+	s.MaxOpenConns = a.MaxOpenConns
+	s.MaxIdleConns = a.MaxIdleConns
+	s.ConnMaxLifetime = a.ConnMaxLifetime
+	s.ConnectTimeout = a.ConnectTimeout
+	s.Keepalive = a.Keepalive
+	return s
+}
+
+// TablesArgs are the arguments of GetTables.
+type TablesArgs struct {
+	Tables []string
+}
+
+// TablesReply is the reply of GetTables.
+type TablesReply struct {
+	Tables []*database.Table
+}
+
+// ColumnsArgs are the arguments of GetColumnsOfTable.
+type ColumnsArgs struct {
+	Table database.Table
+}
+
+// ColumnsReply is the reply of GetColumnsOfTable.
+type ColumnsReply struct {
+	Columns []database.Column
+}
+
+// stdio is the connection over a reader and a writer, e.g. stdin and stdout.
+type stdio struct {
+	io.ReadCloser
+	io.WriteCloser
+}
+
+// Close closes the reader and the writer.
+func (c stdio) Close() error {
+	werr := c.WriteCloser.Close()
+	if err := c.ReadCloser.Close(); err != nil {
+		return err
+	}
+	return werr
+}
@@ -0,0 +1,148 @@
+package plugin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// TestMain serves the fake dialect if the test binary got started as plugin.
+func TestMain(m *testing.M) {
+	if os.Getenv(CookieKey) == CookieValue {
+		if err := Serve(settings.DBTypeMySQL, newFakeDB); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeDB is a dialect of a users and an orders table, it denies the user
+// "denied".
+type fakeDB struct {
+	database.Database
+	s *settings.Settings
+}
+
+func newFakeDB(s *settings.Settings) database.Database {
+	return &fakeDB{Database: database.New(s), s: s}
+}
+
+func (db *fakeDB) Connect(context.Context) error {
+	if db.s.User == "denied" {
+		return errors.New("access denied")
+	}
+	return nil
+}
+
+func (db *fakeDB) Close() error {
+	return nil
+}
+
+func (db *fakeDB) GetTables(_ context.Context, tables ...string) ([]*database.Table, error) {
+	var result []*database.Table
+	for _, name := range []string{"orders", "users"} {
+		if len(tables) == 0 || slices.Contains(tables, name) {
+			result = append(result, &database.Table{Name: name, Comment: sql.NullString{String: name + " of the " + db.s.DbName, Valid: true}})
+		}
+	}
+	return result, nil
+}
+
+func (db *fakeDB) PrepareGetColumnsOfTableStmt(context.Context) error {
+	return nil
+}
+
+func (db *fakeDB) GetColumnsOfTable(_ context.Context, table *database.Table) error {
+	if table.Name != "users" {
+		return fmt.Errorf("table %q not found", table.Name)
+	}
+	table.Columns = []database.Column{
+		{OrdinalPosition: 1, Name: "id", DataType: "int", ColumnType: "int(11)", IsNullable: "NO", ColumnKey: "PRI", Extra: "auto_increment"},
+		{OrdinalPosition: 2, Name: "name", DataType: "varchar", IsNullable: "YES", CharacterMaximumLength: sql.NullInt64{Int64: 50, Valid: true}},
+	}
+	return nil
+}
+
+func TestDatabase(t *testing.T) {
+	t.Parallel()
+
+	server, client := net.Pipe()
+	go func() {
+		assert.NoError(t, serve(server, settings.DBTypeMySQL, newFakeDB))
+	}()
+
+	s := settings.New()
+	s.DbName = "shop"
+	db, err := NewDatabase(s, client, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, settings.DBTypeMySQL, s.DbType)
+
+	ctx := context.Background()
+	_, err = db.GetTables(ctx)
+	assert.EqualError(t, err, "not connected")
+
+	assert.NoError(t, db.Connect(ctx))
+	tables, err := db.GetTables(ctx, "users")
+	assert.NoError(t, err)
+	assert.Equal(t, []*database.Table{{Name: "users", Comment: sql.NullString{String: "users of the shop", Valid: true}}}, tables)
+
+	assert.NoError(t, db.PrepareGetColumnsOfTableStmt(ctx))
+	assert.NoError(t, db.GetColumnsOfTable(ctx, tables[0]))
+	assert.Len(t, tables[0].Columns, 2)
+	assert.True(t, db.IsAutoIncrement(tables[0].Columns[0]))
+	assert.True(t, db.IsString(tables[0].Columns[1]))
+
+	err = db.GetColumnsOfTable(ctx, &database.Table{Name: "orders"})
+	assert.EqualError(t, err, `table "orders" not found`)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = db.GetTables(canceled)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.NoError(t, db.Close())
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	// the test binary serves the fake dialect, see TestMain
+	s := settings.New()
+	s.Plugin = os.Args[0]
+	db, err := Open(s)
+	assert.NoError(t, err)
+	assert.Equal(t, settings.DBTypeMySQL, s.DbType)
+
+	ctx := context.Background()
+	assert.NoError(t, db.Connect(ctx))
+	tables, err := db.GetTables(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+	assert.NoError(t, db.Close())
+
+	s.User = "denied"
+	db, err = Open(s)
+	assert.NoError(t, err)
+	assert.EqualError(t, db.Connect(ctx), "access denied")
+
+	s.Plugin = "tables-to-go-missing-plugin"
+	_, err = Open(s)
+	assert.ErrorContains(t, err, `could not start plugin "tables-to-go-missing-plugin"`)
+}
+
+func TestServe(t *testing.T) {
+	t.Setenv(CookieKey, "")
+	assert.Error(t, Serve(settings.DBTypeMySQL, newFakeDB))
+}
@@ -0,0 +1,129 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"os"
+	"sync"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Serve serves the dialect created by the factory on stdin and stdout until
+// tables-to-go closes the connection. Types is the built-in database type
+// whose mapping of the column types to Go types the dialect follows, the
+// settings of the factory carry it and the connection settings given to
+// tables-to-go.
+func Serve(types settings.DBType, factory func(*settings.Settings) database.Database) error {
+	if os.Getenv(CookieKey) != CookieValue {
+		return errors.New("this is a tables-to-go plugin, run it by tables-to-go -plugin")
+	}
+	return serve(stdio{ReadCloser: os.Stdin, WriteCloser: os.Stdout}, types, factory)
+}
+
+// serve serves the dialect on the connection until it gets closed.
+func serve(conn io.ReadWriteCloser, types settings.DBType, factory func(*settings.Settings) database.Database) error {
+	if factory == nil {
+		return fmt.Errorf("plugin: factory of dialect %q is nil", types)
+	}
+	if !settings.SupportedDbTypes[types] {
+		return fmt.Errorf("plugin: database type %q not supported, must be one of: %v", types, settings.SprintfSupportedDbTypes())
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName(serviceName, &Dialect{types: types, factory: factory}); err != nil {
+		return err
+	}
+	server.ServeConn(conn)
+	return nil
+}
+
+// Dialect is the RPC service of the plugins serving the database of the
+// factory. It is exported for net/rpc only.
+type Dialect struct {
+	types   settings.DBType
+	factory func(*settings.Settings) database.Database
+
+	mu sync.Mutex
+	db database.Database
+}
+
+// Handshake replies the version of the protocol and the database type of
+// the dialect.
+func (d *Dialect) Handshake(_ Empty, reply *HandshakeReply) error {
+	*reply = HandshakeReply{ProtocolVersion: ProtocolVersion, Types: d.types}
+	return nil
+}
+
+// Connect creates the database by the connection settings and connects to
+// it.
+func (d *Dialect) Connect(args ConnectArgs, _ *Empty) error {
+	db := d.factory(args.settings(d.types))
+	if err := db.Connect(context.Background()); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.db = db
+	return nil
+}
+
+// Close closes the connection to the database.
+func (d *Dialect) Close(_ Empty, _ *Empty) error {
+	db, err := d.database()
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+// GetTables replies the tables of the database.
+func (d *Dialect) GetTables(args TablesArgs, reply *TablesReply) error {
+	db, err := d.database()
+	if err != nil {
+		return err
+	}
+	tables, err := db.GetTables(context.Background(), args.Tables...)
+	if err != nil {
+		return err
+	}
+	reply.Tables = tables
+	return nil
+}
+
+// PrepareGetColumnsOfTableStmt prepares the introspection of the columns.
+func (d *Dialect) PrepareGetColumnsOfTableStmt(_ Empty, _ *Empty) error {
+	db, err := d.database()
+	if err != nil {
+		return err
+	}
+	return db.PrepareGetColumnsOfTableStmt(context.Background())
+}
+
+// GetColumnsOfTable replies the columns of the table.
+func (d *Dialect) GetColumnsOfTable(args ColumnsArgs, reply *ColumnsReply) error {
+	db, err := d.database()
+	if err != nil {
+		return err
+	}
+	table := args.Table
+	if err = db.GetColumnsOfTable(context.Background(), &table); err != nil {
+		return err
+	}
+	reply.Columns = table.Columns
+	return nil
+}
+
+// database returns the connected database.
+func (d *Dialect) database() (database.Database, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.db == nil {
+		return nil, errors.New("not connected")
+	}
+	return d.db, nil
+}
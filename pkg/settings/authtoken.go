@@ -0,0 +1,114 @@
+package settings
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// authTokenNames are the names of the modes authenticating by a short-lived
+// token instead of the password.
+var authTokenNames = map[AuthMode]string{
+	AuthModeRDSIAM:   "RDS IAM",
+	AuthModeAzureCLI: "Azure CLI",
+}
+
+// UsesAuthToken reports whether the password is a short-lived token of the
+// authentication of a cloud provider, which needs TLS and gets sent in
+// cleartext to MySQL.
+func (settings *Settings) UsesAuthToken() bool {
+	_, ok := authTokenNames[settings.Auth]
+	return ok
+}
+
+// verifyAuthToken checks the settings of the authentication by a token,
+// given the number of the other sources of the password.
+func (settings *Settings) verifyAuthToken(passwordSources int) error {
+	name := authTokenNames[settings.Auth]
+
+	if passwordSources > 0 {
+		return fmt.Errorf("%s authentication can not be combined with a password, the token replaces it", name)
+	}
+
+	if settings.DSN != "" || settings.Socket != "" {
+		return fmt.Errorf("%s authentication can not be combined with -dsn or -socket", name)
+	}
+
+	if settings.User == "" {
+		return fmt.Errorf("%s authentication needs the database user given by -u", name)
+	}
+
+	if settings.Plugin == "" && settings.DbType.Dialect() != DBTypePostgresql && settings.DbType.Dialect() != DBTypeMySQL {
+		return fmt.Errorf("%s authentication is not supported by %s", name, settings.DbType)
+	}
+
+	if settings.SSLMode == "disable" {
+		return fmt.Errorf("%s authentication needs TLS, it can not be combined with sslmode %q", name, settings.SSLMode)
+	}
+
+	return nil
+}
+
+// readAuthToken sets the password to a token of the authentication, printed
+// by the CLI of the cloud provider with the credentials it is logged in with.
+// The CLI is a prerequisite, a missing one gets its own error.
+func (settings *Settings) readAuthToken() error {
+	name, args := "aws", rdsAuthTokenArgs(settings)
+	if settings.Auth == AuthModeAzureCLI {
+		name, args = "az", azureCLITokenArgs(settings)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%s authentication needs the %s CLI installed and in the PATH: %w",
+			authTokenNames[settings.Auth], name, err)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return fmt.Errorf("could not generate %s auth token of user %q at %s:%s: %w",
+			authTokenNames[settings.Auth], settings.User, settings.Host, settings.Port, err)
+	}
+	settings.Pswd = strings.TrimSpace(string(out))
+	return nil
+}
+
+// rdsAuthTokenArgs returns the arguments of the aws CLI generating the token
+// of the user at the host and port with the credentials of its default
+// chain: the environment, the profile of AWS_PROFILE, SSO or the role of the
+// instance or container.
+func rdsAuthTokenArgs(settings *Settings) []string {
+	args := []string{
+		"rds", "generate-db-auth-token",
+		"--hostname", settings.Host,
+		"--port", settings.Port,
+		"--username", settings.User,
+	}
+	if settings.AWSRegion != "" {
+		args = append(args, "--region", settings.AWSRegion)
+	}
+	return args
+}
+
+// azureCLITokenArgs returns the arguments of the Azure CLI printing an
+// access token of the Azure databases for PostgreSQL and MySQL (oss-rdbms)
+// of the account logged in, e.g. by az login or the managed identity, in
+// AzureTenant if given. Other resources like Azure SQL are not covered.
+func azureCLITokenArgs(settings *Settings) []string {
+	args := []string{
+		"account", "get-access-token",
+		"--resource-type", "oss-rdbms",
+		"--query", "accessToken",
+		"--output", "tsv",
+	}
+	if settings.AzureTenant != "" {
+		args = append(args, "--tenant", settings.AzureTenant)
+	}
+	return args
+}
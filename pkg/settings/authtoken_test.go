@@ -0,0 +1,170 @@
+package settings
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettings_verifyAuthToken(t *testing.T) {
+	t.Parallel()
+
+	rdsIAM := func() *Settings {
+		s := New()
+		s.Auth = AuthModeRDSIAM
+		s.User = "shop"
+		s.SSLMode = "require"
+		return s
+	}
+
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "user of postgres",
+			settings: rdsIAM,
+			isError:  assert.NoError,
+		},
+		{
+			desc: "user of mysql",
+			settings: func() *Settings {
+				s := rdsIAM()
+				s.DbType = DBTypeMySQL
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "Azure CLI token of a user of postgres",
+			settings: func() *Settings {
+				s := rdsIAM()
+				s.Auth = AuthModeAzureCLI
+				s.User = "shop-readers"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "Azure CLI token without user produces error",
+			settings: func() *Settings {
+				s := rdsIAM()
+				s.Auth = AuthModeAzureCLI
+				s.User = ""
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "password produces error",
+			settings: func() *Settings {
+				s := rdsIAM()
+				s.Pswd = "secret"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "no user produces error",
+			settings: func() *Settings {
+				s := rdsIAM()
+				s.User = ""
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "raw DSN produces error",
+			settings: func() *Settings {
+				s := rdsIAM()
+				s.DSN = "postgres://shop@db.local/shop"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "oracle produces error",
+			settings: func() *Settings {
+				s := rdsIAM()
+				s.DbType = DBTypeOracle
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "disabled sslmode produces error",
+			settings: func() *Settings {
+				s := rdsIAM()
+				s.SSLMode = "disable"
+				return s
+			},
+			isError: assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			test.isError(t, test.settings().verifyPassword())
+		})
+	}
+}
+
+func TestSettings_readAuthToken_MissingCLI(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	tests := []struct {
+		auth     AuthMode
+		expected string
+	}{
+		{auth: AuthModeRDSIAM, expected: "RDS IAM authentication needs the aws CLI installed"},
+		{auth: AuthModeAzureCLI, expected: "Azure CLI authentication needs the az CLI installed"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.auth), func(t *testing.T) {
+			s := New()
+			s.Auth = test.auth
+			s.User = "shop"
+			err := s.readAuthToken()
+			assert.ErrorIs(t, err, exec.ErrNotFound)
+			assert.ErrorContains(t, err, test.expected)
+			assert.Empty(t, s.Pswd)
+		})
+	}
+}
+
+func TestRDSAuthTokenArgs(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	s.Host = "shop.abc123.eu-central-1.rds.amazonaws.com"
+	s.Port = "5432"
+	s.User = "shop"
+	assert.Equal(t, []string{
+		"rds", "generate-db-auth-token",
+		"--hostname", "shop.abc123.eu-central-1.rds.amazonaws.com",
+		"--port", "5432",
+		"--username", "shop",
+	}, rdsAuthTokenArgs(s))
+
+	s.AWSRegion = "eu-central-1"
+	assert.Equal(t, []string{"--region", "eu-central-1"}, rdsAuthTokenArgs(s)[8:])
+}
+
+func TestAzureCLITokenArgs(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	assert.Equal(t, []string{
+		"account", "get-access-token",
+		"--resource-type", "oss-rdbms",
+		"--query", "accessToken",
+		"--output", "tsv",
+	}, azureCLITokenArgs(s))
+
+	s.AzureTenant = "contoso.onmicrosoft.com"
+	assert.Equal(t, []string{"--tenant", "contoso.onmicrosoft.com"}, azureCLITokenArgs(s)[8:])
+}
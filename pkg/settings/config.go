@@ -0,0 +1,285 @@
+package settings
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// configStructuredKey is the key of the structured options in the config
+	// file, all other top-level keys are names of command line flags.
+	configStructuredKey = "tags"
+
+	// configProfilesKey is the key of the named profiles in the config file.
+	configProfilesKey = "profiles"
+
+	// configSchemasKey is the key of the schemas mapped to their output in
+	// the config file.
+	configSchemasKey = "schemas"
+)
+
+// DefaultConfigFiles are the config files looked up in the working directory
+// if no config file is given, the first one existing is used.
+var DefaultConfigFiles = []string{"tables-to-go.yaml", "tables-to-go.yml", "tables-to-go.toml"}
+
+// configEnvRefRegexp matches the references ${NAME} of environment variables
+// in the values of the config file and the escaped dollar $$.
+var configEnvRefRegexp = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// config represents the structured options of the config file.
+type config struct {
+	Tags struct {
+		// Overrides maps "table.column" (or "*.column" for all tables) to
+		// the tags to override, an empty value suppresses the tag.
+		Overrides map[string]map[string]string `yaml:"overrides" toml:"overrides"`
+	} `yaml:"tags" toml:"tags"`
+
+	// Schemas maps the schemas to generate to their output path and package.
+	Schemas map[string]SchemaOutput `yaml:"schemas" toml:"schemas"`
+
+	// Profiles are the named profiles selectable via -profile, each with
+	// the same options as the top-level.
+	Profiles map[string]config `yaml:"profiles" toml:"profiles"`
+}
+
+// LoadConfigFile reads the YAML or TOML (by the extension .toml) config file.
+// The top-level keys are the names of the command line flags, their values
+// are set via the given flag set unless the flag was given on the command
+// line already. Lists set the flag once per element, maps once per key=value
+// pair. The key "tags" holds the structured tag options, the key "schemas"
+// maps the schemas to generate to their output path and package.
+//
+// The key "profiles" holds named profiles with the same options, the one
+// selected by the Profile setting wins over the top-level options. The
+// references ${NAME} in the values of the flags get replaced by the
+// environment variables, e.g. to keep the credentials out of the file.
+func (settings *Settings) LoadConfigFile(fs *flag.FlagSet) error {
+	content, err := os.ReadFile(settings.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("could not read config file %q: %w", settings.ConfigFile, err)
+	}
+
+	unmarshal := yaml.Unmarshal
+	if strings.EqualFold(filepath.Ext(settings.ConfigFile), ".toml") {
+		unmarshal = toml.Unmarshal
+	}
+
+	var options map[string]any
+	if err = unmarshal(content, &options); err != nil {
+		return fmt.Errorf("could not parse config file %q: %w", settings.ConfigFile, err)
+	}
+	var cfg config
+	if err = unmarshal(content, &cfg); err != nil {
+		return fmt.Errorf("could not parse config file %q: %w", settings.ConfigFile, err)
+	}
+
+	if settings.Profile != "" {
+		profiles, _ := options[configProfilesKey].(map[string]any)
+		profile, ok := profiles[settings.Profile].(map[string]any)
+		if !ok {
+			return fmt.Errorf("profile %q not found in config file %q", settings.Profile, settings.ConfigFile)
+		}
+		if _, ok = profile[configProfilesKey]; ok {
+			return fmt.Errorf("invalid config file %q: profile %q: nested profiles not supported",
+				settings.ConfigFile, settings.Profile)
+		}
+		if err = applyConfigFlags(fs, profile); err != nil {
+			return fmt.Errorf("invalid config file %q: profile %q: %w", settings.ConfigFile, settings.Profile, err)
+		}
+	}
+
+	if err = applyConfigFlags(fs, options); err != nil {
+		return fmt.Errorf("invalid config file %q: %w", settings.ConfigFile, err)
+	}
+
+	overrides := cfg.Tags.Overrides
+	if settings.Profile != "" {
+		overrides = mergeTagsOverrides(overrides, cfg.Profiles[settings.Profile].Tags.Overrides)
+	}
+	if overrides != nil {
+		settings.TagsOverrides = overrides
+	}
+
+	schemas := cfg.Schemas
+	if settings.Profile != "" {
+		schemas = mergeSchemas(schemas, cfg.Profiles[settings.Profile].Schemas)
+	}
+	if schemas != nil {
+		settings.Schemas = schemas
+	}
+
+	return nil
+}
+
+// FindConfigFile returns the first of the DefaultConfigFiles existing in the
+// given directory, or an empty string if there is none.
+func FindConfigFile(dir string) string {
+	for _, name := range DefaultConfigFiles {
+		file := filepath.Join(dir, name)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file
+		}
+	}
+	return ""
+}
+
+// mergeTagsOverrides returns the tag overrides of base with the ones of
+// profile on top.
+func mergeTagsOverrides(base, profile map[string]map[string]string) map[string]map[string]string {
+	if profile == nil {
+		return base
+	}
+
+	merged := make(map[string]map[string]string, len(base)+len(profile))
+	for key, tags := range base {
+		merged[key] = make(map[string]string, len(tags))
+		for name, value := range tags {
+			merged[key][name] = value
+		}
+	}
+	for key, tags := range profile {
+		if merged[key] == nil {
+			merged[key] = make(map[string]string, len(tags))
+		}
+		for name, value := range tags {
+			merged[key][name] = value
+		}
+	}
+	return merged
+}
+
+// mergeSchemas returns the schemas of base with the ones of profile on top.
+func mergeSchemas(base, profile map[string]SchemaOutput) map[string]SchemaOutput {
+	if profile == nil {
+		return base
+	}
+
+	merged := make(map[string]SchemaOutput, len(base)+len(profile))
+	maps.Copy(merged, base)
+	maps.Copy(merged, profile)
+	return merged
+}
+
+// applyConfigFlags sets the flags given by the options which were not set on
+// the command line.
+func applyConfigFlags(fs *flag.FlagSet, options map[string]any) error {
+	given := map[string]struct{}{}
+	fs.Visit(func(f *flag.Flag) {
+		given[FlagName(f.Name)] = struct{}{}
+	})
+
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if name == configStructuredKey || name == configProfilesKey || name == configSchemasKey {
+			continue
+		}
+		if name == "config" || name == "profile" || fs.Lookup(name) == nil {
+			return fmt.Errorf("unknown option %q", name)
+		}
+		if _, ok := given[FlagName(name)]; ok {
+			continue
+		}
+
+		values, err := configValues(options[name])
+		if err != nil {
+			return fmt.Errorf("option %q: %w", name, err)
+		}
+		for _, value := range values {
+			if value, err = expandConfigEnv(value, os.LookupEnv); err != nil {
+				return fmt.Errorf("option %q: %w", name, err)
+			}
+			if err = fs.Set(name, value); err != nil {
+				return fmt.Errorf("option %q: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// configValues converts the decoded value of an option to the string values
+// to set the flag with.
+func configValues(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case int, int64, uint64, float64:
+		return []string{fmt.Sprint(v)}, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, elem := range v {
+			elemValues, err := configValues(elem)
+			if err != nil {
+				return nil, err
+			}
+			if len(elemValues) != 1 {
+				return nil, fmt.Errorf("nested value %v not supported", elem)
+			}
+			values = append(values, elemValues[0])
+		}
+		return values, nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		values := make([]string, 0, len(v))
+		for _, key := range keys {
+			elemValues, err := configValues(v[key])
+			if err != nil {
+				return nil, err
+			}
+			if len(elemValues) != 1 {
+				return nil, fmt.Errorf("nested value %v not supported", v[key])
+			}
+			values = append(values, key+"="+elemValues[0])
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("value %v of type %T not supported", value, value)
+	}
+}
+
+// expandConfigEnv replaces the references ${NAME} in the value by the
+// environment variables looked up by lookupEnv, $$ by a single $. Other
+// dollars like the submatches $1 or ${1} of replacements are kept as they
+// are. An unset variable is an error instead of silently becoming empty.
+func expandConfigEnv(value string, lookupEnv func(string) (string, bool)) (string, error) {
+	var err error
+	expanded := configEnvRefRegexp.ReplaceAllStringFunc(value, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		name := ref[2 : len(ref)-1]
+		envValue, ok := lookupEnv(name)
+		if !ok && err == nil {
+			err = fmt.Errorf("environment variable %q not set", name)
+		}
+		return envValue
+	})
+	if err != nil {
+		return "", err
+	}
+	return expanded, nil
+}
@@ -0,0 +1,193 @@
+package settings
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// WriteConfigTemplate writes a commented starter config file in YAML or, if
+// isTOML, in TOML with the connection of the settings, the given discovered
+// tables (commented out, all tables get generated by default) and the
+// current output and tag options, meant to be edited and then given via
+// -config. The password is left out on purpose.
+func (settings *Settings) WriteConfigTemplate(w io.Writer, isTOML bool, tables []string) error {
+	t := configTemplate{w: w, isTOML: isTOML}
+
+	t.comment("tables-to-go config file, generated by `tables-to-go init`.")
+	t.comment("The keys are the names of the command line flags, see `tables-to-go -help`,")
+	t.comment("flags given on the command line win over this file.")
+	t.line("")
+
+	t.comment("connection, the password is better set via the environment variable")
+	t.comment(EnvName("p") + " than written down here")
+	t.option("t", string(settings.DbType))
+	if settings.DbType != DBTypeSQLite {
+		t.option("h", settings.Host)
+		t.option("port", settings.Port)
+		if settings.Socket != "" {
+			t.option("socket", settings.Socket)
+		}
+		if settings.Proxy != "" {
+			t.option("proxy", settings.Proxy)
+		}
+		if settings.SSHHost != "" {
+			t.option("ssh-host", settings.SSHHost)
+		}
+		if settings.SSHUser != "" {
+			t.option("ssh-user", settings.SSHUser)
+		}
+		if settings.SSHKey != "" {
+			t.option("ssh-key", settings.SSHKey)
+		}
+		t.option("u", settings.User)
+		if settings.Auth != AuthModePassword {
+			t.option("auth", string(settings.Auth))
+			if settings.AWSRegion != "" {
+				t.option("aws-region", settings.AWSRegion)
+			}
+			if settings.AzureTenant != "" {
+				t.option("azure-tenant", settings.AzureTenant)
+			}
+		}
+	}
+	t.option("d", settings.DbName)
+	if settings.DbType != DBTypeSQLite {
+		t.option("s", settings.Schema)
+	}
+	if settings.DbType.Dialect() == DBTypePostgresql {
+		t.option("sslmode", settings.SSLMode)
+		if settings.KrbSrvName != "" {
+			t.option("krbsrvname", settings.KrbSrvName)
+		}
+		if settings.KrbSPN != "" {
+			t.option("krbspn", settings.KrbSPN)
+		}
+	}
+	if settings.SSLCert != "" {
+		t.option("ssl-cert", settings.SSLCert)
+		t.option("ssl-key", settings.SSLKey)
+	}
+	if settings.SSLRootCert != "" {
+		t.option("ssl-root-cert", settings.SSLRootCert)
+	}
+	t.line("")
+
+	t.comment(fmt.Sprintf("the %d discovered tables, all of them get generated by default, uncomment", len(tables)))
+	t.comment("to generate only the listed ones or filter them by regular expressions")
+	if len(tables) > 0 {
+		t.comment(t.key("table") + "[")
+		for i, table := range tables {
+			separator := ","
+			if i == len(tables)-1 {
+				separator = ""
+			}
+			t.comment("  " + strconv.Quote(table) + separator)
+		}
+		t.comment("]")
+	}
+	t.comment(t.key("include") + `["^order"]`)
+	t.comment(t.key("exclude") + `["_archive$"]`)
+	t.comment("read-only structs of the views along with or instead of the tables")
+	t.boolOption("include-views", settings.IncludeViews)
+	t.boolOption("only-views", settings.OnlyViews)
+	t.line("")
+
+	t.comment("output path and package name of the generated files")
+	t.option("of", relativePath(settings.OutputFilePath))
+	t.option("pn", settings.PackageName)
+	t.comment("struct names: c (CamelCase) or o (original)")
+	t.option("format", string(settings.OutputFormat))
+	t.comment("file names: c (CamelCase) or s (snake_case)")
+	t.option("fn-format", string(settings.FileNameFormat))
+	t.comment("struct fields: ordinal (column order), alphabetical or pk-first (primary keys first)")
+	t.option("field-order", string(settings.FieldOrder))
+	t.comment("foreign keys: none, comments (references table(column)) or fields (also fields of the referenced structs)")
+	t.option("relations", string(settings.Relations))
+	t.comment("comments of the tables and columns in the database as doc comments")
+	t.boolOption("with-comments", settings.WithComments)
+	t.comment("constants of the column names and the methods Columns, Values and ScanTargets")
+	t.boolOption("with-columns", settings.WithColumns)
+	t.comment("names colliding with Go keywords or the generated code: suffix (type_) or prefix (Xtype)")
+	t.option("rename-strategy", string(settings.RenameStrategy))
+	t.comment("oldest Go version of the generated code: 1.21, 1.22 (sql.Null[T]) or 1.24 (omitzero)")
+	t.option("go-version", string(settings.GoVersion))
+	t.comment("NULL columns: sql (sql.Null*), pointer, native or primitive (pointers), json (generated Null* types) or guregu (guregu/null)")
+	t.option("null", string(settings.Null))
+	t.comment("array columns of Postgres: pq (pq.Int64Array, ...) or native (slices scanned by pgx)")
+	t.option("array-type", string(settings.ArrayType))
+	t.comment("CRUD repositories of the tables: none, sql (database/sql) or sqlx")
+	t.option("with-crud", string(settings.CRUD))
+	t.comment("YAML or TOML file of the rules mapping the data types and columns to Go types")
+	if settings.TypeMapFile != "" {
+		t.option("type-map", settings.TypeMapFile)
+	} else {
+		t.comment(t.key("type-map") + `"mappings.yaml"`)
+	}
+	t.line("")
+
+	t.comment("tags, db tags are generated unless tags-no-db is set")
+	t.boolOption("tags-no-db", settings.TagsNoDb)
+	t.boolOption("tags-json", settings.TagsJSON)
+	t.boolOption("tags-yaml", settings.TagsYAML)
+	t.boolOption("tags-toml", settings.TagsTOML)
+	t.boolOption("tags-gorm", settings.TagsGorm)
+	t.boolOption("tags-gorm-constraints", settings.TagsGormConstraints)
+	t.boolOption("tags-validate", settings.TagsValidate)
+
+	return t.err
+}
+
+// configTemplate writes the lines of the config template in YAML or TOML and
+// keeps the first error.
+type configTemplate struct {
+	w      io.Writer
+	isTOML bool
+	err    error
+}
+
+func (t *configTemplate) line(s string) {
+	if t.err == nil {
+		_, t.err = fmt.Fprintln(t.w, s)
+	}
+}
+
+func (t *configTemplate) comment(s string) {
+	t.line("# " + s)
+}
+
+// key returns the key with the separator of its value.
+func (t *configTemplate) key(name string) string {
+	if t.isTOML {
+		return name + " = "
+	}
+	return name + ": "
+}
+
+func (t *configTemplate) option(name string, value string) {
+	t.line(t.key(name) + strconv.Quote(value))
+}
+
+func (t *configTemplate) boolOption(name string, value bool) {
+	t.line(t.key(name) + strconv.FormatBool(value))
+}
+
+// relativePath returns the path relative to the working directory if
+// possible, since the config file is usually versioned with the project.
+func relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	if rel == "." {
+		return rel
+	}
+	return "./" + filepath.ToSlash(rel)
+}
@@ -0,0 +1,93 @@
+package settings
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettings_WriteConfigTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc   string
+		file   string
+		isTOML bool
+	}{
+		{
+			desc: "YAML template loads as config file",
+			file: "tables-to-go.yaml",
+		},
+		{
+			desc:   "TOML template loads as config file",
+			file:   "tables-to-go.toml",
+			isTOML: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			s.User = "shop"
+			s.Pswd = "secret"
+			s.DbName = "shop"
+			s.Port = "5432"
+			s.TagsJSON = true
+
+			var buf bytes.Buffer
+			assert.NoError(t, s.WriteConfigTemplate(&buf, test.isTOML, []string{"orders", "users"}))
+			assert.NotContains(t, buf.String(), "secret")
+			assert.Contains(t, buf.String(), `#   "orders",`)
+			assert.Contains(t, buf.String(), `#   "users"`)
+
+			file := filepath.Join(t.TempDir(), test.file)
+			assert.NoError(t, os.WriteFile(file, buf.Bytes(), 0666))
+
+			loaded := New()
+			loaded.ConfigFile = file
+			fs := newConfigFlagSet(loaded)
+			fs.StringVar(&loaded.Host, "h", loaded.Host, "")
+			fs.StringVar(&loaded.DbName, "d", loaded.DbName, "")
+			fs.StringVar(&loaded.SSLMode, "sslmode", loaded.SSLMode, "")
+			fs.StringVar(&loaded.PackageName, "pn", loaded.PackageName, "")
+			fs.Var(&loaded.OutputFormat, "format", "")
+			fs.Var(&loaded.FileNameFormat, "fn-format", "")
+			fs.Var(&loaded.FieldOrder, "field-order", "")
+			fs.Var(&loaded.Relations, "relations", "")
+			fs.Var(&loaded.RenameStrategy, "rename-strategy", "")
+			fs.Var(&loaded.GoVersion, "go-version", "")
+			fs.Var(&loaded.Null, "null", "")
+			fs.Var(&loaded.ArrayType, "array-type", "")
+			fs.Var(&loaded.CRUD, "with-crud", "")
+			for _, name := range []string{"include-views", "only-views", "with-comments", "with-columns", "tags-no-db", "tags-yaml", "tags-toml", "tags-gorm", "tags-gorm-constraints", "tags-validate"} {
+				fs.Bool(name, false, "")
+			}
+
+			assert.NoError(t, loaded.LoadConfigFile(fs))
+			assert.Equal(t, "shop", loaded.User)
+			assert.Equal(t, "shop", loaded.DbName)
+			assert.Equal(t, "5432", loaded.Port)
+			assert.Equal(t, s.Schema, loaded.Schema)
+			assert.Equal(t, "", loaded.Pswd)
+			assert.True(t, loaded.TagsJSON)
+			assert.Empty(t, loaded.Tables)
+		})
+	}
+}
+
+func TestRelativePath(t *testing.T) {
+	t.Parallel()
+
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+
+	assert.Equal(t, ".", relativePath(wd))
+	assert.Equal(t, "./models", relativePath(filepath.Join(wd, "models")+string(filepath.Separator)))
+
+	outside := filepath.Dir(wd)
+	assert.True(t, strings.HasPrefix(relativePath(outside), string(filepath.Separator)))
+}
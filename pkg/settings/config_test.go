@@ -0,0 +1,495 @@
+package settings
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newConfigFlagSet binds some of the flags of the command line to the given
+// settings.
+func newConfigFlagSet(s *Settings) *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&s.ConfigFile, "config", s.ConfigFile, "")
+	fs.StringVar(&s.Profile, "profile", s.Profile, "")
+	fs.Var(&s.DbType, "t", "")
+	fs.StringVar(&s.User, "u", s.User, "")
+	fs.StringVar(&s.Schema, "s", s.Schema, "")
+	fs.Var(&s.SchemaNames, "schema", "")
+	fs.StringVar(&s.Port, "port", s.Port, "")
+	fs.Var(&s.Tables, "table", "")
+	fs.Var(&s.TablesInclude, "include", "")
+	fs.Var(&s.TablesInclude, "include-pattern", "")
+	fs.Var(&s.TablesExclude, "exclude", "")
+	fs.Var(&s.TablesExclude, "exclude-pattern", "")
+	fs.IntVar(&s.Jobs, "jobs", s.Jobs, "")
+	fs.IntVar(&s.Jobs, "concurrency", s.Jobs, "")
+	fs.BoolVar(&s.TagsJSON, "tags-json", s.TagsJSON, "")
+	fs.Var(&s.TagsNames, "tags-name", "")
+	fs.StringVar(&s.OutputFilePath, "of", s.OutputFilePath, "")
+	return fs
+}
+
+func TestSettings_LoadConfigFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		fileName string
+		content  string
+		args     []string
+		expected func() *Settings
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "empty config file keeps the defaults",
+			fileName: "tables-to-go.yaml",
+			content:  "",
+			expected: New,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "yaml config file sets the flags and tag overrides",
+			fileName: "tables-to-go.yaml",
+			content: `
+t: mysql
+u: root
+port: 3307
+table: [users, groups]
+tags-json: true
+tags-name:
+  usr_id: userId
+tags:
+  overrides:
+    users.password_hash:
+      json: "-"
+`,
+			expected: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.User = "root"
+				s.Port = "3307"
+				s.Tables = StringsFlag{"users", "groups"}
+				s.TagsJSON = true
+				s.TagsNames = MapFlag{"usr_id": "userId"}
+				s.TagsOverrides = map[string]map[string]string{
+					"users.password_hash": {"json": "-"},
+				}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "toml config file sets the flags and tag overrides",
+			fileName: "tables-to-go.toml",
+			content: `
+t = "mysql"
+table = ["users"]
+tags-json = true
+
+[tags.overrides."*.internal_note"]
+db = ""
+`,
+			expected: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.Tables = StringsFlag{"users"}
+				s.TagsJSON = true
+				s.TagsOverrides = map[string]map[string]string{
+					"*.internal_note": {"db": ""},
+				}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "flags given on the command line win",
+			fileName: "tables-to-go.yaml",
+			content: `
+t: mysql
+u: root
+table: [users]
+`,
+			args: []string{"-u", "admin", "-table", "groups"},
+			expected: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.User = "admin"
+				s.Tables = StringsFlag{"groups"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "option of an alias is overridden by the flag given by its name",
+			fileName: "tables-to-go.yaml",
+			content: `
+include-pattern: ["^user"]
+exclude-pattern: ["^flyway_", "^databasechangelog"]
+concurrency: 8
+`,
+			args: []string{"-include", "^order"},
+			expected: func() *Settings {
+				s := New()
+				s.TablesInclude = RegexpsFlag{regexp.MustCompile("^order")}
+				s.TablesExclude = RegexpsFlag{regexp.MustCompile("^flyway_"), regexp.MustCompile("^databasechangelog")}
+				s.Jobs = 8
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "selected profile wins over the top-level options",
+			fileName: "tables-to-go.yaml",
+			content: `
+t: pg
+u: root
+tags-json: true
+tags:
+  overrides:
+    users.id:
+      json: userId
+    users.name:
+      json: userName
+profiles:
+  dev:
+    u: dev
+    of: ./dev
+  prod:
+    t: mysql
+    u: admin
+    of: ./prod
+    tags:
+      overrides:
+        users.id:
+          json: id
+`,
+			args: []string{"-profile", "prod"},
+			expected: func() *Settings {
+				s := New()
+				s.Profile = "prod"
+				s.DbType = DBTypeMySQL
+				s.User = "admin"
+				s.OutputFilePath = "./prod"
+				s.TagsJSON = true
+				s.TagsOverrides = map[string]map[string]string{
+					"users.id":   {"json": "id"},
+					"users.name": {"json": "userName"},
+				}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "schemas are mapped to their output and merged with the profile",
+			fileName: "tables-to-go.yaml",
+			content: `
+t: pg
+schemas:
+  billing:
+    of: internal/models/billing
+  auth:
+    of: internal/models/auth
+    pn: authdto
+profiles:
+  ci:
+    schemas:
+      billing:
+        of: gen/billing
+`,
+			args: []string{"-profile", "ci"},
+			expected: func() *Settings {
+				s := New()
+				s.Profile = "ci"
+				s.Schemas = map[string]SchemaOutput{
+					"billing": {OutputFilePath: "gen/billing"},
+					"auth":    {OutputFilePath: "internal/models/auth", PackageName: "authdto"},
+				}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "schemas from toml config file",
+			fileName: "tables-to-go.toml",
+			content: `
+[schemas.billing]
+of = "internal/models/billing"
+pn = "billing"
+`,
+			expected: func() *Settings {
+				s := New()
+				s.Schemas = map[string]SchemaOutput{
+					"billing": {OutputFilePath: "internal/models/billing", PackageName: "billing"},
+				}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "profiles are ignored without a selected profile",
+			fileName: "tables-to-go.toml",
+			content: `
+u = "root"
+
+[profiles.dev]
+u = "dev"
+`,
+			expected: func() *Settings {
+				s := New()
+				s.User = "root"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "selected profile from toml config file",
+			fileName: "tables-to-go.toml",
+			content: `
+u = "root"
+
+[profiles.dev]
+u = "dev"
+table = ["users"]
+`,
+			args: []string{"-profile", "dev"},
+			expected: func() *Settings {
+				s := New()
+				s.Profile = "dev"
+				s.User = "dev"
+				s.Tables = StringsFlag{"users"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "command line wins over the profile",
+			fileName: "tables-to-go.yaml",
+			content: `
+profiles:
+  dev:
+    u: dev
+`,
+			args: []string{"-profile", "dev", "-u", "admin"},
+			expected: func() *Settings {
+				s := New()
+				s.Profile = "dev"
+				s.User = "admin"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "unknown profile produces error",
+			fileName: "tables-to-go.yaml",
+			content: `
+profiles:
+  dev:
+    u: dev
+`,
+			args: []string{"-profile", "prod"},
+			expected: func() *Settings {
+				s := New()
+				s.Profile = "prod"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc:     "unknown option in profile produces error",
+			fileName: "tables-to-go.yaml",
+			content: `
+profiles:
+  dev:
+    unknown: true
+`,
+			args: []string{"-profile", "dev"},
+			expected: func() *Settings {
+				s := New()
+				s.Profile = "dev"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc:     "unknown option produces error",
+			fileName: "tables-to-go.yaml",
+			content:  "unknown: true",
+			expected: New,
+			isError:  assert.Error,
+		},
+		{
+			desc:     "invalid value produces error",
+			fileName: "tables-to-go.yaml",
+			content:  "t: unknown",
+			expected: func() *Settings {
+				s := New()
+				s.DbType = DBType("unknown")
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc:     "invalid config file produces error",
+			fileName: "tables-to-go.yaml",
+			content:  "tags: [",
+			expected: New,
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), test.fileName)
+			assert.NoError(t, os.WriteFile(configFile, []byte(test.content), 0644))
+
+			s := New()
+			fs := newConfigFlagSet(s)
+			assert.NoError(t, fs.Parse(append(test.args, "-config", configFile)))
+
+			err := s.LoadConfigFile(fs)
+			test.isError(t, err)
+
+			expected := test.expected()
+			expected.ConfigFile = configFile
+			assert.Equal(t, expected, s)
+		})
+	}
+}
+
+func TestSettings_LoadConfigFile_EnvUnset(t *testing.T) {
+	t.Parallel()
+
+	configFile := filepath.Join(t.TempDir(), "tables-to-go.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte("u: ${TABLES_TO_GO_TEST_UNSET_USER}\n"), 0644))
+
+	s := New()
+	fs := newConfigFlagSet(s)
+	assert.NoError(t, fs.Parse([]string{"-config", configFile}))
+	assert.ErrorContains(t, s.LoadConfigFile(fs), "TABLES_TO_GO_TEST_UNSET_USER")
+}
+
+func TestSettings_LoadConfigFile_Missing(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	s.ConfigFile = filepath.Join(t.TempDir(), "missing.yaml")
+	assert.Error(t, s.LoadConfigFile(newConfigFlagSet(s)))
+}
+
+func TestFindConfigFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		files    []string
+		expected string
+	}{
+		{
+			desc:     "no config file",
+			files:    nil,
+			expected: "",
+		},
+		{
+			desc:     "toml config file",
+			files:    []string{"tables-to-go.toml"},
+			expected: "tables-to-go.toml",
+		},
+		{
+			desc:     "yaml wins over yml and toml",
+			files:    []string{"tables-to-go.toml", "tables-to-go.yml", "tables-to-go.yaml"},
+			expected: "tables-to-go.yaml",
+		},
+		{
+			desc:     "other files are ignored",
+			files:    []string{"config.yaml", ".tables-to-go.yaml"},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range test.files {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, 0644))
+			}
+
+			expected := ""
+			if test.expected != "" {
+				expected = filepath.Join(dir, test.expected)
+			}
+			assert.Equal(t, expected, FindConfigFile(dir))
+		})
+	}
+}
+
+func TestExpandConfigEnv(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"DB_USER":     "shop",
+		"DB_PASSWORD": "s3cr$t",
+		"EMPTY":       "",
+	}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	tests := []struct {
+		desc     string
+		value    string
+		expected string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no references",
+			value:    "postgres",
+			expected: "postgres",
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "whole value",
+			value:    "${DB_PASSWORD}",
+			expected: "s3cr$t",
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "multiple references in a value",
+			value:    "${DB_USER}:${DB_PASSWORD}@${EMPTY}",
+			expected: "shop:s3cr$t@",
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "submatches of replacements are kept",
+			value:    "^(.*)_lst$=${1}_list $1",
+			expected: "^(.*)_lst$=${1}_list $1",
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "escaped dollar",
+			value:    "$${DB_USER}",
+			expected: "${DB_USER}",
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "unset variable",
+			value:    "${DB_HOST}",
+			expected: "",
+			isError:  assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual, err := expandConfigEnv(test.value, lookupEnv)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
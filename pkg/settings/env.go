@@ -0,0 +1,103 @@
+package settings
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// EnvPrefix is the prefix of the environment variables setting the flags.
+const EnvPrefix = "TABLES_TO_GO_"
+
+// envNames maps the short flags to the descriptive names used for their
+// environment variables.
+var envNames = map[string]string{
+	"v":   "VERBOSE",
+	"vv":  "VVERBOSE",
+	"f":   "FORCE",
+	"t":   "TYPE",
+	"u":   "USER",
+	"p":   "PASSWORD",
+	"d":   "DATABASE",
+	"s":   "SCHEMA",
+	"h":   "HOST",
+	"of":  "OUTPUT",
+	"pre": "PREFIX",
+	"suf": "SUFFIX",
+	"pn":  "PACKAGE",
+
+	// TABLES_TO_GO_SCHEMA is the one of -s
+	"schema": "SCHEMAS",
+}
+
+// envIgnored are the flags which can not be set via environment variables.
+var envIgnored = map[string]struct{}{
+	"?":       {},
+	"help":    {},
+	"version": {},
+}
+
+// flagAliases maps the alternative names of flags to the flags they set. A
+// flag given by its alias counts as given by its name, and the aliases have
+// no environment variables of their own.
+var flagAliases = map[string]string{
+	"include-pattern": "include",
+	"exclude-pattern": "exclude",
+	"concurrency":     "jobs",
+	"null-type":       "null",
+}
+
+// FlagName returns the name of the flag of the given name or alias.
+func FlagName(name string) string {
+	if alias, ok := flagAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// EnvName returns the name of the environment variable for the given flag,
+// e.g. TABLES_TO_GO_TAGS_JSON for -tags-json or TABLES_TO_GO_PASSWORD for -p.
+func EnvName(flagName string) string {
+	name, ok := envNames[flagName]
+	if !ok {
+		name = strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+	}
+	return EnvPrefix + name
+}
+
+// LoadEnv sets the flags of the given flag set which were not given on the
+// command line from the environment variables found by lookup. The values
+// are set like on the command line, hence lists are comma separated. Flags
+// set this way count as given for the config file.
+func LoadEnv(fs *flag.FlagSet, lookup func(string) (string, bool)) error {
+	given := map[string]struct{}{}
+	fs.Visit(func(f *flag.Flag) {
+		given[FlagName(f.Name)] = struct{}{}
+	})
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil {
+			return
+		}
+		if _, ok := envIgnored[f.Name]; ok {
+			return
+		}
+		if _, ok := flagAliases[f.Name]; ok {
+			return
+		}
+		if _, ok := given[f.Name]; ok {
+			return
+		}
+		name := EnvName(f.Name)
+		value, ok := lookup(name)
+		if !ok {
+			return
+		}
+		if setErr := fs.Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid environment variable %s: %w", name, setErr)
+		}
+	})
+
+	return err
+}
@@ -0,0 +1,189 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		flag     string
+		expected string
+	}{
+		{flag: "p", expected: "TABLES_TO_GO_PASSWORD"},
+		{flag: "of", expected: "TABLES_TO_GO_OUTPUT"},
+		{flag: "port", expected: "TABLES_TO_GO_PORT"},
+		{flag: "s", expected: "TABLES_TO_GO_SCHEMA"},
+		{flag: "schema", expected: "TABLES_TO_GO_SCHEMAS"},
+		{flag: "tags-json", expected: "TABLES_TO_GO_TAGS_JSON"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.flag, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, EnvName(test.flag))
+		})
+	}
+}
+
+func TestLoadEnv(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		env      map[string]string
+		args     []string
+		config   string
+		expected func() *Settings
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no environment variables keep the defaults",
+			expected: New,
+			isError:  assert.NoError,
+		},
+		{
+			desc: "environment variables set the flags",
+			env: map[string]string{
+				"TABLES_TO_GO_TYPE":      "mysql",
+				"TABLES_TO_GO_USER":      "root",
+				"TABLES_TO_GO_PORT":      "3307",
+				"TABLES_TO_GO_TABLE":     "users,orders",
+				"TABLES_TO_GO_TAGS_JSON": "true",
+				"TABLES_TO_GO_TAGS_NAME": "usr_id=userId",
+			},
+			expected: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.User = "root"
+				s.Port = "3307"
+				s.Tables = StringsFlag{"users", "orders"}
+				s.TagsJSON = true
+				s.TagsNames = MapFlag{"usr_id": "userId"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "command line wins over environment variables",
+			env: map[string]string{
+				"TABLES_TO_GO_USER": "root",
+				"TABLES_TO_GO_PORT": "3307",
+			},
+			args: []string{"-u", "admin"},
+			expected: func() *Settings {
+				s := New()
+				s.User = "admin"
+				s.Port = "3307"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "environment variables win over the config file",
+			env: map[string]string{
+				"TABLES_TO_GO_USER": "root",
+			},
+			config: "u: admin\nport: \"3307\"\n",
+			expected: func() *Settings {
+				s := New()
+				s.User = "root"
+				s.Port = "3307"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "aliases have no environment variables and count as given by their name",
+			env: map[string]string{
+				"TABLES_TO_GO_INCLUDE":         "^user",
+				"TABLES_TO_GO_EXCLUDE":         "_tmp$",
+				"TABLES_TO_GO_EXCLUDE_PATTERN": "^flyway_",
+			},
+			args: []string{"-include-pattern", "^order"},
+			expected: func() *Settings {
+				s := New()
+				s.TablesInclude = RegexpsFlag{regexp.MustCompile("^order")}
+				s.TablesExclude = RegexpsFlag{regexp.MustCompile("_tmp$")}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "the schema of -s and the schemas of -schema have their own variables",
+			env: map[string]string{
+				"TABLES_TO_GO_SCHEMA": "billing",
+			},
+			expected: func() *Settings {
+				s := New()
+				s.Schema = "billing"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "the schemas of -schema",
+			env: map[string]string{
+				"TABLES_TO_GO_SCHEMAS": "billing,auth",
+			},
+			expected: func() *Settings {
+				s := New()
+				s.SchemaNames = StringsFlag{"billing", "auth"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "short flag names are not used",
+			env: map[string]string{
+				"TABLES_TO_GO_U": "root",
+			},
+			expected: New,
+			isError:  assert.NoError,
+		},
+		{
+			desc: "invalid value",
+			env: map[string]string{
+				"TABLES_TO_GO_TAGS_JSON": "maybe",
+			},
+			expected: New,
+			isError:  assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := New()
+			fs := newConfigFlagSet(actual)
+			assert.NoError(t, fs.Parse(test.args))
+
+			lookup := func(name string) (string, bool) {
+				value, ok := test.env[name]
+				return value, ok
+			}
+
+			err := LoadEnv(fs, lookup)
+			test.isError(t, err)
+			if err != nil {
+				return
+			}
+
+			if test.config != "" {
+				actual.ConfigFile = filepath.Join(t.TempDir(), "tables-to-go.yaml")
+				assert.NoError(t, os.WriteFile(actual.ConfigFile, []byte(test.config), 0644))
+				assert.NoError(t, actual.LoadConfigFile(fs))
+				actual.ConfigFile = ""
+			}
+
+			assert.Equal(t, test.expected(), actual)
+		})
+	}
+}
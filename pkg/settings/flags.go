@@ -2,18 +2,25 @@ package settings
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 // DBType represents a type of a database.
 type DBType string
 
-// These database types are supported.
+// These database types are supported. CockroachDB speaks the dialect of
+// Postgres and MariaDB the one of MySQL, see Dialect.
 const (
-	DBTypePostgresql DBType = "pg"
-	DBTypeMySQL      DBType = "mysql"
-	DBTypeSQLite     DBType = "sqlite3"
-	DBTypeOracle     DBType = "oracle"
+	DBTypePostgresql  DBType = "pg"
+	DBTypeMySQL       DBType = "mysql"
+	DBTypeSQLite      DBType = "sqlite3"
+	DBTypeOracle      DBType = "oracle"
+	DBTypeCockroachDB DBType = "cockroach"
+	DBTypeMariaDB     DBType = "mariadb"
 )
 
 // Set sets the datatype for the custom type for the flag package.
@@ -34,12 +41,30 @@ func (db DBType) String() string {
 	return string(db)
 }
 
-// These null types are supported. The types native and primitive map to the same
-// underlying builtin golang type.
+// Dialect returns the database type whose SQL dialect and driver the database
+// type shares: pg for CockroachDB, mysql for MariaDB and the database type
+// itself for all others.
+func (db DBType) Dialect() DBType {
+	switch db {
+	case DBTypeCockroachDB:
+		return DBTypePostgresql
+	case DBTypeMariaDB:
+		return DBTypeMySQL
+	}
+	return db
+}
+
+// These null types are supported. The types native, primitive and pointer map
+// to the same underlying builtin golang type. The type json generates
+// wrappers of the sql.Null* types which marshal to JSON null, the type guregu
+// uses the types of github.com/guregu/null/v5.
 const (
 	NullTypeSQL       NullType = "sql"
 	NullTypeNative    NullType = "native"
 	NullTypePrimitive NullType = "primitive"
+	NullTypePointer   NullType = "pointer"
+	NullTypeJSON      NullType = "json"
+	NullTypeGuregu    NullType = "guregu"
 )
 
 // NullType represents a null type.
@@ -64,6 +89,12 @@ func (t NullType) String() string {
 	return string(t)
 }
 
+// IsPointer returns true if the null type maps NULL columns to pointers of
+// the builtin types.
+func (t NullType) IsPointer() bool {
+	return t == NullTypeNative || t == NullTypePrimitive || t == NullTypePointer
+}
+
 // OutputFormat represents an output format option.
 type OutputFormat string
 
@@ -116,6 +147,386 @@ func (of FileNameFormat) String() string {
 	return string(of)
 }
 
+// TagNameFormat represents the naming strategy of the keys of serialization
+// tags like json.
+type TagNameFormat string
+
+// These are the TagNameFormat command line parameter.
+const (
+	TagNameFormatCamelCase TagNameFormat = "c"
+	TagNameFormatSnakeCase TagNameFormat = "s"
+	TagNameFormatOriginal  TagNameFormat = "o"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (f *TagNameFormat) Set(s string) error {
+	*f = TagNameFormat(s)
+	if *f == "" {
+		*f = TagNameFormatOriginal
+	}
+	if !supportedTagNameFormats[*f] {
+		return fmt.Errorf("tag name format %q not supported", *f)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (f TagNameFormat) String() string {
+	return string(f)
+}
+
+// DbTagCase represents how the column names are written into db-tags.
+type DbTagCase string
+
+// These are the DbTagCase command line parameter.
+const (
+	DbTagCaseOriginal DbTagCase = "o"
+	DbTagCaseLower    DbTagCase = "l"
+	DbTagCaseQuoted   DbTagCase = "q"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (c *DbTagCase) Set(s string) error {
+	*c = DbTagCase(s)
+	if *c == "" {
+		*c = DbTagCaseOriginal
+	}
+	if !supportedDbTagCases[*c] {
+		return fmt.Errorf("db tag case %q not supported", *c)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (c DbTagCase) String() string {
+	return string(c)
+}
+
+// OmitMode represents for which columns an omit option like omitempty is
+// appended to a tag.
+type OmitMode string
+
+// These are the OmitMode command line parameter.
+const (
+	OmitModeNone     OmitMode = "none"
+	OmitModeAll      OmitMode = "all"
+	OmitModeNullable OmitMode = "nullable"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (m *OmitMode) Set(s string) error {
+	*m = OmitMode(s)
+	if *m == "" {
+		*m = OmitModeNone
+	}
+	if !supportedOmitModes[*m] {
+		return fmt.Errorf("omit mode %q not supported", *m)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (m OmitMode) String() string {
+	return string(m)
+}
+
+// LogLevel represents the minimum level of the log output.
+type LogLevel string
+
+// These are the LogLevel command line parameter.
+const (
+	LogLevelTrace LogLevel = "trace"
+	LogLevelDebug LogLevel = "debug"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
+	LogLevelError LogLevel = "error"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (l *LogLevel) Set(s string) error {
+	*l = LogLevel(s)
+	if _, ok := supportedLogLevels[*l]; !ok {
+		return fmt.Errorf("log level %q not supported", *l)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (l LogLevel) String() string {
+	return string(l)
+}
+
+// LogFormat represents the format of the log output.
+type LogFormat string
+
+// These are the LogFormat command line parameter.
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (f *LogFormat) Set(s string) error {
+	*f = LogFormat(s)
+	if *f == "" {
+		*f = LogFormatText
+	}
+	if !supportedLogFormats[*f] {
+		return fmt.Errorf("log format %q not supported", *f)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (f LogFormat) String() string {
+	return string(f)
+}
+
+// ListFormat represents the output format of the list-tables command.
+type ListFormat string
+
+// These are the ListFormat command line parameter.
+const (
+	ListFormatPlain ListFormat = "plain"
+	ListFormatJSON  ListFormat = "json"
+	ListFormatTable ListFormat = "table"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (f *ListFormat) Set(s string) error {
+	*f = ListFormat(s)
+	if *f == "" {
+		*f = ListFormatPlain
+	}
+	if !supportedListFormats[*f] {
+		return fmt.Errorf("list format %q not supported", *f)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (f ListFormat) String() string {
+	return string(f)
+}
+
+// FieldOrder represents the order of the fields of the generated structs.
+type FieldOrder string
+
+// These are the FieldOrder command line parameter.
+const (
+	FieldOrderOrdinal      FieldOrder = "ordinal"
+	FieldOrderAlphabetical FieldOrder = "alphabetical"
+	FieldOrderPKFirst      FieldOrder = "pk-first"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (f *FieldOrder) Set(s string) error {
+	*f = FieldOrder(s)
+	if *f == "" {
+		*f = FieldOrderOrdinal
+	}
+	if !supportedFieldOrders[*f] {
+		return fmt.Errorf("field order %q not supported", *f)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (f FieldOrder) String() string {
+	return string(f)
+}
+
+// Relations represents how the foreign keys of the tables get generated.
+type Relations string
+
+// These are the Relations command line parameter.
+const (
+	RelationsNone     Relations = "none"
+	RelationsComments Relations = "comments"
+	RelationsFields   Relations = "fields"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (r *Relations) Set(s string) error {
+	*r = Relations(s)
+	if *r == "" {
+		*r = RelationsNone
+	}
+	if !supportedRelations[*r] {
+		return fmt.Errorf("relations %q not supported", *r)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (r Relations) String() string {
+	return string(r)
+}
+
+// RenameStrategy represents how names colliding with Go keywords or the
+// identifiers of the generated code get renamed.
+type RenameStrategy string
+
+// These are the RenameStrategy command line parameter.
+const (
+	RenameStrategySuffix RenameStrategy = "suffix"
+	RenameStrategyPrefix RenameStrategy = "prefix"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (r *RenameStrategy) Set(s string) error {
+	*r = RenameStrategy(s)
+	if *r == "" {
+		*r = RenameStrategySuffix
+	}
+	if !supportedRenameStrategies[*r] {
+		return fmt.Errorf("rename strategy %q not supported", *r)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (r RenameStrategy) String() string {
+	return string(r)
+}
+
+// ArrayType represents the Go types of the array columns of Postgres.
+type ArrayType string
+
+// These are the ArrayType command line parameter.
+const (
+	ArrayTypePQ     ArrayType = "pq"
+	ArrayTypeNative ArrayType = "native"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (a *ArrayType) Set(s string) error {
+	*a = ArrayType(s)
+	if *a == "" {
+		*a = ArrayTypePQ
+	}
+	if !supportedArrayTypes[*a] {
+		return fmt.Errorf("array type %q not supported", *a)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (a ArrayType) String() string {
+	return string(a)
+}
+
+// CRUD represents the library the generated CRUD repositories of the tables
+// query the database with.
+type CRUD string
+
+// These are the CRUD command line parameter.
+const (
+	CRUDNone CRUD = "none"
+	CRUDSQL  CRUD = "sql"
+	CRUDSQLX CRUD = "sqlx"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (c *CRUD) Set(s string) error {
+	*c = CRUD(s)
+	if *c == "" {
+		*c = CRUDNone
+	}
+	if !supportedCRUDs[*c] {
+		return fmt.Errorf("crud %q not supported", *c)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (c CRUD) String() string {
+	return string(c)
+}
+
+// AuthMode represents how to authenticate at the database.
+type AuthMode string
+
+// These are the AuthMode command line parameter.
+const (
+	AuthModePassword AuthMode = "password"
+	AuthModeRDSIAM   AuthMode = "rds-iam"
+	AuthModeAzureCLI AuthMode = "azure-cli"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (a *AuthMode) Set(s string) error {
+	*a = AuthMode(s)
+	if *a == "" {
+		*a = AuthModePassword
+	}
+	if !supportedAuthModes[*a] {
+		return fmt.Errorf("auth mode %q not supported", *a)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (a AuthMode) String() string {
+	return string(a)
+}
+
+// GoVersion represents the oldest Go version the generated code has to
+// compile with.
+type GoVersion string
+
+// These are the GoVersion command line parameter.
+const (
+	GoVersion121 GoVersion = "1.21"
+	GoVersion122 GoVersion = "1.22"
+	GoVersion124 GoVersion = "1.24"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (v *GoVersion) Set(s string) error {
+	*v = GoVersion(strings.TrimPrefix(s, "go"))
+	if *v == "" {
+		*v = GoVersion121
+	}
+	if !supportedGoVersions[*v] {
+		return fmt.Errorf("go version %q not supported, supported are: %s", *v, SprintfSupportedGoVersions())
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (v GoVersion) String() string {
+	return string(v)
+}
+
+// AtLeast returns true if the version is the same as or newer than other.
+func (v GoVersion) AtLeast(other GoVersion) bool {
+	return goMinorVersion(v) >= goMinorVersion(other)
+}
+
+// goMinorVersion returns the minor version of the Go 1 version, e.g. 22 for
+// "1.22", or 0 if it is no Go 1 version.
+func goMinorVersion(v GoVersion) int {
+	minor, err := strconv.Atoi(strings.TrimPrefix(string(v), "1."))
+	if err != nil {
+		return 0
+	}
+	return minor
+}
+
 // StringsFlag can be used to specify multiple occurrences of a flag and hence
 // multiple values without having to split anything by a delimiter.
 type StringsFlag []string
@@ -132,3 +543,291 @@ func (s *StringsFlag) Set(val string) error {
 	*s = append(*s, vals...)
 	return nil
 }
+
+// MapFlag can be used to specify multiple key=value pairs, either by multiple
+// occurrences of a flag or comma separated.
+type MapFlag map[string]string
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (m *MapFlag) String() string {
+	return fmt.Sprintf("%v", map[string]string(*m))
+}
+
+// Set sets the key=value pairs for the MapFlag.
+func (m *MapFlag) Set(val string) error {
+	if *m == nil {
+		*m = MapFlag{}
+	}
+	for _, pair := range strings.Split(val, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid key=value pair %q", pair)
+		}
+		(*m)[key] = value
+	}
+	return nil
+}
+
+// RegexpsFlag can be used to specify multiple regular expressions by multiple
+// occurrences of a flag. The values are not split by commas since regular
+// expressions often contain them.
+type RegexpsFlag []*regexp.Regexp
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (r *RegexpsFlag) String() string {
+	patterns := make([]string, len(*r))
+	for i, re := range *r {
+		patterns[i] = re.String()
+	}
+	return fmt.Sprintf("%v", patterns)
+}
+
+// Set compiles and appends the regular expression for the RegexpsFlag.
+func (r *RegexpsFlag) Set(val string) error {
+	re, err := regexp.Compile(val)
+	if err != nil {
+		return fmt.Errorf("invalid regular expression %q: %w", val, err)
+	}
+	*r = append(*r, re)
+	return nil
+}
+
+// MatchString returns true if any of the regular expressions matches s.
+func (r RegexpsFlag) MatchString(s string) bool {
+	for _, re := range r {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ColumnExclude excludes the columns matching Column of the tables matching
+// Table, both regular expressions have to match the whole name.
+type ColumnExclude struct {
+	Table  *regexp.Regexp
+	Column *regexp.Regexp
+}
+
+// String returns the ColumnExclude in the format of the flag.
+func (c ColumnExclude) String() string {
+	column := unanchor(c.Column.String())
+	if c.Table == nil {
+		return column
+	}
+	return unanchor(c.Table.String()) + "=" + column
+}
+
+// Matches returns true if the column of the table is excluded.
+func (c ColumnExclude) Matches(table, column string) bool {
+	if c.Table != nil && !c.Table.MatchString(table) {
+		return false
+	}
+	return c.Column.MatchString(column)
+}
+
+// ColumnExcludesFlag can be used to specify multiple column exclusions of the
+// format [table=]column by multiple occurrences of a flag. Table and column
+// are names or regular expressions, without table the column is excluded
+// from all tables. The values are not split by commas since regular
+// expressions often contain them.
+type ColumnExcludesFlag []ColumnExclude
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (c *ColumnExcludesFlag) String() string {
+	return fmt.Sprintf("%v", []ColumnExclude(*c))
+}
+
+// Set parses and appends the column exclusion for the ColumnExcludesFlag.
+func (c *ColumnExcludesFlag) Set(val string) error {
+	var exclude ColumnExclude
+
+	table, column, ok := strings.Cut(val, "=")
+	if !ok {
+		table, column = "", val
+	}
+	if column == "" || (ok && table == "") {
+		return fmt.Errorf("invalid column exclusion %q, expected [table=]column", val)
+	}
+
+	var err error
+	if ok {
+		if exclude.Table, err = regexp.Compile(anchor(table)); err != nil {
+			return fmt.Errorf("invalid table of column exclusion %q: %w", val, err)
+		}
+	}
+	if exclude.Column, err = regexp.Compile(anchor(column)); err != nil {
+		return fmt.Errorf("invalid column of column exclusion %q: %w", val, err)
+	}
+
+	*c = append(*c, exclude)
+	return nil
+}
+
+// anchor makes the regular expression match the whole string only.
+func anchor(expr string) string {
+	return "^(?:" + expr + ")$"
+}
+
+// unanchor reverts anchor.
+func unanchor(expr string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(expr, "^(?:"), ")$")
+}
+
+// InflectionRule replaces the matches of Pattern in a table name by
+// Replacement, which may refer to submatches like $1.
+type InflectionRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// String returns the InflectionRule in the format of the flag.
+func (r InflectionRule) String() string {
+	return r.Pattern.String() + "=" + r.Replacement
+}
+
+// InflectionRulesFlag can be used to specify multiple inflection rules of the
+// format pattern=replacement by multiple occurrences of a flag. The values
+// are not split by commas since regular expressions often contain them.
+type InflectionRulesFlag []InflectionRule
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (r *InflectionRulesFlag) String() string {
+	return fmt.Sprintf("%v", []InflectionRule(*r))
+}
+
+// Set parses and appends the inflection rule for the InflectionRulesFlag.
+func (r *InflectionRulesFlag) Set(val string) error {
+	pattern, replacement, ok := strings.Cut(val, "=")
+	if !ok || pattern == "" {
+		return fmt.Errorf("invalid inflection rule %q, expected pattern=replacement", val)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern of inflection rule %q: %w", val, err)
+	}
+	*r = append(*r, InflectionRule{Pattern: re, Replacement: replacement})
+	return nil
+}
+
+// ExtraTag is a static tag added to the fields of all columns matching the
+// pattern.
+type ExtraTag struct {
+	// Pattern matches "table.column" with the syntax of path.Match.
+	Pattern string
+	Key     string
+	Value   string
+}
+
+// ExtraTagsFlag can be used to specify multiple static tags of the format
+// [pattern=]key:value by multiple occurrences of a flag. The values are not
+// split by commas since tag values often contain them.
+type ExtraTagsFlag []ExtraTag
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (e *ExtraTagsFlag) String() string {
+	return fmt.Sprintf("%v", []ExtraTag(*e))
+}
+
+// Set parses and appends the static tag for the ExtraTagsFlag.
+func (e *ExtraTagsFlag) Set(val string) error {
+	tag := ExtraTag{Pattern: "*.*"}
+
+	rest := val
+	colon := strings.Index(rest, ":")
+	if eq := strings.Index(rest, "="); eq >= 0 && eq < colon {
+		tag.Pattern, rest = rest[:eq], rest[eq+1:]
+		if _, err := path.Match(tag.Pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern of extra tag %q: %w", val, err)
+		}
+	}
+
+	key, value, ok := strings.Cut(rest, ":")
+	if !ok || key == "" || strings.ContainsAny(key, " \"`") {
+		return fmt.Errorf("invalid extra tag %q, expected [pattern=]key:value", val)
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	tag.Key, tag.Value = key, value
+
+	*e = append(*e, tag)
+	return nil
+}
+
+// CustomTag is a tag of the key whose value is generated per column from a
+// text/template.
+type CustomTag struct {
+	Key      string
+	Template string
+}
+
+// CustomTagsFlag can be used to specify multiple custom tags of the format
+// key:template by multiple occurrences of a flag. The values are not split by
+// commas since templates may contain them.
+type CustomTagsFlag []CustomTag
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (c *CustomTagsFlag) String() string {
+	return fmt.Sprintf("%v", []CustomTag(*c))
+}
+
+// Set parses and appends the custom tag for the CustomTagsFlag.
+func (c *CustomTagsFlag) Set(val string) error {
+	key, tmpl, ok := strings.Cut(val, ":")
+	if !ok || key == "" || strings.ContainsAny(key, " \"`") || strings.TrimSpace(tmpl) == "" {
+		return fmt.Errorf("invalid custom tag %q, expected key:template", val)
+	}
+	*c = append(*c, CustomTag{Key: key, Template: tmpl})
+	return nil
+}
+
+// TemplateFuncsFlag can be used to define functions of templates of the
+// format name=expression by multiple occurrences of a flag. The expression is
+// a template pipeline on the argument of the function as dot, e.g.
+// short=slice . 0 3. The values are not split by commas since expressions
+// may contain them.
+type TemplateFuncsFlag map[string]string
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (f *TemplateFuncsFlag) String() string {
+	return fmt.Sprintf("%v", map[string]string(*f))
+}
+
+// Set parses and adds the function for the TemplateFuncsFlag.
+func (f *TemplateFuncsFlag) Set(val string) error {
+	name, expression, ok := strings.Cut(val, "=")
+	if !ok || !isTemplateFuncName(name) || strings.TrimSpace(expression) == "" {
+		return fmt.Errorf("invalid template function %q, expected name=expression", val)
+	}
+	if *f == nil {
+		*f = TemplateFuncsFlag{}
+	}
+	(*f)[name] = expression
+	return nil
+}
+
+// isTemplateFuncName returns true if the name is a valid name of a template
+// function: letters, digits and underscores, not starting with a digit.
+func isTemplateFuncName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatterGo is the name of the built-in output formatter generating Go
+// structs, the default Formatter.
+const FormatterGo = "go"
@@ -2,6 +2,7 @@ package settings
 
 import (
 	"flag"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,3 +53,375 @@ func TestStringsFlag_Set(t *testing.T) {
 		})
 	}
 }
+
+func TestMapFlag_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		args     []string
+		expected MapFlag
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no flag, no values",
+			args:     []string{},
+			expected: nil,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "one flag",
+			args:     []string{"-name", "foo=bar"},
+			expected: MapFlag{"foo": "bar"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "multiple flags with comma separator",
+			args:     []string{"-name", "foo=bar,baz=qux", "-name", "quux=-"},
+			expected: MapFlag{"foo": "bar", "baz": "qux", "quux": "-"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty value is allowed",
+			args:     []string{"-name", "foo="},
+			expected: MapFlag{"foo": ""},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "missing separator produces error",
+			args:     []string{"-name", "foo"},
+			expected: MapFlag{},
+			isError:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var actual MapFlag
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&actual, "name", "")
+			err := fs.Parse(tt.args)
+			tt.isError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestExtraTagsFlag_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		args     []string
+		expected ExtraTagsFlag
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no flag, no values",
+			args:     []string{},
+			expected: nil,
+			isError:  assert.NoError,
+		},
+		{
+			desc: "tag without pattern applies to all columns",
+			args: []string{"-extra-tag", "validate:-"},
+			expected: ExtraTagsFlag{
+				{Pattern: "*.*", Key: "validate", Value: "-"},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "multiple flags with pattern, quoted value and commas",
+			args: []string{"-extra-tag", `users.*=audit:"true"`, "-extra-tag", "validate:required,max=5"},
+			expected: ExtraTagsFlag{
+				{Pattern: "users.*", Key: "audit", Value: "true"},
+				{Pattern: "*.*", Key: "validate", Value: "required,max=5"},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "missing separator produces error",
+			args:     []string{"-extra-tag", "validate"},
+			expected: nil,
+			isError:  assert.Error,
+		},
+		{
+			desc:     "invalid pattern produces error",
+			args:     []string{"-extra-tag", "users.[=validate:-"},
+			expected: nil,
+			isError:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var actual ExtraTagsFlag
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&actual, "extra-tag", "")
+			err := fs.Parse(tt.args)
+			tt.isError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCustomTagsFlag_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		args     []string
+		expected CustomTagsFlag
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc: "multiple custom tags keep their order",
+			args: []string{"-custom-tag", "bson:{{ .Column.Name }}", "-custom-tag", `db2:{{ .Column.Name }},{{ "a:b" }}`},
+			expected: CustomTagsFlag{
+				{Key: "bson", Template: "{{ .Column.Name }}"},
+				{Key: "db2", Template: `{{ .Column.Name }},{{ "a:b" }}`},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "missing template produces error",
+			args:     []string{"-custom-tag", "bson:"},
+			expected: nil,
+			isError:  assert.Error,
+		},
+		{
+			desc:     "invalid key produces error",
+			args:     []string{"-custom-tag", `"bson":{{ .Column.Name }}`},
+			expected: nil,
+			isError:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var actual CustomTagsFlag
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&actual, "custom-tag", "")
+			err := fs.Parse(tt.args)
+			tt.isError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestRegexpsFlag_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		args     []string
+		expected []string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no flag, no values",
+			args:     []string{},
+			expected: []string{},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "multiple flags, values with commas are not split",
+			args:     []string{"-include", "order_.*", "-include", "^t_[a-z]{1,3}$"},
+			expected: []string{"order_.*", "^t_[a-z]{1,3}$"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "invalid regular expression produces error",
+			args:     []string{"-include", "order_("},
+			expected: []string{},
+			isError:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var actual RegexpsFlag
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&actual, "include", "")
+			err := fs.Parse(tt.args)
+			tt.isError(t, err)
+
+			patterns := []string{}
+			for _, re := range actual {
+				patterns = append(patterns, re.String())
+			}
+			assert.Equal(t, tt.expected, patterns)
+		})
+	}
+}
+
+func TestColumnExcludesFlag_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		args     []string
+		expected []string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no flag, no values",
+			args:     []string{},
+			expected: []string{},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "columns with and without table",
+			args:     []string{"-exclude-column", "legacy_flag", "-exclude-column", "audit_.*=payload"},
+			expected: []string{"legacy_flag", "audit_.*=payload"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty column produces error",
+			args:     []string{"-exclude-column", "users="},
+			expected: []string{},
+			isError:  assert.Error,
+		},
+		{
+			desc:     "empty table produces error",
+			args:     []string{"-exclude-column", "=payload"},
+			expected: []string{},
+			isError:  assert.Error,
+		},
+		{
+			desc:     "invalid regular expression produces error",
+			args:     []string{"-exclude-column", "blob_("},
+			expected: []string{},
+			isError:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var actual ColumnExcludesFlag
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&actual, "exclude-column", "")
+			err := fs.Parse(tt.args)
+			tt.isError(t, err)
+
+			excludes := []string{}
+			for _, exclude := range actual {
+				excludes = append(excludes, exclude.String())
+			}
+			assert.Equal(t, tt.expected, excludes)
+		})
+	}
+}
+
+func TestInflectionRulesFlag_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		args     []string
+		expected []string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no flag, no values",
+			args:     []string{},
+			expected: []string{},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "multiple flags, values with commas are not split",
+			args:     []string{"-inflection", "(?i)schemata$=schema", "-inflection", "^(.{1,3})ae$=${1}a"},
+			expected: []string{"(?i)schemata$=schema", "^(.{1,3})ae$=${1}a"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "missing replacement produces error",
+			args:     []string{"-inflection", "schemata"},
+			expected: []string{},
+			isError:  assert.Error,
+		},
+		{
+			desc:     "invalid regular expression produces error",
+			args:     []string{"-inflection", "schem(=schema"},
+			expected: []string{},
+			isError:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var actual InflectionRulesFlag
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&actual, "inflection", "")
+			err := fs.Parse(tt.args)
+			tt.isError(t, err)
+
+			rules := []string{}
+			for _, rule := range actual {
+				rules = append(rules, rule.String())
+			}
+			assert.Equal(t, tt.expected, rules)
+		})
+	}
+}
+
+func TestTemplateFuncsFlag_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		args     []string
+		expected TemplateFuncsFlag
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "multiple flags, expressions with commas are not split",
+			args:     []string{"-func", "short=slice . 0 3", "-func", `id_name=printf "%s,%s" . "id"`},
+			expected: TemplateFuncsFlag{"short": "slice . 0 3", "id_name": `printf "%s,%s" . "id"`},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "missing expression produces error",
+			args:     []string{"-func", "short="},
+			expected: nil,
+			isError:  assert.Error,
+		},
+		{
+			desc:     "invalid name produces error",
+			args:     []string{"-func", "1short=slice . 0 3"},
+			expected: nil,
+			isError:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var actual TemplateFuncsFlag
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&actual, "func", "")
+			err := fs.Parse(tt.args)
+			tt.isError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestDBType_Dialect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dbType   DBType
+		expected DBType
+	}{
+		{dbType: DBTypePostgresql, expected: DBTypePostgresql},
+		{dbType: DBTypeMySQL, expected: DBTypeMySQL},
+		{dbType: DBTypeSQLite, expected: DBTypeSQLite},
+		{dbType: DBTypeOracle, expected: DBTypeOracle},
+		{dbType: DBTypeCockroachDB, expected: DBTypePostgresql},
+		{dbType: DBTypeMariaDB, expected: DBTypeMySQL},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.dbType), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.dbType.Dialect())
+		})
+	}
+}
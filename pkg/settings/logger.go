@@ -0,0 +1,65 @@
+package settings
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// LevelTrace is the level of the most verbose log output like the processed
+// columns, below slog.LevelDebug.
+const LevelTrace = slog.LevelDebug - 4
+
+// Level returns the minimum level of the log output: the given LogLevel or
+// the one derived from the verbosity.
+func (settings *Settings) Level() slog.Level {
+	if level, ok := supportedLogLevels[settings.LogLevel]; ok {
+		return level
+	}
+
+	switch {
+	case settings.Quiet:
+		return slog.LevelError
+	case settings.VVerbose:
+		return LevelTrace
+	case settings.Verbose:
+		return slog.LevelDebug
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// NewLogger creates the logger writing the log output in the LogFormat with
+// the Level of the settings to w.
+func (settings *Settings) NewLogger(w io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{
+		Level: settings.Level(),
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.LevelKey {
+				if level, ok := attr.Value.Any().(slog.Level); ok && level == LevelTrace {
+					attr.Value = slog.StringValue("TRACE")
+				}
+			}
+			return attr
+		},
+	}
+
+	if settings.LogFormat == LogFormatJSON {
+		return slog.New(slog.NewJSONHandler(w, options))
+	}
+
+	replaceLevel := options.ReplaceAttr
+	options.ReplaceAttr = func(groups []string, attr slog.Attr) slog.Attr {
+		// the time is noise in the human-readable output
+		if len(groups) == 0 && attr.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return replaceLevel(groups, attr)
+	}
+	return slog.New(slog.NewTextHandler(w, options))
+}
+
+// Trace logs at LevelTrace with the default logger.
+func Trace(msg string, args ...any) {
+	slog.Log(context.Background(), LevelTrace, msg, args...)
+}
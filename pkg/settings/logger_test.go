@@ -0,0 +1,97 @@
+package settings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettings_Level(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		expected slog.Level
+	}{
+		{
+			desc:     "default settings log info",
+			settings: New,
+			expected: slog.LevelInfo,
+		},
+		{
+			desc: "verbose logs debug",
+			settings: func() *Settings {
+				s := New()
+				s.Verbose = true
+				return s
+			},
+			expected: slog.LevelDebug,
+		},
+		{
+			desc: "more verbose logs trace",
+			settings: func() *Settings {
+				s := New()
+				s.Verbose = true
+				s.VVerbose = true
+				return s
+			},
+			expected: LevelTrace,
+		},
+		{
+			desc: "quiet logs errors only",
+			settings: func() *Settings {
+				s := New()
+				s.Quiet = true
+				return s
+			},
+			expected: slog.LevelError,
+		},
+		{
+			desc: "log level wins over the verbosity",
+			settings: func() *Settings {
+				s := New()
+				s.VVerbose = true
+				s.LogLevel = LogLevelWarn
+				return s
+			},
+			expected: slog.LevelWarn,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.settings().Level())
+		})
+	}
+}
+
+func TestSettings_NewLogger(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	s.LogLevel = LogLevelTrace
+	s.LogFormat = LogFormatJSON
+
+	var buf bytes.Buffer
+	logger := s.NewLogger(&buf)
+	logger.Log(context.Background(), LevelTrace, "column", "name", "id")
+
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "TRACE", entry["level"])
+	assert.Equal(t, "column", entry["msg"])
+	assert.Equal(t, "id", entry["name"])
+
+	buf.Reset()
+	s.LogFormat = LogFormatText
+	s.LogLevel = LogLevelInfo
+	logger = s.NewLogger(&buf)
+	logger.Debug("hidden")
+	logger.Info("tables", "count", 2)
+	assert.Equal(t, "level=INFO msg=tables count=2\n", buf.String())
+}
@@ -0,0 +1,23 @@
+package settings
+
+import "flag"
+
+// secretFlags are the flags whose values are left out of the set options.
+var secretFlags = map[string]struct{}{
+	"p":   {},
+	"dsn": {},
+}
+
+// SetOptions returns the values of the flags of the flag set which got set,
+// i.e. by the command line, environment variables or the config file, by
+// their names. The values of secret flags like the password are left out.
+func SetOptions(fs *flag.FlagSet) map[string]string {
+	options := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		if _, ok := secretFlags[f.Name]; ok {
+			return
+		}
+		options[f.Name] = f.Value.String()
+	})
+	return options
+}
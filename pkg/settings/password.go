@@ -0,0 +1,100 @@
+package settings
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// verifyPassword checks that the password is given by at most one source.
+func (settings *Settings) verifyPassword() error {
+	sources := 0
+	for _, given := range []bool{
+		settings.Pswd != "",
+		settings.PasswordFile != "",
+		settings.PasswordKeyring != "",
+		settings.PasswordSource != "",
+		settings.PasswordPrompt,
+	} {
+		if given {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("password can only be given by one of -p, -password-file, -password-keyring and -password-source")
+	}
+
+	if settings.UsesAuthToken() {
+		return settings.verifyAuthToken(sources)
+	}
+
+	if sources > 0 && settings.DSN != "" {
+		return fmt.Errorf("password can not be combined with -dsn, the DSN holds the password")
+	}
+
+	if settings.PasswordKeyring != "" && settings.User == "" {
+		return fmt.Errorf("password keyring needs the user given by -u to look up the password")
+	}
+
+	if settings.PasswordSource != "" {
+		if _, err := parsePasswordSource(settings.PasswordSource); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadPassword sets the password from the password file, the OS keyring or
+// the secrets manager, if given, or to the token of the RDS IAM or Azure CLI
+// authentication. The password file holds the password only, trailing line
+// breaks are ignored.
+func (settings *Settings) ReadPassword() error {
+	switch {
+	case settings.PasswordFile != "":
+		content, err := os.ReadFile(settings.PasswordFile)
+		if err != nil {
+			return fmt.Errorf("could not read password file: %w", err)
+		}
+		settings.Pswd = strings.TrimRight(string(content), "\r\n")
+	case settings.UsesAuthToken():
+		return settings.readAuthToken()
+	case settings.PasswordSource != "":
+		return settings.readPasswordSource()
+	case settings.PasswordKeyring != "":
+		name, args, err := keyringCommand(runtime.GOOS, settings.PasswordKeyring, settings.User)
+		if err != nil {
+			return err
+		}
+		var stderr bytes.Buffer
+		cmd := exec.Command(name, args...)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+			return fmt.Errorf("could not look up password of user %q in keyring service %q: %w",
+				settings.User, settings.PasswordKeyring, err)
+		}
+		settings.Pswd = strings.TrimRight(string(out), "\r\n")
+	}
+	return nil
+}
+
+// keyringCommand returns the command printing the password of the user
+// stored for the service in the keyring of the OS: the login keychain on
+// macOS and the Secret Service (e.g. GNOME Keyring or KWallet) elsewhere.
+func keyringCommand(goos, service, user string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		return "security", []string{"find-generic-password", "-s", service, "-a", user, "-w"}, nil
+	case "windows", "plan9":
+		return "", nil, fmt.Errorf("password keyring not supported on %s, use -password-file instead", goos)
+	default:
+		return "secret-tool", []string{"lookup", "service", service, "username", user}, nil
+	}
+}
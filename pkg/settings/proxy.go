@@ -0,0 +1,37 @@
+package settings
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// verifyProxy checks the URL of the proxy the connections get dialed
+// through.
+func (settings *Settings) verifyProxy() error {
+	if settings.Proxy == "" {
+		return nil
+	}
+
+	u, err := url.Parse(settings.Proxy)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "socks5", "socks5h", "http":
+	default:
+		return fmt.Errorf("proxy scheme %q not supported, use socks5, socks5h or http", u.Scheme)
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return fmt.Errorf("proxy URL %q needs the host and port of the proxy", u.Redacted())
+	}
+
+	if settings.SSHHost != "" || settings.Socket != "" {
+		return fmt.Errorf("proxy can not be combined with -ssh-host or -socket")
+	}
+
+	if settings.Plugin == "" && settings.DbType.Dialect() != DBTypePostgresql && settings.DbType.Dialect() != DBTypeMySQL {
+		return fmt.Errorf("proxy is not supported by %s", settings.DbType)
+	}
+
+	return nil
+}
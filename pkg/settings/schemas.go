@@ -0,0 +1,111 @@
+package settings
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// SchemaOutput is the output path and package of the structs of a schema and
+// the prefix of their file and struct names, replacing Prefix if given.
+type SchemaOutput struct {
+	OutputFilePath string `yaml:"of" toml:"of"`
+	PackageName    string `yaml:"pn" toml:"pn"`
+	Prefix         string `yaml:"prefix" toml:"prefix"`
+}
+
+// prepareSchemas adds the schemas of SchemaNames to Schemas, defaults the
+// output path of the schemas to a directory named like the schema in the
+// output path, their package to the name of the output directory and, by
+// SchemaPrefix, their prefix to the name of the schema, and verifies the
+// output paths.
+func (settings *Settings) prepareSchemas() (err error) {
+	for _, name := range settings.SchemaNames {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if settings.Schemas == nil {
+			settings.Schemas = make(map[string]SchemaOutput)
+		}
+		if _, ok := settings.Schemas[name]; !ok {
+			settings.Schemas[name] = SchemaOutput{}
+		}
+	}
+
+	if len(settings.Schemas) == 0 {
+		return nil
+	}
+
+	if settings.DbType == DBTypeSQLite {
+		return fmt.Errorf("schemas are not supported for %s", settings.DbType)
+	}
+
+	if settings.Watch {
+		return fmt.Errorf("schemas can not be combined with watch mode")
+	}
+
+	for name, out := range settings.Schemas {
+		if out.OutputFilePath == "" {
+			out.OutputFilePath = filepath.Join(settings.OutputFilePath, name)
+		}
+		if err = verifyOutputPath(out.OutputFilePath); err != nil {
+			return fmt.Errorf("schema %q: %w", name, err)
+		}
+		if out.OutputFilePath, err = prepareOutputPath(out.OutputFilePath); err != nil {
+			return fmt.Errorf("schema %q: %w", name, err)
+		}
+		if out.PackageName == "" {
+			out.PackageName = filepath.Base(out.OutputFilePath)
+		}
+		if out.Prefix == "" && settings.SchemaPrefix {
+			out.Prefix = name + "_"
+		}
+		settings.Schemas[name] = out
+	}
+
+	return nil
+}
+
+// ForEachSchema calls fn once per schema of Schemas in the order of their
+// names, with Schema, OutputFilePath and PackageName set to the ones of the
+// schema and Prefix to the one of the schema if given. The database of MySQL
+// is its schema, so DbName gets set as well. Without Schemas fn is called
+// once with the settings as they are.
+func (settings *Settings) ForEachSchema(fn func() error) error {
+	if len(settings.Schemas) == 0 {
+		return fn()
+	}
+
+	schema, dbName := settings.Schema, settings.DbName
+	outputFilePath, packageName, prefix := settings.OutputFilePath, settings.PackageName, settings.Prefix
+	defer func() {
+		settings.Schema, settings.DbName = schema, dbName
+		settings.OutputFilePath, settings.PackageName, settings.Prefix = outputFilePath, packageName, prefix
+	}()
+
+	for _, name := range slices.Sorted(maps.Keys(settings.Schemas)) {
+		out := settings.Schemas[name]
+
+		settings.Schema = name
+		if settings.DbType.Dialect() == DBTypeMySQL {
+			settings.DbName = name
+		}
+		settings.OutputFilePath = out.OutputFilePath
+		settings.PackageName = out.PackageName
+		settings.Prefix = prefix
+		if out.Prefix != "" {
+			settings.Prefix = out.Prefix
+		}
+
+		slog.Info("generating schema", "schema", name, "path", out.OutputFilePath, "package", out.PackageName)
+
+		if err := fn(); err != nil {
+			return fmt.Errorf("schema %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package settings
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettings_prepareSchemas(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "billing"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal", "auth"), 0755))
+
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		expected map[string]SchemaOutput
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no schemas",
+			settings: New,
+			expected: nil,
+			isError:  assert.NoError,
+		},
+		{
+			desc: "output path and package default to the schema",
+			settings: func() *Settings {
+				s := New()
+				s.OutputFilePath = dir
+				s.Schemas = map[string]SchemaOutput{"billing": {}}
+				return s
+			},
+			expected: map[string]SchemaOutput{
+				"billing": {
+					OutputFilePath: filepath.Join(dir, "billing") + string(filepath.Separator),
+					PackageName:    "billing",
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "package defaults to the name of the output path",
+			settings: func() *Settings {
+				s := New()
+				s.Schemas = map[string]SchemaOutput{
+					"auth_v2": {OutputFilePath: filepath.Join(dir, "internal", "auth")},
+					"billing": {OutputFilePath: filepath.Join(dir, "billing"), PackageName: "invoices"},
+				}
+				return s
+			},
+			expected: map[string]SchemaOutput{
+				"auth_v2": {
+					OutputFilePath: filepath.Join(dir, "internal", "auth") + string(filepath.Separator),
+					PackageName:    "auth",
+				},
+				"billing": {
+					OutputFilePath: filepath.Join(dir, "billing") + string(filepath.Separator),
+					PackageName:    "invoices",
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "schemas of the flag get added to the ones of the config",
+			settings: func() *Settings {
+				s := New()
+				s.OutputFilePath = dir
+				s.Schemas = map[string]SchemaOutput{"auth_v2": {OutputFilePath: filepath.Join(dir, "internal", "auth")}}
+				s.SchemaNames = StringsFlag{"billing", "auth_v2"}
+				return s
+			},
+			expected: map[string]SchemaOutput{
+				"auth_v2": {
+					OutputFilePath: filepath.Join(dir, "internal", "auth") + string(filepath.Separator),
+					PackageName:    "auth",
+				},
+				"billing": {
+					OutputFilePath: filepath.Join(dir, "billing") + string(filepath.Separator),
+					PackageName:    "billing",
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "prefix defaults to the schema",
+			settings: func() *Settings {
+				s := New()
+				s.OutputFilePath = dir
+				s.SchemaPrefix = true
+				s.Schemas = map[string]SchemaOutput{"auth_v2": {OutputFilePath: filepath.Join(dir, "internal", "auth"), Prefix: "auth_"}}
+				s.SchemaNames = StringsFlag{"billing"}
+				return s
+			},
+			expected: map[string]SchemaOutput{
+				"auth_v2": {
+					OutputFilePath: filepath.Join(dir, "internal", "auth") + string(filepath.Separator),
+					PackageName:    "auth",
+					Prefix:         "auth_",
+				},
+				"billing": {
+					OutputFilePath: filepath.Join(dir, "billing") + string(filepath.Separator),
+					PackageName:    "billing",
+					Prefix:         "billing_",
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "missing output path produces error",
+			settings: func() *Settings {
+				s := New()
+				s.OutputFilePath = dir
+				s.Schemas = map[string]SchemaOutput{"sales": {}}
+				return s
+			},
+			expected: map[string]SchemaOutput{"sales": {}},
+			isError:  assert.Error,
+		},
+		{
+			desc: "sqlite produces error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeSQLite
+				s.Schemas = map[string]SchemaOutput{"billing": {}}
+				return s
+			},
+			expected: map[string]SchemaOutput{"billing": {}},
+			isError:  assert.Error,
+		},
+		{
+			desc: "watch mode produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Watch = true
+				s.Schemas = map[string]SchemaOutput{"billing": {}}
+				return s
+			},
+			expected: map[string]SchemaOutput{"billing": {}},
+			isError:  assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			s := test.settings()
+			err := s.prepareSchemas()
+			test.isError(t, err)
+			assert.Equal(t, test.expected, s.Schemas)
+		})
+	}
+}
+
+func TestSettings_ForEachSchema(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		schema, dbName, outputFilePath, packageName string
+	}
+
+	t.Run("without schemas the settings are used as they are", func(t *testing.T) {
+		t.Parallel()
+
+		s := New()
+		var calls []call
+		err := s.ForEachSchema(func() error {
+			calls = append(calls, call{s.Schema, s.DbName, s.OutputFilePath, s.PackageName})
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []call{{s.Schema, s.DbName, s.OutputFilePath, s.PackageName}}, calls)
+	})
+
+	t.Run("schemas in order of their names", func(t *testing.T) {
+		t.Parallel()
+
+		s := New()
+		s.DbType = DBTypeMySQL
+		s.DbName = "shop"
+		s.Schemas = map[string]SchemaOutput{
+			"billing": {OutputFilePath: "/models/billing/", PackageName: "billing"},
+			"auth":    {OutputFilePath: "/models/auth/", PackageName: "auth"},
+		}
+		expected := *s
+
+		var calls []call
+		err := s.ForEachSchema(func() error {
+			calls = append(calls, call{s.Schema, s.DbName, s.OutputFilePath, s.PackageName})
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []call{
+			{"auth", "auth", "/models/auth/", "auth"},
+			{"billing", "billing", "/models/billing/", "billing"},
+		}, calls)
+
+		// the settings get restored
+		assert.Equal(t, expected, *s)
+	})
+
+	t.Run("prefix of the schema replaces the prefix", func(t *testing.T) {
+		t.Parallel()
+
+		s := New()
+		s.Prefix = "db_"
+		s.Schemas = map[string]SchemaOutput{
+			"auth":    {OutputFilePath: "/models/auth/", PackageName: "auth"},
+			"billing": {OutputFilePath: "/models/billing/", PackageName: "billing", Prefix: "billing_"},
+		}
+
+		var prefixes []string
+		err := s.ForEachSchema(func() error {
+			prefixes = append(prefixes, s.Prefix)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"db_", "billing_"}, prefixes)
+		assert.Equal(t, "db_", s.Prefix)
+	})
+
+	t.Run("error stops at the failing schema", func(t *testing.T) {
+		t.Parallel()
+
+		s := New()
+		s.Schemas = map[string]SchemaOutput{
+			"auth":    {OutputFilePath: "/models/auth/", PackageName: "auth"},
+			"billing": {OutputFilePath: "/models/billing/", PackageName: "billing"},
+		}
+
+		var calls []string
+		err := s.ForEachSchema(func() error {
+			calls = append(calls, s.Schema)
+			return errors.New("failed")
+		})
+		assert.EqualError(t, err, `schema "auth": failed`)
+		assert.Equal(t, []string{"auth"}, calls)
+		assert.Equal(t, "public", s.Schema)
+	})
+}
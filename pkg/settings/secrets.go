@@ -0,0 +1,124 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// The kinds of the secrets managers of the PasswordSource.
+const (
+	secretsManagerVault = "vault"
+	secretsManagerAWS   = "aws-sm"
+)
+
+// secretsManagerNames are the names of the secrets managers in errors.
+var secretsManagerNames = map[string]string{
+	secretsManagerVault: "HashiCorp Vault",
+	secretsManagerAWS:   "AWS Secrets Manager",
+}
+
+// secretRef references the password in a secrets manager: the secret and its
+// field holding the password, if any.
+type secretRef struct {
+	manager string
+	secret  string
+	field   string
+}
+
+// parsePasswordSource parses the PasswordSource, <manager>:<secret>#<field>:
+// vault:secret/db#password or aws-sm:prod/db#password. The field of Vault
+// defaults to password, without field the whole secret of AWS Secrets
+// Manager is the password.
+func parsePasswordSource(source string) (secretRef, error) {
+	manager, secret, ok := strings.Cut(source, ":")
+	if _, known := secretsManagerNames[manager]; !ok || !known {
+		return secretRef{}, fmt.Errorf("password source %q is none of vault:<path>#<field> and aws-sm:<secret>#<key>", source)
+	}
+
+	secret, field, _ := strings.Cut(secret, "#")
+	if secret == "" {
+		return secretRef{}, fmt.Errorf("password source %q needs the secret", source)
+	}
+	if strings.HasPrefix(secret, "-") {
+		return secretRef{}, fmt.Errorf("password source %q: the secret can not start with -", source)
+	}
+	if field == "" && manager == secretsManagerVault {
+		field = "password"
+	}
+	return secretRef{manager: manager, secret: secret, field: field}, nil
+}
+
+// secretCommand returns the command printing the secret by the CLI of the
+// secrets manager with the credentials it is configured with: VAULT_ADDR and
+// VAULT_TOKEN (or the token helper) of the vault CLI and the default
+// credential chain of the aws CLI in AWSRegion, if given. The secret is never
+// an argument of its own the CLI could take for an option.
+func secretCommand(ref secretRef, settings *Settings) (string, []string) {
+	if ref.manager == secretsManagerVault {
+		return "vault", []string{"kv", "get", "-field=" + ref.field, "--", ref.secret}
+	}
+
+	args := []string{
+		"secretsmanager", "get-secret-value",
+		"--secret-id=" + ref.secret,
+		"--query", "SecretString",
+		"--output", "text",
+	}
+	if settings.AWSRegion != "" {
+		args = append(args, "--region", settings.AWSRegion)
+	}
+	return "aws", args
+}
+
+// readPasswordSource sets the password to the one fetched from the secrets
+// manager of the PasswordSource.
+func (settings *Settings) readPasswordSource() error {
+	ref, err := parsePasswordSource(settings.PasswordSource)
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	name, args := secretCommand(ref, settings)
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return fmt.Errorf("could not fetch password %q from %s: %w",
+			settings.PasswordSource, secretsManagerNames[ref.manager], err)
+	}
+
+	secret := strings.TrimRight(string(out), "\r\n")
+	if ref.manager == secretsManagerAWS && ref.field != "" {
+		if secret, err = secretField(secret, ref.field); err != nil {
+			return fmt.Errorf("could not read password %q from %s: %w",
+				settings.PasswordSource, secretsManagerNames[ref.manager], err)
+		}
+	}
+	settings.Pswd = secret
+	return nil
+}
+
+// secretField returns the field of the secret, a JSON object like the ones
+// of the credentials of RDS managed by AWS Secrets Manager.
+func secretField(secret, field string) (string, error) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("secret is no JSON object of key %q", field)
+	}
+	value, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("secret has no key %q", field)
+	}
+	password, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q of the secret is no string", field)
+	}
+	return password, nil
+}
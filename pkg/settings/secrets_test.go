@@ -0,0 +1,139 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePasswordSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		source   string
+		expected secretRef
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "vault with field",
+			source:   "vault:secret/db#pass",
+			expected: secretRef{manager: "vault", secret: "secret/db", field: "pass"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "vault defaults to field password",
+			source:   "vault:database/static-creds/shop",
+			expected: secretRef{manager: "vault", secret: "database/static-creds/shop", field: "password"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "aws secrets manager with key",
+			source:   "aws-sm:arn:aws:secretsmanager:eu-central-1:123456789012:secret:prod/db-AbCdEf#password",
+			expected: secretRef{manager: "aws-sm", secret: "arn:aws:secretsmanager:eu-central-1:123456789012:secret:prod/db-AbCdEf", field: "password"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "aws secrets manager without key",
+			source:   "aws-sm:prod/db",
+			expected: secretRef{manager: "aws-sm", secret: "prod/db"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:    "unknown secrets manager produces error",
+			source:  "gcp-sm:projects/shop/secrets/db",
+			isError: assert.Error,
+		},
+		{
+			desc:    "no secrets manager produces error",
+			source:  "secret/db#password",
+			isError: assert.Error,
+		},
+		{
+			desc:    "secret starting with - produces error",
+			source:  "vault:-address=https://evil.example.com#password",
+			isError: assert.Error,
+		},
+		{
+			desc:    "no secret produces error",
+			source:  "vault:#password",
+			isError: assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			ref, err := parsePasswordSource(test.source)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, ref)
+		})
+	}
+}
+
+func TestSecretCommand(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	name, args := secretCommand(secretRef{manager: "vault", secret: "secret/db", field: "password"}, s)
+	assert.Equal(t, "vault", name)
+	assert.Equal(t, []string{"kv", "get", "-field=password", "--", "secret/db"}, args)
+
+	s.AWSRegion = "eu-central-1"
+	name, args = secretCommand(secretRef{manager: "aws-sm", secret: "prod/db", field: "password"}, s)
+	assert.Equal(t, "aws", name)
+	assert.Equal(t, []string{
+		"secretsmanager", "get-secret-value",
+		"--secret-id=prod/db",
+		"--query", "SecretString",
+		"--output", "text",
+		"--region", "eu-central-1",
+	}, args)
+}
+
+func TestSecretField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		secret   string
+		field    string
+		expected string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "key of the credentials of RDS",
+			secret:   `{"engine":"postgres","port":5432,"username":"shop","password":"s3cr3t\"!"}`,
+			field:    "password",
+			expected: `s3cr3t"!`,
+			isError:  assert.NoError,
+		},
+		{
+			desc:    "missing key produces error",
+			secret:  `{"username":"shop"}`,
+			field:   "password",
+			isError: assert.Error,
+		},
+		{
+			desc:    "key of no string produces error",
+			secret:  `{"port":5432}`,
+			field:   "port",
+			isError: assert.Error,
+		},
+		{
+			desc:    "no JSON produces error",
+			secret:  "s3cr3t",
+			field:   "password",
+			isError: assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			password, err := secretField(test.secret, test.field)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, password)
+		})
+	}
+}
@@ -2,17 +2,25 @@ package settings
 
 import (
 	"fmt"
+	"go/token"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 )
 
 var (
 	// SupportedDbTypes represents the supported databases
 	SupportedDbTypes = map[DBType]bool{
-		DBTypePostgresql: true,
-		DBTypeMySQL:      true,
-		DBTypeSQLite:     true,
-		DBTypeOracle:     true,
+		DBTypePostgresql:  true,
+		DBTypeMySQL:       true,
+		DBTypeSQLite:      true,
+		DBTypeOracle:      true,
+		DBTypeCockroachDB: true,
+		DBTypeMariaDB:     true,
 	}
 
 	// supportedOutputFormats represents the supported output formats
@@ -23,10 +31,12 @@ var (
 
 	// dbDefaultPorts maps the database type to the default ports
 	dbDefaultPorts = map[DBType]string{
-		DBTypePostgresql: "5432",
-		DBTypeMySQL:      "3306",
-		DBTypeSQLite:     "",
-		DBTypeOracle:     "1521",
+		DBTypePostgresql:  "5432",
+		DBTypeMySQL:       "3306",
+		DBTypeSQLite:      "",
+		DBTypeOracle:      "1521",
+		DBTypeCockroachDB: "26257",
+		DBTypeMariaDB:     "3306",
 	}
 
 	// supportedNullTypes represents the supported types of NULL types
@@ -34,6 +44,111 @@ var (
 		NullTypeSQL:       true,
 		NullTypeNative:    true,
 		NullTypePrimitive: true,
+		NullTypePointer:   true,
+		NullTypeJSON:      true,
+		NullTypeGuregu:    true,
+	}
+
+	// supportedTagNameFormats represents the supported naming strategies of
+	// serialization tags
+	supportedTagNameFormats = map[TagNameFormat]bool{
+		TagNameFormatCamelCase: true,
+		TagNameFormatSnakeCase: true,
+		TagNameFormatOriginal:  true,
+	}
+
+	// supportedDbTagCases represents the supported cases of the db-tags
+	supportedDbTagCases = map[DbTagCase]bool{
+		DbTagCaseOriginal: true,
+		DbTagCaseLower:    true,
+		DbTagCaseQuoted:   true,
+	}
+
+	// supportedOmitModes represents the supported modes of omit tag options
+	supportedOmitModes = map[OmitMode]bool{
+		OmitModeNone:     true,
+		OmitModeAll:      true,
+		OmitModeNullable: true,
+	}
+
+	// supportedLogLevels maps the supported log levels to the slog levels
+	supportedLogLevels = map[LogLevel]slog.Level{
+		LogLevelTrace: LevelTrace,
+		LogLevelDebug: slog.LevelDebug,
+		LogLevelInfo:  slog.LevelInfo,
+		LogLevelWarn:  slog.LevelWarn,
+		LogLevelError: slog.LevelError,
+	}
+
+	// supportedLogFormats represents the supported formats of the log output
+	supportedLogFormats = map[LogFormat]bool{
+		LogFormatText: true,
+		LogFormatJSON: true,
+	}
+
+	// defaultSensitiveColumns are the patterns of sensitive column names used
+	// if none were given
+	defaultSensitiveColumns = []string{"*password*", "*secret*", "*token*"}
+
+	// supportedListFormats represents the supported formats of list-tables
+	supportedListFormats = map[ListFormat]bool{
+		ListFormatPlain: true,
+		ListFormatJSON:  true,
+		ListFormatTable: true,
+	}
+
+	// supportedFieldOrders represents the supported orders of the struct
+	// fields
+	supportedFieldOrders = map[FieldOrder]bool{
+		FieldOrderOrdinal:      true,
+		FieldOrderAlphabetical: true,
+		FieldOrderPKFirst:      true,
+	}
+
+	// supportedRelations represents the supported ways to generate the
+	// foreign keys
+	supportedRelations = map[Relations]bool{
+		RelationsNone:     true,
+		RelationsComments: true,
+		RelationsFields:   true,
+	}
+
+	// supportedRenameStrategies represents the supported strategies renaming
+	// colliding names
+	supportedRenameStrategies = map[RenameStrategy]bool{
+		RenameStrategySuffix: true,
+		RenameStrategyPrefix: true,
+	}
+
+	// supportedArrayTypes represents the supported Go types of the array
+	// columns
+	supportedArrayTypes = map[ArrayType]bool{
+		ArrayTypePQ:     true,
+		ArrayTypeNative: true,
+	}
+
+	// supportedCRUDs represents the supported libraries of the generated
+	// CRUD repositories
+	supportedCRUDs = map[CRUD]bool{
+		CRUDNone: true,
+		CRUDSQL:  true,
+		CRUDSQLX: true,
+	}
+
+	// supportedAuthModes represents the supported ways to authenticate at
+	// the database
+	supportedAuthModes = map[AuthMode]bool{
+		AuthModePassword: true,
+		AuthModeRDSIAM:   true,
+		AuthModeAzureCLI: true,
+	}
+
+	// supportedGoVersions represents the supported oldest Go versions of the
+	// generated code
+	supportedGoVersions = map[GoVersion]bool{
+		GoVersion121: true,
+		GoVersion122: true,
+		GoVersion124: true,
 	}
 
 	// supportedFileNameFormats represents the supported filename formats
@@ -47,8 +162,20 @@ var (
 type Settings struct {
 	Verbose  bool
 	VVerbose bool
+	Quiet    bool
 	Force    bool // continue through errors
 
+	// ContinueOnError continues through the failures of tables like Force
+	// but fails the run at its end with all of them
+	ContinueOnError bool
+
+	// LogLevel overrides the level derived from Verbose, VVerbose and Quiet
+	LogLevel  LogLevel
+	LogFormat LogFormat
+
+	ConfigFile string
+	Profile    string
+
 	DbType DBType
 
 	User    string
@@ -61,25 +188,355 @@ type Settings struct {
 	Socket  string
 	Tables  StringsFlag
 
+	// DSN is the driver specific data source name to connect with instead
+	// of the one built from the connection settings
+	DSN string
+
+	// SSHHost is the bastion host, optionally with its port, the connection
+	// to the database gets tunneled through by the ssh client of the system,
+	// logging in as SSHUser with the private key file SSHKey, if given
+	SSHHost string
+	SSHUser string
+	SSHKey  string
+
+	// Proxy is the URL of the SOCKS5 proxy (socks5, or socks5h resolving the
+	// host by the proxy) or HTTP proxy (http, by CONNECT) the connections to
+	// the database get dialed through, with its user and password, if any
+	Proxy string
+
+	// SSLCert and SSLKey are the files of the client certificate and its
+	// private key, SSLRootCert the one of the CA certificates the server
+	// certificate gets verified by, all in PEM format
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+
+	// KrbSrvName is the Kerberos service name of the Postgres server, the
+	// SPN is KrbSrvName/host, or KrbSPN is the whole SPN, for the GSSAPI
+	// authentication
+	KrbSrvName string
+	KrbSPN     string
+
+	// PasswordFile, PasswordKeyring (the service of the password in the
+	// OS keyring) and PasswordSource (the secret of the password in a
+	// secrets manager) are read into Pswd by ReadPassword, PasswordPrompt
+	// asks for it instead
+	PasswordFile    string
+	PasswordKeyring string
+	PasswordSource  string
+	PasswordPrompt  bool
+
+	// Auth is how to authenticate at the database: by the password, by a
+	// short-lived token of the AWS RDS IAM authentication, generated by the
+	// aws CLI in AWSRegion, if given, or by an access token of the Azure
+	// databases for PostgreSQL and MySQL, printed by the Azure CLI of
+	// AzureTenant, if given
+	Auth        AuthMode
+	AWSRegion   string
+	AzureTenant string
+
+	// TablesInclude and TablesExclude filter the tables by their names
+	TablesInclude RegexpsFlag
+	TablesExclude RegexpsFlag
+
+	// IncludeViews generates the views, and the materialized views of
+	// Postgres and Oracle, along with the tables, OnlyViews them instead of
+	// the tables
+	IncludeViews bool
+	OnlyViews    bool
+
+	// Interactive lets the user select the tables to generate
+	Interactive bool
+
+	// Watch polls the schema every WatchInterval and regenerates the
+	// affected files
+	Watch         bool
+	WatchInterval time.Duration
+
+	// Progress reports the processed tables during the generation
+	Progress bool
+
+	// Timeout limits the whole run, zero means no timeout
+	Timeout time.Duration
+
+	// Retries is the number of retries of failing connects and introspection
+	// queries, waiting RetryDelay before the first one and doubling it for
+	// each further one
+	Retries    int
+	RetryDelay time.Duration
+
+	// Jobs is the number of tables whose columns get introspected in
+	// parallel
+	Jobs int
+
+	// Snapshot runs the introspection in one read-only transaction, by the
+	// databases supporting it
+	Snapshot bool
+
+	// MaxOpenConns, MaxIdleConns and ConnMaxLifetime tune the pool of the
+	// connections to the database, zero keeps the defaults of database/sql
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+
+	// ConnectTimeout limits every attempt of connecting to the database and
+	// every ping of the keepalive, zero means none
+	ConnectTimeout time.Duration
+
+	// Keepalive pings the database in this interval during the run and
+	// closes the connections idle for longer, so idle timeouts of cloud
+	// databases or firewalls do not drop the connections in use, zero
+	// disables it
+	Keepalive time.Duration
+
+	// BatchColumns gets the columns of all tables in one query instead of
+	// one query per table, by the databases supporting it
+	BatchColumns bool
+
+	// PgCatalog introspects Postgres by its system catalogs instead of the
+	// views of the information_schema
+	PgCatalog bool
+
+	// DryRun reports what would be generated without writing anything
+	DryRun bool
+
+	// LogQueries logs every statement executed against the database with
+	// its arguments and duration
+	LogQueries bool
+
+	// Manifest writes the manifest of the generated files and the options
+	// used into the output path
+	Manifest bool
+
+	// Incremental writes only the structs of the tables changed since the
+	// previous run, whose state gets written into the output path
+	Incremental bool
+
+	// CacheDir is the directory caching the introspected schema, runs
+	// generate from it instead of introspecting while it is younger than
+	// CacheTTL, zero never expires. NoCache introspects anyway and refreshes
+	// the cache. Empty caches nothing
+	CacheDir string
+	CacheTTL time.Duration
+	NoCache  bool
+
+	// EmitIR is the file the introspected schema gets written to as JSON,
+	// empty writes none
+	EmitIR string
+
+	// FromIR is the file of an emitted schema to generate from instead of
+	// connecting to the database, empty connects
+	FromIR string
+
+	// Stdout writes the generated files to stdout instead of the output
+	// path
+	Stdout bool
+
+	// FromSQL are the SQL files or directories of migrations whose CREATE
+	// TABLE statements to generate from instead of connecting to the
+	// database, empty connects
+	FromSQL StringsFlag
+
+	// FromDBML is the DBML file whose tables to generate from instead of
+	// connecting to the database, empty connects
+	FromDBML string
+
+	// Plugin is the plugin binary of a dialect connecting to the database
+	// instead of the built-in ones, empty uses the built-in ones
+	Plugin string
+
+	// ListFormat is the output format of the list-tables command,
+	// ListDetails adds the number of rows and the comment of the tables
+	ListFormat  ListFormat
+	ListDetails bool
+
+	// ColumnsExclude are the columns left out of the generated structs
+	ColumnsExclude ColumnExcludesFlag
+
+	// IgnoreMarker leaves out the tables and columns whose comment in the
+	// database contains it, empty disables it
+	IgnoreMarker string
+
 	OutputFilePath string
 	OutputFormat   OutputFormat
 
+	// Formatter is the name of the output formatter turning the tables into
+	// files, FormatterGo or a registered one
+	Formatter string
+
+	// Template is the file of the text/template rendering the struct of a
+	// table instead of the built-in layout
+	Template string
+
+	// Schemas maps the schemas to generate in one run to their output path
+	// and package, replacing Schema, OutputFilePath and PackageName
+	Schemas map[string]SchemaOutput
+	// SchemaNames are the schemas given by -schema, added to Schemas with
+	// their default output path and package
+	SchemaNames StringsFlag
+	// SchemaPrefix prefixes the file and struct names of the schemas without
+	// prefix of their own by the name of the schema
+	SchemaPrefix bool
+
 	FileNameFormat FileNameFormat
 	PackageName    string
-	Prefix         string
-	Suffix         string
-	Null           NullType
+
+	// Strict fails the generation if any column has a type without mapping
+	// instead of generating a string field
+	Strict bool
+
+	// FieldOrder is the order of the struct fields: the ordinal position of
+	// the columns, alphabetical or the primary key columns first
+	FieldOrder FieldOrder
+
+	// Relations generates the foreign keys of the tables: none, comments
+	// naming the referenced columns above the fields of the foreign keys or
+	// additionally fields of the structs of the referenced tables
+	Relations Relations
+
+	// WithComments writes the comments of the tables and columns in the
+	// database as doc comments of the structs and their fields
+	WithComments bool
+
+	// WithColumns generates constants of the column names and the methods
+	// Columns, Values and ScanTargets in the order of the struct fields
+	WithColumns bool
+
+	// EnumTypes generates a named string type with a constant per value,
+	// sql.Scanner and driver.Valuer for the enum columns instead of string
+	EnumTypes bool
+
+	// ArrayType maps the array columns of Postgres to the array types of
+	// github.com/lib/pq or to slices, which pgx scans natively
+	ArrayType ArrayType
+
+	// RenameStrategy renames the structs and fields colliding with Go
+	// keywords, predeclared identifiers, imported packages or helper types of
+	// the generated code: suffixed by an underscore or prefixed by X
+	RenameStrategy RenameStrategy
+
+	// GoVersion is the oldest Go version the generated code has to compile
+	// with, newer constructs like sql.Null[T] or omitzero are used only if
+	// it supports them
+	GoVersion GoVersion
+
+	Prefix string
+	Suffix string
+	Null   NullType
+
+	// TypeMapFile is the YAML or TOML file of the TypeMap, the rules mapping
+	// the columns to Go types before the built-in mapping
+	TypeMapFile string
+	TypeMap     []TypeMapRule
+
+	// StripPrefixes and StripSuffixes are removed from the table names
+	// before naming the structs and files
+	StripPrefixes StringsFlag
+	StripSuffixes StringsFlag
+
+	// Singularize the table names for the struct names, SingularExceptions
+	// map plural words to their singular and InflectionRules replace the
+	// built-in rules for the table names they match
+	Singularize        bool
+	SingularExceptions MapFlag
+	InflectionRules    InflectionRulesFlag
+
+	// StructNames maps table names to the names of their structs, used
+	// instead of the table name before the default camel-casing
+	StructNames MapFlag
 
 	NoInitialism bool
 
-	TagsNoDb bool
+	// Initialisms extend the default initialisms or replace them if
+	// InitialismsOnly is set
+	Initialisms     StringsFlag
+	InitialismsOnly bool
+
+	TagsNoDb   bool
+	TagsDbCase DbTagCase
+
+	// naming strategy and per-column overrides shared by all serialization
+	// tags like json, yaml and toml
+	TagsNameFormat TagNameFormat
+	TagsNames      MapFlag
+
+	TagsJSON bool
+
+	TagsJSONOmitEmpty        OmitMode
+	TagsJSONOmitEmptyColumns StringsFlag
+	TagsJSONOmitZero         OmitMode
+	TagsJSONOmitZeroColumns  StringsFlag
+
+	TagsYAML         bool
+	TagsTOML         bool
+	TagsMapstructure bool
+	TagsCSV          bool
 
 	TagsMastermindStructable       bool
 	TagsMastermindStructableOnly   bool
 	IsMastermindStructableRecorder bool
 
-	// TODO not implemented yet
 	TagsGorm bool
+	TagsBun  bool
+	TagsXorm bool
+	TagsGoPg bool
+
+	// TagsGormConstraints adds the unique, index, precision and scale
+	// attributes of the constraints and types to the gorm-tags
+	TagsGormConstraints bool
+
+	TagsReform   bool
+	TagsValidate bool
+	TagsSwagger  bool
+	TagsFaker    bool
+	TagsGraphQL  bool
+	TagsParquet  bool
+
+	// TagsParquetMillis generates the temporal columns as UnixMilli, the
+	// milliseconds since the epoch parquet-go encodes, instead of time.Time
+	TagsParquetMillis bool
+
+	TagsProtobuf           bool
+	TagsProtobufFieldsFile string
+
+	// TagsCustom is a text/template generating an additional tag per column,
+	// CustomTags generate the values of tags of their keys by their templates
+	// and TagsCustomFuncs are additional functions of them defined by
+	// expressions
+	TagsCustom      string
+	CustomTags      CustomTagsFlag
+	TagsCustomFuncs TemplateFuncsFlag
+
+	// TagsOverrides maps "table.column" (or "*.column") to tags replacing,
+	// adding or (if empty) suppressing the generated ones
+	TagsOverrides map[string]map[string]string
+
+	// TagsOrder lists the keys of the tags to put first, in that order
+	TagsOrder StringsFlag
+	// Tags lists the names of the taggers to enable, built-in ones as well as
+	// the ones registered by tagger.Register
+	Tags StringsFlag
+
+	// TagsExtra are static tags added to the fields of matching columns
+	TagsExtra ExtraTagsFlag
+
+	// SensitiveColumns are patterns of column names excluded from
+	// serialization and String(), nil means the default patterns
+	SensitiveColumns StringsFlag
+
+	GenericRepository bool
+
+	// CRUD generates a repository with the CRUD operations of every table
+	// querying by database/sql or sqlx, none generates none
+	CRUD CRUD
+
+	// GeneratorVersion is the version of tables-to-go written into the
+	// header of the generated files, empty omits it
+	GeneratorVersion string
+
+	// Options are the flags set by the command line, environment variables
+	// or the config file by their names, written into the manifest
+	Options map[string]string
 }
 
 // New constructs Settings with default values.
@@ -93,8 +550,17 @@ func New() *Settings {
 	return &Settings{
 		Verbose:  false,
 		VVerbose: false,
+		Quiet:    false,
 		Force:    false,
 
+		ContinueOnError: false,
+
+		LogLevel:  "", // left blank, derived from the verbosity
+		LogFormat: LogFormatText,
+
+		ConfigFile: "",
+		Profile:    "",
+
 		DbType:         DBTypePostgresql,
 		User:           "",
 		Pswd:           "",
@@ -104,23 +570,150 @@ func New() *Settings {
 		Port:           "", // left blank, automatically determined if not set
 		SSLMode:        "", // left blank, will set the default for Postgres to 'disable'
 		Socket:         "",
+		SSHHost:        "",
+		SSHUser:        "",
+		SSHKey:         "",
+		SSLCert:        "",
+		SSLKey:         "",
+		SSLRootCert:    "",
+		KrbSrvName:     "",
+		KrbSPN:         "",
+		Auth:           AuthModePassword,
+		AWSRegion:      "",
+		AzureTenant:    "",
+		TablesInclude:  nil,
+		TablesExclude:  nil,
+		IncludeViews:   false,
+		OnlyViews:      false,
+		ColumnsExclude: nil,
+		IgnoreMarker:   "tables-to-go:ignore",
+		Interactive:    false,
+		Watch:          false,
+		WatchInterval:  30 * time.Second,
+		Progress:       false,
+		Jobs:           1,
+		BatchColumns:   false,
+		PgCatalog:      false,
+		Snapshot:       false,
+
+		MaxOpenConns:    0,
+		MaxIdleConns:    0,
+		ConnMaxLifetime: 0,
+		ConnectTimeout:  30 * time.Second,
+		Keepalive:       0,
+
+		Proxy: "",
+
+		Timeout:        0,
+		Retries:        0,
+		RetryDelay:     time.Second,
+		DryRun:         false,
+		Manifest:       false,
+		Incremental:    false,
+		CacheDir:       "",
+		CacheTTL:       time.Hour,
+		NoCache:        false,
+		EmitIR:         "",
+		FromIR:         "",
+		Stdout:         false,
+		FromSQL:        nil,
+		FromDBML:       "",
+		Plugin:         "",
+		LogQueries:     false,
+		ListFormat:     ListFormatPlain,
+		ListDetails:    false,
 		OutputFilePath: dir,
 		OutputFormat:   OutputFormatCamelCase,
+		Formatter:      FormatterGo,
+		Template:       "",
+		SchemaNames:    nil,
+		SchemaPrefix:   false,
 		FileNameFormat: FileNameFormatCamelCase,
+		FieldOrder:     FieldOrderOrdinal,
+		Relations:      RelationsNone,
+		WithComments:   false,
+		WithColumns:    false,
+		EnumTypes:      false,
+		ArrayType:      ArrayTypePQ,
+		RenameStrategy: RenameStrategySuffix,
+		GoVersion:      GoVersion121,
+		Strict:         false,
 		PackageName:    "dto",
 		Prefix:         "",
 		Suffix:         "",
 		Null:           NullTypeSQL,
+		TypeMapFile:    "",
+		TypeMap:        nil,
+		StructNames:    MapFlag{},
+		StripPrefixes:  nil,
+		StripSuffixes:  nil,
+
+		Singularize:        false,
+		SingularExceptions: MapFlag{},
+		InflectionRules:    nil,
 
 		NoInitialism: false,
 
-		TagsNoDb: false,
+		Initialisms:     nil,
+		InitialismsOnly: false,
+
+		TagsNoDb:   false,
+		TagsDbCase: DbTagCaseOriginal,
+
+		TagsNameFormat: TagNameFormatOriginal,
+		TagsNames:      MapFlag{},
+
+		TagsJSON: false,
+
+		TagsJSONOmitEmpty:        OmitModeNone,
+		TagsJSONOmitEmptyColumns: nil,
+		TagsJSONOmitZero:         OmitModeNone,
+		TagsJSONOmitZeroColumns:  nil,
+
+		TagsYAML:         false,
+		TagsTOML:         false,
+		TagsMapstructure: false,
+		TagsCSV:          false,
 
 		TagsMastermindStructable:       false,
 		TagsMastermindStructableOnly:   false,
 		IsMastermindStructableRecorder: false,
 
 		TagsGorm: false,
+		TagsBun:  false,
+		TagsXorm: false,
+		TagsGoPg: false,
+
+		TagsGormConstraints: false,
+
+		TagsReform:   false,
+		TagsValidate: false,
+		TagsSwagger:  false,
+		TagsFaker:    false,
+		TagsGraphQL:  false,
+		TagsParquet:  false,
+
+		TagsParquetMillis: false,
+
+		TagsProtobuf:           false,
+		TagsProtobufFieldsFile: "", // left blank, the sidecar file in the output path is used
+
+		TagsCustom:      "",
+		CustomTags:      nil,
+		TagsCustomFuncs: TemplateFuncsFlag{},
+		TagsOverrides:   nil,
+
+		TagsOrder: nil,
+		Tags:      nil,
+		TagsExtra: nil,
+
+		SensitiveColumns: nil,
+
+		GenericRepository: false,
+		CRUD:              CRUDNone,
+
+		GeneratorVersion: "",
+		Options:          nil,
 	}
 }
 
@@ -135,18 +728,228 @@ func (settings *Settings) Verify() (err error) {
 		return err
 	}
 
+	if err = settings.prepareSchemas(); err != nil {
+		return err
+	}
+
 	if settings.Port == "" {
 		settings.Port = dbDefaultPorts[settings.DbType]
 	}
 
 	if settings.SSLMode == "" {
-		settings.SSLMode = "disable"
+		settings.SSLMode = settings.defaultSSLMode()
+	}
+
+	if settings.Watch && settings.WatchInterval <= 0 {
+		return fmt.Errorf("interval of watch mode must be positive")
 	}
 
 	if settings.PackageName == "" {
 		return fmt.Errorf("name of package can not be empty")
 	}
 
+	if !token.IsIdentifier(settings.PackageName) {
+		return fmt.Errorf("name of package %q is no valid Go identifier", settings.PackageName)
+	}
+
+	if settings.Quiet && (settings.Verbose || settings.VVerbose) {
+		return fmt.Errorf("quiet mode can not be combined with verbose output")
+	}
+
+	if err = settings.loadTypeMap(); err != nil {
+		return err
+	}
+
+	if err = settings.verifyPassword(); err != nil {
+		return err
+	}
+
+	if err = settings.verifySocket(); err != nil {
+		return err
+	}
+
+	if err = settings.verifyTLS(); err != nil {
+		return err
+	}
+
+	if (settings.KrbSrvName != "" || settings.KrbSPN != "") && (settings.DSN != "" || settings.DbType != DBTypePostgresql) {
+		return fmt.Errorf("kerberos service name and SPN are supported by %s only and can not be combined with -dsn", DBTypePostgresql)
+	}
+
+	if settings.SSHHost == "" && (settings.SSHUser != "" || settings.SSHKey != "") {
+		return fmt.Errorf("ssh user and key need the ssh host given by -ssh-host")
+	}
+
+	if settings.SSHHost != "" && (settings.DSN != "" || settings.Socket != "" || settings.DbType == DBTypeSQLite) {
+		return fmt.Errorf("ssh tunnel can not be combined with -dsn, -socket or %s", DBTypeSQLite)
+	}
+
+	if strings.HasPrefix(settings.SSHHost, "-") || strings.HasPrefix(settings.SSHUser, "-") {
+		return fmt.Errorf("ssh host and user can not start with -, the ssh client would take them for options")
+	}
+
+	if err = settings.verifyProxy(); err != nil {
+		return err
+	}
+
+	if settings.Timeout < 0 {
+		return fmt.Errorf("timeout can not be negative")
+	}
+
+	if settings.Retries < 0 {
+		return fmt.Errorf("number of retries can not be negative")
+	}
+
+	if settings.Retries > 0 && settings.RetryDelay <= 0 {
+		return fmt.Errorf("delay between retries must be positive")
+	}
+
+	if settings.Jobs < 1 {
+		return fmt.Errorf("number of jobs must be at least 1")
+	}
+
+	if settings.Snapshot && (settings.Jobs > 1 || settings.Watch) {
+		return fmt.Errorf("snapshot can not be combined with parallel jobs or watch mode")
+	}
+
+	if settings.PgCatalog && settings.DbType != DBTypePostgresql {
+		return fmt.Errorf("introspection by pg_catalog is supported by database type %q only", DBTypePostgresql)
+	}
+
+	if settings.MaxOpenConns < 0 || settings.MaxIdleConns < 0 || settings.ConnMaxLifetime < 0 {
+		return fmt.Errorf("settings of the connection pool can not be negative")
+	}
+
+	if settings.ConnectTimeout < 0 || settings.Keepalive < 0 {
+		return fmt.Errorf("connect timeout and keepalive can not be negative")
+	}
+
+	if settings.DryRun && settings.Watch {
+		return fmt.Errorf("dry run can not be combined with watch mode")
+	}
+
+	if settings.Manifest && (settings.DryRun || settings.Watch) {
+		return fmt.Errorf("manifest can not be combined with dry run or watch mode")
+	}
+
+	if settings.Incremental && (settings.DryRun || settings.Watch || settings.Manifest || settings.Stdout) {
+		return fmt.Errorf("incremental generation can not be combined with dry run, watch mode, manifest or writing to stdout")
+	}
+
+	if settings.Incremental && settings.Formatter != FormatterGo {
+		return fmt.Errorf("incremental generation can not be combined with formatter %q, it supports the Go files only", settings.Formatter)
+	}
+
+	if settings.CacheTTL < 0 {
+		return fmt.Errorf("time to live of the cache can not be negative")
+	}
+
+	if settings.CacheDir != "" && (settings.Watch || settings.Interactive || len(settings.Schemas) > 1) {
+		return fmt.Errorf("caching the schema can not be combined with watch mode, interactive selection or multiple schemas")
+	}
+
+	if settings.EmitIR != "" && (settings.DryRun || settings.Watch) {
+		return fmt.Errorf("emitting the schema can not be combined with dry run or watch mode")
+	}
+
+	if settings.EmitIR != "" && len(settings.Schemas) > 1 {
+		return fmt.Errorf("emitting the schema can not be combined with multiple schemas")
+	}
+
+	if settings.FromIR != "" && settings.Watch {
+		return fmt.Errorf("generating from a schema file can not be combined with watch mode")
+	}
+
+	if settings.FromIR != "" && len(settings.Schemas) > 1 {
+		return fmt.Errorf("generating from a schema file can not be combined with multiple schemas")
+	}
+
+	if settings.Stdout && (settings.DryRun || settings.Watch || settings.Manifest) {
+		return fmt.Errorf("writing to stdout can not be combined with dry run, watch mode or manifest")
+	}
+
+	if settings.Stdout && len(settings.Schemas) > 1 {
+		return fmt.Errorf("writing to stdout can not be combined with multiple schemas")
+	}
+
+	if settings.ContinueOnError && settings.Force {
+		return fmt.Errorf("continuing on errors can not be combined with force mode, which skips the failing tables without failing")
+	}
+
+	for table, name := range settings.StructNames {
+		if name == "" {
+			return fmt.Errorf("struct name of table %q can not be empty", table)
+		}
+	}
+
+	if len(settings.TagsCustomFuncs) > 0 && settings.TagsCustom == "" && len(settings.CustomTags) == 0 {
+		return fmt.Errorf("custom tag functions need a custom tag template")
+	}
+
+	if settings.Formatter != FormatterGo && (settings.DryRun || settings.Manifest) {
+		return fmt.Errorf("formatter %q can not be combined with dry run or manifest, they support the Go files only", settings.Formatter)
+	}
+
+	if settings.CRUD == CRUDSQLX && settings.TagsNoDb {
+		return fmt.Errorf("crud %q scans the rows by the db-tags, it can not be combined with tags-no-db", settings.CRUD)
+	}
+
+	if settings.Template != "" && settings.Formatter != FormatterGo {
+		return fmt.Errorf("template renders the Go structs, it can not be combined with formatter %q", settings.Formatter)
+	}
+
+	if settings.HasRelations() && settings.FromDBML != "" {
+		return fmt.Errorf("relations can not be combined with generating from DBML, the foreign keys are read from the database, a schema file or SQL files")
+	}
+
+	if len(settings.FromSQL) > 0 && settings.FromIR != "" {
+		return fmt.Errorf("generating from SQL files can not be combined with generating from a schema file")
+	}
+
+	if len(settings.FromSQL) > 0 && settings.Watch {
+		return fmt.Errorf("generating from SQL files can not be combined with watch mode")
+	}
+
+	if len(settings.FromSQL) > 0 && len(settings.Schemas) > 1 {
+		return fmt.Errorf("generating from SQL files can not be combined with multiple schemas")
+	}
+
+	if len(settings.FromSQL) > 0 && settings.DbType.Dialect() != DBTypePostgresql && settings.DbType.Dialect() != DBTypeMySQL {
+		return fmt.Errorf("generating from SQL files supports the database types %s and %s only", DBTypePostgresql, DBTypeMySQL)
+	}
+
+	if settings.HasViews() && (len(settings.FromSQL) > 0 || settings.FromDBML != "") {
+		return fmt.Errorf("views can not be combined with generating from SQL files or DBML")
+	}
+
+	if settings.FromDBML != "" && (settings.FromIR != "" || len(settings.FromSQL) > 0) {
+		return fmt.Errorf("generating from a DBML file can not be combined with generating from a schema file or SQL files")
+	}
+
+	if settings.FromDBML != "" && settings.Watch {
+		return fmt.Errorf("generating from a DBML file can not be combined with watch mode")
+	}
+
+	if settings.FromDBML != "" && len(settings.Schemas) > 1 {
+		return fmt.Errorf("generating from a DBML file can not be combined with multiple schemas")
+	}
+
+	if settings.FromDBML != "" && settings.DbType.Dialect() != DBTypePostgresql && settings.DbType.Dialect() != DBTypeMySQL {
+		return fmt.Errorf("generating from a DBML file supports the database types %s and %s only", DBTypePostgresql, DBTypeMySQL)
+	}
+
+	if settings.Plugin != "" && (settings.FromIR != "" || len(settings.FromSQL) > 0 || settings.FromDBML != "") {
+		return fmt.Errorf("a dialect plugin can not be combined with generating from a schema file, SQL files or a DBML file")
+	}
+
+	if settings.Quiet && settings.Progress {
+		return fmt.Errorf("quiet mode can not be combined with progress reporting")
+	}
+
+	if err = settings.verifyGoVersion(); err != nil {
+		return err
+	}
+
 	if settings.VVerbose {
 		settings.Verbose = true
 	}
@@ -154,27 +957,59 @@ func (settings *Settings) Verify() (err error) {
 	return err
 }
 
+// verifyGoVersion checks that the options need no newer Go version than the
+// one the generated code has to compile with.
+func (settings *Settings) verifyGoVersion() error {
+	if !settings.GoVersion.AtLeast(GoVersion124) &&
+		(settings.TagsJSONOmitZero != OmitModeNone || len(settings.TagsJSONOmitZeroColumns) > 0) {
+		return fmt.Errorf("omitzero needs Go 1.24, set -go-version 1.24 or leave out -tags-json-omitzero and -tags-json-omitzero-column")
+	}
+	return nil
+}
+
 func (settings *Settings) verifyOutputPath() (err error) {
+	return verifyOutputPath(settings.OutputFilePath)
+}
+
+func (settings *Settings) prepareOutputPath() (outputFilePath string, err error) {
+	return prepareOutputPath(settings.OutputFilePath)
+}
 
-	info, err := os.Stat(settings.OutputFilePath)
+func verifyOutputPath(outputFilePath string) (err error) {
+
+	info, err := os.Stat(outputFilePath)
 
 	if os.IsNotExist(err) {
-		return fmt.Errorf("output file path %q does not exists", settings.OutputFilePath)
+		return fmt.Errorf("output file path %q does not exists", outputFilePath)
 	}
 
 	if !info.Mode().IsDir() {
-		return fmt.Errorf("output file path %q is not a directory", settings.OutputFilePath)
+		return fmt.Errorf("output file path %q is not a directory", outputFilePath)
 	}
 
 	return err
 }
 
-func (settings *Settings) prepareOutputPath() (outputFilePath string, err error) {
-	outputFilePath, err = filepath.Abs(settings.OutputFilePath)
+func prepareOutputPath(outputFilePath string) (string, error) {
+	outputFilePath, err := filepath.Abs(outputFilePath)
 	outputFilePath += string(filepath.Separator)
 	return outputFilePath, err
 }
 
+// RegisterDbType adds the database type to the supported ones, the port is
+// the default port of its databases. It is called by database.Register and
+// panics if the type is empty or already supported.
+func RegisterDbType(dbType DBType, defaultPort string) {
+	if dbType == "" {
+		panic("settings: database type is empty")
+	}
+	if SupportedDbTypes[dbType] {
+		panic(fmt.Sprintf("settings: database type %q is already supported", dbType))
+	}
+	SupportedDbTypes[dbType] = true
+	dbDefaultPorts[dbType] = defaultPort
+}
+
 // SprintfSupportedDbTypes returns a slice of strings as names of the supported
 // database types
 func SprintfSupportedDbTypes() string {
@@ -182,6 +1017,18 @@ func SprintfSupportedDbTypes() string {
 	for name := range SupportedDbTypes {
 		names = append(names, string(name))
 	}
+	slices.Sort(names)
+	return fmt.Sprintf("%v", names)
+}
+
+// SprintfSupportedGoVersions returns a slice of strings as versions of the
+// supported oldest Go versions of the generated code.
+func SprintfSupportedGoVersions() string {
+	names := make([]string, 0, len(supportedGoVersions))
+	for name := range supportedGoVersions {
+		names = append(names, string(name))
+	}
+	slices.Sort(names)
 	return fmt.Sprintf("%v", names)
 }
 
@@ -201,6 +1048,70 @@ func (settings *Settings) IsNullTypeSQL() bool {
 	return settings.Null == NullTypeSQL
 }
 
+// IsNullTypeJSON returns true if the type given by the command line args is of
+// null type JSON.
+func (settings *Settings) IsNullTypeJSON() bool {
+	return settings.Null == NullTypeJSON
+}
+
+// IsNullTypeGuregu returns true if the type given by the command line args is
+// of null type guregu.
+func (settings *Settings) IsNullTypeGuregu() bool {
+	return settings.Null == NullTypeGuregu
+}
+
+// IsNullTypeGeneric returns true if the generated code compiles with Go 1.22
+// or newer and hence can use the generic sql.Null[T] for NULL columns.
+func (settings *Settings) IsNullTypeGeneric() bool {
+	return settings.GoVersion.AtLeast(GoVersion122)
+}
+
+// IsSensitiveColumn returns true if the given column name matches one of the
+// sensitive column patterns, case-insensitive.
+func (settings *Settings) IsSensitiveColumn(column string) bool {
+	patterns := []string(settings.SensitiveColumns)
+	if patterns == nil {
+		patterns = defaultSensitiveColumns
+	}
+
+	column = strings.ToLower(column)
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		if ok, _ := path.Match(strings.ToLower(pattern), column); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTableIncluded returns true if the given table name matches any of the
+// include patterns (if given) and none of the exclude patterns.
+func (settings *Settings) IsTableIncluded(table string) bool {
+	if len(settings.TablesInclude) > 0 && !settings.TablesInclude.MatchString(table) {
+		return false
+	}
+	return !settings.TablesExclude.MatchString(table)
+}
+
+// IsColumnExcluded returns true if the column of the table matches any of the
+// column exclusions.
+func (settings *Settings) IsColumnExcluded(table, column string) bool {
+	for _, exclude := range settings.ColumnsExclude {
+		if exclude.Matches(table, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIgnoredByComment returns true if the comment of a table or column in the
+// database contains the ignore marker.
+func (settings *Settings) IsIgnoredByComment(comment string) bool {
+	return settings.IgnoreMarker != "" && strings.Contains(comment, settings.IgnoreMarker)
+}
+
 // ShouldInitialism returns whether column names should be converted
 // to initialisms or not.
 func (settings *Settings) ShouldInitialism() bool {
@@ -218,3 +1129,26 @@ func (settings *Settings) IsOutputFormatCamelCase() bool {
 func (settings *Settings) IsFileNameFormatSnakeCase() bool {
 	return settings.FileNameFormat == FileNameFormatSnakeCase
 }
+
+// HasRelations returns if the foreign keys of the tables get generated.
+func (settings *Settings) HasRelations() bool {
+	return settings.Relations != RelationsNone
+}
+
+// HasViews returns if the views get generated, along with the tables or
+// instead of them.
+func (settings *Settings) HasViews() bool {
+	return settings.IncludeViews || settings.OnlyViews
+}
+
+// HasCRUD returns if the tables get a repository with their CRUD
+// operations.
+func (settings *Settings) HasCRUD() bool {
+	return settings.CRUD != CRUDNone
+}
+
+// HasRelationFields returns if the structs get the fields of the structs of
+// the tables referenced by their foreign keys.
+func (settings *Settings) HasRelationFields() bool {
+	return settings.Relations == RelationsFields
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -51,252 +52,1449 @@ func TestSettings_Verify(t *testing.T) {
 			isError: assert.Error,
 		},
 		{
-			desc: "set v-verbose mode activates verbose mode without error",
+			desc: "package name being a Go keyword produces error",
 			settings: func() *Settings {
 				s := New()
-				s.VVerbose = true
+				s.PackageName = "type"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "package name with dash produces error",
+			settings: func() *Settings {
+				s := New()
+				s.PackageName = "my-models"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "watch mode without positive interval produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Watch = true
+				s.WatchInterval = 0
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "quiet mode combined with verbose mode produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Quiet = true
+				s.Verbose = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "ssh tunnel",
+			settings: func() *Settings {
+				s := New()
+				s.SSHHost = "bastion.example.com:2222"
+				s.SSHUser = "deploy"
+				s.SSHKey = "/home/deploy/.ssh/id_ed25519"
 				return s
 			},
 			isError: assert.NoError,
 		},
-	}
-	for _, test := range tests {
-		t.Run(test.desc, func(t *testing.T) {
-			settings := test.settings()
-			err := settings.Verify()
+		{
+			desc: "ssh user without ssh host produces error",
+			settings: func() *Settings {
+				s := New()
+				s.SSHUser = "deploy"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "ssh tunnel combined with socket produces error",
+			settings: func() *Settings {
+				s := New()
+				s.SSHHost = "bastion.example.com"
+				s.Socket = "/var/run/postgresql"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "ssh host starting with - produces error",
+			settings: func() *Settings {
+				s := New()
+				s.SSHHost = "-oProxyCommand=touch /tmp/pwned"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "ssh user starting with - produces error",
+			settings: func() *Settings {
+				s := New()
+				s.SSHHost = "bastion.example.com"
+				s.SSHUser = "-oProxyCommand=touch /tmp/pwned"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "ssh tunnel to sqlite produces error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeSQLite
+				s.SSHHost = "bastion.example.com"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "kerberos service name of postgres",
+			settings: func() *Settings {
+				s := New()
+				s.KrbSrvName = "pgsql"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "kerberos SPN of mysql produces error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.KrbSPN = "postgres/db.example.com@EXAMPLE.COM"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative timeout produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Timeout = -time.Second
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative connect timeout produces error",
+			settings: func() *Settings {
+				s := New()
+				s.ConnectTimeout = -time.Second
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative keepalive produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Keepalive = -time.Minute
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative retries produce error",
+			settings: func() *Settings {
+				s := New()
+				s.Retries = -1
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "retries without delay produce error",
+			settings: func() *Settings {
+				s := New()
+				s.Retries = 3
+				s.RetryDelay = 0
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "less than one job produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Jobs = 0
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "snapshot combined with parallel jobs produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Snapshot = true
+				s.Jobs = 4
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "incremental generation combined with manifest produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Incremental = true
+				s.Manifest = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative time to live of the cache produces error",
+			settings: func() *Settings {
+				s := New()
+				s.CacheDir = "/tmp/cache"
+				s.CacheTTL = -time.Minute
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "cache combined with watch mode produces error",
+			settings: func() *Settings {
+				s := New()
+				s.CacheDir = "/tmp/cache"
+				s.Watch = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "pg_catalog introspection of mysql produces error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.PgCatalog = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "negative connection pool settings produce error",
+			settings: func() *Settings {
+				s := New()
+				s.MaxIdleConns = -1
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "dry run combined with watch mode produces error",
+			settings: func() *Settings {
+				s := New()
+				s.DryRun = true
+				s.Watch = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "quiet mode combined with progress reporting produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Quiet = true
+				s.Progress = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "manifest combined with dry run produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Manifest = true
+				s.DryRun = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "emitting the schema combined with watch mode produces error",
+			settings: func() *Settings {
+				s := New()
+				s.EmitIR = "schema.json"
+				s.Watch = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "emitting the schema combined with multiple schemas produces error",
+			settings: func() *Settings {
+				s := New()
+				s.EmitIR = "schema.json"
+				s.Schemas = map[string]SchemaOutput{"public": {OutputFilePath: "."}, "billing": {OutputFilePath: "."}}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "generating from a schema file combined with watch mode produces error",
+			settings: func() *Settings {
+				s := New()
+				s.FromIR = "schema.json"
+				s.Watch = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "writing to stdout combined with dry run produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Stdout = true
+				s.DryRun = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "continuing on errors combined with force mode produces error",
+			settings: func() *Settings {
+				s := New()
+				s.ContinueOnError = true
+				s.Force = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "custom tag functions with custom tags succeed",
+			settings: func() *Settings {
+				s := New()
+				s.TagsCustomFuncs = TemplateFuncsFlag{"short": "slice . 0 3"}
+				s.CustomTags = CustomTagsFlag{{Key: "bson", Template: "{{ short .Column.Name }}"}}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "custom tag functions without custom tag template produces error",
+			settings: func() *Settings {
+				s := New()
+				s.TagsCustomFuncs = TemplateFuncsFlag{"short": "slice . 0 3"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "other formatter than go succeeds",
+			settings: func() *Settings {
+				s := New()
+				s.Formatter = "proto"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "other formatter than go combined with manifest produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Formatter = "proto"
+				s.Manifest = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "crud sqlx without db-tags produces error",
+			settings: func() *Settings {
+				s := New()
+				s.CRUD = CRUDSQLX
+				s.TagsNoDb = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "other formatter than go combined with template produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Formatter = "proto"
+				s.Template = "struct.tmpl"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "generating from SQL files of Postgres succeeds",
+			settings: func() *Settings {
+				s := New()
+				s.FromSQL = StringsFlag{"migrations"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "relations combined with generating from SQL files succeed",
+			settings: func() *Settings {
+				s := New()
+				s.FromSQL = StringsFlag{"schema.sql"}
+				s.Relations = RelationsComments
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "relations combined with generating from DBML produce error",
+			settings: func() *Settings {
+				s := New()
+				s.FromDBML = "schema.dbml"
+				s.Relations = RelationsComments
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "views combined with generating from SQL files produce error",
+			settings: func() *Settings {
+				s := New()
+				s.FromSQL = StringsFlag{"schema.sql"}
+				s.IncludeViews = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "only views of a schema file succeed",
+			settings: func() *Settings {
+				s := New()
+				s.FromIR = "schema.json"
+				s.OnlyViews = true
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "generating from SQL files and a schema file produces error",
+			settings: func() *Settings {
+				s := New()
+				s.FromSQL = StringsFlag{"schema.sql"}
+				s.FromIR = "schema.json"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "generating from SQL files combined with watch mode produces error",
+			settings: func() *Settings {
+				s := New()
+				s.FromSQL = StringsFlag{"schema.sql"}
+				s.Watch = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "generating from SQL files of SQLite produces error",
+			settings: func() *Settings {
+				s := New()
+				s.FromSQL = StringsFlag{"schema.sql"}
+				s.DbType = DBTypeSQLite
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "generating from a DBML file and SQL files produces error",
+			settings: func() *Settings {
+				s := New()
+				s.FromDBML = "schema.dbml"
+				s.FromSQL = StringsFlag{"schema.sql"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "generating from a DBML file of Oracle produces error",
+			settings: func() *Settings {
+				s := New()
+				s.FromDBML = "schema.dbml"
+				s.DbType = DBTypeOracle
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "dialect plugin combined with SQL files produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Plugin = "tables-to-go-acme"
+				s.FromSQL = StringsFlag{"schema.sql"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "struct name",
+			settings: func() *Settings {
+				s := New()
+				s.StructNames = MapFlag{"tbl_usr": "User"}
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "empty struct name produces error",
+			settings: func() *Settings {
+				s := New()
+				s.StructNames = MapFlag{"tbl_usr": ""}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "omitzero before Go 1.24 produces error",
+			settings: func() *Settings {
+				s := New()
+				s.TagsJSONOmitZeroColumns = StringsFlag{"deleted_at"}
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "omitzero with Go 1.24 produces no error",
+			settings: func() *Settings {
+				s := New()
+				s.GoVersion = GoVersion124
+				s.TagsJSONOmitZero = OmitModeNullable
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "set v-verbose mode activates verbose mode without error",
+			settings: func() *Settings {
+				s := New()
+				s.VVerbose = true
+				return s
+			},
+			isError: assert.NoError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			settings := test.settings()
+			err := settings.Verify()
+			test.isError(t, err)
+		})
+	}
+}
+
+func TestSettings_IsNullTypeSQL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		expected bool
+	}{
+		{
+			desc:     "in default settings sql NULL type is activated",
+			settings: New,
+			expected: true,
+		},
+		{
+			desc: "explicit enabled sql NULL type ativates sql NULL type",
+			settings: func() *Settings {
+				s := New()
+				s.Null = NullTypeSQL
+				return s
+			},
+			expected: true,
+		},
+		{
+			desc: "native NULL type deativates sql NULL type",
+			settings: func() *Settings {
+				s := New()
+				s.Null = NullTypeNative
+				return s
+			},
+			expected: false,
+		},
+		{
+			desc: "primitve NULL type deativates sql NULL type",
+			settings: func() *Settings {
+				s := New()
+				s.Null = NullTypePrimitive
+				return s
+			},
+			expected: false,
+		},
+		{
+			desc: "any other NULL type deativates sql NULL type",
+			settings: func() *Settings {
+				s := New()
+				s.Null = NullType("any")
+				return s
+			},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			settings := test.settings()
+			actual := settings.IsNullTypeSQL()
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestSettings_IsSensitiveColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		patterns StringsFlag
+		column   string
+		expected bool
+	}{
+		{
+			desc:     "default patterns match password columns",
+			patterns: nil,
+			column:   "password_hash",
+			expected: true,
+		},
+		{
+			desc:     "default patterns match case-insensitive",
+			patterns: nil,
+			column:   "APIToken",
+			expected: true,
+		},
+		{
+			desc:     "default patterns do not match other columns",
+			patterns: nil,
+			column:   "name",
+			expected: false,
+		},
+		{
+			desc:     "given patterns replace the default ones",
+			patterns: StringsFlag{"ssn", "*_pin"},
+			column:   "card_pin",
+			expected: true,
+		},
+		{
+			desc:     "given patterns do not include the default ones",
+			patterns: StringsFlag{"ssn"},
+			column:   "password",
+			expected: false,
+		},
+		{
+			desc:     "empty pattern disables the default ones",
+			patterns: StringsFlag{""},
+			column:   "password",
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			s.SensitiveColumns = test.patterns
+			assert.Equal(t, test.expected, s.IsSensitiveColumn(test.column))
+		})
+	}
+}
+
+func TestSettings_IsTableIncluded(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		include  []string
+		exclude  []string
+		table    string
+		expected bool
+	}{
+		{
+			desc:     "no patterns include all tables",
+			table:    "orders",
+			expected: true,
+		},
+		{
+			desc:     "table matching an include pattern",
+			include:  []string{"^order_.*", "^customers$"},
+			table:    "order_items",
+			expected: true,
+		},
+		{
+			desc:     "table matching no include pattern",
+			include:  []string{"^order_.*"},
+			table:    "customers",
+			expected: false,
+		},
+		{
+			desc:     "table matching an exclude pattern",
+			exclude:  []string{".*_archive$"},
+			table:    "orders_archive",
+			expected: false,
+		},
+		{
+			desc:     "exclude wins over include",
+			include:  []string{"^orders"},
+			exclude:  []string{".*_archive$"},
+			table:    "orders_archive",
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			for _, pattern := range test.include {
+				assert.NoError(t, s.TablesInclude.Set(pattern))
+			}
+			for _, pattern := range test.exclude {
+				assert.NoError(t, s.TablesExclude.Set(pattern))
+			}
+			assert.Equal(t, test.expected, s.IsTableIncluded(test.table))
+		})
+	}
+}
+
+func TestSettings_IsColumnExcluded(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		excludes []string
+		table    string
+		column   string
+		expected bool
+	}{
+		{
+			desc:     "no exclusions",
+			table:    "users",
+			column:   "avatar",
+			expected: false,
+		},
+		{
+			desc:     "column excluded from all tables",
+			excludes: []string{"legacy_flag"},
+			table:    "users",
+			column:   "legacy_flag",
+			expected: true,
+		},
+		{
+			desc:     "name has to match the whole column name",
+			excludes: []string{"legacy"},
+			table:    "users",
+			column:   "legacy_flag",
+			expected: false,
+		},
+		{
+			desc:     "regular expression of table and column",
+			excludes: []string{"audit_.*=payload|raw_.*"},
+			table:    "audit_log",
+			column:   "raw_body",
+			expected: true,
+		},
+		{
+			desc:     "column of other table is not excluded",
+			excludes: []string{"audit_.*=payload"},
+			table:    "users",
+			column:   "payload",
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			for _, exclude := range test.excludes {
+				assert.NoError(t, s.ColumnsExclude.Set(exclude))
+			}
+			assert.Equal(t, test.expected, s.IsColumnExcluded(test.table, test.column))
+		})
+	}
+}
+
+func TestSettings_IsIgnoredByComment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		marker   string
+		comment  string
+		expected bool
+	}{
+		{
+			desc:     "comment without marker",
+			marker:   "tables-to-go:ignore",
+			comment:  "all orders",
+			expected: false,
+		},
+		{
+			desc:     "comment containing the marker",
+			marker:   "tables-to-go:ignore",
+			comment:  "internal bookkeeping, tables-to-go:ignore",
+			expected: true,
+		},
+		{
+			desc:     "custom marker",
+			marker:   "@nogen",
+			comment:  "@nogen",
+			expected: true,
+		},
+		{
+			desc:     "empty marker disables it",
+			marker:   "",
+			comment:  "tables-to-go:ignore",
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			s.IgnoreMarker = test.marker
+			assert.Equal(t, test.expected, s.IsIgnoredByComment(test.comment))
+		})
+	}
+}
+
+func TestSettings_ShouldInitialism(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		expected bool
+	}{
+		{
+			desc:     "in default settings initialism is activated",
+			settings: New,
+			expected: true,
+		},
+		{
+			desc: "explicit enabled initialism ativates initialism",
+			settings: func() *Settings {
+				s := New()
+				s.NoInitialism = false
+				return s
+			},
+			expected: true,
+		},
+		{
+			desc: "disabled initialism deactivates initialism",
+			settings: func() *Settings {
+				s := New()
+				s.NoInitialism = true
+				return s
+			},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			settings := test.settings()
+			actual := settings.ShouldInitialism()
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestSettings_IsOutputFormatCamelCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		expected bool
+	}{
+		{
+			desc:     "in default settings camel case is activated",
+			settings: New,
+			expected: true,
+		},
+		{
+			desc: "explicit enabled camel case ativates initialism",
+			settings: func() *Settings {
+				s := New()
+				s.OutputFormat = OutputFormatCamelCase
+				return s
+			},
+			expected: true,
+		},
+		{
+			desc: "disabled camel case deactivates camel case",
+			settings: func() *Settings {
+				s := New()
+				s.OutputFormat = OutputFormatOriginal
+				return s
+			},
+			expected: false,
+		},
+		{
+			desc: "any other output format deativates camel case",
+			settings: func() *Settings {
+				s := New()
+				s.OutputFormat = OutputFormat("any")
+				return s
+			},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			settings := test.settings()
+			actual := settings.IsOutputFormatCamelCase()
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestSettings_IsFileNameFormatSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		expected bool
+	}{
+		{
+			desc:     "in default settings camel case will be used",
+			settings: New,
+			expected: false,
+		},
+		{
+			desc: "use snake case",
+			settings: func() *Settings {
+				s := New()
+				s.FileNameFormat = FileNameFormatSnakeCase
+				return s
+			},
+			expected: true,
+		},
+		{
+			desc: "any other output format will converted to camel case",
+			settings: func() *Settings {
+				s := New()
+				s.FileNameFormat = FileNameFormat("any")
+				return s
+			},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			settings := test.settings()
+			actual := settings.IsFileNameFormatSnakeCase()
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDbType_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected DBType
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "typed supported db type produces no error and gets set",
+			input:    string(DBTypePostgresql),
+			expected: DBTypePostgresql,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed supported db type produces no error and gets set",
+			input:    string("pg"),
+			expected: DBTypePostgresql,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty db type produces no error and gets default",
+			input:    "",
+			expected: DBTypePostgresql,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed unsupported db type produces error and invalid db type",
+			input:    string("invalid"),
+			expected: DBType("invalid"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := DBTypeMySQL
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestNullType_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected NullType
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "typed supported NULL type produces no error and gets set",
+			input:    string(NullTypePrimitive),
+			expected: NullTypePrimitive,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed supported NULL type produces no error and gets set",
+			input:    string("native"),
+			expected: NullTypeNative,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed json NULL type produces no error and gets set",
+			input:    string("json"),
+			expected: NullTypeJSON,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty NULL type produces no error and gets default",
+			input:    "",
+			expected: NullTypeSQL,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed unsupported NULL type produces error and invalid NULL type",
+			input:    string("invalid"),
+			expected: NullType("invalid"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := NullTypeSQL
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestOutputFormat_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected OutputFormat
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "typed supported output type produces no error and gets set",
+			input:    string(OutputFormatCamelCase),
+			expected: OutputFormatCamelCase,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed supported output type produces no error and gets set",
+			input:    string("o"),
+			expected: OutputFormatOriginal,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty output type produces no error and gets default",
+			input:    "",
+			expected: OutputFormatCamelCase,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed unsupported output type produces error and invalid output type",
+			input:    string("invalid"),
+			expected: OutputFormat("invalid"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := OutputFormatCamelCase
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestFileNameFormat_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected FileNameFormat
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "typed supported filename type produces no error and gets set",
+			input:    string(FileNameFormatCamelCase),
+			expected: FileNameFormatCamelCase,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed supported filename type produces no error and gets set",
+			input:    string("c"),
+			expected: FileNameFormatCamelCase,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty output type produces no error and gets default",
+			input:    "",
+			expected: FileNameFormatCamelCase,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed unsupported output type produces error and invalid output type",
+			input:    string("invalid"),
+			expected: FileNameFormat("invalid"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := FileNameFormatCamelCase
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestTagNameFormat_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected TagNameFormat
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "string typed supported tag name format produces no error and gets set",
+			input:    string("c"),
+			expected: TagNameFormatCamelCase,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty tag name format produces no error and gets default",
+			input:    "",
+			expected: TagNameFormatOriginal,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed unsupported tag name format produces error and invalid tag name format",
+			input:    string("invalid"),
+			expected: TagNameFormat("invalid"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := TagNameFormatSnakeCase
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDbTagCase_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected DbTagCase
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "string typed supported db tag case produces no error and gets set",
+			input:    string("q"),
+			expected: DbTagCaseQuoted,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty db tag case produces no error and gets default",
+			input:    "",
+			expected: DbTagCaseOriginal,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed unsupported db tag case produces error and invalid db tag case",
+			input:    string("invalid"),
+			expected: DbTagCase("invalid"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := DbTagCaseLower
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestOmitMode_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected OmitMode
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "string typed supported omit mode produces no error and gets set",
+			input:    string("nullable"),
+			expected: OmitModeNullable,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty omit mode produces no error and gets default",
+			input:    "",
+			expected: OmitModeNone,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string typed unsupported omit mode produces error and invalid omit mode",
+			input:    string("invalid"),
+			expected: OmitMode("invalid"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := OmitModeAll
+			err := actual.Set(test.input)
 			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
 
-func TestSettings_IsNullTypeSQL(t *testing.T) {
+func TestLogLevel_Set(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		desc     string
-		settings func() *Settings
-		expected bool
+		input    string
+		expected LogLevel
+		isError  assert.ErrorAssertionFunc
 	}{
 		{
-			desc:     "in default settings sql NULL type is activated",
-			settings: New,
-			expected: true,
+			desc:     "string typed supported log level produces no error and gets set",
+			input:    string("trace"),
+			expected: LogLevelTrace,
+			isError:  assert.NoError,
 		},
 		{
-			desc: "explicit enabled sql NULL type ativates sql NULL type",
-			settings: func() *Settings {
-				s := New()
-				s.Null = NullTypeSQL
-				return s
-			},
-			expected: true,
+			desc:     "string typed unsupported log level produces error and invalid log level",
+			input:    string("invalid"),
+			expected: LogLevel("invalid"),
+			isError:  assert.Error,
 		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := LogLevelInfo
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestLogFormat_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected LogFormat
+		isError  assert.ErrorAssertionFunc
+	}{
 		{
-			desc: "native NULL type deativates sql NULL type",
-			settings: func() *Settings {
-				s := New()
-				s.Null = NullTypeNative
-				return s
-			},
-			expected: false,
+			desc:     "string typed supported log format produces no error and gets set",
+			input:    string("json"),
+			expected: LogFormatJSON,
+			isError:  assert.NoError,
 		},
 		{
-			desc: "primitve NULL type deativates sql NULL type",
-			settings: func() *Settings {
-				s := New()
-				s.Null = NullTypePrimitive
-				return s
-			},
-			expected: false,
+			desc:     "empty log format produces no error and gets default",
+			input:    "",
+			expected: LogFormatText,
+			isError:  assert.NoError,
 		},
 		{
-			desc: "any other NULL type deativates sql NULL type",
-			settings: func() *Settings {
-				s := New()
-				s.Null = NullType("any")
-				return s
-			},
-			expected: false,
+			desc:     "string typed unsupported log format produces error and invalid log format",
+			input:    string("invalid"),
+			expected: LogFormat("invalid"),
+			isError:  assert.Error,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			settings := test.settings()
-			actual := settings.IsNullTypeSQL()
+			actual := LogFormatJSON
+			err := actual.Set(test.input)
+			test.isError(t, err)
 			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
 
-func TestSettings_ShouldInitialism(t *testing.T) {
+func TestListFormat_Set(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		desc     string
-		settings func() *Settings
-		expected bool
+		input    string
+		expected ListFormat
+		isError  assert.ErrorAssertionFunc
 	}{
 		{
-			desc:     "in default settings initialism is activated",
-			settings: New,
-			expected: true,
+			desc:     "string typed supported list format produces no error and gets set",
+			input:    string("table"),
+			expected: ListFormatTable,
+			isError:  assert.NoError,
 		},
 		{
-			desc: "explicit enabled initialism ativates initialism",
-			settings: func() *Settings {
-				s := New()
-				s.NoInitialism = false
-				return s
-			},
-			expected: true,
+			desc:     "empty list format produces no error and gets default",
+			input:    "",
+			expected: ListFormatPlain,
+			isError:  assert.NoError,
 		},
 		{
-			desc: "disabled initialism deactivates initialism",
-			settings: func() *Settings {
-				s := New()
-				s.NoInitialism = true
-				return s
-			},
-			expected: false,
+			desc:     "string typed unsupported list format produces error and invalid list format",
+			input:    string("invalid"),
+			expected: ListFormat("invalid"),
+			isError:  assert.Error,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			settings := test.settings()
-			actual := settings.ShouldInitialism()
+			actual := ListFormatJSON
+			err := actual.Set(test.input)
+			test.isError(t, err)
 			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
 
-func TestSettings_IsOutputFormatCamelCase(t *testing.T) {
+func TestFieldOrder_Set(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		desc     string
-		settings func() *Settings
-		expected bool
+		input    string
+		expected FieldOrder
+		isError  assert.ErrorAssertionFunc
 	}{
 		{
-			desc:     "in default settings camel case is activated",
-			settings: New,
-			expected: true,
-		},
-		{
-			desc: "explicit enabled camel case ativates initialism",
-			settings: func() *Settings {
-				s := New()
-				s.OutputFormat = OutputFormatCamelCase
-				return s
-			},
-			expected: true,
+			desc:     "string typed supported field order produces no error and gets set",
+			input:    string("pk-first"),
+			expected: FieldOrderPKFirst,
+			isError:  assert.NoError,
 		},
 		{
-			desc: "disabled camel case deactivates camel case",
-			settings: func() *Settings {
-				s := New()
-				s.OutputFormat = OutputFormatOriginal
-				return s
-			},
-			expected: false,
+			desc:     "empty field order produces no error and gets default",
+			input:    "",
+			expected: FieldOrderOrdinal,
+			isError:  assert.NoError,
 		},
 		{
-			desc: "any other output format deativates camel case",
-			settings: func() *Settings {
-				s := New()
-				s.OutputFormat = OutputFormat("any")
-				return s
-			},
-			expected: false,
+			desc:     "string typed unsupported field order produces error and invalid field order",
+			input:    string("invalid"),
+			expected: FieldOrder("invalid"),
+			isError:  assert.Error,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			settings := test.settings()
-			actual := settings.IsOutputFormatCamelCase()
+			actual := FieldOrderAlphabetical
+			err := actual.Set(test.input)
+			test.isError(t, err)
 			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
 
-func TestSettings_IsFileNameFormatSnakeCase(t *testing.T) {
+func TestRelations_Set(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		desc     string
-		settings func() *Settings
-		expected bool
+		input    string
+		expected Relations
+		isError  assert.ErrorAssertionFunc
 	}{
 		{
-			desc:     "in default settings camel case will be used",
-			settings: New,
-			expected: false,
+			desc:     "string typed supported relations produce no error and get set",
+			input:    string("fields"),
+			expected: RelationsFields,
+			isError:  assert.NoError,
 		},
 		{
-			desc: "use snake case",
-			settings: func() *Settings {
-				s := New()
-				s.FileNameFormat = FileNameFormatSnakeCase
-				return s
-			},
-			expected: true,
+			desc:     "empty relations produce no error and get default",
+			input:    "",
+			expected: RelationsNone,
+			isError:  assert.NoError,
 		},
 		{
-			desc: "any other output format will converted to camel case",
-			settings: func() *Settings {
-				s := New()
-				s.FileNameFormat = FileNameFormat("any")
-				return s
-			},
-			expected: false,
+			desc:     "string typed unsupported relations produce error and invalid relations",
+			input:    string("invalid"),
+			expected: Relations("invalid"),
+			isError:  assert.Error,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			settings := test.settings()
-			actual := settings.IsFileNameFormatSnakeCase()
+			actual := RelationsComments
+			err := actual.Set(test.input)
+			test.isError(t, err)
 			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
 
-func TestDbType_Set(t *testing.T) {
+func TestRenameStrategy_Set(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		desc     string
 		input    string
-		expected DBType
+		expected RenameStrategy
 		isError  assert.ErrorAssertionFunc
 	}{
 		{
-			desc:     "typed supported db type produces no error and gets set",
-			input:    string(DBTypePostgresql),
-			expected: DBTypePostgresql,
-			isError:  assert.NoError,
-		},
-		{
-			desc:     "string typed supported db type produces no error and gets set",
-			input:    string("pg"),
-			expected: DBTypePostgresql,
+			desc:     "string typed supported rename strategy produces no error and gets set",
+			input:    string("prefix"),
+			expected: RenameStrategyPrefix,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "empty db type produces no error and gets default",
+			desc:     "empty rename strategy produces no error and gets default",
 			input:    "",
-			expected: DBTypePostgresql,
+			expected: RenameStrategySuffix,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "string typed unsupported db type produces error and invalid db type",
+			desc:     "string typed unsupported rename strategy produces error and invalid rename strategy",
 			input:    string("invalid"),
-			expected: DBType("invalid"),
+			expected: RenameStrategy("invalid"),
 			isError:  assert.Error,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			actual := DBTypeMySQL
+			actual := RenameStrategyPrefix
 			err := actual.Set(test.input)
 			test.isError(t, err)
 			assert.Equal(t, test.expected, actual)
@@ -304,43 +1502,37 @@ func TestDbType_Set(t *testing.T) {
 	}
 }
 
-func TestNullType_Set(t *testing.T) {
+func TestArrayType_Set(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		desc     string
 		input    string
-		expected NullType
+		expected ArrayType
 		isError  assert.ErrorAssertionFunc
 	}{
 		{
-			desc:     "typed supported NULL type produces no error and gets set",
-			input:    string(NullTypePrimitive),
-			expected: NullTypePrimitive,
-			isError:  assert.NoError,
-		},
-		{
-			desc:     "string typed supported NULL type produces no error and gets set",
+			desc:     "string typed supported array type produces no error and gets set",
 			input:    string("native"),
-			expected: NullTypeNative,
+			expected: ArrayTypeNative,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "empty NULL type produces no error and gets default",
+			desc:     "empty array type produces no error and gets default",
 			input:    "",
-			expected: NullTypeSQL,
+			expected: ArrayTypePQ,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "string typed unsupported NULL type produces error and invalid NULL type",
+			desc:     "string typed unsupported array type produces error and invalid array type",
 			input:    string("invalid"),
-			expected: NullType("invalid"),
+			expected: ArrayType("invalid"),
 			isError:  assert.Error,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			actual := NullTypeSQL
+			actual := ArrayTypeNative
 			err := actual.Set(test.input)
 			test.isError(t, err)
 			assert.Equal(t, test.expected, actual)
@@ -348,43 +1540,75 @@ func TestNullType_Set(t *testing.T) {
 	}
 }
 
-func TestOutputFormat_Set(t *testing.T) {
+func TestCRUD_Set(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		desc     string
 		input    string
-		expected OutputFormat
+		expected CRUD
 		isError  assert.ErrorAssertionFunc
 	}{
 		{
-			desc:     "typed supported output type produces no error and gets set",
-			input:    string(OutputFormatCamelCase),
-			expected: OutputFormatCamelCase,
+			desc:     "string typed supported crud produces no error and gets set",
+			input:    string("sqlx"),
+			expected: CRUDSQLX,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "string typed supported output type produces no error and gets set",
-			input:    string("o"),
-			expected: OutputFormatOriginal,
+			desc:     "empty crud produces no error and gets default",
+			input:    "",
+			expected: CRUDNone,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "empty output type produces no error and gets default",
+			desc:     "string typed unsupported crud produces error and invalid crud",
+			input:    string("gorm"),
+			expected: CRUD("gorm"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := CRUDSQL
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestAuthMode_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		input    string
+		expected AuthMode
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "string typed supported auth mode produces no error and gets set",
+			input:    "rds-iam",
+			expected: AuthModeRDSIAM,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty auth mode produces no error and gets default",
 			input:    "",
-			expected: OutputFormatCamelCase,
+			expected: AuthModePassword,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "string typed unsupported output type produces error and invalid output type",
-			input:    string("invalid"),
-			expected: OutputFormat("invalid"),
+			desc:     "string typed unsupported auth mode produces error and invalid auth mode",
+			input:    "kerberos",
+			expected: AuthMode("kerberos"),
 			isError:  assert.Error,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			actual := OutputFormatCamelCase
+			actual := AuthModeRDSIAM
 			err := actual.Set(test.input)
 			test.isError(t, err)
 			assert.Equal(t, test.expected, actual)
@@ -392,43 +1616,43 @@ func TestOutputFormat_Set(t *testing.T) {
 	}
 }
 
-func TestFileNameFormat_Set(t *testing.T) {
+func TestGoVersion_Set(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		desc     string
 		input    string
-		expected FileNameFormat
+		expected GoVersion
 		isError  assert.ErrorAssertionFunc
 	}{
 		{
-			desc:     "typed supported filename type produces no error and gets set",
-			input:    string(FileNameFormatCamelCase),
-			expected: FileNameFormatCamelCase,
+			desc:     "supported go version produces no error and gets set",
+			input:    "1.24",
+			expected: GoVersion124,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "string typed supported filename type produces no error and gets set",
-			input:    string("c"),
-			expected: FileNameFormatCamelCase,
+			desc:     "go version with go prefix produces no error and gets set",
+			input:    "go1.22",
+			expected: GoVersion122,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "empty output type produces no error and gets default",
+			desc:     "empty go version produces no error and gets default",
 			input:    "",
-			expected: FileNameFormatCamelCase,
+			expected: GoVersion121,
 			isError:  assert.NoError,
 		},
 		{
-			desc:     "string typed unsupported output type produces error and invalid output type",
-			input:    string("invalid"),
-			expected: FileNameFormat("invalid"),
+			desc:     "unsupported go version produces error",
+			input:    "1.20",
+			expected: GoVersion("1.20"),
 			isError:  assert.Error,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			actual := FileNameFormatCamelCase
+			actual := GoVersion124
 			err := actual.Set(test.input)
 			test.isError(t, err)
 			assert.Equal(t, test.expected, actual)
@@ -436,6 +1660,15 @@ func TestFileNameFormat_Set(t *testing.T) {
 	}
 }
 
+func TestGoVersion_AtLeast(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, GoVersion121.AtLeast(GoVersion121))
+	assert.False(t, GoVersion121.AtLeast(GoVersion122))
+	assert.True(t, GoVersion124.AtLeast(GoVersion122))
+	assert.False(t, GoVersion("").AtLeast(GoVersion121))
+}
+
 func TestSprintfSupportedDbTypes(t *testing.T) {
 	t.Parallel()
 
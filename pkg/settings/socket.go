@@ -0,0 +1,25 @@
+package settings
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// verifySocket checks that the unix socket is supported by the database type
+// and given by its absolute path, relative ones are taken for host names by
+// the Postgres driver.
+func (settings *Settings) verifySocket() error {
+	if settings.Socket == "" {
+		return nil
+	}
+
+	if settings.Plugin == "" && settings.DbType.Dialect() != DBTypePostgresql && settings.DbType.Dialect() != DBTypeMySQL {
+		return fmt.Errorf("socket is not supported by %s, only by %s and %s", settings.DbType, DBTypePostgresql, DBTypeMySQL)
+	}
+
+	if !filepath.IsAbs(settings.Socket) {
+		return fmt.Errorf("socket %q must be an absolute path", settings.Socket)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettings_verifySocket(t *testing.T) {
+	t.Parallel()
+
+	socket := func(dbType DBType, socket string) func() *Settings {
+		return func() *Settings {
+			s := New()
+			s.DbType = dbType
+			s.Socket = socket
+			return s
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no socket",
+			settings: New,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "socket directory of postgres",
+			settings: socket(DBTypePostgresql, "/var/run/postgresql"),
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "socket file of postgres",
+			settings: socket(DBTypePostgresql, "/var/run/postgresql/.s.PGSQL.5433"),
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "socket file of mysql",
+			settings: socket(DBTypeMySQL, "/var/run/mysqld/mysqld.sock"),
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "relative socket produces error",
+			settings: socket(DBTypeMySQL, "mysqld.sock"),
+			isError:  assert.Error,
+		},
+		{
+			desc:     "socket of sqlite produces error",
+			settings: socket(DBTypeSQLite, "/tmp/db.sock"),
+			isError:  assert.Error,
+		},
+		{
+			desc:     "socket of oracle produces error",
+			settings: socket(DBTypeOracle, "/tmp/db.sock"),
+			isError:  assert.Error,
+		},
+		{
+			desc: "socket of a plugin",
+			settings: func() *Settings {
+				s := socket(DBTypeOracle, "/tmp/db.sock")()
+				s.Plugin = "/usr/local/bin/tables-to-go-mssql"
+				return s
+			},
+			isError: assert.NoError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			test.isError(t, test.settings().verifySocket())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package settings
+
+import "fmt"
+
+// hasTLSFiles reports whether any of the client certificate, its key or the
+// CA certificates is given.
+func (settings *Settings) hasTLSFiles() bool {
+	return settings.SSLCert != "" || settings.SSLKey != "" || settings.SSLRootCert != ""
+}
+
+// verifyTLS checks that the client certificate comes with its key and that
+// the certificates are supported by the database type.
+func (settings *Settings) verifyTLS() error {
+	if !settings.hasTLSFiles() {
+		return nil
+	}
+
+	if (settings.SSLCert == "") != (settings.SSLKey == "") {
+		return fmt.Errorf("client certificate needs both -ssl-cert and -ssl-key")
+	}
+
+	if settings.DSN != "" {
+		return fmt.Errorf("TLS certificates can not be combined with -dsn, the DSN holds them")
+	}
+
+	if settings.Plugin == "" && (settings.DbType == DBTypeSQLite || settings.DbType == DBTypeOracle) {
+		return fmt.Errorf("TLS certificates are not supported by %s", settings.DbType)
+	}
+
+	if settings.SSLMode == "disable" {
+		return fmt.Errorf("TLS certificates can not be combined with sslmode %q", settings.SSLMode)
+	}
+
+	return nil
+}
+
+// defaultSSLMode returns the sslmode if none is given: verify-full given the
+// CA certificates, require given only a client certificate or authenticating
+// by a token, which needs TLS, and disable otherwise.
+func (settings *Settings) defaultSSLMode() string {
+	switch {
+	case settings.SSLRootCert != "":
+		return "verify-full"
+	case settings.hasTLSFiles() || settings.UsesAuthToken():
+		return "require"
+	default:
+		return "disable"
+	}
+}
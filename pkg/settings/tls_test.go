@@ -0,0 +1,109 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettings_verifyTLS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "no certificates",
+			settings: New,
+			isError:  assert.NoError,
+		},
+		{
+			desc: "client certificate and CA certificates",
+			settings: func() *Settings {
+				s := New()
+				s.SSLCert = "/etc/ssl/client.pem"
+				s.SSLKey = "/etc/ssl/client.key"
+				s.SSLRootCert = "/etc/ssl/ca.pem"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "CA certificates of mysql",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeMySQL
+				s.SSLRootCert = "/etc/ssl/ca.pem"
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "client certificate without key produces error",
+			settings: func() *Settings {
+				s := New()
+				s.SSLCert = "/etc/ssl/client.pem"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "certificates combined with raw DSN produce error",
+			settings: func() *Settings {
+				s := New()
+				s.SSLRootCert = "/etc/ssl/ca.pem"
+				s.DSN = "host=db.local sslmode=verify-full"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "certificates of oracle produce error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeOracle
+				s.SSLRootCert = "/etc/ssl/ca.pem"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "certificates with disabled sslmode produce error",
+			settings: func() *Settings {
+				s := New()
+				s.SSLRootCert = "/etc/ssl/ca.pem"
+				s.SSLMode = "disable"
+				return s
+			},
+			isError: assert.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			test.isError(t, test.settings().verifyTLS())
+		})
+	}
+}
+
+func TestSettings_defaultSSLMode(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	assert.Equal(t, "disable", s.defaultSSLMode())
+
+	s.Auth = AuthModeRDSIAM
+	assert.Equal(t, "require", s.defaultSSLMode())
+	s.Auth = AuthModeAzureCLI
+	assert.Equal(t, "require", s.defaultSSLMode())
+	s.Auth = AuthModePassword
+
+	s.SSLCert = "/etc/ssl/client.pem"
+	s.SSLKey = "/etc/ssl/client.key"
+	assert.Equal(t, "require", s.defaultSSLMode())
+
+	s.SSLRootCert = "/etc/ssl/ca.pem"
+	assert.Equal(t, "verify-full", s.defaultSSLMode())
+}
@@ -0,0 +1,169 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// TypeMapRule maps the columns of a data type, of a database type and of
+// table.column patterns, if given, to a Go type.
+type TypeMapRule struct {
+	// DbType is the database type the rule applies to, all if empty
+	DbType DBType `yaml:"db" toml:"db"`
+	// Type is the pattern of the data type of the columns, e.g. uuid or
+	// varchar(*), matched case-insensitively against the data type and the
+	// full column type
+	Type string `yaml:"type" toml:"type"`
+	// Column is the pattern of the columns as table.column, or column for
+	// the ones of all tables
+	Column string `yaml:"column" toml:"column"`
+	// GoType is the Go type of the columns, qualified by the import path of
+	// its package like github.com/google/uuid.UUID unless Imports are given
+	GoType string `yaml:"go" toml:"go"`
+	// Nullable is the Go type of the nullable columns, by default a pointer
+	// to GoType
+	Nullable string `yaml:"nullable" toml:"nullable"`
+	// Imports are the import paths the Go types need
+	Imports []string `yaml:"imports" toml:"imports"`
+}
+
+// typeMapFile is the file of TypeMapFile.
+type typeMapFile struct {
+	Mappings []TypeMapRule `yaml:"mappings" toml:"mappings"`
+}
+
+// The major versions of the import paths are no part of the names of the
+// packages, e.g. of github.com/jackc/pgx/v5 and gopkg.in/yaml.v3.
+var (
+	majorVersionElem   = regexp.MustCompile(`^v[0-9]+$`)
+	majorVersionSuffix = regexp.MustCompile(`\.v[0-9]+$`)
+)
+
+// loadTypeMap reads the rules of TypeMapFile, the YAML or TOML (by the
+// extension .toml) file, into TypeMap.
+func (settings *Settings) loadTypeMap() error {
+	if settings.TypeMapFile == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(settings.TypeMapFile)
+	if err != nil {
+		return fmt.Errorf("could not read type map %q: %w", settings.TypeMapFile, err)
+	}
+
+	unmarshal := yaml.Unmarshal
+	if strings.EqualFold(filepath.Ext(settings.TypeMapFile), ".toml") {
+		unmarshal = toml.Unmarshal
+	}
+	var file typeMapFile
+	if err = unmarshal(content, &file); err != nil {
+		return fmt.Errorf("could not parse type map %q: %w", settings.TypeMapFile, err)
+	}
+
+	settings.TypeMap = make([]TypeMapRule, 0, len(file.Mappings))
+	for i, rule := range file.Mappings {
+		if err = rule.prepare(); err != nil {
+			return fmt.Errorf("invalid type map %q: mapping %d: %w", settings.TypeMapFile, i+1, err)
+		}
+		settings.TypeMap = append(settings.TypeMap, rule)
+	}
+	return nil
+}
+
+// prepare verifies the rule and resolves its Go types qualified by the import
+// paths of their packages.
+func (rule *TypeMapRule) prepare() error {
+	if rule.DbType != "" && !SupportedDbTypes[rule.DbType] {
+		return fmt.Errorf("database type %q not supported, must be one of: %v", rule.DbType, SprintfSupportedDbTypes())
+	}
+	if rule.Type == "" && rule.Column == "" {
+		return errors.New("type or column needed")
+	}
+	for _, pattern := range []string{rule.Type, rule.Column} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q", pattern)
+		}
+	}
+	if rule.GoType == "" {
+		return errors.New("go type needed")
+	}
+
+	if rule.Nullable == "" {
+		rule.Nullable = rule.GoType
+		if !strings.HasPrefix(rule.GoType, "*") && !strings.HasPrefix(rule.GoType, "[]") && !strings.HasPrefix(rule.GoType, "map[") {
+			rule.Nullable = "*" + rule.GoType
+		}
+	}
+	if len(rule.Imports) > 0 {
+		return nil
+	}
+
+	var imp string
+	rule.GoType, imp = qualifiedType(rule.GoType)
+	if imp != "" {
+		rule.Imports = append(rule.Imports, imp)
+	}
+	rule.Nullable, imp = qualifiedType(rule.Nullable)
+	if imp != "" && (len(rule.Imports) == 0 || rule.Imports[0] != imp) {
+		rule.Imports = append(rule.Imports, imp)
+	}
+	return nil
+}
+
+// qualifiedType returns the Go type qualified by the import path of its
+// package, like *github.com/google/uuid.UUID, as qualified by the name of the
+// package, *uuid.UUID, and the import path. Types of no package are returned
+// as they are.
+func qualifiedType(goType string) (string, string) {
+	name := strings.TrimLeft(goType, "*[]")
+	prefix := goType[:len(goType)-len(name)]
+
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 || strings.ContainsAny(name, "[]") {
+		return goType, ""
+	}
+	imp := name[:dot]
+
+	elems := strings.Split(imp, "/")
+	pkg := elems[len(elems)-1]
+	if len(elems) > 1 && majorVersionElem.MatchString(pkg) {
+		pkg = elems[len(elems)-2]
+	}
+	pkg = majorVersionSuffix.ReplaceAllString(pkg, "")
+	return prefix + pkg + name[dot:], imp
+}
+
+// TypeMapRuleOf returns the first rule of the TypeMap matching the column of
+// the table by its data type and column type.
+func (settings *Settings) TypeMapRuleOf(table, column, dataType, columnType string) (TypeMapRule, bool) {
+	for _, rule := range settings.TypeMap {
+		if rule.DbType != "" && rule.DbType != settings.DbType {
+			continue
+		}
+		if rule.Type != "" && !matchFold(rule.Type, dataType) && !matchFold(rule.Type, columnType) {
+			continue
+		}
+		if rule.Column != "" && !matchFold(rule.Column, column) && !matchFold(rule.Column, table+"."+column) {
+			continue
+		}
+		return rule, true
+	}
+	return TypeMapRule{}, false
+}
+
+// matchFold returns true if the name matches the pattern case-insensitively.
+func matchFold(pattern, name string) bool {
+	if name == "" {
+		return false
+	}
+	ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return ok
+}
@@ -0,0 +1,221 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTypeMap(t *testing.T) {
+	t.Parallel()
+
+	expected := []TypeMapRule{
+		{
+			DbType:   DBTypePostgresql,
+			Type:     "uuid",
+			GoType:   "uuid.UUID",
+			Nullable: "*uuid.UUID",
+			Imports:  []string{"github.com/google/uuid"},
+		},
+		{
+			Column:   "orders.total",
+			GoType:   "decimal.Decimal",
+			Nullable: "decimal.NullDecimal",
+			Imports:  []string{"github.com/shopspring/decimal"},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		file     string
+		content  string
+		expected string
+	}{
+		{
+			desc: "yaml",
+			file: "mappings.yaml",
+			content: `mappings:
+  - db: pg
+    type: uuid
+    go: github.com/google/uuid.UUID
+  - column: orders.total
+    go: github.com/shopspring/decimal.Decimal
+    nullable: github.com/shopspring/decimal.NullDecimal
+`,
+		},
+		{
+			desc: "toml",
+			file: "mappings.toml",
+			content: `[[mappings]]
+db = "pg"
+type = "uuid"
+go = "github.com/google/uuid.UUID"
+
+[[mappings]]
+column = "orders.total"
+go = "github.com/shopspring/decimal.Decimal"
+nullable = "github.com/shopspring/decimal.NullDecimal"
+`,
+		},
+		{
+			desc: "invalid mapping",
+			file: "mappings.yaml",
+			content: `mappings:
+  - type: uuid
+    go: github.com/google/uuid.UUID
+  - type: json
+`,
+			expected: `invalid type map "%s": mapping 2: go type needed`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), test.file)
+			assert.NoError(t, os.WriteFile(file, []byte(test.content), 0666))
+
+			s := New()
+			s.TypeMapFile = file
+			err := s.loadTypeMap()
+			if test.expected != "" {
+				assert.EqualError(t, err, fmt.Sprintf(test.expected, file))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, expected, s.TypeMap)
+		})
+	}
+}
+
+func TestTypeMapRule_Prepare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		rule     TypeMapRule
+		expected TypeMapRule
+		err      string
+	}{
+		{
+			desc:     "builtin type",
+			rule:     TypeMapRule{Type: "jsonb", GoType: "[]byte"},
+			expected: TypeMapRule{Type: "jsonb", GoType: "[]byte", Nullable: "[]byte"},
+		},
+		{
+			desc:     "pointer to standard library type",
+			rule:     TypeMapRule{Type: "interval", GoType: "time.Duration"},
+			expected: TypeMapRule{Type: "interval", GoType: "time.Duration", Nullable: "*time.Duration", Imports: []string{"time"}},
+		},
+		{
+			desc:     "major version",
+			rule:     TypeMapRule{Type: "inet", GoType: "github.com/jackc/pgx/v5/pgtype.Inet", Nullable: "github.com/jackc/pgx/v5/pgtype.Inet"},
+			expected: TypeMapRule{Type: "inet", GoType: "pgtype.Inet", Nullable: "pgtype.Inet", Imports: []string{"github.com/jackc/pgx/v5/pgtype"}},
+		},
+		{
+			desc:     "nullable of another package",
+			rule:     TypeMapRule{Type: "numeric", GoType: "github.com/shopspring/decimal.Decimal", Nullable: "database/sql.NullString"},
+			expected: TypeMapRule{Type: "numeric", GoType: "decimal.Decimal", Nullable: "sql.NullString", Imports: []string{"github.com/shopspring/decimal", "database/sql"}},
+		},
+		{
+			desc:     "given imports",
+			rule:     TypeMapRule{Column: "*.tags", GoType: "pq.StringArray", Imports: []string{"github.com/lib/pq"}},
+			expected: TypeMapRule{Column: "*.tags", GoType: "pq.StringArray", Nullable: "*pq.StringArray", Imports: []string{"github.com/lib/pq"}},
+		},
+		{
+			desc: "unsupported database type",
+			rule: TypeMapRule{DbType: "db2", Type: "uuid", GoType: "string"},
+			err:  `database type "db2" not supported, must be one of: ` + SprintfSupportedDbTypes(),
+		},
+		{
+			desc: "no type or column",
+			rule: TypeMapRule{GoType: "string"},
+			err:  "type or column needed",
+		},
+		{
+			desc: "invalid pattern",
+			rule: TypeMapRule{Type: "varchar[", GoType: "string"},
+			err:  `invalid pattern "varchar["`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := test.rule.prepare()
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.rule)
+		})
+	}
+}
+
+func TestQualifiedType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		goType   string
+		expected string
+		imp      string
+	}{
+		{goType: "string", expected: "string"},
+		{goType: "[]byte", expected: "[]byte"},
+		{goType: "map[string]any", expected: "map[string]any"},
+		{goType: "json.RawMessage", expected: "json.RawMessage", imp: "json"},
+		{goType: "encoding/json.RawMessage", expected: "json.RawMessage", imp: "encoding/json"},
+		{goType: "*github.com/google/uuid.UUID", expected: "*uuid.UUID", imp: "github.com/google/uuid"},
+		{goType: "[]github.com/google/uuid.UUID", expected: "[]uuid.UUID", imp: "github.com/google/uuid"},
+		{goType: "github.com/jackc/pgx/v5.Identifier", expected: "pgx.Identifier", imp: "github.com/jackc/pgx/v5"},
+		{goType: "gopkg.in/yaml.v3.Node", expected: "yaml.Node", imp: "gopkg.in/yaml.v3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.goType, func(t *testing.T) {
+			actual, imp := qualifiedType(test.goType)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.imp, imp)
+		})
+	}
+}
+
+func TestSettings_TypeMapRuleOf(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	s.DbType = DBTypeMySQL
+	s.TypeMap = []TypeMapRule{
+		{DbType: DBTypePostgresql, Type: "uuid", GoType: "pg"},
+		{Column: "orders.id", GoType: "order"},
+		{Type: "uuid", GoType: "uuid"},
+		{Type: "varchar(36)", Column: "*_uuid", GoType: "varchar"},
+		{Type: "tinyint(1)", GoType: "bool"},
+	}
+
+	tests := []struct {
+		desc       string
+		table      string
+		column     string
+		dataType   string
+		columnType string
+		expected   string
+	}{
+		{desc: "other database type", table: "users", column: "id", dataType: "uuid", expected: "uuid"},
+		{desc: "table and column", table: "orders", column: "ID", dataType: "int", expected: "order"},
+		{desc: "column of other table", table: "users", column: "id", dataType: "int"},
+		{desc: "type and column", table: "users", column: "group_uuid", dataType: "varchar", columnType: "VARCHAR(36)", expected: "varchar"},
+		{desc: "type not matching column", table: "users", column: "name", dataType: "varchar", columnType: "varchar(36)"},
+		{desc: "column type", table: "users", column: "active", dataType: "tinyint", columnType: "tinyint(1)", expected: "bool"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			rule, ok := s.TypeMapRuleOf(test.table, test.column, test.dataType, test.columnType)
+			assert.Equal(t, test.expected != "", ok)
+			assert.Equal(t, test.expected, rule.GoType)
+		})
+	}
+}
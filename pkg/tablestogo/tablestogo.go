@@ -0,0 +1,224 @@
+// Package tablestogo is the entry point to embed tables-to-go into other
+// tools instead of running the binary. Run does what the binary does without
+// a command, Introspect and Generate are its two steps.
+//
+// The generation keeps its state in package variables, hence only one
+// generation may run at a time.
+package tablestogo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/internal/cli"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Result is the outcome of a generation.
+type Result struct {
+	// Tables are the names of the generated tables
+	Tables []string
+	// Files are the paths of the written files in the order of writing
+	Files []string
+}
+
+// Run verifies the settings, reads the password from its source, connects to
+// the database, or reads the schema file of FromIR, the SQL files of FromSQL
+// or the DBML file of FromDBML, and generates the structs of its tables into
+// the output path of the settings, or of every schema of the settings. It
+// stops before the next table once the context is done.
+func Run(ctx context.Context, s *settings.Settings) (Result, error) {
+	return run(ctx, s, nil)
+}
+
+// RunTo does what Run does but writes the files to the target instead of the
+// output path of the settings, e.g. to an output.MemoryTarget capturing the
+// generated code without touching the disk. The files of every schema of the
+// settings go to the same target, the Files of the Result are their names in
+// it.
+func RunTo(ctx context.Context, s *settings.Settings, target output.Target) (Result, error) {
+	return run(ctx, s, target)
+}
+
+// RunInMemory does what Run does but returns the generated files by their
+// names instead of writing them, i.e. RunTo with an output.MemoryTarget.
+// Continuing on errors the files of the other tables are returned along with
+// the TableErrors.
+func RunInMemory(ctx context.Context, s *settings.Settings) (map[string][]byte, error) {
+	target := output.NewMemoryTarget()
+	if _, err := RunTo(ctx, s, target); err != nil {
+		var tableErrs TableErrors
+		if !errors.As(err, &tableErrs) {
+			return nil, err
+		}
+		return target.Files(), err
+	}
+	return target.Files(), nil
+}
+
+func run(ctx context.Context, s *settings.Settings, target output.Target) (Result, error) {
+	if err := s.Verify(); err != nil {
+		return Result{}, err
+	}
+	if err := s.ReadPassword(); err != nil {
+		return Result{}, err
+	}
+
+	db, err := cli.OpenDatabase(s)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := db.Connect(ctx); err != nil {
+		return Result{}, err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			slog.Error("could not close the database connection", "error", err)
+		}
+	}()
+
+	var (
+		result   Result
+		failures TableErrors
+	)
+	err = s.ForEachSchema(func() error {
+		tables, err := Introspect(ctx, s, db)
+		if err = collect(&failures, err); err != nil {
+			return err
+		}
+		out, path := output.NewFileWriter(s.OutputFilePath), s.OutputFilePath
+		if target != nil {
+			out, path = output.NewTargetWriter(target), ""
+		}
+		schemaResult, err := generate(s, db, tables, out, path)
+		result.Tables = append(result.Tables, schemaResult.Tables...)
+		result.Files = append(result.Files, schemaResult.Files...)
+		return collect(&failures, err)
+	})
+	if err == nil && len(failures) > 0 {
+		err = failures
+	}
+	return result, err
+}
+
+// collect adds the failures of err to the failures and returns nil if it is
+// TableErrors, otherwise it returns err.
+func collect(failures *TableErrors, err error) error {
+	var tableErrs TableErrors
+	if !errors.As(err, &tableErrs) {
+		return err
+	}
+	*failures = append(*failures, tableErrs...)
+	return nil
+}
+
+// Introspect returns the tables of the connected database selected by the
+// settings with their columns. Continuing on errors the tables whose columns
+// can not be read are returned as TableErrors along with the other tables.
+func Introspect(ctx context.Context, s *settings.Settings, db database.Database) ([]*database.Table, error) {
+	return cli.Introspect(ctx, s, db)
+}
+
+// Generate writes the structs of the tables and the helper types they need
+// to out, continuing on errors along with the TableErrors of the failing
+// tables. The database provides the mapping of the column types, it does not
+// need to be connected. Writers only recording the changes like the
+// output.DryRunWriter write no files, hence the Files of the Result are
+// empty then.
+func Generate(s *settings.Settings, db database.Database, tables []*database.Table, out output.Writer) (Result, error) {
+	return generate(s, db, tables, out, s.OutputFilePath)
+}
+
+// generate is Generate recording the files written in the given path.
+func generate(s *settings.Settings, db database.Database, tables []*database.Table, out output.Writer, path string) (Result, error) {
+	if _, ok := out.(changeRecorder); ok {
+		generated, err := cli.Generate(s, db, tables, out)
+		return Result{Tables: generated}, err
+	}
+
+	recorder := &recordingWriter{Writer: out, path: path}
+	generated, err := cli.Generate(s, db, tables, recorder)
+	return Result{Tables: generated, Files: recorder.files}, err
+}
+
+// changeRecorder is implemented by the writers only recording the changes.
+type changeRecorder interface {
+	Changes() map[string]output.Change
+}
+
+// recordingWriter records the paths of the files written by the Writer.
+type recordingWriter struct {
+	output.Writer
+	path  string
+	files []string
+}
+
+// Write is the implementation of the Writer interface.
+func (w *recordingWriter) Write(tableName string, content string) error {
+	if err := w.Writer.Write(tableName, content); err != nil {
+		return err
+	}
+	w.record(tableName + output.FileWriterExtension)
+	return nil
+}
+
+// WriteRaw is the implementation of the RawWriter interface, writers not
+// implementing it can not write the files of other formatters than the Go
+// one.
+func (w *recordingWriter) WriteRaw(name string, content []byte) error {
+	raw, ok := w.Writer.(output.RawWriter)
+	if !ok {
+		return fmt.Errorf("writer can not write %s as it is", name)
+	}
+	if err := raw.WriteRaw(name, content); err != nil {
+		return err
+	}
+	w.record(name)
+	return nil
+}
+
+// record records the file of the given name got written.
+func (w *recordingWriter) record(name string) {
+	file := filepath.Join(w.path, name)
+	if !slices.Contains(w.files, file) {
+		w.files = append(w.files, file)
+	}
+}
+
+// NamingStrategy names the structs generated of the tables, their fields and
+// their files, e.g. to implement the naming conventions of a company once.
+type NamingStrategy = cli.NamingStrategy
+
+// DefaultNaming is the naming by the settings, the binary uses it. Custom
+// strategies can embed it to only change parts of the naming.
+type DefaultNaming = cli.DefaultNaming
+
+// SetNamingStrategy replaces the naming of the following generations by the
+// strategy, nil restores the DefaultNaming.
+func SetNamingStrategy(strategy NamingStrategy) {
+	cli.SetNamingStrategy(strategy)
+}
+
+// Hooks get called during the generation, e.g. to collect metrics, drive a
+// progress UI or veto tables, instead of tables-to-go printing anything.
+type Hooks = cli.Hooks
+
+// SetHooks replaces the hooks of the following generations, the zero Hooks
+// remove them.
+func SetHooks(hooks Hooks) {
+	cli.SetHooks(hooks)
+}
+
+// TableError is the failure of a table, or of a column of it.
+type TableError = cli.TableError
+
+// TableErrors are the failures of the tables of a run continuing on errors,
+// returned at its end. Run and RunTo return them along with the Result of the
+// other tables.
+type TableErrors = cli.TableErrors
@@ -0,0 +1,358 @@
+package tablestogo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go/token"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database/fake"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/ir"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/output"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// newDB returns the fake database of the tables, the columns of the
+// audit_log can not be read.
+func newDB(s *settings.Settings, tables []*database.Table) *fake.Database {
+	return fake.New(s, tables...).FailColumns("audit_log", errors.New("permission denied"))
+}
+
+func newTables() []*database.Table {
+	return []*database.Table{
+		{
+			Name: "users",
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+				{OrdinalPosition: 2, Name: "name", DataType: "text", IsNullable: "YES"},
+			},
+		},
+		{
+			Name: "audit_log",
+		},
+	}
+}
+
+func TestIntrospect(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	db := newDB(s, newTables())
+
+	_, err := Introspect(context.Background(), s, db)
+	assert.EqualError(t, err, `could not get columns of table "audit_log": permission denied`)
+
+	s.Force = true
+	tables, err := Introspect(context.Background(), s, db)
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "users", tables[0].Name)
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+
+	s := settings.New()
+	s.OutputFilePath = dir
+	s.Null = settings.NullTypeJSON
+	db := database.New(s)
+
+	result, err := Generate(s, db, newTables()[:1], output.NewFileWriter(dir))
+	assert.NoError(t, err)
+	assert.Equal(t, Result{
+		Tables: []string{"users"},
+		Files:  []string{filepath.Join(dir, "Users.go"), filepath.Join(dir, "nulltypes_gen.go")},
+	}, result)
+
+	for _, file := range result.Files {
+		_, err = os.Stat(file)
+		assert.NoError(t, err)
+	}
+
+	result, err = Generate(s, db, newTables()[:1], output.NewDryRunWriter(dir))
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Tables: []string{"users"}}, result)
+}
+
+func TestRunTo(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "schema.sql")
+	assert.NoError(t, os.WriteFile(schema, []byte("CREATE TABLE users (id serial PRIMARY KEY, name text);"), 0o600))
+
+	s := settings.New()
+	s.OutputFilePath = dir
+	s.FromSQL = settings.StringsFlag{schema}
+	target := output.NewMemoryTarget()
+
+	result, err := RunTo(context.Background(), s, target)
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Tables: []string{"users"}, Files: []string{"Users.go"}}, result)
+
+	content, err := fs.ReadFile(target.FS(), "Users.go")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "type Users struct")
+
+	_, err = os.Stat(filepath.Join(dir, "Users.go"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRunInMemory(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "schema.sql")
+	assert.NoError(t, os.WriteFile(schema, []byte("CREATE TABLE users (id serial PRIMARY KEY, name text);"), 0o600))
+
+	s := settings.New()
+	s.OutputFilePath = dir
+	s.FromSQL = settings.StringsFlag{schema}
+
+	files, err := RunInMemory(context.Background(), s)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Contains(t, string(files["Users.go"]), "type Users struct")
+
+	_, err = os.Stat(filepath.Join(dir, "Users.go"))
+	assert.True(t, os.IsNotExist(err))
+
+	s = settings.New()
+	s.FromSQL = settings.StringsFlag{filepath.Join(dir, "missing.sql")}
+	files, err = RunInMemory(context.Background(), s)
+	assert.Error(t, err)
+	assert.Nil(t, files)
+}
+
+// prefixNaming prefixes the structs and their files by "T" and names the
+// fields like the columns otherwise the default way.
+type prefixNaming struct {
+	DefaultNaming
+}
+
+func (n prefixNaming) StructName(s *settings.Settings, table string) (string, error) {
+	name, err := n.DefaultNaming.StructName(s, table)
+	return "T" + name, err
+}
+
+func (prefixNaming) FieldName(_ *settings.Settings, _, column string) (string, error) {
+	return strings.ToUpper(column), nil
+}
+
+func (prefixNaming) FileName(_ *settings.Settings, table, _ string) string {
+	return "t_" + table
+}
+
+func TestSetNamingStrategy(t *testing.T) {
+	SetNamingStrategy(prefixNaming{})
+	defer SetNamingStrategy(nil)
+
+	s := settings.New()
+	target := output.NewMemoryTarget()
+
+	result, err := Generate(s, database.New(s), newTables()[:1], output.NewTargetWriter(target))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"t_users.go"}, slices.Collect(maps.Keys(target.Files())))
+	assert.Len(t, result.Files, 1)
+
+	content := string(target.Files()["t_users.go"])
+	assert.Contains(t, content, "type TUsers struct")
+	assert.Contains(t, content, "ID ")
+	assert.Contains(t, content, "NAME ")
+}
+
+func TestGenerateFormatter(t *testing.T) {
+	output.RegisterFormatter("names", output.FormatterFunc(func(_ *settings.Settings, schema *ir.Schema) ([]output.FormattedFile, error) {
+		names := make([]string, 0, len(schema.Tables))
+		for _, table := range schema.Tables {
+			names = append(names, table.Name)
+		}
+		return []output.FormattedFile{{Name: "tables.txt", Content: []byte(strings.Join(names, "\n"))}}, nil
+	}))
+
+	s := settings.New()
+	s.Formatter = "names"
+	target := output.NewMemoryTarget()
+
+	result, err := Generate(s, database.New(s), newTables()[:1], output.NewTargetWriter(target))
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Tables: []string{"users"}, Files: []string{filepath.Join(s.OutputFilePath, "tables.txt")}}, result)
+	assert.Equal(t, map[string][]byte{"tables.txt": []byte("users")}, target.Files())
+
+	// the Go structs are a formatter like any other
+	s = settings.New()
+	formatter, err := output.LookupFormatter(settings.FormatterGo)
+	assert.NoError(t, err)
+	files, err := formatter.Format(s, ir.FromTables(s, newTables()[:1]))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, "Users.go", files[0].Name)
+	assert.Contains(t, string(files[0].Content), "type Users struct")
+}
+
+func TestSetHooks(t *testing.T) {
+	var started, mapped, written, warnings []string
+	SetHooks(Hooks{
+		OnTableStart: func(table *database.Table) bool {
+			started = append(started, table.Name)
+			return table.Name != "orders"
+		},
+		OnColumnMapped: func(table *database.Table, column database.Column, goType string) {
+			mapped = append(mapped, table.Name+"."+column.Name+" "+goType)
+		},
+		OnFileWritten: func(name string) {
+			written = append(written, name)
+		},
+		OnWarning: func(message string) {
+			warnings = append(warnings, message)
+		},
+	})
+	defer SetHooks(Hooks{})
+
+	tables := append(newTables()[:1], &database.Table{
+		Name:    "orders",
+		Columns: []database.Column{{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"}},
+	}, &database.Table{
+		Name:    "points",
+		Columns: []database.Column{{OrdinalPosition: 1, Name: "location", DataType: "geometry", IsNullable: "NO"}},
+	})
+
+	s := settings.New()
+	target := output.NewMemoryTarget()
+	result, err := Generate(s, database.New(s), tables, output.NewTargetWriter(target))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"users", "points"}, result.Tables)
+
+	assert.Equal(t, []string{"users", "orders", "points"}, started)
+	assert.Equal(t, []string{"users.id int", "users.name sql.NullString", "points.location string"}, mapped)
+	assert.Equal(t, []string{"Users.go", "Points.go"}, written)
+	assert.Equal(t, []string{`no mapping for the type of points.location (geometry), generated as string`}, warnings)
+
+	// dry runs write no files
+	written = nil
+	_, err = Generate(s, database.New(s), tables, output.NewDryRunWriter(t.TempDir()))
+	assert.NoError(t, err)
+	assert.Empty(t, written)
+}
+
+// strictNaming fails to name the fields of columns which are no Go
+// identifiers instead of sanitizing them.
+type strictNaming struct {
+	DefaultNaming
+}
+
+func (strictNaming) FieldName(_ *settings.Settings, table, column string) (string, error) {
+	if !token.IsIdentifier(column) {
+		return "", fmt.Errorf("column name %q in table %q contains invalid characters", column, table)
+	}
+	return strings.ToUpper(column), nil
+}
+
+func TestContinueOnError(t *testing.T) {
+	SetNamingStrategy(strictNaming{})
+	defer SetNamingStrategy(nil)
+
+	s := settings.New()
+	s.ContinueOnError = true
+	tables := append(newTables(), &database.Table{
+		Name:    "events",
+		Columns: []database.Column{{OrdinalPosition: 1, Name: "a-b", DataType: "integer", IsNullable: "NO"}},
+	})
+	db := newDB(s, tables)
+
+	introspected, err := Introspect(context.Background(), s, db)
+	var failures TableErrors
+	assert.ErrorAs(t, err, &failures)
+	assert.Len(t, introspected, 2)
+
+	target := output.NewMemoryTarget()
+	result, err := Generate(s, db, introspected, output.NewTargetWriter(target))
+	assert.Equal(t, []string{"users"}, result.Tables)
+	assert.ErrorAs(t, err, &failures)
+
+	var tableErr *TableError
+	assert.ErrorAs(t, err, &tableErr)
+	assert.Equal(t, "events", tableErr.Table)
+	assert.Equal(t, "a-b", tableErr.Column)
+	assert.EqualError(t, err, "1 tables failed:\n  "+`column "a-b" of table "events": column name "a-b" in table "events" contains invalid characters`)
+}
+
+// syntheticTables returns the tables of a synthetic schema, with an id, a
+// foreign key and columns of the common types.
+func syntheticTables(count, columns int) []*database.Table {
+	types := []string{"integer", "character varying", "text", "boolean", "timestamp without time zone", "numeric", "jsonb"}
+
+	tables := make([]*database.Table, count)
+	for i := range tables {
+		table := &database.Table{Name: fmt.Sprintf("table_%04d", i)}
+		table.Columns = append(table.Columns,
+			database.Column{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO",
+				ConstraintName: sql.NullString{String: table.Name + "_pkey", Valid: true}, ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true}},
+			database.Column{OrdinalPosition: 2, Name: "parent_id", DataType: "integer", IsNullable: "YES",
+				ConstraintName: sql.NullString{String: table.Name + "_parent_id_fkey", Valid: true}, ConstraintType: sql.NullString{String: "FOREIGN KEY", Valid: true}},
+		)
+		for j := len(table.Columns); j < columns; j++ {
+			nullable := "NO"
+			if j%2 == 0 {
+				nullable = "YES"
+			}
+			table.Columns = append(table.Columns, database.Column{
+				OrdinalPosition: j + 1,
+				Name:            fmt.Sprintf("column_%02d", j),
+				DataType:        types[j%len(types)],
+				IsNullable:      nullable,
+			})
+		}
+		tables[i] = table
+	}
+	return tables
+}
+
+func BenchmarkIntrospect(b *testing.B) {
+	for _, size := range []int{10, 100, 1000} {
+		for _, jobs := range []int{1, 8} {
+			b.Run(fmt.Sprintf("tables=%d/jobs=%d", size, jobs), func(b *testing.B) {
+				s := settings.New()
+				s.Jobs = jobs
+				// the latency of a database in the same network
+				db := fake.New(s, syntheticTables(size, 20)...).Latency(100 * time.Microsecond)
+
+				b.ResetTimer()
+				for range b.N {
+					if _, err := Introspect(context.Background(), s, db); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	for _, size := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("tables=%d", size), func(b *testing.B) {
+			s := settings.New()
+			s.TagsJSON = true
+			s.TagsGorm = true
+			db := fake.New(s)
+			tables := syntheticTables(size, 20)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				target := output.NewMemoryTarget()
+				if _, err := Generate(s, db, tables, output.NewTargetWriter(target)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
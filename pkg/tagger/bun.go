@@ -0,0 +1,36 @@
+package tagger
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+// Bun represents the uptrace/bun "bun"-tag.
+type Bun struct{}
+
+// GenerateTag for Bun to satisfy the Tagger interface.
+func (t Bun) GenerateTag(db database.Database, column database.Column) string {
+	tag := column.Name
+
+	isPk := db.IsPrimaryKey(column)
+	if isPk {
+		tag += ",pk"
+	}
+
+	isAutoIncrement := db.IsAutoIncrement(column)
+	if isAutoIncrement {
+		tag += ",autoincrement"
+	}
+
+	// primary keys are implicitly not null for bun
+	if !isPk && !db.IsNullable(column) {
+		tag += ",notnull"
+	}
+
+	// zero values should be inserted as NULL / DEFAULT to let the database
+	// fill in the default value.
+	if isAutoIncrement || column.DefaultValue.Valid {
+		tag += ",nullzero"
+	}
+
+	return `bun:"` + tag + `"`
+}
@@ -0,0 +1,98 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestBun_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		desc     string
+		column   database.Column
+		expected string
+	}
+
+	tests := map[settings.DBType][]test{
+		settings.DBTypePostgresql: {
+			{
+				desc: "nullable column generates only the column name",
+				column: database.Column{
+					Name:       "column_name",
+					IsNullable: "YES",
+				},
+				expected: `bun:"column_name"`,
+			},
+			{
+				desc: "not nullable column generates notnull",
+				column: database.Column{
+					Name:       "column_name",
+					IsNullable: "NO",
+				},
+				expected: `bun:"column_name,notnull"`,
+			},
+			{
+				desc: "serial PK column generates pk, autoincrement and nullzero",
+				column: database.Column{
+					Name:       "id",
+					IsNullable: "NO",
+					DefaultValue: sql.NullString{
+						String: "nextval('foo_id_seq'::regclass)",
+						Valid:  true,
+					},
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+				},
+				expected: `bun:"id,pk,autoincrement,nullzero"`,
+			},
+			{
+				desc: "column with default generates nullzero",
+				column: database.Column{
+					Name:       "created_at",
+					IsNullable: "NO",
+					DefaultValue: sql.NullString{
+						String: "now()",
+						Valid:  true,
+					},
+				},
+				expected: `bun:"created_at,notnull,nullzero"`,
+			},
+		},
+		settings.DBTypeMySQL: {
+			{
+				desc: "auto increment PK column generates pk, autoincrement and nullzero",
+				column: database.Column{
+					Name:       "id",
+					IsNullable: "NO",
+					ColumnKey:  "PRI",
+					Extra:      "auto_increment",
+				},
+				expected: `bun:"id,pk,autoincrement,nullzero"`,
+			},
+		},
+	}
+
+	tagger := new(Bun)
+
+	for dbType, tests := range tests {
+		t.Run(dbType.String(), func(t *testing.T) {
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+			for _, test := range tests {
+				t.Run(test.desc, func(t *testing.T) {
+					actual := tagger.GenerateTag(db, test.column)
+					assert.Equal(t, test.expected, actual)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package tagger
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// CSV represents the "csv"-tag of gocarina/gocsv.
+type CSV struct {
+	format    settings.TagNameFormat
+	overrides map[string]string
+}
+
+// NewCSV creates a new CSV tagger with the naming strategy and per-column
+// overrides given by the settings.
+func NewCSV(s *settings.Settings) *CSV {
+	return &CSV{
+		format:    s.TagsNameFormat,
+		overrides: s.TagsNames,
+	}
+}
+
+// GenerateTag for CSV to satisfy the Tagger interface.
+func (t CSV) GenerateTag(_ database.Database, column database.Column) string {
+	return `csv:"` + tagName(t.format, t.overrides, column.Name) + `"`
+}
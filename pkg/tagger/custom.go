@@ -0,0 +1,225 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// customFuncs are the functions available in custom tag templates, the
+// built-in ones and the ones of RegisterTemplateFunc.
+var customFuncs = template.FuncMap{
+	"camel":  strcase.ToLowerCamel,
+	"pascal": strcase.ToCamel,
+	"snake":  strcase.ToSnake,
+	"lower":  strings.ToLower,
+	"upper":  strings.ToUpper,
+}
+
+// RegisterTemplateFunc makes the function available by its name in custom tag
+// templates, e.g. a casing or comment wrapping helper of the templates of a
+// company. The function follows the rules of text/template.FuncMap.
+// RegisterTemplateFunc is meant to be called in an init function and panics
+// if the name is empty or already defined, or if fn is no function.
+func RegisterTemplateFunc(name string, fn any) {
+	if name == "" {
+		panic("tagger: template function name is empty")
+	}
+	if _, ok := customFuncs[name]; ok {
+		panic(fmt.Sprintf("tagger: template function %q is already defined", name))
+	}
+	if fn == nil || reflect.TypeOf(fn).Kind() != reflect.Func {
+		panic(fmt.Sprintf("tagger: template function %q is no function", name))
+	}
+	customFuncs[name] = fn
+}
+
+// TemplateFuncs returns the functions available in custom tag templates, the
+// built-in ones and the ones of RegisterTemplateFunc, for other templates of
+// the generated code.
+func TemplateFuncs() template.FuncMap {
+	return maps.Clone(customFuncs)
+}
+
+// expressionFuncs returns the functions defined by the template expressions
+// of the given names, each executing its expression on its argument as dot.
+// The expressions can use the functions of customFuncs but not each other.
+func expressionFuncs(expressions map[string]string) (template.FuncMap, error) {
+	funcs := make(template.FuncMap, len(expressions))
+	for name, expression := range expressions {
+		if _, ok := customFuncs[name]; ok {
+			return nil, fmt.Errorf("custom tag function %q is already defined", name)
+		}
+		tmpl, err := template.New(name).Funcs(customFuncs).Parse("{{ " + expression + " }}")
+		if err != nil {
+			return nil, fmt.Errorf("could not parse custom tag function %q: %w", name, err)
+		}
+		funcs[name] = func(arg any) (string, error) {
+			var result strings.Builder
+			err := tmpl.Execute(&result, arg)
+			return result.String(), err
+		}
+	}
+	return funcs, nil
+}
+
+// Custom represents a tag of any format generated from a user defined
+// template, e.g.
+//
+//	mytag:"{{ .Column.Name }}{{ if .IsPrimaryKey }},pk{{ end }}"
+//
+// and the tags of the keys of the custom tags whose values are generated
+// from their templates, e.g. bson:{{ .Column.Name }}. The templates are
+// executed with a CustomTagData for every column.
+type Custom struct {
+	text      string
+	tags      []settings.CustomTag
+	funcs     map[string]string
+	format    settings.TagNameFormat
+	overrides map[string]string
+
+	parsed bool
+	tmpl   *template.Template
+	keyed  []keyedTemplate
+	table  string
+}
+
+// keyedTemplate is the parsed template of the value of the tag of the key.
+type keyedTemplate struct {
+	key  string
+	tmpl *template.Template
+}
+
+// CustomTagData is the data custom tag templates get executed with.
+type CustomTagData struct {
+	Table  string
+	Column database.Column
+
+	// TagName is the name of the column following the naming strategy and
+	// the overrides of the serialization tags.
+	TagName string
+
+	IsPrimaryKey    bool
+	IsAutoIncrement bool
+	IsNullable      bool
+	IsString        bool
+	IsText          bool
+	IsInteger       bool
+	IsFloat         bool
+	IsTemporal      bool
+}
+
+// NewCustom creates a new Custom tagger with the template given by the
+// settings.
+func NewCustom(s *settings.Settings) *Custom {
+	return &Custom{
+		text:      s.TagsCustom,
+		tags:      s.CustomTags,
+		funcs:     s.TagsCustomFuncs,
+		format:    s.TagsNameFormat,
+		overrides: s.TagsNames,
+	}
+}
+
+// BeginTable sets the table the following columns belong to. The templates
+// are parsed and executed once on the first call to report errors in them
+// early.
+func (t *Custom) BeginTable(table string) error {
+	if !t.parsed {
+		funcs, err := expressionFuncs(t.funcs)
+		if err != nil {
+			return err
+		}
+		if t.text != "" {
+			if t.tmpl, err = parseCustomTemplate("tag", t.text, funcs); err != nil {
+				return fmt.Errorf("custom tag template: %w", err)
+			}
+		}
+		for _, tag := range t.tags {
+			tmpl, err := parseCustomTemplate(tag.Key, tag.Template, funcs)
+			if err != nil {
+				return fmt.Errorf("custom tag template of %q: %w", tag.Key, err)
+			}
+			t.keyed = append(t.keyed, keyedTemplate{key: tag.Key, tmpl: tmpl})
+		}
+		t.parsed = true
+	}
+	t.table = table
+	return nil
+}
+
+// parseCustomTemplate parses the template with the given functions and
+// executes it once without column to report errors in it early.
+func parseCustomTemplate(name, text string, funcs template.FuncMap) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(customFuncs).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse: %w", err)
+	}
+	// errors of functions depend on the column, e.g. slicing its name
+	err = tmpl.Execute(new(strings.Builder), CustomTagData{})
+	if err != nil && !isFuncError(err) {
+		return nil, fmt.Errorf("could not execute: %w", err)
+	}
+	return tmpl, nil
+}
+
+// isFuncError returns true if the error of executing a template is the one
+// returned by a function.
+func isFuncError(err error) bool {
+	var execErr template.ExecError
+	return errors.As(err, &execErr) && errors.Unwrap(execErr.Err) != nil
+}
+
+// GenerateTag for Custom to satisfy the Tagger interface.
+func (t *Custom) GenerateTag(db database.Database, column database.Column) string {
+	if !t.parsed {
+		return ""
+	}
+
+	data := CustomTagData{
+		Table:           t.table,
+		Column:          column,
+		TagName:         tagName(t.format, t.overrides, column.Name),
+		IsPrimaryKey:    db.IsPrimaryKey(column),
+		IsAutoIncrement: db.IsAutoIncrement(column),
+		IsNullable:      db.IsNullable(column),
+		IsString:        db.IsString(column),
+		IsText:          db.IsText(column),
+		IsInteger:       db.IsInteger(column),
+		IsFloat:         db.IsFloat(column),
+		IsTemporal:      db.IsTemporal(column),
+	}
+
+	var tags []string
+	if t.tmpl != nil {
+		if tag := executeCustomTemplate(t.tmpl, data); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	for _, keyed := range t.keyed {
+		if value := executeCustomTemplate(keyed.tmpl, data); value != "" {
+			tags = append(tags, keyed.key+`:"`+escapeTagValue(value)+`"`)
+		}
+	}
+
+	return strings.Join(tags, " ")
+}
+
+// executeCustomTemplate returns the trimmed output of the template for the
+// column. The template was already executed successfully in BeginTable,
+// errors depending on the column are rendered as nothing.
+func executeCustomTemplate(tmpl *template.Template, data CustomTagData) string {
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out.String())
+}
@@ -0,0 +1,200 @@
+package tagger
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestCustom_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		template string
+		tags     settings.CustomTagsFlag
+		funcs    settings.TemplateFuncsFlag
+		column   database.Column
+		expected string
+	}{
+		{
+			desc:     "template renders the column name",
+			template: `mytag:"{{ .Column.Name }}"`,
+			column: database.Column{
+				Name: "user_id",
+			},
+			expected: `mytag:"user_id"`,
+		},
+		{
+			desc:     "template renders conditions on the column",
+			template: `mytag:"{{ .Column.Name }}{{ if .IsPrimaryKey }},pk{{ end }}"`,
+			column: database.Column{
+				Name: "id",
+				ConstraintType: sql.NullString{
+					String: "PRIMARY KEY",
+					Valid:  true,
+				},
+			},
+			expected: `mytag:"id,pk"`,
+		},
+		{
+			desc:     "template renders the table and functions",
+			template: `mytag:"{{ .Table | upper }}.{{ camel .Column.Name }}"`,
+			column: database.Column{
+				Name: "user_id",
+			},
+			expected: `mytag:"TEST_TABLE.userId"`,
+		},
+		{
+			desc:     "template renders functions defined by expressions",
+			template: `mytag:"{{ short .Column.Name }}"`,
+			funcs:    settings.TemplateFuncsFlag{"short": "slice . 0 3 | upper"},
+			column: database.Column{
+				Name: "username",
+			},
+			expected: `mytag:"USE"`,
+		},
+		{
+			desc:     "template renders nothing",
+			template: `{{ if .IsNullable }}mytag:"nullable"{{ end }}`,
+			column: database.Column{
+				Name:       "user_id",
+				IsNullable: "NO",
+			},
+			expected: "",
+		},
+		{
+			desc: "custom tags render the values of their keys",
+			tags: settings.CustomTagsFlag{
+				{Key: "bson", Template: "{{ .Column.Name }}{{ if .IsNullable }},omitempty{{ end }}"},
+				{Key: "dynamodbav", Template: "{{ camel .Column.Name }}"},
+			},
+			column: database.Column{
+				Name:       "user_id",
+				IsNullable: "YES",
+			},
+			expected: `bson:"user_id,omitempty" dynamodbav:"userId"`,
+		},
+		{
+			desc:     "custom tags follow the template",
+			template: `mytag:"{{ .Column.Name }}"`,
+			tags:     settings.CustomTagsFlag{{Key: "bson", Template: "{{ short .Column.Name }}"}},
+			funcs:    settings.TemplateFuncsFlag{"short": "slice . 0 3"},
+			column: database.Column{
+				Name: "username",
+			},
+			expected: `mytag:"username" bson:"use"`,
+		},
+		{
+			desc: "custom tag rendering nothing is left out and values are escaped",
+			tags: settings.CustomTagsFlag{
+				{Key: "bson", Template: "{{ if .IsPrimaryKey }}_id{{ end }}"},
+				{Key: "doc", Template: `say "{{ .Column.Name }}"`},
+			},
+			column: database.Column{
+				Name: "user_id",
+			},
+			expected: `doc:"say \"user_id\""`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.TagsCustom = test.template
+			s.CustomTags = test.tags
+			s.TagsCustomFuncs = test.funcs
+			tagger := NewCustom(s)
+			assert.NoError(t, tagger.BeginTable("test_table"))
+			actual := tagger.GenerateTag(database.New(s), test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestCustom_BeginTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		template string
+		tags     settings.CustomTagsFlag
+		funcs    settings.TemplateFuncsFlag
+		isError  bool
+	}{
+		{
+			desc:     "valid template",
+			template: `mytag:"{{ .TagName }}"`,
+			isError:  false,
+		},
+		{
+			desc:     "unparsable template",
+			template: `mytag:"{{ .TagName "`,
+			isError:  true,
+		},
+		{
+			desc:     "unknown field",
+			template: `mytag:"{{ .Unknown }}"`,
+			isError:  true,
+		},
+		{
+			desc:     "function failing for the empty column",
+			template: `mytag:"{{ short .Column.Name }}"`,
+			funcs:    settings.TemplateFuncsFlag{"short": "slice . 0 3"},
+			isError:  false,
+		},
+		{
+			desc:     "function with unknown function",
+			template: `mytag:"{{ short .Column.Name }}"`,
+			funcs:    settings.TemplateFuncsFlag{"short": "substr . 0 3"},
+			isError:  true,
+		},
+		{
+			desc:     "function shadowing a built-in one",
+			template: `mytag:"{{ upper .Column.Name }}"`,
+			funcs:    settings.TemplateFuncsFlag{"upper": "."},
+			isError:  true,
+		},
+		{
+			desc:    "valid custom tag",
+			tags:    settings.CustomTagsFlag{{Key: "bson", Template: "{{ .TagName }}"}},
+			isError: false,
+		},
+		{
+			desc:    "unparsable custom tag",
+			tags:    settings.CustomTagsFlag{{Key: "bson", Template: "{{ .TagName "}},
+			isError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.TagsCustom = test.template
+			s.CustomTags = test.tags
+			s.TagsCustomFuncs = test.funcs
+			err := NewCustom(s).BeginTable("test_table")
+			assert.Equal(t, test.isError, err != nil)
+		})
+	}
+}
+
+func TestRegisterTemplateFunc(t *testing.T) {
+	defer delete(customFuncs, "wrap")
+
+	RegisterTemplateFunc("wrap", func(s string) string { return "<" + s + ">" })
+
+	s := settings.New()
+	s.TagsCustom = `mytag:"{{ wrap .Column.Name }}"`
+	tagger := NewCustom(s)
+	assert.NoError(t, tagger.BeginTable("test_table"))
+	assert.Equal(t, `mytag:"<user_id>"`, tagger.GenerateTag(database.New(s), database.Column{Name: "user_id"}))
+
+	assert.Panics(t, func() { RegisterTemplateFunc("wrap", strings.TrimSpace) })
+	assert.Panics(t, func() { RegisterTemplateFunc("upper", strings.TrimSpace) })
+	assert.Panics(t, func() { RegisterTemplateFunc("", strings.TrimSpace) })
+	assert.Panics(t, func() { RegisterTemplateFunc("nofunc", "wrap") })
+}
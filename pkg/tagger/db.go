@@ -1,13 +1,73 @@
 package tagger
 
 import (
+	"strings"
+
 	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
 )
 
 // Db is the standard "db"-tag.
-type Db struct{}
+type Db struct {
+	dbCase settings.DbTagCase
+	dbType settings.DBType
+}
+
+// NewDb creates a new Db tagger writing the column names in the case given by
+// the settings.
+func NewDb(s *settings.Settings) *Db {
+	return &Db{
+		dbCase: s.TagsDbCase,
+		dbType: s.DbType.Dialect(),
+	}
+}
 
 // GenerateTag for Db to satisfy the Tagger interface.
 func (t Db) GenerateTag(_ database.Database, column database.Column) string {
-	return `db:"` + column.Name + `"`
+	name := column.Name
+
+	switch t.dbCase {
+	case settings.DbTagCaseLower:
+		name = strings.ToLower(name)
+	case settings.DbTagCaseQuoted:
+		if needsQuoting(t.dbType, name) {
+			name = `\"` + strings.ReplaceAll(name, `"`, `\"\"`) + `\"`
+		}
+	}
+
+	return `db:"` + name + `"`
+}
+
+// needsQuoting returns true if the given identifier has to be quoted to keep
+// its case or special characters in the given database. MySQL treats column
+// names case-insensitive and quotes with backticks, which can not be used in
+// struct tags, hence its names are never quoted.
+func needsQuoting(dbType settings.DBType, name string) bool {
+	switch dbType {
+	case settings.DBTypeMySQL:
+		return false
+	case settings.DBTypeOracle:
+		// unquoted identifiers are folded to upper case
+		return name != strings.ToUpper(name) || !isPlainIdentifier(name)
+	case settings.DBTypeSQLite:
+		// identifiers are case-insensitive
+		return !isPlainIdentifier(name)
+	default:
+		// unquoted identifiers are folded to lower case
+		return name != strings.ToLower(name) || !isPlainIdentifier(name)
+	}
+}
+
+// isPlainIdentifier returns true if the given name consists of ASCII letters,
+// digits and underscores only and does not start with a digit.
+func isPlainIdentifier(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
 }
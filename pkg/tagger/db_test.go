@@ -0,0 +1,95 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestDb_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		dbCase   settings.DbTagCase
+		column   string
+		expected string
+	}{
+		{
+			desc:     "original case keeps the column name",
+			dbType:   settings.DBTypePostgresql,
+			dbCase:   settings.DbTagCaseOriginal,
+			column:   "UserID",
+			expected: `db:"UserID"`,
+		},
+		{
+			desc:     "lower case converts the column name",
+			dbType:   settings.DBTypePostgresql,
+			dbCase:   settings.DbTagCaseLower,
+			column:   "UserID",
+			expected: `db:"userid"`,
+		},
+		{
+			desc:     "quoted case keeps lower case postgres names unquoted",
+			dbType:   settings.DBTypePostgresql,
+			dbCase:   settings.DbTagCaseQuoted,
+			column:   "user_id",
+			expected: `db:"user_id"`,
+		},
+		{
+			desc:     "quoted case quotes mixed case postgres names",
+			dbType:   settings.DBTypePostgresql,
+			dbCase:   settings.DbTagCaseQuoted,
+			column:   "UserID",
+			expected: `db:"\"UserID\""`,
+		},
+		{
+			desc:     "quoted case quotes names with special characters",
+			dbType:   settings.DBTypeSQLite,
+			dbCase:   settings.DbTagCaseQuoted,
+			column:   "user id",
+			expected: `db:"\"user id\""`,
+		},
+		{
+			desc:     "quoted case keeps mixed case sqlite names unquoted",
+			dbType:   settings.DBTypeSQLite,
+			dbCase:   settings.DbTagCaseQuoted,
+			column:   "UserID",
+			expected: `db:"UserID"`,
+		},
+		{
+			desc:     "quoted case keeps upper case oracle names unquoted",
+			dbType:   settings.DBTypeOracle,
+			dbCase:   settings.DbTagCaseQuoted,
+			column:   "USER_ID",
+			expected: `db:"USER_ID"`,
+		},
+		{
+			desc:     "quoted case quotes lower case oracle names",
+			dbType:   settings.DBTypeOracle,
+			dbCase:   settings.DbTagCaseQuoted,
+			column:   "user_id",
+			expected: `db:"\"user_id\""`,
+		},
+		{
+			desc:     "quoted case never quotes mysql names",
+			dbType:   settings.DBTypeMySQL,
+			dbCase:   settings.DbTagCaseQuoted,
+			column:   "User ID",
+			expected: `db:"User ID"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			s.TagsDbCase = test.dbCase
+			actual := NewDb(s).GenerateTag(database.New(s), database.Column{Name: test.column})
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
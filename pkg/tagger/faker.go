@@ -0,0 +1,102 @@
+package tagger
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// fakerRule maps a column name matching the check to a go-faker provider.
+type fakerRule struct {
+	matches  func(name string) bool
+	provider string
+}
+
+func nameContains(parts ...string) func(string) bool {
+	return func(name string) bool {
+		for _, part := range parts {
+			if strings.Contains(name, part) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func nameIs(names ...string) func(string) bool {
+	return func(name string) bool {
+		for _, n := range names {
+			if name == n || strings.HasSuffix(name, "_"+n) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// fakerRules are checked in order, the first matching one wins.
+var fakerRules = []fakerRule{
+	{nameContains("email", "e_mail"), "email"},
+	{nameIs("uuid", "guid"), "uuid_hyphenated"},
+	{nameIs("first_name", "firstname", "given_name"), "first_name"},
+	{nameIs("last_name", "lastname", "surname", "family_name"), "last_name"},
+	{nameIs("username", "user_name", "login"), "username"},
+	{nameIs("name", "full_name", "fullname"), "name"},
+	{nameContains("phone", "mobile"), "phone_number"},
+	{nameIs("url", "website", "homepage", "link"), "url"},
+	{nameIs("ip", "ip_address", "ipv4"), "ipv4"},
+	{nameIs("ipv6"), "ipv6"},
+	{nameContains("password"), "password"},
+	{nameIs("date", "day"), "date"},
+	{nameIs("time"), "time"},
+	{nameIs("title", "subject"), "sentence"},
+	{nameIs("description", "comment", "note", "notes", "body", "text"), "paragraph"},
+}
+
+// Faker represents the go-faker/faker "faker"-tag inferred from the names and
+// types of the columns.
+type Faker struct {
+	nullType settings.NullType
+}
+
+// NewFaker creates a new Faker tagger. Since the providers only fill strings,
+// nullable columns get tagged only if they are mapped to pointers by the null
+// type given by the settings.
+func NewFaker(s *settings.Settings) *Faker {
+	return &Faker{
+		nullType: s.Null,
+	}
+}
+
+// GenerateTag for Faker to satisfy the Tagger interface. It returns an empty
+// string if the column can be filled by faker without a hint.
+func (t Faker) GenerateTag(db database.Database, column database.Column) string {
+	if db.IsInteger(column) || db.IsFloat(column) || db.IsTemporal(column) || isBoolean(column) {
+		return ""
+	}
+	if db.IsNullable(column) && !t.nullType.IsPointer() {
+		return ""
+	}
+
+	// oneof separates the values by commas without a way to escape them
+	if len(column.EnumValues) > 0 && !slices.ContainsFunc(column.EnumValues, func(value string) bool {
+		return strings.Contains(value, ",")
+	}) {
+		return `faker:"oneof: ` + escapeTagValue(strings.Join(column.EnumValues, ", ")) + `"`
+	}
+
+	if strings.EqualFold(column.DataType, "uuid") {
+		return `faker:"uuid_hyphenated"`
+	}
+
+	name := strings.ToLower(column.Name)
+	for _, rule := range fakerRules {
+		if rule.matches(name) {
+			return `faker:"` + rule.provider + `"`
+		}
+	}
+
+	return ""
+}
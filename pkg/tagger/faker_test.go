@@ -0,0 +1,131 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestFaker_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		nullType settings.NullType
+		column   database.Column
+		expected string
+	}{
+		{
+			desc:     "email column generates email",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "contact_email",
+				DataType:   "character varying",
+				IsNullable: "NO",
+			},
+			expected: `faker:"email"`,
+		},
+		{
+			desc:     "uuid column generates uuid_hyphenated",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "external_id",
+				DataType:   "uuid",
+				IsNullable: "NO",
+			},
+			expected: `faker:"uuid_hyphenated"`,
+		},
+		{
+			desc:     "first name column generates first_name",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "FirstName",
+				DataType:   "text",
+				IsNullable: "NO",
+			},
+			expected: `faker:"first_name"`,
+		},
+		{
+			desc:     "name column generates name",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "customer_name",
+				DataType:   "text",
+				IsNullable: "NO",
+			},
+			expected: `faker:"name"`,
+		},
+		{
+			desc:     "date column stored as string generates date",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "birth_date",
+				DataType:   "character varying",
+				IsNullable: "NO",
+			},
+			expected: `faker:"date"`,
+		},
+		{
+			desc:     "enum column generates oneof",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "mood",
+				DataType:   "enum",
+				IsNullable: "NO",
+				EnumValues: []string{"happy", "sad"},
+			},
+			expected: `faker:"oneof: happy, sad"`,
+		},
+		{
+			desc:     "temporal column generates no tag",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "created_date",
+				DataType:   "date",
+				IsNullable: "NO",
+			},
+			expected: "",
+		},
+		{
+			desc:     "unknown column name generates no tag",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "foo",
+				DataType:   "text",
+				IsNullable: "NO",
+			},
+			expected: "",
+		},
+		{
+			desc:     "nullable column with sql null type generates no tag",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "email",
+				DataType:   "text",
+				IsNullable: "YES",
+			},
+			expected: "",
+		},
+		{
+			desc:     "nullable column with primitive null type generates tag",
+			nullType: settings.NullTypePrimitive,
+			column: database.Column{
+				Name:       "email",
+				DataType:   "text",
+				IsNullable: "YES",
+			},
+			expected: `faker:"email"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Null = test.nullType
+			actual := NewFaker(s).GenerateTag(database.New(s), test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package tagger
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+// GoPg represents the go-pg "pg"-tag.
+type GoPg struct{}
+
+// GenerateTag for GoPg to satisfy the Tagger interface.
+func (t GoPg) GenerateTag(db database.Database, column database.Column) string {
+	tag := column.Name
+
+	isPk := db.IsPrimaryKey(column)
+	if isPk {
+		tag += ",pk"
+	}
+
+	// go-pg marshals zero values as NULL, which lets the database fill in
+	// the default, but fails for not nullable columns without a default.
+	if !isPk && !db.IsNullable(column) {
+		tag += ",notnull"
+		if !column.DefaultValue.Valid {
+			tag += ",use_zero"
+		}
+	}
+
+	return `pg:"` + tag + `"`
+}
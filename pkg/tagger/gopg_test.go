@@ -0,0 +1,77 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestGoPg_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		column   database.Column
+		expected string
+	}{
+		{
+			desc: "nullable column generates only the column name",
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: `pg:"column_name"`,
+		},
+		{
+			desc: "not nullable column generates notnull and use_zero",
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "NO",
+			},
+			expected: `pg:"column_name,notnull,use_zero"`,
+		},
+		{
+			desc: "not nullable column with default generates only notnull",
+			column: database.Column{
+				Name:       "created_at",
+				IsNullable: "NO",
+				DefaultValue: sql.NullString{
+					String: "now()",
+					Valid:  true,
+				},
+			},
+			expected: `pg:"created_at,notnull"`,
+		},
+		{
+			desc: "serial PK column generates pk",
+			column: database.Column{
+				Name:       "id",
+				IsNullable: "NO",
+				DefaultValue: sql.NullString{
+					String: "nextval('foo_id_seq'::regclass)",
+					Valid:  true,
+				},
+				ConstraintType: sql.NullString{
+					String: "PRIMARY KEY",
+					Valid:  true,
+				},
+			},
+			expected: `pg:"id,pk"`,
+		},
+	}
+
+	s := settings.New()
+	db := database.New(s)
+	tagger := new(GoPg)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := tagger.GenerateTag(db, test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package tagger
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Gorm represents the "gorm"-tag.
+type Gorm struct {
+	constraints bool
+}
+
+// NewGorm creates a new Gorm tagger, which adds the attributes of the
+// constraints and the precision of decimal columns if set by the settings.
+func NewGorm(s *settings.Settings) *Gorm {
+	return &Gorm{
+		constraints: s.TagsGormConstraints,
+	}
+}
+
+// GenerateTag for Gorm to satisfy the Tagger interface.
+func (t Gorm) GenerateTag(db database.Database, column database.Column) string {
+	options := []string{"column:" + column.Name}
+
+	isPrimaryKey := db.IsPrimaryKey(column)
+	if isPrimaryKey {
+		options = append(options, "primaryKey")
+	}
+
+	isAutoIncrement := db.IsAutoIncrement(column)
+	if isAutoIncrement {
+		options = append(options, "autoIncrement")
+	}
+
+	if column.CharacterMaximumLength.Valid && column.CharacterMaximumLength.Int64 > 0 {
+		options = append(options, "size:"+strconv.FormatInt(column.CharacterMaximumLength.Int64, 10))
+	}
+
+	if t.constraints {
+		if !isPrimaryKey {
+			options = append(options, gormIndexes(column)...)
+		}
+		options = append(options, gormPrecision(column)...)
+	}
+
+	if !db.IsNullable(column) {
+		options = append(options, "not null")
+	}
+
+	// the default of auto increment columns is the sequence or the like,
+	// which is maintained by the database.
+	if column.DefaultValue.Valid && !isAutoIncrement {
+		options = append(options, "default:"+gormDefaultValue(column.DefaultValue.String))
+	}
+
+	return `gorm:"` + strings.Join(options, ";") + `"`
+}
+
+// gormIndexes returns the index attributes of the column: a uniqueIndex named
+// like the constraint for each unique constraint, which GORM combines to a
+// composite one for constraints spanning multiple columns, or unique and
+// index as given by the column key of MySQL.
+func gormIndexes(column database.Column) []string {
+	constraints := column.Constraints
+	if len(constraints) == 0 && column.ConstraintType.Valid {
+		constraints = []database.Constraint{{Name: column.ConstraintName.String, Type: column.ConstraintType.String}}
+	}
+
+	var options []string
+	for _, constraint := range constraints {
+		if constraint.Type == "UNIQUE" && constraint.Name != "" {
+			options = append(options, "uniqueIndex:"+escapeTagValue(constraint.Name))
+		}
+	}
+
+	switch column.ColumnKey {
+	case "UNI":
+		options = append(options, "unique")
+	case "MUL":
+		options = append(options, "index")
+	}
+	return options
+}
+
+// gormPrecision returns the precision and, if known, the scale of decimal
+// columns. The scale is taken from the column type like decimal(10,2) of
+// MySQL.
+func gormPrecision(column database.Column) []string {
+	switch strings.ToLower(column.DataType) {
+	case "decimal", "numeric", "number":
+	default:
+		return nil
+	}
+	if !column.NumericPrecision.Valid || column.NumericPrecision.Int64 <= 0 {
+		return nil
+	}
+
+	options := []string{"precision:" + strconv.FormatInt(column.NumericPrecision.Int64, 10)}
+	_, args, _ := strings.Cut(column.ColumnType, "(")
+	args, _, _ = strings.Cut(args, ")")
+	if _, scale, ok := strings.Cut(args, ","); ok {
+		if scale, err := strconv.Atoi(strings.TrimSpace(scale)); err == nil {
+			options = append(options, "scale:"+strconv.Itoa(scale))
+		}
+	}
+	return options
+}
+
+// gormDefaultValue strips type casts from the given default value and escapes
+// it to be used within a struct tag.
+func gormDefaultValue(value string) string {
+	return escapeTagValue(stripTypeCast(value))
+}
+
+// stripTypeCast strips type casts like 'foo'::character varying from the given
+// default value.
+func stripTypeCast(value string) string {
+	if idx := strings.LastIndex(value, "::"); idx > 0 && !strings.ContainsAny(value[idx:], "')") {
+		value = value[:idx]
+	}
+	return value
+}
@@ -0,0 +1,210 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestGorm_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		desc        string
+		constraints bool
+		column      database.Column
+		expected    string
+	}
+
+	tests := map[settings.DBType][]test{
+		settings.DBTypePostgresql: {
+			{
+				desc: "nullable column generates only the column name",
+				column: database.Column{
+					Name:       "column_name",
+					IsNullable: "YES",
+				},
+				expected: `gorm:"column:column_name"`,
+			},
+			{
+				desc: "serial PK column generates PK, auto increment and not null without default",
+				column: database.Column{
+					Name:       "id",
+					IsNullable: "NO",
+					DefaultValue: sql.NullString{
+						String: "nextval('foo_id_seq'::regclass)",
+						Valid:  true,
+					},
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:id;primaryKey;autoIncrement;not null"`,
+			},
+			{
+				desc: "varchar column with default generates size, not null and default without cast",
+				column: database.Column{
+					Name:       "name",
+					IsNullable: "NO",
+					CharacterMaximumLength: sql.NullInt64{
+						Int64: 20,
+						Valid: true,
+					},
+					DefaultValue: sql.NullString{
+						String: "'foo'::character varying",
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:name;size:20;not null;default:'foo'"`,
+			},
+			{
+				desc: "default with double quotes gets escaped",
+				column: database.Column{
+					Name:       "data",
+					IsNullable: "YES",
+					DefaultValue: sql.NullString{
+						String: `'{"a":1}'::jsonb`,
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:data;default:'{\"a\":1}'"`,
+			},
+			{
+				desc:        "unique constraints generate unique indexes named like the constraints",
+				constraints: true,
+				column: database.Column{
+					Name:       "email",
+					IsNullable: "NO",
+					Constraints: []database.Constraint{
+						{Name: "users_email_key", Type: "UNIQUE"},
+						{Name: "users_email_tenant_key", Type: "UNIQUE"},
+						{Name: "users_email_fkey", Type: "FOREIGN KEY"},
+					},
+				},
+				expected: `gorm:"column:email;uniqueIndex:users_email_key;uniqueIndex:users_email_tenant_key;not null"`,
+			},
+			{
+				desc: "unique constraint is ignored without constraints",
+				column: database.Column{
+					Name:       "email",
+					IsNullable: "YES",
+					Constraints: []database.Constraint{
+						{Name: "users_email_key", Type: "UNIQUE"},
+					},
+				},
+				expected: `gorm:"column:email"`,
+			},
+			{
+				desc:        "primary key generates no unique index",
+				constraints: true,
+				column: database.Column{
+					Name:       "id",
+					IsNullable: "NO",
+					ConstraintName: sql.NullString{
+						String: "users_pkey",
+						Valid:  true,
+					},
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:id;primaryKey;not null"`,
+			},
+			{
+				desc:        "numeric column generates precision",
+				constraints: true,
+				column: database.Column{
+					Name:       "price",
+					DataType:   "numeric",
+					IsNullable: "YES",
+					NumericPrecision: sql.NullInt64{
+						Int64: 10,
+						Valid: true,
+					},
+				},
+				expected: `gorm:"column:price;precision:10"`,
+			},
+		},
+		settings.DBTypeMySQL: {
+			{
+				desc: "auto increment PK column generates PK, auto increment and not null",
+				column: database.Column{
+					Name:       "id",
+					IsNullable: "NO",
+					ColumnKey:  "PRI",
+					Extra:      "auto_increment",
+				},
+				expected: `gorm:"column:id;primaryKey;autoIncrement;not null"`,
+			},
+			{
+				desc: "column with default generates default",
+				column: database.Column{
+					Name:       "created_at",
+					IsNullable: "NO",
+					DefaultValue: sql.NullString{
+						String: "CURRENT_TIMESTAMP",
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP"`,
+			},
+			{
+				desc:        "unique column key generates unique",
+				constraints: true,
+				column: database.Column{
+					Name:       "email",
+					IsNullable: "NO",
+					ColumnKey:  "UNI",
+				},
+				expected: `gorm:"column:email;unique;not null"`,
+			},
+			{
+				desc:        "indexed column generates index",
+				constraints: true,
+				column: database.Column{
+					Name:       "user_id",
+					IsNullable: "YES",
+					ColumnKey:  "MUL",
+				},
+				expected: `gorm:"column:user_id;index"`,
+			},
+			{
+				desc:        "decimal column generates precision and scale of the column type",
+				constraints: true,
+				column: database.Column{
+					Name:       "price",
+					DataType:   "decimal",
+					ColumnType: "decimal(10,2) unsigned",
+					IsNullable: "NO",
+					NumericPrecision: sql.NullInt64{
+						Int64: 10,
+						Valid: true,
+					},
+				},
+				expected: `gorm:"column:price;precision:10;scale:2;not null"`,
+			},
+		},
+	}
+
+	for dbType, tests := range tests {
+		t.Run(dbType.String(), func(t *testing.T) {
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+			for _, test := range tests {
+				t.Run(test.desc, func(t *testing.T) {
+					s := settings.New()
+					s.TagsGormConstraints = test.constraints
+					actual := NewGorm(s).GenerateTag(db, test.column)
+					assert.Equal(t, test.expected, actual)
+				})
+			}
+		})
+	}
+}
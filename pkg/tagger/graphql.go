@@ -0,0 +1,26 @@
+package tagger
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// GraphQL represents the graph-gophers "graphql"-tag binding the struct field
+// to a field of a GraphQL type.
+type GraphQL struct {
+	overrides map[string]string
+}
+
+// NewGraphQL creates a new GraphQL tagger with the per-column overrides given
+// by the settings. Field names always follow the camelCase convention of
+// GraphQL schemas instead of the naming strategy of the serialization tags.
+func NewGraphQL(s *settings.Settings) *GraphQL {
+	return &GraphQL{
+		overrides: s.TagsNames,
+	}
+}
+
+// GenerateTag for GraphQL to satisfy the Tagger interface.
+func (t GraphQL) GenerateTag(_ database.Database, column database.Column) string {
+	return `graphql:"` + tagName(settings.TagNameFormatCamelCase, t.overrides, column.Name) + `"`
+}
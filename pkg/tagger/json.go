@@ -0,0 +1,65 @@
+package tagger
+
+import (
+	"slices"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// JSON represents the "json"-tag.
+type JSON struct {
+	format    settings.TagNameFormat
+	overrides map[string]string
+
+	omitEmpty        settings.OmitMode
+	omitEmptyColumns []string
+	omitZero         settings.OmitMode
+	omitZeroColumns  []string
+}
+
+// NewJSON creates a new JSON tagger with the naming strategy and per-column
+// overrides given by the settings.
+func NewJSON(s *settings.Settings) *JSON {
+	return &JSON{
+		format:    s.TagsNameFormat,
+		overrides: s.TagsNames,
+
+		omitEmpty:        s.TagsJSONOmitEmpty,
+		omitEmptyColumns: s.TagsJSONOmitEmptyColumns,
+		omitZero:         s.TagsJSONOmitZero,
+		omitZeroColumns:  s.TagsJSONOmitZeroColumns,
+	}
+}
+
+// GenerateTag for JSON to satisfy the Tagger interface.
+func (t JSON) GenerateTag(db database.Database, column database.Column) string {
+	name := tagName(t.format, t.overrides, column.Name)
+	if name == "-" {
+		return `json:"-"`
+	}
+
+	if shouldOmit(db, column, t.omitEmpty, t.omitEmptyColumns) {
+		name += ",omitempty"
+	}
+	if shouldOmit(db, column, t.omitZero, t.omitZeroColumns) {
+		name += ",omitzero"
+	}
+
+	return `json:"` + name + `"`
+}
+
+// shouldOmit returns true if an omit option should be appended to the tag of
+// the column, either because of the mode or because the column is listed
+// explicitly.
+func shouldOmit(db database.Database, column database.Column, mode settings.OmitMode, columns []string) bool {
+	switch mode {
+	case settings.OmitModeAll:
+		return true
+	case settings.OmitModeNullable:
+		if db.IsNullable(column) {
+			return true
+		}
+	}
+	return slices.Contains(columns, column.Name)
+}
@@ -0,0 +1,164 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestJSON_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		column   database.Column
+		expected string
+	}{
+		{
+			desc:     "default format keeps the original column name",
+			settings: settings.New,
+			column: database.Column{
+				Name: "user_id",
+			},
+			expected: `json:"user_id"`,
+		},
+		{
+			desc: "camel case format converts the column name to lower camel case",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNameFormat = settings.TagNameFormatCamelCase
+				return s
+			},
+			column: database.Column{
+				Name: "user_id",
+			},
+			expected: `json:"userId"`,
+		},
+		{
+			desc: "snake case format converts the column name to snake case",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNameFormat = settings.TagNameFormatSnakeCase
+				return s
+			},
+			column: database.Column{
+				Name: "UserID",
+			},
+			expected: `json:"user_id"`,
+		},
+		{
+			desc: "override of the column wins over the format",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNameFormat = settings.TagNameFormatCamelCase
+				s.TagsNames = settings.MapFlag{"password_hash": "-"}
+				return s
+			},
+			column: database.Column{
+				Name: "password_hash",
+			},
+			expected: `json:"-"`,
+		},
+		{
+			desc: "override of another column does not apply",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNameFormat = settings.TagNameFormatCamelCase
+				s.TagsNames = settings.MapFlag{"password_hash": "-"}
+				return s
+			},
+			column: database.Column{
+				Name: "user_name",
+			},
+			expected: `json:"userName"`,
+		},
+		{
+			desc: "omitempty for all columns appends omitempty",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSONOmitEmpty = settings.OmitModeAll
+				return s
+			},
+			column: database.Column{
+				Name: "user_name",
+			},
+			expected: `json:"user_name,omitempty"`,
+		},
+		{
+			desc: "omitempty for nullable columns appends omitempty to nullable column",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSONOmitEmpty = settings.OmitModeNullable
+				return s
+			},
+			column: database.Column{
+				Name:       "user_name",
+				IsNullable: "YES",
+			},
+			expected: `json:"user_name,omitempty"`,
+		},
+		{
+			desc: "omitempty for nullable columns does not append omitempty to not nullable column",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSONOmitEmpty = settings.OmitModeNullable
+				return s
+			},
+			column: database.Column{
+				Name:       "user_name",
+				IsNullable: "NO",
+			},
+			expected: `json:"user_name"`,
+		},
+		{
+			desc: "omitempty for listed column appends omitempty",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSONOmitEmptyColumns = settings.StringsFlag{"user_name"}
+				return s
+			},
+			column: database.Column{
+				Name: "user_name",
+			},
+			expected: `json:"user_name,omitempty"`,
+		},
+		{
+			desc: "omitempty and omitzero are both appended",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSONOmitEmpty = settings.OmitModeAll
+				s.TagsJSONOmitZeroColumns = settings.StringsFlag{"created_at"}
+				return s
+			},
+			column: database.Column{
+				Name: "created_at",
+			},
+			expected: `json:"created_at,omitempty,omitzero"`,
+		},
+		{
+			desc: "excluded column gets no omit options",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNames = settings.MapFlag{"password_hash": "-"}
+				s.TagsJSONOmitEmpty = settings.OmitModeAll
+				return s
+			},
+			column: database.Column{
+				Name: "password_hash",
+			},
+			expected: `json:"-"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := test.settings()
+			tagger := NewJSON(s)
+			actual := tagger.GenerateTag(database.New(s), test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package tagger
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Mapstructure represents the "mapstructure"-tag.
+type Mapstructure struct {
+	format    settings.TagNameFormat
+	overrides map[string]string
+}
+
+// NewMapstructure creates a new Mapstructure tagger with the naming strategy
+// and per-column overrides given by the settings.
+func NewMapstructure(s *settings.Settings) *Mapstructure {
+	return &Mapstructure{
+		format:    s.TagsNameFormat,
+		overrides: s.TagsNames,
+	}
+}
+
+// GenerateTag for Mapstructure to satisfy the Tagger interface.
+func (t Mapstructure) GenerateTag(_ database.Database, column database.Column) string {
+	return `mapstructure:"` + tagName(t.format, t.overrides, column.Name) + `"`
+}
@@ -0,0 +1,63 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestMapstructure_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		column   database.Column
+		expected string
+	}{
+		{
+			desc:     "default format keeps the original column name",
+			settings: settings.New,
+			column: database.Column{
+				Name: "user_id",
+			},
+			expected: `mapstructure:"user_id"`,
+		},
+		{
+			desc: "camel case format converts the column name to lower camel case",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNameFormat = settings.TagNameFormatCamelCase
+				return s
+			},
+			column: database.Column{
+				Name: "user_id",
+			},
+			expected: `mapstructure:"userId"`,
+		},
+		{
+			desc: "override of the column wins over the format",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNameFormat = settings.TagNameFormatCamelCase
+				s.TagsNames = settings.MapFlag{"password_hash": "-"}
+				return s
+			},
+			column: database.Column{
+				Name: "password_hash",
+			},
+			expected: `mapstructure:"-"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := test.settings()
+			tagger := NewMapstructure(s)
+			actual := tagger.GenerateTag(database.New(s), test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package tagger
+
+import (
+	"github.com/iancoleman/strcase"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// tagName returns the key of a serialization tag for the given column name
+// according to the naming strategy. An override for the column wins over the
+// naming strategy.
+func tagName(format settings.TagNameFormat, overrides map[string]string, column string) string {
+	if name, ok := overrides[column]; ok {
+		return name
+	}
+
+	switch format {
+	case settings.TagNameFormatCamelCase:
+		return strcase.ToLowerCamel(column)
+	case settings.TagNameFormatSnakeCase:
+		return strcase.ToSnake(column)
+	default:
+		return column
+	}
+}
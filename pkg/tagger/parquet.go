@@ -0,0 +1,36 @@
+package tagger
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+// Parquet represents the "parquet"-tag of xitongsys/parquet-go with the name,
+// the physical (and converted) type and the repetition type of the column.
+type Parquet struct{}
+
+// GenerateTag for Parquet to satisfy the Tagger interface.
+func (t Parquet) GenerateTag(db database.Database, column database.Column) string {
+	repetition := "REQUIRED"
+	if db.IsNullable(column) {
+		repetition = "OPTIONAL"
+	}
+
+	return `parquet:"name=` + escapeTagValue(column.Name) + `, ` + parquetType(db, column) +
+		`, repetitiontype=` + repetition + `"`
+}
+
+// parquetType returns the type options of the column.
+func parquetType(db database.Database, column database.Column) string {
+	switch {
+	case db.IsInteger(column):
+		return "type=INT64"
+	case db.IsFloat(column):
+		return "type=DOUBLE"
+	case db.IsTemporal(column):
+		return "type=INT64, convertedtype=TIMESTAMP_MILLIS"
+	case isBoolean(column):
+		return "type=BOOLEAN"
+	default:
+		return "type=BYTE_ARRAY, convertedtype=UTF8"
+	}
+}
@@ -0,0 +1,97 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestParquet_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		desc     string
+		column   database.Column
+		expected string
+	}
+
+	tests := map[settings.DBType][]test{
+		settings.DBTypePostgresql: {
+			{
+				desc: "not nullable integer column generates required INT64",
+				column: database.Column{
+					Name:       "id",
+					DataType:   "integer",
+					IsNullable: "NO",
+				},
+				expected: `parquet:"name=id, type=INT64, repetitiontype=REQUIRED"`,
+			},
+			{
+				desc: "nullable text column generates optional UTF8 byte array",
+				column: database.Column{
+					Name:       "name",
+					DataType:   "text",
+					IsNullable: "YES",
+				},
+				expected: `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`,
+			},
+			{
+				desc: "float column generates DOUBLE",
+				column: database.Column{
+					Name:       "height",
+					DataType:   "double precision",
+					IsNullable: "NO",
+				},
+				expected: `parquet:"name=height, type=DOUBLE, repetitiontype=REQUIRED"`,
+			},
+			{
+				desc: "temporal column generates timestamp",
+				column: database.Column{
+					Name:       "created_at",
+					DataType:   "timestamp without time zone",
+					IsNullable: "NO",
+				},
+				expected: `parquet:"name=created_at, type=INT64, convertedtype=TIMESTAMP_MILLIS, repetitiontype=REQUIRED"`,
+			},
+			{
+				desc: "boolean column generates BOOLEAN",
+				column: database.Column{
+					Name:       "active",
+					DataType:   "boolean",
+					IsNullable: "NO",
+				},
+				expected: `parquet:"name=active, type=BOOLEAN, repetitiontype=REQUIRED"`,
+			},
+		},
+		settings.DBTypeMySQL: {
+			{
+				desc: "tinyint column generates INT64",
+				column: database.Column{
+					Name:       "flags",
+					DataType:   "tinyint",
+					IsNullable: "NO",
+				},
+				expected: `parquet:"name=flags, type=INT64, repetitiontype=REQUIRED"`,
+			},
+		},
+	}
+
+	tagger := new(Parquet)
+
+	for dbType, tests := range tests {
+		t.Run(dbType.String(), func(t *testing.T) {
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+			for _, test := range tests {
+				t.Run(test.desc, func(t *testing.T) {
+					actual := tagger.GenerateTag(db, test.column)
+					assert.Equal(t, test.expected, actual)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package tagger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// ProtobufFieldsFileName is the name of the sidecar file in the output path
+// the protobuf field numbers get persisted to if no other file was given.
+const ProtobufFieldsFileName = "protobuf_fields.json"
+
+// Protobuf represents the "protobuf"-tag as generated by protoc-gen-go.
+//
+// The field numbers of a message must never change once they are in use,
+// hence they are persisted per table and column in a sidecar file and read
+// again on the next run. New columns get the next free number of the table,
+// numbers of dropped columns are kept to never be reused.
+type Protobuf struct {
+	path string
+
+	loaded bool
+	table  string
+	fields map[string]map[string]int
+}
+
+// NewProtobuf creates a new Protobuf tagger persisting the field numbers to
+// the file given by the settings.
+func NewProtobuf(s *settings.Settings) *Protobuf {
+	path := s.TagsProtobufFieldsFile
+	if path == "" {
+		path = filepath.Join(s.OutputFilePath, ProtobufFieldsFileName)
+	}
+	return &Protobuf{
+		path:   path,
+		fields: map[string]map[string]int{},
+	}
+}
+
+// BeginTable sets the table the following columns belong to. The persisted
+// field numbers are read on the first call.
+func (t *Protobuf) BeginTable(table string) error {
+	if !t.loaded {
+		if err := t.load(); err != nil {
+			return err
+		}
+		t.loaded = true
+	}
+	t.table = table
+	return nil
+}
+
+// GenerateTag for Protobuf to satisfy the Tagger interface.
+func (t *Protobuf) GenerateTag(db database.Database, column database.Column) string {
+	return `protobuf:"` + protobufWireType(db, column) + "," +
+		strconv.Itoa(t.fieldNumber(column.Name)) + ",opt,name=" + column.Name + `,proto3"`
+}
+
+// Persist writes the field numbers to the sidecar file.
+func (t *Protobuf) Persist() error {
+	content, err := json.MarshalIndent(t.fields, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode protobuf field numbers: %w", err)
+	}
+	if err = os.WriteFile(t.path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("could not write protobuf field numbers to %q: %w", t.path, err)
+	}
+	return nil
+}
+
+func (t *Protobuf) load() error {
+	content, err := os.ReadFile(t.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read protobuf field numbers from %q: %w", t.path, err)
+	}
+	if err = json.Unmarshal(content, &t.fields); err != nil {
+		return fmt.Errorf("could not decode protobuf field numbers from %q: %w", t.path, err)
+	}
+	return nil
+}
+
+// fieldNumber returns the persisted field number of the column in the current
+// table or assigns the next free one.
+func (t *Protobuf) fieldNumber(column string) int {
+	fields, ok := t.fields[t.table]
+	if !ok {
+		fields = map[string]int{}
+		t.fields[t.table] = fields
+	}
+
+	if number, ok := fields[column]; ok {
+		return number
+	}
+
+	number := 1
+	for _, n := range fields {
+		if n >= number {
+			number = n + 1
+		}
+	}
+	fields[column] = number
+
+	return number
+}
+
+// protobufWireType returns the wire type of the scalar value of the column.
+func protobufWireType(db database.Database, column database.Column) string {
+	switch {
+	case db.IsInteger(column), isBoolean(column):
+		return "varint"
+	case db.IsFloat(column):
+		return "fixed64"
+	default:
+		// strings, bytes and messages like google.protobuf.Timestamp
+		return "bytes"
+	}
+}
@@ -0,0 +1,117 @@
+package tagger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestProtobuf_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	s.OutputFilePath = t.TempDir()
+	db := database.New(s)
+
+	tagger := NewProtobuf(s)
+	assert.NoError(t, tagger.BeginTable("test_table"))
+
+	tests := []struct {
+		desc     string
+		column   database.Column
+		expected string
+	}{
+		{
+			desc: "integer column generates varint with the first field number",
+			column: database.Column{
+				Name:     "id",
+				DataType: "integer",
+			},
+			expected: `protobuf:"varint,1,opt,name=id,proto3"`,
+		},
+		{
+			desc: "text column generates bytes with the next field number",
+			column: database.Column{
+				Name:     "name",
+				DataType: "text",
+			},
+			expected: `protobuf:"bytes,2,opt,name=name,proto3"`,
+		},
+		{
+			desc: "float column generates fixed64",
+			column: database.Column{
+				Name:     "height",
+				DataType: "double precision",
+			},
+			expected: `protobuf:"fixed64,3,opt,name=height,proto3"`,
+		},
+		{
+			desc: "boolean column generates varint",
+			column: database.Column{
+				Name:     "active",
+				DataType: "boolean",
+			},
+			expected: `protobuf:"varint,4,opt,name=active,proto3"`,
+		},
+		{
+			desc: "already numbered column keeps its field number",
+			column: database.Column{
+				Name:     "id",
+				DataType: "integer",
+			},
+			expected: `protobuf:"varint,1,opt,name=id,proto3"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := tagger.GenerateTag(db, test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestProtobuf_Persist(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	s.TagsProtobufFieldsFile = filepath.Join(t.TempDir(), "fields.json")
+	db := database.New(s)
+
+	id := database.Column{Name: "id", DataType: "integer"}
+	name := database.Column{Name: "name", DataType: "text"}
+	email := database.Column{Name: "email", DataType: "text"}
+
+	first := NewProtobuf(s)
+	assert.NoError(t, first.BeginTable("users"))
+	first.GenerateTag(db, id)
+	first.GenerateTag(db, name)
+	assert.NoError(t, first.BeginTable("groups"))
+	first.GenerateTag(db, name)
+	assert.NoError(t, first.Persist())
+
+	content, err := os.ReadFile(s.TagsProtobufFieldsFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"groups\": {\n    \"name\": 1\n  },\n  \"users\": {\n    \"id\": 1,\n    \"name\": 2\n  }\n}\n", string(content))
+
+	// the column "id" got dropped, its number must not be reused
+	second := NewProtobuf(s)
+	assert.NoError(t, second.BeginTable("users"))
+	assert.Equal(t, `protobuf:"bytes,3,opt,name=email,proto3"`, second.GenerateTag(db, email))
+	assert.Equal(t, `protobuf:"bytes,2,opt,name=name,proto3"`, second.GenerateTag(db, name))
+}
+
+func TestProtobuf_BeginTable_InvalidFile(t *testing.T) {
+	t.Parallel()
+
+	s := settings.New()
+	s.TagsProtobufFieldsFile = filepath.Join(t.TempDir(), "fields.json")
+	assert.NoError(t, os.WriteFile(s.TagsProtobufFieldsFile, []byte("not json"), 0644))
+
+	err := NewProtobuf(s).BeginTable("users")
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+package tagger
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+// Reform represents the gopkg.in/reform.v1 "reform"-tag. Reform additionally
+// needs a magic comment above the struct, which is written by the generator.
+type Reform struct{}
+
+// GenerateTag for Reform to satisfy the Tagger interface.
+func (t Reform) GenerateTag(db database.Database, column database.Column) string {
+	isPk := ""
+	if db.IsPrimaryKey(column) {
+		isPk = ",pk"
+	}
+	return `reform:"` + column.Name + isPk + `"`
+}
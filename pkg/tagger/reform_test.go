@@ -0,0 +1,70 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestReform_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		desc     string
+		column   database.Column
+		expected string
+	}
+
+	tests := map[settings.DBType][]test{
+		settings.DBTypePostgresql: {
+			{
+				desc: "non PK column generates standard reform-tag",
+				column: database.Column{
+					Name: "column_name",
+				},
+				expected: `reform:"column_name"`,
+			},
+			{
+				desc: "PK column generates reform-tag with PK indicator",
+				column: database.Column{
+					Name: "id",
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+				},
+				expected: `reform:"id,pk"`,
+			},
+		},
+		settings.DBTypeMySQL: {
+			{
+				desc: "PK column generates reform-tag with PK indicator",
+				column: database.Column{
+					Name:      "id",
+					ColumnKey: "PRI",
+				},
+				expected: `reform:"id,pk"`,
+			},
+		},
+	}
+
+	tagger := new(Reform)
+
+	for dbType, tests := range tests {
+		t.Run(dbType.String(), func(t *testing.T) {
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+			for _, test := range tests {
+				t.Run(test.desc, func(t *testing.T) {
+					actual := tagger.GenerateTag(db, test.column)
+					assert.Equal(t, test.expected, actual)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package tagger
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// Swagger represents the "swaggertype"-, "format"-, "example"- and
+// "enums"-tags understood by swaggo/swag.
+type Swagger struct {
+	nullType settings.NullType
+}
+
+// NewSwagger creates a new Swagger tagger. The null type given by the
+// settings determines whether nullable columns need an explicit swaggertype.
+func NewSwagger(s *settings.Settings) *Swagger {
+	return &Swagger{
+		nullType: s.Null,
+	}
+}
+
+// GenerateTag for Swagger to satisfy the Tagger interface. It returns an
+// empty string if swag can document the field on its own.
+func (t Swagger) GenerateTag(db database.Database, column database.Column) string {
+	var tags []string
+
+	// swag can not look into the sql.Null* (and wrapping) structs, hence
+	// the type of the value has to be given explicitly.
+	if db.IsNullable(column) && !t.nullType.IsPointer() {
+		tags = append(tags, `swaggertype:"`+swaggerType(db, column)+`"`)
+		if db.IsTemporal(column) {
+			tags = append(tags, `format:"date-time"`)
+		}
+	}
+
+	if example, ok := swaggerExample(db, column); ok {
+		tags = append(tags, `example:"`+example+`"`)
+	}
+
+	if len(column.EnumValues) > 0 {
+		values := make([]string, len(column.EnumValues))
+		for i, value := range column.EnumValues {
+			values[i] = escapeTagValue(value)
+		}
+		tags = append(tags, `enums:"`+strings.Join(values, ",")+`"`)
+	}
+
+	return strings.Join(tags, " ")
+}
+
+// swaggerType returns the primitive OpenAPI type of the column.
+func swaggerType(db database.Database, column database.Column) string {
+	switch {
+	case db.IsInteger(column):
+		return "integer"
+	case db.IsFloat(column):
+		return "number"
+	case isBoolean(column):
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// swaggerExample returns the default value of the column as example, as long
+// as it is a literal. Expressions like now() or sequences are no examples.
+func swaggerExample(db database.Database, column database.Column) (string, bool) {
+	if !column.DefaultValue.Valid || db.IsAutoIncrement(column) {
+		return "", false
+	}
+
+	value := strings.TrimSpace(stripTypeCast(column.DefaultValue.String))
+
+	switch {
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	case strings.EqualFold(value, "true") || strings.EqualFold(value, "false"):
+		value = strings.ToLower(value)
+	default:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", false
+		}
+	}
+
+	return escapeTagValue(value), true
+}
+
+// escapeTagValue escapes the given value to be used within a struct tag.
+func escapeTagValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
@@ -0,0 +1,145 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestSwagger_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		nullType settings.NullType
+		column   database.Column
+		expected string
+	}{
+		{
+			desc:     "not nullable column without default generates no tag",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "integer",
+				IsNullable: "NO",
+			},
+			expected: "",
+		},
+		{
+			desc:     "nullable integer column with sql null type generates swaggertype",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "integer",
+				IsNullable: "YES",
+			},
+			expected: `swaggertype:"integer"`,
+		},
+		{
+			desc:     "nullable temporal column with json null type generates swaggertype and format",
+			nullType: settings.NullTypeJSON,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "timestamp without time zone",
+				IsNullable: "YES",
+			},
+			expected: `swaggertype:"string" format:"date-time"`,
+		},
+		{
+			desc:     "nullable column with primitive null type generates no tag",
+			nullType: settings.NullTypePrimitive,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "boolean",
+				IsNullable: "YES",
+			},
+			expected: "",
+		},
+		{
+			desc:     "nullable column with pointer null type generates no tag",
+			nullType: settings.NullTypePointer,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "integer",
+				IsNullable: "YES",
+			},
+			expected: "",
+		},
+		{
+			desc:     "nullable column with guregu null type generates type",
+			nullType: settings.NullTypeGuregu,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "integer",
+				IsNullable: "YES",
+			},
+			expected: `swaggertype:"integer"`,
+		},
+		{
+			desc:     "literal string default generates example",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "character varying",
+				IsNullable: "NO",
+				DefaultValue: sql.NullString{
+					String: "'it''s \"new\"'::character varying",
+					Valid:  true,
+				},
+			},
+			expected: `example:"it's \"new\""`,
+		},
+		{
+			desc:     "literal numeric default generates example",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "numeric",
+				IsNullable: "NO",
+				DefaultValue: sql.NullString{
+					String: "1.5",
+					Valid:  true,
+				},
+			},
+			expected: `example:"1.5"`,
+		},
+		{
+			desc:     "function default generates no example",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "timestamp without time zone",
+				IsNullable: "NO",
+				DefaultValue: sql.NullString{
+					String: "now()",
+					Valid:  true,
+				},
+			},
+			expected: "",
+		},
+		{
+			desc:     "enum column generates enums",
+			nullType: settings.NullTypeSQL,
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "enum",
+				IsNullable: "NO",
+				EnumValues: []string{"happy", "sad"},
+			},
+			expected: `enums:"happy,sad"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Null = test.nullType
+			db := database.New(s)
+			actual := NewSwagger(s).GenerateTag(db, test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
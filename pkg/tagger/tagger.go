@@ -1,6 +1,9 @@
 package tagger
 
 import (
+	"fmt"
+	"maps"
+	"path"
 	"strings"
 	"sync"
 
@@ -14,6 +17,70 @@ const (
 	// number is an ascending sequence of i*2 to determine which tags to generate later
 	tagDb         = 1
 	tagMastermind = 2
+	tagJSON       = 4
+	tagYAML       = 8
+	tagTOML       = 16
+	tagGorm       = 32
+	tagBun        = 64
+	tagReform     = 128
+	tagValidate   = 256
+	tagSwagger    = 512
+	tagProtobuf   = 1024
+	tagMapstruct  = 2048
+	tagCustom     = 4096
+	tagFaker      = 8192
+	tagGraphQL    = 16384
+	tagCSV        = 32768
+	tagParquet    = 65536
+	tagXorm       = 131072
+	tagGoPg       = 262144
+)
+
+var (
+	// builtinTags maps the names of the built-in taggers to enable by -tags
+	// to their numbers, the names are the ones of their flags.
+	builtinTags = map[string]int{
+		"db":           tagDb,
+		"structable":   tagMastermind,
+		"json":         tagJSON,
+		"yaml":         tagYAML,
+		"toml":         tagTOML,
+		"gorm":         tagGorm,
+		"bun":          tagBun,
+		"reform":       tagReform,
+		"validate":     tagValidate,
+		"swagger":      tagSwagger,
+		"protobuf":     tagProtobuf,
+		"mapstructure": tagMapstruct,
+		"faker":        tagFaker,
+		"graphql":      tagGraphQL,
+		"csv":          tagCSV,
+		"parquet":      tagParquet,
+		"xorm":         tagXorm,
+		"go-pg":        tagGoPg,
+	}
+
+	// registered holds the taggers registered by Register by their names.
+	registered = map[string]Tagger{}
+
+	// skipTagKeys maps the numbers of the taggers whose tag "-" excludes the
+	// field to the keys of their tags.
+	skipTagKeys = map[int]string{
+		tagDb:        "db",
+		tagJSON:      "json",
+		tagYAML:      "yaml",
+		tagTOML:      "toml",
+		tagGorm:      "gorm",
+		tagBun:       "bun",
+		tagValidate:  "validate",
+		tagMapstruct: "mapstructure",
+		tagFaker:     "faker",
+		tagGraphQL:   "graphql",
+		tagCSV:       "csv",
+		tagParquet:   "parquet",
+		tagXorm:      "xorm",
+		tagGoPg:      "pg",
+	}
 )
 
 var stringPool = sync.Pool{
@@ -27,12 +94,64 @@ type Tagger interface {
 	GenerateTag(db database.Database, column database.Column) string
 }
 
+// TableTagger is implemented by taggers which need to know the table the
+// columns belong to.
+type TableTagger interface {
+	Tagger
+	BeginTable(table string) error
+}
+
+// Persister is implemented by taggers which keep state across runs.
+type Persister interface {
+	Persist() error
+}
+
+// Register makes the tagger available by its name to be enabled by -tags,
+// after the built-in taggers it generates its tags in the order of -tags. A
+// tagger may implement TableTagger and Persister. Register is meant to be
+// called in the init function of the package implementing the tagger and
+// panics if the name is empty, the one of a built-in tagger or already
+// registered, or if the tagger is nil.
+func Register(name string, tagger Tagger) {
+	if tagger == nil {
+		panic(fmt.Sprintf("tagger: tagger %q is nil", name))
+	}
+	if name == "" {
+		panic("tagger: name is empty")
+	}
+	if _, ok := builtinTags[name]; ok {
+		panic(fmt.Sprintf("tagger: name %q is the one of a built-in tagger", name))
+	}
+	if _, ok := registered[name]; ok {
+		panic(fmt.Sprintf("tagger: tagger %q is already registered", name))
+	}
+	registered[name] = tagger
+}
+
+// VerifyNames returns an error if one of the names to enable by -tags is
+// neither the one of a built-in nor of a registered tagger.
+func VerifyNames(names []string) error {
+	for _, name := range names {
+		if _, ok := builtinTags[name]; ok {
+			continue
+		}
+		if _, ok := registered[name]; !ok {
+			return fmt.Errorf("unknown tagger %q in -tags, must be a built-in one like json or one registered by tagger.Register", name)
+		}
+	}
+	return nil
+}
+
 // Taggers represents the supported tags to generate.
 type Taggers struct {
 	settings *settings.Settings
 
 	enabledTags int
 	taggers     map[int]Tagger
+	// registered are the enabled taggers of Register in the order of -tags
+	registered []Tagger
+
+	table string
 }
 
 // NewTaggers is the constructor function to create the supported taggers.
@@ -41,8 +160,25 @@ func NewTaggers(s *settings.Settings) *Taggers {
 		settings:    s,
 		enabledTags: tagDb,
 		taggers: map[int]Tagger{
-			tagDb:         new(Db),
+			tagDb:         NewDb(s),
 			tagMastermind: new(Mastermind),
+			tagJSON:       NewJSON(s),
+			tagYAML:       NewYAML(s),
+			tagTOML:       NewTOML(s),
+			tagGorm:       NewGorm(s),
+			tagBun:        new(Bun),
+			tagReform:     new(Reform),
+			tagValidate:   new(Validate),
+			tagSwagger:    NewSwagger(s),
+			tagProtobuf:   NewProtobuf(s),
+			tagMapstruct:  NewMapstructure(s),
+			tagCustom:     NewCustom(s),
+			tagFaker:      NewFaker(s),
+			tagGraphQL:    NewGraphQL(s),
+			tagCSV:        NewCSV(s),
+			tagParquet:    new(Parquet),
+			tagXorm:       new(Xorm),
+			tagGoPg:       new(GoPg),
 		},
 	}
 
@@ -51,6 +187,14 @@ func NewTaggers(s *settings.Settings) *Taggers {
 	return t
 }
 
+// IsEnabled reports whether the settings enable the built-in tagger of the
+// given name, by its flag or by -tags.
+func IsEnabled(s *settings.Settings, name string) bool {
+	t := &Taggers{settings: s, enabledTags: tagDb}
+	t.enableTags()
+	return t.enabledTags&builtinTags[name] != 0
+}
+
 // enableTags enables the tags to generate as given by the settings.
 // If multiple, standalone tags where specified (the ones with "only" in their names),
 // the last specified standalone tag wins.
@@ -61,12 +205,131 @@ func (t *Taggers) enableTags() {
 	if t.settings.TagsMastermindStructable {
 		t.enabledTags |= tagMastermind
 	}
+	if t.settings.TagsJSON {
+		t.enabledTags |= tagJSON
+	}
+	if t.settings.TagsYAML {
+		t.enabledTags |= tagYAML
+	}
+	if t.settings.TagsTOML {
+		t.enabledTags |= tagTOML
+	}
+	if t.settings.TagsGorm {
+		t.enabledTags |= tagGorm
+	}
+	if t.settings.TagsBun {
+		t.enabledTags |= tagBun
+	}
+	if t.settings.TagsReform {
+		t.enabledTags |= tagReform
+	}
+	if t.settings.TagsValidate {
+		t.enabledTags |= tagValidate
+	}
+	if t.settings.TagsSwagger {
+		t.enabledTags |= tagSwagger
+	}
+	if t.settings.TagsProtobuf {
+		t.enabledTags |= tagProtobuf
+	}
+	if t.settings.TagsMapstructure {
+		t.enabledTags |= tagMapstruct
+	}
+	if t.settings.TagsCustom != "" || len(t.settings.CustomTags) > 0 {
+		t.enabledTags |= tagCustom
+	}
+	if t.settings.TagsFaker {
+		t.enabledTags |= tagFaker
+	}
+	if t.settings.TagsGraphQL {
+		t.enabledTags |= tagGraphQL
+	}
+	if t.settings.TagsCSV {
+		t.enabledTags |= tagCSV
+	}
+	if t.settings.TagsParquet {
+		t.enabledTags |= tagParquet
+	}
+	if t.settings.TagsXorm {
+		t.enabledTags |= tagXorm
+	}
+	if t.settings.TagsGoPg {
+		t.enabledTags |= tagGoPg
+	}
+	for _, name := range t.settings.Tags {
+		if bit, ok := builtinTags[name]; ok {
+			t.enabledTags |= bit
+		} else if tagger, ok := registered[name]; ok {
+			t.registered = append(t.registered, tagger)
+		}
+	}
 	if t.settings.TagsMastermindStructableOnly {
 		t.enabledTags = tagsDisabled
 		t.enabledTags |= tagMastermind
 	}
 }
 
+// BeginTable tells the enabled taggers that the columns of the given table
+// are tagged next.
+func (t *Taggers) BeginTable(table string) error {
+	t.table = table
+	for bit := 1; bit <= t.enabledTags; bit *= 2 {
+		if t.enabledTags&bit == 0 {
+			continue
+		}
+		if tagger, ok := t.taggers[bit].(TableTagger); ok {
+			if err := tagger.BeginTable(table); err != nil {
+				return err
+			}
+		}
+	}
+	for _, tagger := range t.registered {
+		if tagger, ok := tagger.(TableTagger); ok {
+			if err := tagger.BeginTable(table); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Persist persists the state of the enabled taggers.
+func (t *Taggers) Persist() error {
+	for bit := 1; bit <= t.enabledTags; bit *= 2 {
+		if t.enabledTags&bit == 0 {
+			continue
+		}
+		if persister, ok := t.taggers[bit].(Persister); ok {
+			if err := persister.Persist(); err != nil {
+				return err
+			}
+		}
+	}
+	for _, tagger := range t.registered {
+		if persister, ok := tagger.(Persister); ok {
+			if err := persister.Persist(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// overrides returns the static extra tags and the tag overrides of the given
+// column of the current table. Overrides win over extra tags and the ones for
+// the specific table win over the ones for all tables.
+func (t *Taggers) overrides(column string) map[string]string {
+	overrides := map[string]string{}
+	for _, extra := range t.settings.TagsExtra {
+		if ok, _ := path.Match(extra.Pattern, t.table+"."+column); ok {
+			overrides[extra.Key] = extra.Value
+		}
+	}
+	maps.Copy(overrides, t.settings.TagsOverrides["*."+column])
+	maps.Copy(overrides, t.settings.TagsOverrides[t.table+"."+column])
+	return overrides
+}
+
 // GenerateTag creates based on the enabled tags and the given database and column
 // the tag for the struct field.
 func (t *Taggers) GenerateTag(db database.Database, column database.Column) (tags string) {
@@ -78,17 +341,52 @@ func (t *Taggers) GenerateTag(db database.Database, column database.Column) (tag
 
 	for bit := 1; bit <= t.enabledTags; bit *= 2 {
 		shouldTag := t.enabledTags&bit > 0
-		if shouldTag {
-			sb.WriteString(t.taggers[bit].GenerateTag(db, column))
+		if !shouldTag {
+			continue
+		}
+		// taggers may skip columns they have nothing to say about
+		if tag := t.taggers[bit].GenerateTag(db, column); tag != "" {
+			sb.WriteString(tag)
+			sb.WriteString(" ")
+		}
+	}
+	for _, tagger := range t.registered {
+		if tag := tagger.GenerateTag(db, column); tag != "" {
+			sb.WriteString(tag)
 			sb.WriteString(" ")
 		}
 	}
 
 	tags = sb.String()
 
+	isSensitive := t.settings.IsSensitiveColumn(column.Name)
+	overrides := t.overrides(column.Name)
+	if isSensitive || len(overrides) > 0 || len(t.settings.TagsOrder) > 0 {
+		parts := parseTags(tags)
+		if isSensitive {
+			parts = redactTags(parts)
+		}
+		tags = joinTags(orderTags(applyOverrides(parts, overrides), t.settings.TagsOrder))
+	}
+
 	if len(tags) > 0 {
 		tags = "`" + strings.TrimSpace(tags) + "`"
 	}
 
 	return tags
 }
+
+// GenerateSkipTag creates the tag excluding a field which is no column, e.g.
+// a relation field, from the enabled taggers which would process it.
+func (t *Taggers) GenerateSkipTag() string {
+	var parts []tagPart
+	for bit := 1; bit <= t.enabledTags; bit *= 2 {
+		if key, ok := skipTagKeys[bit]; ok && t.enabledTags&bit > 0 {
+			parts = append(parts, tagPart{key: key, value: `"-"`})
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "`" + joinTags(orderTags(parts, t.settings.TagsOrder)) + "`"
+}
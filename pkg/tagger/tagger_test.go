@@ -79,14 +79,273 @@ func TestTaggers_GenerateTags(t *testing.T) {
 			},
 			expected: "`stbl:\"column_name\"`",
 		},
+		{
+			desc: "default db-tag with enabled json-tag creates db- and json-tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`db:\"column_name\" json:\"column_name\"`",
+		},
+		{
+			desc: "enabled json-, yaml-, toml- and mapstructure-tags share the same naming strategy",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsYAML = true
+				s.TagsTOML = true
+				s.TagsMapstructure = true
+				s.TagsNameFormat = settings.TagNameFormatCamelCase
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`db:\"column_name\" json:\"columnName\" yaml:\"columnName\" toml:\"columnName\" mapstructure:\"columnName\"`",
+		},
+		{
+			desc: "enabled validate-tag without rules for the column is left out",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsValidate = true
+				return s
+			},
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: "`db:\"column_name\"`",
+		},
+		{
+			desc: "enabled validate-tag for not nullable column creates db- and validate-tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsValidate = true
+				return s
+			},
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "uuid",
+				IsNullable: "NO",
+			},
+			expected: "`db:\"column_name\" validate:\"required,uuid\"`",
+		},
+		{
+			desc: "tag overrides of the table win over the ones of all tables",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsOverrides = map[string]map[string]string{
+					"*.password_hash":          {"json": "-", "db": ""},
+					"test_table.password_hash": {"json": "secret"},
+				}
+				return s
+			},
+			column: database.Column{
+				Name: "password_hash",
+			},
+			expected: "`json:\"secret\"`",
+		},
+		{
+			desc: "tag overrides of other tables are not applied",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsOverrides = map[string]map[string]string{
+					"other_table.password_hash": {"json": "-"},
+				}
+				return s
+			},
+			column: database.Column{
+				Name: "password_hash",
+			},
+			expected: "`db:\"password_hash\"`",
+		},
+		{
+			desc: "tag order puts the given tags first",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsYAML = true
+				s.TagsOrder = settings.StringsFlag{"yaml", "json"}
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`yaml:\"column_name\" json:\"column_name\" db:\"column_name\"`",
+		},
+		{
+			desc: "extra tags are added to the matching columns and lose against overrides",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsExtra = settings.ExtraTagsFlag{
+					{Pattern: "*.*", Key: "validate", Value: "-"},
+					{Pattern: "test_table.*", Key: "audit", Value: "true"},
+					{Pattern: "other_table.*", Key: "other", Value: "true"},
+				}
+				s.TagsOverrides = map[string]map[string]string{
+					"*.column_name": {"audit": "false"},
+				}
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`db:\"column_name\" audit:\"false\" validate:\"-\"`",
+		},
+		{
+			desc: "sensitive columns are excluded from serialization tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsYAML = true
+				return s
+			},
+			column: database.Column{
+				Name: "password_hash",
+			},
+			expected: "`db:\"password_hash\" json:\"-\" yaml:\"-\"`",
+		},
+		{
+			desc: "overrides win over sensitive columns",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsOverrides = map[string]map[string]string{
+					"*.api_token": {"json": "apiToken"},
+				}
+				return s
+			},
+			column: database.Column{
+				Name: "api_token",
+			},
+			expected: "`db:\"api_token\" json:\"apiToken\"`",
+		},
+		{
+			desc: "suppressing all tags generates no tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsOverrides = map[string]map[string]string{
+					"test_table.password_hash": {"db": ""},
+				}
+				return s
+			},
+			column: database.Column{
+				Name: "password_hash",
+			},
+			expected: "",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
 			s := test.settings()
 			taggers := NewTaggers(s)
+			assert.NoError(t, taggers.BeginTable("test_table"))
 			db := database.New(s)
 			actual := taggers.GenerateTag(db, test.column)
 			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
+
+func TestTaggers_GenerateSkipTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc:     "db",
+			settings: settings.New,
+			expected: "`db:\"-\"`",
+		},
+		{
+			desc: "serialization tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsYAML = true
+				s.TagsCSV = true
+				return s
+			},
+			expected: "`db:\"-\" json:\"-\" yaml:\"-\" csv:\"-\"`",
+		},
+		{
+			desc: "orm tags by -tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Tags = []string{"gorm", "go-pg", "validate"}
+				return s
+			},
+			expected: "`db:\"-\" gorm:\"-\" validate:\"-\" pg:\"-\"`",
+		},
+		{
+			desc: "ordered",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsOrder = []string{"json", "db"}
+				return s
+			},
+			expected: "`json:\"-\" db:\"-\"`",
+		},
+		{
+			desc: "taggers without skip tag",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNoDb = true
+				s.TagsProtobuf = true
+				return s
+			},
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, NewTaggers(test.settings()).GenerateSkipTag())
+		})
+	}
+}
+
+// auditTagger is a tagger registered by a third party.
+type auditTagger struct {
+	tables []string
+}
+
+func (a *auditTagger) GenerateTag(_ database.Database, column database.Column) string {
+	return `audit:"` + a.tables[len(a.tables)-1] + "." + column.Name + `"`
+}
+
+func (a *auditTagger) BeginTable(table string) error {
+	a.tables = append(a.tables, table)
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	audit := &auditTagger{}
+	Register("audit", audit)
+
+	assert.Panics(t, func() { Register("audit", &auditTagger{}) })
+	assert.Panics(t, func() { Register("json", &auditTagger{}) })
+	assert.Panics(t, func() { Register("", &auditTagger{}) })
+	assert.Panics(t, func() { Register("nil", nil) })
+
+	assert.NoError(t, VerifyNames([]string{"audit", "json", "go-pg"}))
+	assert.EqualError(t, VerifyNames([]string{"json", "unknown"}),
+		`unknown tagger "unknown" in -tags, must be a built-in one like json or one registered by tagger.Register`)
+
+	s := settings.New()
+	s.Tags = []string{"audit", "json"}
+	taggers := NewTaggers(s)
+	assert.NoError(t, taggers.BeginTable("users"))
+	assert.Equal(t, []string{"users"}, audit.tables)
+
+	actual := taggers.GenerateTag(nil, database.Column{Name: "email"})
+	assert.Equal(t, "`db:\"email\" json:\"email\" audit:\"users.email\"`", actual)
+}
@@ -0,0 +1,136 @@
+package tagger
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// tagPart is a single key:"value" pair of a struct tag. The value is kept
+// quoted as generated.
+type tagPart struct {
+	key   string
+	value string
+}
+
+// parseTags splits the space separated key:"value" pairs generated by the
+// taggers. Malformed remainders are kept as they are.
+func parseTags(tags string) []tagPart {
+	var parts []tagPart
+	for {
+		tags = strings.TrimLeft(tags, " ")
+		if tags == "" {
+			return parts
+		}
+
+		colon := strings.Index(tags, `:"`)
+		if colon <= 0 || strings.Contains(tags[:colon], " ") {
+			return append(parts, tagPart{value: tags})
+		}
+
+		// find the closing quote, skipping escaped ones
+		end := colon + 2
+		for end < len(tags) && tags[end] != '"' {
+			if tags[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(tags) {
+			return append(parts, tagPart{value: tags})
+		}
+
+		parts = append(parts, tagPart{key: tags[:colon], value: tags[colon+1 : end+1]})
+		tags = tags[end+1:]
+	}
+}
+
+// serializationTagKeys are the keys of the tags (un)marshalling the fields,
+// which sensitive columns get excluded from.
+var serializationTagKeys = []string{"json", "yaml", "toml", "mapstructure", "csv", "graphql"}
+
+// redactTags excludes the field from all serialization tags.
+func redactTags(parts []tagPart) []tagPart {
+	for i, part := range parts {
+		if slices.Contains(serializationTagKeys, part.key) {
+			parts[i].value = `"-"`
+		}
+	}
+	return parts
+}
+
+// applyOverrides replaces the values of the tags given by the overrides, adds
+// the ones not yet generated (sorted by key) and removes the ones overridden
+// with an empty value.
+func applyOverrides(parts []tagPart, overrides map[string]string) []tagPart {
+	if len(overrides) == 0 {
+		return parts
+	}
+
+	applied := map[string]struct{}{}
+	result := make([]tagPart, 0, len(parts)+len(overrides))
+	for _, part := range parts {
+		value, ok := overrides[part.key]
+		if !ok || part.key == "" {
+			result = append(result, part)
+			continue
+		}
+		applied[part.key] = struct{}{}
+		if value != "" {
+			result = append(result, tagPart{key: part.key, value: strconv.Quote(value)})
+		}
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if _, ok := applied[key]; ok || overrides[key] == "" {
+			continue
+		}
+		result = append(result, tagPart{key: key, value: strconv.Quote(overrides[key])})
+	}
+
+	return result
+}
+
+// joinTags renders the tag parts as space separated key:"value" pairs.
+func joinTags(parts []tagPart) string {
+	var sb strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		if part.key != "" {
+			sb.WriteString(part.key)
+			sb.WriteString(":")
+		}
+		sb.WriteString(part.value)
+	}
+	return sb.String()
+}
+
+// orderTags sorts the tag parts by the position of their keys in the given
+// order. Keys not in the order keep their relative order behind the ordered
+// ones.
+func orderTags(parts []tagPart, order []string) []tagPart {
+	if len(order) == 0 {
+		return parts
+	}
+
+	position := func(key string) int {
+		if idx := slices.Index(order, key); idx >= 0 {
+			return idx
+		}
+		return len(order)
+	}
+
+	slices.SortStableFunc(parts, func(a, b tagPart) int {
+		return position(a.key) - position(b.key)
+	})
+
+	return parts
+}
@@ -0,0 +1,132 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		tags     string
+		expected []tagPart
+	}{
+		{
+			desc:     "empty tags",
+			tags:     "",
+			expected: nil,
+		},
+		{
+			desc: "multiple tags",
+			tags: `db:"id" json:"id,omitempty" `,
+			expected: []tagPart{
+				{key: "db", value: `"id"`},
+				{key: "json", value: `"id,omitempty"`},
+			},
+		},
+		{
+			desc: "escaped quotes within values",
+			tags: `example:"say \"hi\"" db:"greeting"`,
+			expected: []tagPart{
+				{key: "example", value: `"say \"hi\""`},
+				{key: "db", value: `"greeting"`},
+			},
+		},
+		{
+			desc: "malformed remainder is kept",
+			tags: `db:"id" broken`,
+			expected: []tagPart{
+				{key: "db", value: `"id"`},
+				{value: "broken"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseTags(test.tags))
+		})
+	}
+}
+
+func TestRedactTags(t *testing.T) {
+	t.Parallel()
+
+	parts := parseTags(`db:"password" json:"password,omitempty" yaml:"password" validate:"required"`)
+	assert.Equal(t, `db:"password" json:"-" yaml:"-" validate:"required"`, joinTags(redactTags(parts)))
+}
+
+func TestApplyOverrides(t *testing.T) {
+	t.Parallel()
+
+	parts := []tagPart{
+		{key: "db", value: `"password_hash"`},
+		{key: "json", value: `"password_hash"`},
+	}
+
+	tests := []struct {
+		desc      string
+		overrides map[string]string
+		expected  string
+	}{
+		{
+			desc:      "no overrides keep the tags",
+			overrides: nil,
+			expected:  `db:"password_hash" json:"password_hash"`,
+		},
+		{
+			desc:      "override replaces the value in place",
+			overrides: map[string]string{"json": "-"},
+			expected:  `db:"password_hash" json:"-"`,
+		},
+		{
+			desc:      "empty override suppresses the tag",
+			overrides: map[string]string{"db": ""},
+			expected:  `json:"password_hash"`,
+		},
+		{
+			desc:      "unknown tags are appended sorted by key",
+			overrides: map[string]string{"yaml": "-", "toml": "-", "xml": ""},
+			expected:  `db:"password_hash" json:"password_hash" toml:"-" yaml:"-"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, joinTags(applyOverrides(parts, test.overrides)))
+		})
+	}
+}
+
+func TestOrderTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		order    []string
+		expected string
+	}{
+		{
+			desc:     "no order keeps the tags",
+			order:    nil,
+			expected: `db:"id" json:"id" yaml:"id" validate:"required"`,
+		},
+		{
+			desc:     "ordered tags come first",
+			order:    []string{"json", "db"},
+			expected: `json:"id" db:"id" yaml:"id" validate:"required"`,
+		},
+		{
+			desc:     "unknown keys in the order are ignored",
+			order:    []string{"validate", "xml"},
+			expected: `validate:"required" db:"id" json:"id" yaml:"id"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			parts := parseTags(`db:"id" json:"id" yaml:"id" validate:"required"`)
+			assert.Equal(t, test.expected, joinTags(orderTags(parts, test.order)))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package tagger
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// TOML represents the "toml"-tag.
+type TOML struct {
+	format    settings.TagNameFormat
+	overrides map[string]string
+}
+
+// NewTOML creates a new TOML tagger with the naming strategy and per-column
+// overrides given by the settings.
+func NewTOML(s *settings.Settings) *TOML {
+	return &TOML{
+		format:    s.TagsNameFormat,
+		overrides: s.TagsNames,
+	}
+}
+
+// GenerateTag for TOML to satisfy the Tagger interface.
+func (t TOML) GenerateTag(_ database.Database, column database.Column) string {
+	return `toml:"` + tagName(t.format, t.overrides, column.Name) + `"`
+}
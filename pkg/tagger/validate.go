@@ -0,0 +1,112 @@
+package tagger
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+// Validate represents the go-playground/validator "validate"-tag derived from
+// the constraints of the column.
+//
+// Nullable columns get "omitempty" as the first rule, for sql.Null* types a
+// custom type func has to be registered at the validator to apply the rules
+// to the underlying value. Not nullable columns get "required" only if the
+// zero value of their Go type is no valid value of the column, because the
+// rule fails for the zero value: a 0, false or empty string stored in the
+// database is no missing value.
+type Validate struct{}
+
+// GenerateTag for Validate to satisfy the Tagger interface. It returns an
+// empty string if no rule applies to the column.
+func (t Validate) GenerateTag(db database.Database, column database.Column) string {
+	var rules []string
+
+	if db.IsNullable(column) {
+		rules = append(rules, "omitempty")
+	} else if !column.DefaultValue.Valid && !db.IsAutoIncrement(column) && isZeroValueMissing(db, column) {
+		rules = append(rules, "required")
+	}
+
+	if column.CharacterMaximumLength.Valid && column.CharacterMaximumLength.Int64 > 0 &&
+		(db.IsString(column) || db.IsText(column)) {
+		rules = append(rules, "max="+strconv.FormatInt(column.CharacterMaximumLength.Int64, 10))
+	}
+
+	if strings.EqualFold(column.DataType, "uuid") {
+		rules = append(rules, "uuid")
+	}
+
+	if oneOf, ok := validateOneOf(column.EnumValues); ok {
+		rules = append(rules, oneOf)
+	}
+
+	if len(rules) == 0 || (len(rules) == 1 && rules[0] == "omitempty") {
+		return ""
+	}
+
+	return `validate:"` + strings.Join(rules, ",") + `"`
+}
+
+// isZeroValueMissing reports whether the zero value of the Go type of the not
+// nullable column means a missing value: the zero time, the empty uuid and the
+// empty string of an enum without an empty label.
+func isZeroValueMissing(db database.Database, column database.Column) bool {
+	switch {
+	case db.IsTemporal(column):
+		return true
+	case strings.EqualFold(column.DataType, "uuid"):
+		return true
+	case len(column.EnumValues) > 0:
+		for _, value := range column.EnumValues {
+			if value == "" {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// validateOneOf returns the oneof-rule of the enum values. It returns false if
+// there are no values or one of them can not be expressed as parameter.
+func validateOneOf(values []string) (string, bool) {
+	if len(values) == 0 {
+		return "", false
+	}
+	params := make([]string, len(values))
+	for i, value := range values {
+		param, ok := validateParam(value)
+		if !ok {
+			return "", false
+		}
+		params[i] = param
+	}
+	return "oneof=" + strings.Join(params, " "), true
+}
+
+// validateParam escapes the given value to be used as parameter of a rule.
+// The validator needs commas and pipes in hex notation, empty values and
+// values with spaces get quoted. It returns false for values the validator
+// can not parse back: quoted values containing a quote and values containing
+// the hex notation itself.
+func validateParam(value string) (string, bool) {
+	if strings.Contains(value, "0x2C") || strings.Contains(value, "0x7C") {
+		return "", false
+	}
+	if value == "" || strings.ContainsAny(value, " \t\n") {
+		if strings.Contains(value, "'") {
+			return "", false
+		}
+		value = "'" + value + "'"
+	}
+	value = strings.ReplaceAll(value, ",", "0x2C")
+	value = strings.ReplaceAll(value, "|", "0x7C")
+	return escapeTagValue(value), true
+}
+
+func isBoolean(column database.Column) bool {
+	dataType := strings.ToLower(column.DataType)
+	return dataType == "boolean" || dataType == "bool"
+}
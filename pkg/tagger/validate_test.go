@@ -0,0 +1,217 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestValidate_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		desc     string
+		column   database.Column
+		expected string
+	}
+
+	tests := map[settings.DBType][]test{
+		settings.DBTypePostgresql: {
+			{
+				desc: "nullable column without constraints generates no tag",
+				column: database.Column{
+					Name:       "column_name",
+					DataType:   "integer",
+					IsNullable: "YES",
+				},
+				expected: "",
+			},
+			{
+				desc: "not nullable integer column generates no required, 0 is valid",
+				column: database.Column{
+					Name:       "column_name",
+					DataType:   "integer",
+					IsNullable: "NO",
+				},
+				expected: "",
+			},
+			{
+				desc: "not nullable numeric column generates no required, 0 is valid",
+				column: database.Column{
+					Name:       "price",
+					DataType:   "numeric",
+					IsNullable: "NO",
+				},
+				expected: "",
+			},
+			{
+				desc: "not nullable text column generates no required, '' is valid",
+				column: database.Column{
+					Name:       "note",
+					DataType:   "text",
+					IsNullable: "NO",
+				},
+				expected: "",
+			},
+			{
+				desc: "not nullable temporal column generates required",
+				column: database.Column{
+					Name:       "published_at",
+					DataType:   "timestamp without time zone",
+					IsNullable: "NO",
+				},
+				expected: `validate:"required"`,
+			},
+			{
+				desc: "not nullable column with default generates no tag",
+				column: database.Column{
+					Name:       "created_at",
+					DataType:   "timestamp without time zone",
+					IsNullable: "NO",
+					DefaultValue: sql.NullString{
+						String: "now()",
+						Valid:  true,
+					},
+				},
+				expected: "",
+			},
+			{
+				desc: "not nullable boolean column generates no tag",
+				column: database.Column{
+					Name:       "active",
+					DataType:   "boolean",
+					IsNullable: "NO",
+				},
+				expected: "",
+			},
+			{
+				desc: "varchar column generates max length",
+				column: database.Column{
+					Name:       "name",
+					DataType:   "character varying",
+					IsNullable: "NO",
+					CharacterMaximumLength: sql.NullInt64{
+						Int64: 255,
+						Valid: true,
+					},
+				},
+				expected: `validate:"max=255"`,
+			},
+			{
+				desc: "nullable varchar column generates omitempty and max length",
+				column: database.Column{
+					Name:       "name",
+					DataType:   "character varying",
+					IsNullable: "YES",
+					CharacterMaximumLength: sql.NullInt64{
+						Int64: 64,
+						Valid: true,
+					},
+				},
+				expected: `validate:"omitempty,max=64"`,
+			},
+			{
+				desc: "uuid column generates uuid of any version",
+				column: database.Column{
+					Name:       "external_id",
+					DataType:   "uuid",
+					IsNullable: "NO",
+				},
+				expected: `validate:"required,uuid"`,
+			},
+		},
+		settings.DBTypeMySQL: {
+			{
+				desc: "auto increment column generates no tag",
+				column: database.Column{
+					Name:       "id",
+					DataType:   "int",
+					IsNullable: "NO",
+					ColumnKey:  "PRI",
+					Extra:      "auto_increment",
+				},
+				expected: "",
+			},
+			{
+				desc: "enum column generates oneof",
+				column: database.Column{
+					Name:       "mood",
+					DataType:   "enum",
+					IsNullable: "NO",
+					EnumValues: []string{"happy", "sad"},
+				},
+				expected: `validate:"required,oneof=happy sad"`,
+			},
+			{
+				desc: "enum values with spaces, commas and quotes get escaped",
+				column: database.Column{
+					Name:       "mood",
+					DataType:   "enum",
+					IsNullable: "YES",
+					EnumValues: []string{"very happy", "a,b", `"sad"`},
+				},
+				expected: `validate:"omitempty,oneof='very happy' a0x2Cb \"sad\""`,
+			},
+			{
+				desc: "enum labels with commas and pipes get escaped",
+				column: database.Column{
+					Name:       "flags",
+					DataType:   "enum",
+					IsNullable: "NO",
+					EnumValues: []string{"a,b", "c|d", "e, f|g"},
+				},
+				expected: `validate:"required,oneof=a0x2Cb c0x7Cd 'e0x2C f0x7Cg'"`,
+			},
+			{
+				desc: "empty enum label gets quoted and drops required",
+				column: database.Column{
+					Name:       "mood",
+					DataType:   "enum",
+					IsNullable: "NO",
+					EnumValues: []string{"happy", ""},
+				},
+				expected: `validate:"oneof=happy ''"`,
+			},
+			{
+				desc: "enum label with a quote and a space generates no oneof",
+				column: database.Column{
+					Name:       "mood",
+					DataType:   "enum",
+					IsNullable: "YES",
+					EnumValues: []string{"happy", "don't know"},
+				},
+				expected: "",
+			},
+			{
+				desc: "enum label with the hex notation generates no oneof",
+				column: database.Column{
+					Name:       "mood",
+					DataType:   "enum",
+					IsNullable: "YES",
+					EnumValues: []string{"happy", "0x2C"},
+				},
+				expected: "",
+			},
+		},
+	}
+
+	tagger := new(Validate)
+
+	for dbType, tests := range tests {
+		t.Run(dbType.String(), func(t *testing.T) {
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+			for _, test := range tests {
+				t.Run(test.desc, func(t *testing.T) {
+					actual := tagger.GenerateTag(db, test.column)
+					assert.Equal(t, test.expected, actual)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package tagger
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+)
+
+// Xorm represents the "xorm"-tag.
+type Xorm struct{}
+
+// GenerateTag for Xorm to satisfy the Tagger interface.
+func (t Xorm) GenerateTag(db database.Database, column database.Column) string {
+	var options []string
+
+	if db.IsPrimaryKey(column) {
+		options = append(options, "pk")
+	}
+
+	if db.IsAutoIncrement(column) {
+		options = append(options, "autoincr")
+	}
+
+	if column.CharacterMaximumLength.Valid && column.CharacterMaximumLength.Int64 > 0 && db.IsString(column) {
+		options = append(options, "varchar("+strconv.FormatInt(column.CharacterMaximumLength.Int64, 10)+")")
+	}
+
+	if db.IsNullable(column) {
+		options = append(options, "null")
+	} else {
+		options = append(options, "notnull")
+	}
+
+	options = append(options, "'"+column.Name+"'")
+
+	return `xorm:"` + strings.Join(options, " ") + `"`
+}
@@ -0,0 +1,91 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestXorm_GenerateTag(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		desc     string
+		column   database.Column
+		expected string
+	}
+
+	tests := map[settings.DBType][]test{
+		settings.DBTypePostgresql: {
+			{
+				desc: "nullable column generates null and the column name",
+				column: database.Column{
+					Name:       "column_name",
+					IsNullable: "YES",
+				},
+				expected: `xorm:"null 'column_name'"`,
+			},
+			{
+				desc: "serial PK column generates pk, autoincr and notnull",
+				column: database.Column{
+					Name:       "id",
+					IsNullable: "NO",
+					DefaultValue: sql.NullString{
+						String: "nextval('foo_id_seq'::regclass)",
+						Valid:  true,
+					},
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+				},
+				expected: `xorm:"pk autoincr notnull 'id'"`,
+			},
+			{
+				desc: "varchar column generates the size",
+				column: database.Column{
+					Name:       "name",
+					DataType:   "character varying",
+					IsNullable: "NO",
+					CharacterMaximumLength: sql.NullInt64{
+						Int64: 255,
+						Valid: true,
+					},
+				},
+				expected: `xorm:"varchar(255) notnull 'name'"`,
+			},
+		},
+		settings.DBTypeMySQL: {
+			{
+				desc: "auto increment PK column generates pk, autoincr and notnull",
+				column: database.Column{
+					Name:       "id",
+					IsNullable: "NO",
+					ColumnKey:  "PRI",
+					Extra:      "auto_increment",
+				},
+				expected: `xorm:"pk autoincr notnull 'id'"`,
+			},
+		},
+	}
+
+	tagger := new(Xorm)
+
+	for dbType, tests := range tests {
+		t.Run(dbType.String(), func(t *testing.T) {
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+			for _, test := range tests {
+				t.Run(test.desc, func(t *testing.T) {
+					actual := tagger.GenerateTag(db, test.column)
+					assert.Equal(t, test.expected, actual)
+				})
+			}
+		})
+	}
+}
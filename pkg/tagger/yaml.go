@@ -0,0 +1,26 @@
+package tagger
+
+import (
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/database"
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+// YAML represents the "yaml"-tag.
+type YAML struct {
+	format    settings.TagNameFormat
+	overrides map[string]string
+}
+
+// NewYAML creates a new YAML tagger with the naming strategy and per-column
+// overrides given by the settings.
+func NewYAML(s *settings.Settings) *YAML {
+	return &YAML{
+		format:    s.TagsNameFormat,
+		overrides: s.TagsNames,
+	}
+}
+
+// GenerateTag for YAML to satisfy the Tagger interface.
+func (t YAML) GenerateTag(_ database.Database, column database.Column) string {
+	return `yaml:"` + tagName(t.format, t.overrides, column.Name) + `"`
+}
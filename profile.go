@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startProfiles starts the CPU profile of the run written to cpu.pprof in the
+// directory, the returned func stops it and writes the heap profile to
+// heap.pprof, to inspect them by go tool pprof.
+func startProfiles(dir string) (func() error, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create the profile directory: %w", err)
+	}
+
+	cpu, err := os.Create(filepath.Join(dir, "cpu.pprof"))
+	if err != nil {
+		return nil, fmt.Errorf("could not create the CPU profile: %w", err)
+	}
+	if err = pprof.StartCPUProfile(cpu); err != nil {
+		return nil, errors.Join(fmt.Errorf("could not start the CPU profile: %w", err), cpu.Close())
+	}
+
+	return func() error {
+		pprof.StopCPUProfile()
+		if err := cpu.Close(); err != nil {
+			return fmt.Errorf("could not write the CPU profile: %w", err)
+		}
+
+		heap, err := os.Create(filepath.Join(dir, "heap.pprof"))
+		if err != nil {
+			return fmt.Errorf("could not create the heap profile: %w", err)
+		}
+		// the heap profile shows the allocations up to the last GC
+		runtime.GC()
+		if err = pprof.WriteHeapProfile(heap); err != nil {
+			return errors.Join(fmt.Errorf("could not write the heap profile: %w", err), heap.Close())
+		}
+		return heap.Close()
+	}, nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Dominik-Friedrich/tables-to-go/v2/pkg/settings"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		desc     string
+		command  string
+		args     []string
+		generate bool
+		code     int
+		expected []string
+		files    []string
+		err      string
+	}{
+		{
+			desc:    "generate",
+			command: "generate",
+			files:   []string{"Users.go"},
+		},
+		{
+			desc:     "check without drift",
+			command:  "check",
+			generate: true,
+		},
+		{
+			desc:    "check with drift",
+			command: "check",
+			code:    exitCodeDrift,
+		},
+		{
+			desc:     "list-tables",
+			command:  "list-tables",
+			expected: []string{"users\n"},
+		},
+		{
+			desc:     "describe",
+			command:  "describe",
+			args:     []string{"users"},
+			expected: []string{"users", "name"},
+		},
+		{
+			desc:    "describe unknown table",
+			command: "describe",
+			args:    []string{"orders"},
+			code:    exitCodeError,
+			err:     `table "orders" not found`,
+		},
+		{
+			desc:    "init",
+			command: "init",
+			args:    []string{"tables-to-go.yaml"},
+			files:   []string{"tables-to-go.yaml"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			schema := filepath.Join(dir, "schema.sql")
+			err := os.WriteFile(schema, []byte("CREATE TABLE users (id integer NOT NULL PRIMARY KEY, name text);\n"), 0666)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			cmdArgs := &CmdArgs{Settings: settings.New()}
+			cmdArgs.FromSQL = settings.StringsFlag{schema}
+			cmdArgs.OutputFilePath = filepath.Join(dir, "dto")
+			if !assert.NoError(t, os.Mkdir(cmdArgs.OutputFilePath, 0777)) || !assert.NoError(t, cmdArgs.Verify()) {
+				return
+			}
+
+			if test.generate {
+				code, err := run(context.Background(), cmdArgs, "generate", nil, &bytes.Buffer{})
+				if !assert.NoError(t, err) || !assert.Equal(t, exitCodeOK, code) {
+					return
+				}
+			}
+
+			args := test.args
+			if test.command == "init" {
+				args = []string{filepath.Join(dir, args[0])}
+			}
+
+			var stdout bytes.Buffer
+			code, err := run(context.Background(), cmdArgs, test.command, args, &stdout)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.code, code)
+			for _, expected := range test.expected {
+				assert.Contains(t, stdout.String(), expected)
+			}
+			for _, file := range test.files {
+				if test.command != "init" {
+					file = filepath.Join("dto", file)
+				}
+				assert.FileExists(t, filepath.Join(dir, file))
+			}
+		})
+	}
+}
+
+func TestRunOffline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		command  string
+		args     []string
+		expected []string
+		err      string
+	}{
+		{
+			desc:     "help",
+			command:  "help",
+			expected: []string{"Usage:\n  tables-to-go [command] [flags] [arguments]\n", "  describe     print the introspected columns of the table\n", "Flags:\n  -t string\n"},
+		},
+		{
+			desc:     "help of command",
+			command:  "help",
+			args:     []string{"describe"},
+			expected: []string{"Usage:\n  tables-to-go describe [flags] <table>\n\nPrint the introspected columns of the table.\n", "Flags:\n"},
+		},
+		{
+			desc:    "help of unknown command",
+			command: "help",
+			args:    []string{"generat"},
+			err:     `unknown command "generat", see 'tables-to-go help'`,
+		},
+		{
+			desc:     "version",
+			command:  "version",
+			expected: []string{"tables-to-go/"},
+		},
+		{
+			desc:     "completion",
+			command:  "completion",
+			args:     []string{"bash"},
+			expected: []string{"complete -o default -F _tables_to_go tables-to-go\n"},
+		},
+		{
+			desc:    "completion of unknown shell",
+			command: "completion",
+			args:    []string{"tcsh"},
+			err:     `unsupported shell "tcsh", supported: [bash zsh fish powershell]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			fs := flag.NewFlagSet("tables-to-go", flag.ContinueOnError)
+			fs.String("t", "pg", "type of database")
+
+			var out bytes.Buffer
+			err := runOffline(&out, fs, test.command, test.args)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			for _, expected := range test.expected {
+				assert.Contains(t, out.String(), expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+# bash completion for tables-to-go
+_tables_to_go() {
+	local cur="${COMP_WORDS[COMP_CWORD]}"
+	local prev="${COMP_WORDS[COMP_CWORD-1]}"
+	case "$prev" in
+	-config) COMPREPLY=($(compgen -f -- "$cur")); return ;;
+	-of) COMPREPLY=($(compgen -d -- "$cur")); return ;;
+	-t) COMPREPLY=($(compgen -W "pg mysql sqlite3 oracle cockroach mariadb" -- "$cur")); return ;;
+	completion) COMPREPLY=($(compgen -W "bash zsh fish powershell" -- "$cur")); return ;;
+	esac
+	if [[ "$cur" == -* ]]; then
+		COMPREPLY=($(compgen -W "-config -of -pn -t -v" -- "$cur"))
+	else
+		COMPREPLY=($(compgen -W "check completion describe generate help init list-tables version" -- "$cur"))
+	fi
+}
+complete -o default -F _tables_to_go tables-to-go
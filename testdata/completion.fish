@@ -0,0 +1,9 @@
+# fish completion for tables-to-go
+complete -c tables-to-go -f
+complete -c tables-to-go -n __fish_use_subcommand -a 'check completion describe generate help init list-tables version'
+complete -c tables-to-go -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish powershell'
+complete -c tables-to-go -o 'config' -d 'config file to load' -r -F
+complete -c tables-to-go -o 'of' -d 'output file path' -x -a '(__fish_complete_directories)'
+complete -c tables-to-go -o 'pn' -d 'package name [default: dto]: it\'s the name of the package' -x
+complete -c tables-to-go -o 't' -d 'type of database to use' -x -a 'pg mysql sqlite3 oracle cockroach mariadb'
+complete -c tables-to-go -o 'v' -d 'verbose output'